@@ -0,0 +1,212 @@
+package paypal
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// localeRegexp matches the locale format PayPal accepts, e.g. "da", "en-US", "zh-Hant-HK"
+var localeRegexp = regexp.MustCompile(`^[a-z]{2}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+
+// AppCtxOption configures an ApplicationContext built by NewApplicationContext
+type AppCtxOption func(*ApplicationContext)
+
+// WithLocale sets the BCP 47 locale of the pages the buyer sees, e.g. "en-US"
+func WithLocale(locale string) AppCtxOption {
+	return func(a *ApplicationContext) {
+		a.Locale = locale
+	}
+}
+
+// WithLandingPage sets the type of landing page to show on the PayPal site
+func WithLandingPage(landingPage LandingPage) AppCtxOption {
+	return func(a *ApplicationContext) {
+		a.LandingPage = landingPage
+	}
+}
+
+// WithShippingPreference sets where the shipping address is taken from
+func WithShippingPreference(shippingPreference ShippingPreference) AppCtxOption {
+	return func(a *ApplicationContext) {
+		a.ShippingPreference = shippingPreference
+	}
+}
+
+// WithUserAction sets the label of the button the buyer uses to approve the payment
+func WithUserAction(userAction UserAction) AppCtxOption {
+	return func(a *ApplicationContext) {
+		a.UserAction = userAction
+	}
+}
+
+// WithPayeePreferred sets the payment method the payee prefers, use
+// PayeePreferredImmediatePaymentRequired to reject delayed funding such as eChecks
+func WithPayeePreferred(payeePreferred PayeePreferred) AppCtxOption {
+	return func(a *ApplicationContext) {
+		if a.PaymentMethod == nil {
+			a.PaymentMethod = &PaymentMethod{}
+		}
+		a.PaymentMethod.PayeePreferred = payeePreferred
+	}
+}
+
+// NewApplicationContext returns a validated ApplicationContext.
+// Shipping preference, user action and landing page default to the values PayPal
+// would use when they are omitted and can be changed with the option helpers.
+func NewApplicationContext(brandName, returnURL, cancelURL string, opts ...AppCtxOption) (*ApplicationContext, error) {
+	appCtx := newAppCtx(brandName, returnURL, cancelURL, opts...)
+
+	if err := appCtx.Validate(); err != nil {
+		return nil, err
+	}
+
+	return appCtx, nil
+}
+
+// newAppCtx returns an ApplicationContext with the defaults of
+// NewApplicationContext and the options applied, without validating it
+func newAppCtx(brandName, returnURL, cancelURL string, opts ...AppCtxOption) *ApplicationContext {
+	appCtx := &ApplicationContext{
+		BrandName:          brandName,
+		ReturnURL:          returnURL,
+		CancelURL:          cancelURL,
+		ShippingPreference: ShippingPreferenceGetFromFile,
+		UserAction:         UserActionContinue,
+		LandingPage:        LandingPageNoPreference,
+	}
+
+	for _, opt := range opts {
+		opt(appCtx)
+	}
+
+	return appCtx
+}
+
+// NewExperienceContext returns a validated ExperienceContext for the PayPal
+// payment source of an order, which replaces its deprecated application_context.
+// It takes the ApplicationContext options, WithPayeePreferred sets the
+// payment_method_preference, and has the same defaults:
+//
+//	experience, err := paypal.NewExperienceContext("My Shop", returnURL, cancelURL,
+//		paypal.WithUserAction(paypal.UserActionPayNow),
+//		paypal.WithShippingPreference(paypal.ShippingPreferenceNoShipping))
+//	source := &paypal.PaymentSource{Paypal: &paypal.PaymentSourcePaypal{ExperienceContext: experience}}
+func NewExperienceContext(brandName, returnURL, cancelURL string, opts ...AppCtxOption) (*ExperienceContext, error) {
+	appCtx := newAppCtx(brandName, returnURL, cancelURL, opts...)
+
+	experience := &ExperienceContext{
+		BrandName:          appCtx.BrandName,
+		Locale:             appCtx.Locale,
+		ShippingPreference: appCtx.ShippingPreference,
+		UserAction:         appCtx.UserAction,
+		LandingPage:        appCtx.LandingPage,
+		ReturnURL:          appCtx.ReturnURL,
+		CancelURL:          appCtx.CancelURL,
+	}
+	if appCtx.PaymentMethod != nil {
+		experience.PaymentMethodPreference = string(appCtx.PaymentMethod.PayeePreferred)
+	}
+
+	if err := experience.Validate(); err != nil {
+		return nil, err
+	}
+
+	return experience, nil
+}
+
+// Validate checks the ExperienceContext fields against the constraints documented by PayPal,
+// unlike application_context it has no BILLING landing page nor SUBSCRIBE_NOW user action
+func (e *ExperienceContext) Validate() error {
+	if err := validateExperience(e.BrandName, e.ReturnURL, e.CancelURL, e.Locale, e.ShippingPreference); err != nil {
+		return err
+	}
+
+	switch e.UserAction {
+	case "", UserActionContinue, UserActionPayNow:
+	default:
+		return fmt.Errorf("paypal: invalid user_action %q", e.UserAction)
+	}
+
+	switch e.LandingPage {
+	case "", LandingPageLogin, LandingPageGuestCheckout, LandingPageNoPreference:
+	default:
+		return fmt.Errorf("paypal: invalid landing_page %q", e.LandingPage)
+	}
+
+	switch e.PaymentMethodPreference {
+	case "", PaymentMethodPreferenceUnrestricted, PaymentMethodPreferenceImmediatePaymentRequired:
+	default:
+		return fmt.Errorf("paypal: invalid payment_method_preference %q", e.PaymentMethodPreference)
+	}
+
+	return nil
+}
+
+// Validate checks the ApplicationContext fields against the constraints documented by PayPal
+func (a *ApplicationContext) Validate() error {
+	if err := validateExperience(a.BrandName, a.ReturnURL, a.CancelURL, a.Locale, a.ShippingPreference); err != nil {
+		return err
+	}
+
+	switch a.UserAction {
+	case "", UserActionContinue, UserActionPayNow, UserActionSubscribeNow:
+	default:
+		return fmt.Errorf("paypal: invalid user_action %q", a.UserAction)
+	}
+
+	switch a.LandingPage {
+	case "", LandingPageLogin, LandingPageBilling, LandingPageNoPreference:
+	default:
+		return fmt.Errorf("paypal: invalid landing_page %q", a.LandingPage)
+	}
+
+	if a.PaymentMethod != nil {
+		switch a.PaymentMethod.PayeePreferred {
+		case "", PayeePreferredUnrestricted, PayeePreferredImmediatePaymentRequired:
+		default:
+			return fmt.Errorf("paypal: invalid payee_preferred %q", a.PaymentMethod.PayeePreferred)
+		}
+	}
+
+	return nil
+}
+
+// validateExperience checks the fields application_context and experience_context have in common
+func validateExperience(brandName, returnURL, cancelURL, locale string, shippingPreference ShippingPreference) error {
+	if len(brandName) > 127 {
+		return fmt.Errorf("paypal: brand_name must be at most 127 characters, got %d", len(brandName))
+	}
+
+	if err := validateRedirectURL("return_url", returnURL); err != nil {
+		return err
+	}
+	if err := validateRedirectURL("cancel_url", cancelURL); err != nil {
+		return err
+	}
+
+	if locale != "" && !localeRegexp.MatchString(locale) {
+		return fmt.Errorf("paypal: invalid locale %q", locale)
+	}
+
+	switch shippingPreference {
+	case "", ShippingPreferenceGetFromFile, ShippingPreferenceNoShipping, ShippingPreferenceSetProvidedAddress:
+	default:
+		return fmt.Errorf("paypal: invalid shipping_preference %q", shippingPreference)
+	}
+
+	return nil
+}
+
+func validateRedirectURL(field, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("paypal: %s must be an absolute http(s) URL, got %q", field, value)
+	}
+
+	return nil
+}
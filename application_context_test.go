@@ -0,0 +1,86 @@
+package paypal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewApplicationContext(t *testing.T) {
+	appCtx, err := NewApplicationContext("Shop", "https://example.com/return", "https://example.com/cancel",
+		WithLocale("en-US"),
+		WithUserAction(UserActionPayNow),
+		WithShippingPreference(ShippingPreferenceNoShipping),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if appCtx.BrandName != "Shop" ||
+		appCtx.Locale != "en-US" ||
+		appCtx.UserAction != UserActionPayNow ||
+		appCtx.ShippingPreference != ShippingPreferenceNoShipping ||
+		appCtx.LandingPage != LandingPageNoPreference {
+		t.Errorf("ApplicationContext is incorrect, Given: %+v", appCtx)
+	}
+}
+
+func TestNewApplicationContext_invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		returnURL string
+		opts      []AppCtxOption
+	}{
+		{"relative return url", "/return", nil},
+		{"invalid locale", "https://example.com", []AppCtxOption{WithLocale("english")}},
+		{"invalid user action", "https://example.com", []AppCtxOption{WithUserAction("PAY_LATER")}},
+		{"invalid shipping preference", "https://example.com", []AppCtxOption{WithShippingPreference("SHIP")}},
+		{"invalid payee preferred", "https://example.com", []AppCtxOption{WithPayeePreferred("INSTANT")}},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewApplicationContext("Shop", tt.returnURL, "", tt.opts...); err == nil {
+			t.Errorf("%s: expecting an error got nil", tt.name)
+		}
+	}
+}
+
+func TestNewApplicationContext_payeePreferred(t *testing.T) {
+	appCtx, err := NewApplicationContext("Shop", "", "", WithPayeePreferred(PayeePreferredImmediatePaymentRequired))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := json.Marshal(appCtx)
+	if !strings.Contains(string(b), `"payment_method":{"payee_preferred":"IMMEDIATE_PAYMENT_REQUIRED"}`) {
+		t.Errorf("expecting payment_method to be serialized, got %s", b)
+	}
+}
+
+func TestNewExperienceContext(t *testing.T) {
+	experience, err := NewExperienceContext("Shop", "https://example.com/return", "https://example.com/cancel",
+		WithLandingPage(LandingPageGuestCheckout),
+		WithUserAction(UserActionPayNow),
+		WithPayeePreferred(PayeePreferredImmediatePaymentRequired),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := json.Marshal(experience)
+	expected := `{"brand_name":"Shop","shipping_preference":"GET_FROM_FILE","user_action":"PAY_NOW","landing_page":"GUEST_CHECKOUT",` +
+		`"payment_method_preference":"IMMEDIATE_PAYMENT_REQUIRED","return_url":"https://example.com/return","cancel_url":"https://example.com/cancel"}`
+	if string(b) != expected {
+		t.Errorf("unexpected experience context %s", b)
+	}
+
+	for _, opts := range [][]AppCtxOption{
+		{WithUserAction(UserActionSubscribeNow)},
+		{WithLandingPage(LandingPageBilling)},
+		{WithLocale("en_US")},
+	} {
+		if _, err := NewExperienceContext("Shop", "https://example.com/return", "", opts...); err == nil {
+			t.Errorf("expecting an error for %+v", opts)
+		}
+	}
+}
@@ -5,8 +5,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"unicode/utf8"
 )
 
+// SoftDescriptorMaxLength is the maximum length of the soft_descriptor PayPal
+// accepts on captures, including the prefix PayPal adds to the statement.
+const SoftDescriptorMaxLength = 22
+
+// Validate checks the capture request before it is sent to PayPal
+func (r *PaymentCaptureRequest) Validate() error {
+	if n := utf8.RuneCountInString(r.SoftDescriptor); n > SoftDescriptorMaxLength {
+		return fmt.Errorf("paypal: soft_descriptor must be at most %d characters, got %d", SoftDescriptorMaxLength, n)
+	}
+	return nil
+}
+
 // GetAuthorization returns an authorization by ID
 // Endpoint: GET /v2/payments/authorizations/ID
 func (c *Client) GetAuthorization(ctx context.Context, authID string) (*Authorization, error) {
@@ -37,6 +50,12 @@ func (c *Client) CaptureAuthorizationWithPaypalRequestId(ctx context.Context,
 	paymentCaptureRequest *PaymentCaptureRequest,
 	requestID string,
 ) (*PaymentCaptureResponse, error) {
+	if paymentCaptureRequest != nil {
+		if err := paymentCaptureRequest.Validate(); err != nil {
+			return &PaymentCaptureResponse{}, err
+		}
+	}
+
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/authorizations/"+authID+"/capture"), paymentCaptureRequest)
 	paymentCaptureResponse := &PaymentCaptureResponse{}
 
@@ -52,7 +71,9 @@ func (c *Client) CaptureAuthorizationWithPaypalRequestId(ctx context.Context,
 	return paymentCaptureResponse, err
 }
 
-// VoidAuthorization voids a previously authorized payment
+// VoidAuthorization voids a previously authorized payment. PayPal answers with
+// an empty body unless SetReturnRepresentation is used, only the ID and status
+// of the returned authorization are set then.
 // Endpoint: POST /v2/payments/authorizations/ID/void
 func (c *Client) VoidAuthorization(ctx context.Context, authID string) (*Authorization, error) {
 	buf := bytes.NewBuffer([]byte(""))
@@ -63,18 +84,39 @@ func (c *Client) VoidAuthorization(ctx context.Context, authID string) (*Authori
 		return auth, err
 	}
 
-	err = c.SendWithAuth(req, auth)
-	return auth, err
+	if err = c.SendWithAuth(req, auth); err != nil {
+		return auth, err
+	}
+	if auth.ID == "" {
+		auth.ID = authID
+		auth.Status = AuthorizationStatusVoided
+	}
+	return auth, nil
 }
 
 // ReauthorizeAuthorization reauthorize a Paypal account payment.
 // PayPal recommends to reauthorize payment after ~3 days
 // Endpoint: POST /v2/payments/authorizations/ID/reauthorize
 func (c *Client) ReauthorizeAuthorization(ctx context.Context, authID string, a *Amount) (*Authorization, error) {
-	buf := bytes.NewBuffer([]byte(`{"amount":{"currency":"` + a.Currency + `","total":"` + a.Total + `"}}`))
-	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/authorizations/"+authID+"/reauthorize"), buf)
+	var amount *Money
+	if a != nil {
+		amount = &Money{Currency: a.Currency, Value: a.Total}
+	}
+	return c.ReauthorizeAuthorizationAmount(ctx, authID, amount)
+}
+
+// ReauthorizeAuthorizationAmount reauthorizes an authorized PayPal account payment
+// once its honor period of 3 days expired. A nil amount reauthorizes the full amount.
+// Doc: https://developer.paypal.com/docs/api/payments/v2/#authorizations_reauthorize
+// Endpoint: POST /v2/payments/authorizations/ID/reauthorize
+func (c *Client) ReauthorizeAuthorizationAmount(ctx context.Context, authID string, amount *Money) (*Authorization, error) {
+	type reauthorizeRequest struct {
+		Amount *Money `json:"amount,omitempty"`
+	}
+
 	auth := &Authorization{}
 
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/authorizations/"+authID+"/reauthorize"), reauthorizeRequest{Amount: amount})
 	if err != nil {
 		return auth, err
 	}
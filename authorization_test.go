@@ -0,0 +1,61 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestAuthorizationVoidAndReauthorize(t *testing.T) {
+	var reauthorizeBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "/v2/payments/authorizations/A1/void":
+			w.WriteHeader(http.StatusNoContent)
+		case "/v2/payments/authorizations/A1/reauthorize":
+			data, _ := ioutil.ReadAll(r.Body)
+			reauthorizeBody = string(data)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"A2","status":"CREATED","expiration_time":"2026-11-12T10:00:00Z"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	auth, err := c.VoidAuthorization(ctx, "A1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.ID != "A1" || auth.Status != AuthorizationStatusVoided {
+		t.Errorf("unexpected voided authorization %+v", auth)
+	}
+
+	auth, err = c.ReauthorizeAuthorization(ctx, "A1", &Amount{Currency: "USD", Total: "10.00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reauthorizeBody != `{"amount":{"currency_code":"USD","value":"10.00"}}` {
+		t.Errorf("unexpected reauthorize request %s", reauthorizeBody)
+	}
+	if auth.ID != "A2" || auth.ExpirationTime == nil {
+		t.Errorf("unexpected reauthorization %+v", auth)
+	}
+
+	if _, err = c.ReauthorizeAuthorizationAmount(ctx, "A1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if reauthorizeBody != `{}` {
+		t.Errorf("expecting a full reauthorization, got %s", reauthorizeBody)
+	}
+}
@@ -0,0 +1,167 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// defaultBatchConcurrency is the number of calls a Batch makes at the same time by default
+const defaultBatchConcurrency = 4
+
+type (
+	// Batch makes many API calls in parallel, e.g. the captures of an end of
+	// day settlement. Each call goes through the client like any other, so the
+	// rate limit, retries and circuit breaker of the client apply.
+	Batch struct {
+		c           *Client
+		ctx         context.Context
+		concurrency int
+	}
+
+	// BatchRefund is a refund of a Batch, see RefundCaptures
+	BatchRefund struct {
+		CaptureID string
+		Request   RefundCaptureRequest
+	}
+
+	// CaptureOrderResult is the outcome of capturing one order of a Batch
+	CaptureOrderResult struct {
+		OrderID  string
+		Response *CaptureOrderResponse
+		Err      error
+	}
+
+	// RefundCaptureResult is the outcome of one refund of a Batch
+	RefundCaptureResult struct {
+		CaptureID string
+		Response  *RefundResponse
+		Err       error
+	}
+
+	// BatchError lists the failed calls of a Batch by ID, in the order of the
+	// calls for an ID called more than once, e.g. two refunds of a capture
+	BatchError struct {
+		Errors map[string][]error
+	}
+)
+
+// Batch returns a Batch making its calls with ctx, 4 at a time
+//
+//	results := c.Batch(ctx).Concurrency(8).CaptureOrders(orderIDs...)
+func (c *Client) Batch(ctx context.Context) *Batch {
+	return &Batch{c: c, ctx: ctx, concurrency: defaultBatchConcurrency}
+}
+
+// Concurrency sets the number of calls made at the same time, at least 1
+func (b *Batch) Concurrency(n int) *Batch {
+	if n < 1 {
+		n = 1
+	}
+	b.concurrency = n
+	return b
+}
+
+// CaptureOrders captures the approved orders, results are in the order of orderIDs
+func (b *Batch) CaptureOrders(orderIDs ...string) []CaptureOrderResult {
+	results := make([]CaptureOrderResult, len(orderIDs))
+	b.run(len(orderIDs), func(ctx context.Context, i int) {
+		results[i].OrderID = orderIDs[i]
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			return
+		}
+		results[i].Response, results[i].Err = b.c.CaptureOrder(ctx, orderIDs[i], CaptureOrderRequest{})
+	})
+	return results
+}
+
+// RefundCaptures refunds the captures, results are in the order of refunds
+func (b *Batch) RefundCaptures(refunds ...BatchRefund) []RefundCaptureResult {
+	results := make([]RefundCaptureResult, len(refunds))
+	b.run(len(refunds), func(ctx context.Context, i int) {
+		results[i].CaptureID = refunds[i].CaptureID
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			return
+		}
+		results[i].Response, results[i].Err = b.c.RefundCapture(ctx, refunds[i].CaptureID, refunds[i].Request)
+	})
+	return results
+}
+
+// Run calls fn for i from 0 to n-1 with the concurrency of the batch, for calls
+// the Batch has no method for. fn stores its results, e.g. in a slice indexed by i.
+// Once the context of the batch is done the remaining calls are skipped.
+func (b *Batch) Run(n int, fn func(ctx context.Context, i int) error) []error {
+	errs := make([]error, n)
+	b.run(n, func(ctx context.Context, i int) {
+		if errs[i] = ctx.Err(); errs[i] == nil {
+			errs[i] = fn(ctx, i)
+		}
+	})
+	return errs
+}
+
+// run calls fn for i from 0 to n-1, concurrency calls at a time
+func (b *Batch) run(n int, fn func(ctx context.Context, i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < b.concurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(b.ctx, i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// CaptureOrdersError returns a *BatchError with the failed captures, nil when all succeeded
+func CaptureOrdersError(results []CaptureOrderResult) error {
+	e := &BatchError{Errors: map[string][]error{}}
+	for _, r := range results {
+		if r.Err != nil {
+			e.Errors[r.OrderID] = append(e.Errors[r.OrderID], r.Err)
+		}
+	}
+	return e.orNil()
+}
+
+// RefundCapturesError returns a *BatchError with the failed refunds, nil when all succeeded
+func RefundCapturesError(results []RefundCaptureResult) error {
+	e := &BatchError{Errors: map[string][]error{}}
+	for _, r := range results {
+		if r.Err != nil {
+			e.Errors[r.CaptureID] = append(e.Errors[r.CaptureID], r.Err)
+		}
+	}
+	return e.orNil()
+}
+
+func (e *BatchError) orNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error lists the failed IDs with their errors
+func (e *BatchError) Error() string {
+	var failures []string
+	for id, errs := range e.Errors {
+		for _, err := range errs {
+			failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+		}
+	}
+	sort.Strings(failures)
+	return fmt.Sprintf("paypal: %d batch calls failed: %s", len(failures), strings.Join(failures, "; "))
+}
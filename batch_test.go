@@ -0,0 +1,104 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestBatchCaptureOrders(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		id := strings.Split(r.URL.Path, "/")[4]
+		switch {
+		case id == "DECLINED":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY","details":[{"issue":"INSTRUMENT_DECLINED"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/refund"):
+			w.Write([]byte(`{"id":"R-` + id + `","status":"COMPLETED"}`))
+		default:
+			w.Write([]byte(`{"id":"` + id + `","status":"COMPLETED"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	ids := []string{"O1", "O2", "DECLINED", "O4", "O5", "O6"}
+	results := c.Batch(ctx).Concurrency(2).CaptureOrders(ids...)
+	if len(results) != len(ids) {
+		t.Fatalf("expecting %d results, got %d", len(ids), len(results))
+	}
+	for i, r := range results {
+		if r.OrderID != ids[i] {
+			t.Errorf("result %d: expecting order %s, got %s", i, ids[i], r.OrderID)
+		}
+		if (r.Err != nil) != (r.OrderID == "DECLINED") || (r.Err == nil && r.Response.ID != r.OrderID) {
+			t.Errorf("unexpected result %+v", r)
+		}
+	}
+	if maxRunning > 2 {
+		t.Errorf("expecting at most 2 concurrent calls, got %d", maxRunning)
+	}
+
+	err := CaptureOrdersError(results)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || len(batchErr.Errors["DECLINED"]) != 1 ||
+		!errors.Is(batchErr.Errors["DECLINED"][0], ErrInstrumentDeclined) {
+		t.Errorf("expecting the declined capture in the batch error, got %v", err)
+	}
+
+	// Failures of calls with the same ID are all kept
+	err = RefundCapturesError([]RefundCaptureResult{
+		{CaptureID: "C1", Err: errors.New("first")},
+		{CaptureID: "C1", Err: errors.New("second")},
+		{CaptureID: "C2"},
+	})
+	if !errors.As(err, &batchErr) || len(batchErr.Errors["C1"]) != 2 ||
+		err.Error() != "paypal: 2 batch calls failed: C1: first; C1: second" {
+		t.Errorf("expecting both refunds of C1 in the batch error, got %v", err)
+	}
+
+	refunds := c.Batch(ctx).RefundCaptures(BatchRefund{CaptureID: "C1"}, BatchRefund{CaptureID: "C2"})
+	if RefundCapturesError(refunds) != nil || refunds[1].Response.ID != "R-C2" {
+		t.Errorf("unexpected refunds %+v", refunds)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	errs := c.Batch(canceled).Run(3, func(ctx context.Context, i int) error {
+		t.Errorf("expecting no call after the context is done")
+		return nil
+	})
+	for _, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expecting context.Canceled, got %v", err)
+		}
+	}
+}
@@ -17,7 +17,7 @@ type (
 		MerchantPreferences MerchantPreferences `json:"merchant_preferences,omitempty"`
 		CreateTime          time.Time           `json:"create_time,omitempty"`
 		UpdateTime          time.Time           `json:"update_time,omitempty"`
-		Links               []Link              `json:"links,omitempty"`
+		Links               Links               `json:"links,omitempty"`
 	}
 
 	// CreateBillingResp.
@@ -30,7 +30,7 @@ type (
 		Name        string      `json:"name,omitempty"`
 		Description string      `json:"description,omitempty"`
 		Plan        BillingPlan `json:"plan,omitempty"`
-		Links       []Link      `json:"links,omitempty"`
+		Links       Links       `json:"links,omitempty"`
 		StartTime   time.Time   `json:"start_time,omitempty"`
 	}
 
@@ -2,9 +2,39 @@ package paypal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 )
 
+// Possible values for `type` in the BillingPlan of a reference transaction
+// agreement token
+//
+// https://developer.paypal.com/docs/limited-release/reference-transactions/
+const (
+	BillingAgreementTypeMerchantInitiated       string = "MERCHANT_INITIATED_BILLING"
+	BillingAgreementTypeMerchantInitiatedSingle string = "MERCHANT_INITIATED_BILLING_SINGLE_AGREEMENT"
+	BillingAgreementTypeChannelInitiated        string = "CHANNEL_INITIATED_BILLING"
+)
+
+// PaymentSourceTokenTypeBillingAgreement is the PaymentSourceToken type to pay
+// with a billing agreement ID
+const PaymentSourceTokenTypeBillingAgreement string = "BILLING_AGREEMENT"
+
+// Possible values for `state` in BillingAgreementFromToken
+const (
+	BillingAgreementStateActive    string = "ACTIVE"
+	BillingAgreementStateCancelled string = "CANCELLED"
+)
+
+// ApprovalURL returns the link the buyer must be redirected to to approve the
+// billing agreement, it is empty when PayPal did not return one
+func (t *BillingAgreementToken) ApprovalURL() string {
+	if link := FindLink(t.Links, "approval_url"); link != nil {
+		return link.Href
+	}
+	return ""
+}
+
 // CreatePaypalBillingAgreementToken - Use this call to create a billing agreement token
 // Endpoint: POST /v1/billing-agreements/agreement-tokens
 // Deprecated: use CreateBillingAgreementToken instead
@@ -90,6 +120,30 @@ func (c *Client) CreateBillingAgreementFromToken(
 	return billingAgreement, nil
 }
 
+// GetBillingAgreement - Use this call to show details for a billing agreement
+// Endpoint: GET /v1/billing-agreements/agreements/{agreement_id}
+func (c *Client) GetBillingAgreement(
+	ctx context.Context,
+	billingAgreementID string,
+) (*BillingAgreementFromToken, error) {
+	billingAgreement := &BillingAgreementFromToken{}
+
+	req, err := c.NewRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s%s%s", c.APIBase, "/v1/billing-agreements/agreements/", billingAgreementID),
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.SendWithAuth(req, billingAgreement); err != nil {
+		return billingAgreement, err
+	}
+
+	return billingAgreement, nil
+}
+
 // CancelBillingAgreement - Use this call to cancel a billing agreement
 // Endpoint: POST /v1/billing-agreements/agreements/{agreement_id}/cancel
 func (c *Client) CancelBillingAgreement(
@@ -113,3 +167,60 @@ func (c *Client) CancelBillingAgreement(
 
 	return nil
 }
+
+// ChargeBillingAgreement takes a merchant-initiated payment against a billing
+// agreement: it creates an order for the purchase units and captures it with
+// the agreement as payment source, no buyer approval is needed.
+// clientMetadataID is sent as PayPal-Client-Metadata-Id with both calls, it is
+// the risk correlation ID PayPal requires when the buyer is present on the site
+// and may be empty otherwise.
+// idempotencyKey identifies the charge, e.g. the ID of the invoice being paid.
+// The PayPal-Request-Id of both calls is derived from it, so calling again with
+// the same key after an error or a lost response returns the order and capture
+// of the first call instead of charging the buyer twice, for as long as PayPal
+// keeps the request IDs. Use a new key for every charge.
+// Endpoint: POST /v2/checkout/orders
+// Endpoint: POST /v2/checkout/orders/ID/capture
+func (c *Client) ChargeBillingAgreement(
+	ctx context.Context,
+	billingAgreementID string,
+	purchaseUnits []PurchaseUnitRequest,
+	clientMetadataID string,
+	idempotencyKey string,
+) (*CaptureOrderResponse, error) {
+	if billingAgreementID == "" {
+		return nil, errors.New("paypal: billing agreement ID is required")
+	}
+	if len(purchaseUnits) == 0 {
+		return nil, errors.New("paypal: at least one purchase unit is required")
+	}
+	if idempotencyKey == "" {
+		return nil, errors.New("paypal: idempotency key is required to charge a billing agreement")
+	}
+
+	if clientMetadataID != "" {
+		ctx = WithRequestOptions(ctx, WithClientMetadataID(clientMetadataID))
+	}
+
+	order, err := c.CreateOrderWithRequest(ctx, CreateOrderRequest{
+		Intent:        OrderIntentCapture,
+		PurchaseUnits: purchaseUnits,
+	}, idempotencyKey+"-create")
+	if err != nil {
+		return nil, err
+	}
+
+	capture, err := c.CaptureOrderWithPaypalRequestId(ctx, order.ID, CaptureOrderRequest{
+		PaymentSource: &PaymentSource{
+			Token: &PaymentSourceToken{
+				ID:   billingAgreementID,
+				Type: PaymentSourceTokenTypeBillingAgreement,
+			},
+		},
+	}, idempotencyKey+"-capture")
+	if err != nil {
+		return capture, fmt.Errorf("paypal: capturing order %s: %w", order.ID, err)
+	}
+
+	return capture, nil
+}
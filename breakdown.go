@@ -0,0 +1,352 @@
+package paypal
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Validate checks that the currency is supported and that the breakdown adds
+// up to the amount, PayPal rejects orders where it doesn't with
+// ITEM_TOTAL_MISMATCH or AMOUNT_MISMATCH:
+// item_total + tax_total + shipping + handling + insurance - shipping_discount - discount = value
+func (a PurchaseUnitAmount) Validate() error {
+	if a.Currency != "" && !IsValidCurrency(a.Currency) {
+		return fmt.Errorf("paypal: unsupported currency %q", a.Currency)
+	}
+	if a.Breakdown == nil {
+		return nil
+	}
+
+	value, err := parseAmount(a.Value)
+	if err != nil {
+		return err
+	}
+
+	b := a.Breakdown
+	total := new(big.Rat)
+	parts := []struct {
+		name  string
+		money *Money
+		sign  int
+	}{
+		{"item_total", b.ItemTotal, 1},
+		{"tax_total", b.TaxTotal, 1},
+		{"shipping", b.Shipping, 1},
+		{"handling", b.Handling, 1},
+		{"insurance", b.Insurance, 1},
+		{"shipping_discount", b.ShippingDiscount, -1},
+		{"discount", b.Discount, -1},
+	}
+	for _, part := range parts {
+		if part.money == nil {
+			continue
+		}
+		if part.money.Currency != a.Currency {
+			return fmt.Errorf("paypal: breakdown %s currency %s does not match amount currency %s", part.name, part.money.Currency, a.Currency)
+		}
+		v, err := parseAmount(part.money.Value)
+		if err != nil {
+			return fmt.Errorf("paypal: breakdown %s: %v", part.name, err)
+		}
+		if part.sign < 0 {
+			total.Sub(total, v)
+		} else {
+			total.Add(total, v)
+		}
+	}
+
+	if total.Cmp(value) != 0 {
+		decimals := CurrencyDecimals(a.Currency)
+		return fmt.Errorf("paypal: amount breakdown adds up to %s %s but amount value is %s %s",
+			total.FloatString(decimals), a.Currency, a.Value, a.Currency)
+	}
+
+	return nil
+}
+
+// Validate checks the shipping country, the amount breakdown and that the items add up to its
+// item_total and tax_total, which PayPal rejects with ITEM_TOTAL_MISMATCH
+// and TAX_TOTAL_MISMATCH otherwise
+func (p PurchaseUnitRequest) Validate() error {
+	if p.Shipping != nil && p.Shipping.Address != nil {
+		if country := p.Shipping.Address.CountryCode; country != "" && !IsValidCountry(country) {
+			return fmt.Errorf("paypal: invalid shipping country_code %q", country)
+		}
+	}
+	if err := validateShippingOptions(p.Shipping, p.Amount); err != nil {
+		return err
+	}
+	if p.Amount == nil {
+		return nil
+	}
+	if err := p.Amount.Validate(); err != nil {
+		return err
+	}
+	if p.Amount.Breakdown == nil || len(p.Items) == 0 {
+		return nil
+	}
+
+	itemTotal, taxTotal := new(big.Rat), new(big.Rat)
+	for i, item := range p.Items {
+		if err := addItem(p.Amount.Currency, item, itemTotal, taxTotal); err != nil {
+			return fmt.Errorf("paypal: item %d: %v", i, err)
+		}
+	}
+
+	checks := []struct {
+		name  string
+		money *Money
+		total *big.Rat
+	}{
+		{"item_total", p.Amount.Breakdown.ItemTotal, itemTotal},
+		{"tax_total", p.Amount.Breakdown.TaxTotal, taxTotal},
+	}
+	for _, check := range checks {
+		if check.money == nil {
+			continue
+		}
+		v, err := parseAmount(check.money.Value)
+		if err != nil {
+			return fmt.Errorf("paypal: breakdown %s: %v", check.name, err)
+		}
+		if v.Cmp(check.total) != 0 {
+			return fmt.Errorf("paypal: items add up to %s %s but breakdown %s is %s %s",
+				check.total.FloatString(CurrencyDecimals(p.Amount.Currency)), p.Amount.Currency,
+				check.name, check.money.Value, check.money.Currency)
+		}
+	}
+
+	return nil
+}
+
+// validateShippingOptions checks that at most one shipping option is selected
+// and that its amount is the shipping of the breakdown, PayPal rejects orders
+// with MULTIPLE_SHIPPING_OPTION_SELECTED or
+// PREFERRED_SHIPPING_OPTION_AMOUNT_MISMATCH otherwise
+func validateShippingOptions(shipping *ShippingDetail, amount *PurchaseUnitAmount) error {
+	if shipping == nil {
+		return nil
+	}
+
+	var selected *ShippingOption
+	ids := make(map[string]bool, len(shipping.Options))
+	for i, option := range shipping.Options {
+		if ids[option.ID] {
+			return fmt.Errorf("paypal: shipping option ID %q is used twice", option.ID)
+		}
+		ids[option.ID] = true
+		if !option.Selected {
+			continue
+		}
+		if selected != nil {
+			return fmt.Errorf("paypal: shipping options %q and %q are both selected", selected.ID, option.ID)
+		}
+		selected = &shipping.Options[i]
+	}
+	if selected == nil || selected.Amount == nil || amount == nil || amount.Breakdown == nil || amount.Breakdown.Shipping == nil {
+		return nil
+	}
+
+	option, err := parseAmount(selected.Amount.Value)
+	if err != nil {
+		return fmt.Errorf("paypal: shipping option %q: %v", selected.ID, err)
+	}
+	breakdown, err := parseAmount(amount.Breakdown.Shipping.Value)
+	if err != nil {
+		return fmt.Errorf("paypal: breakdown shipping: %v", err)
+	}
+	if option.Cmp(breakdown) != 0 || selected.Amount.Currency != amount.Breakdown.Shipping.Currency {
+		return fmt.Errorf("paypal: selected shipping option %q costs %s %s but breakdown shipping is %s %s", selected.ID,
+			selected.Amount.Value, selected.Amount.Currency, amount.Breakdown.Shipping.Value, amount.Breakdown.Shipping.Currency)
+	}
+	return nil
+}
+
+// AmountBuilder assembles a PurchaseUnitAmount from its items, taxes, shipping,
+// handling, insurance and discounts, and computes the total from them:
+//
+//	b := paypal.NewAmountBuilder("USD").
+//		AddItems(items...).
+//		Shipping("4.50").
+//		Discount("5.00")
+//	amount, err := b.Build()
+//	unit := paypal.PurchaseUnitRequest{Amount: amount, Items: b.Items()}
+//
+// The first invalid value is returned by Build.
+type AmountBuilder struct {
+	currency string
+	items    []Item
+	tax      *big.Rat
+	parts    map[string]*big.Rat
+	err      error
+}
+
+// NewAmountBuilder returns an AmountBuilder for the currency
+func NewAmountBuilder(currency string) *AmountBuilder {
+	b := &AmountBuilder{
+		currency: currency,
+		tax:      new(big.Rat),
+		parts:    map[string]*big.Rat{},
+	}
+	if !IsValidCurrency(currency) {
+		b.err = fmt.Errorf("paypal: unsupported currency %q", currency)
+	}
+	return b
+}
+
+// AddItems adds the items, their unit amount and tax multiplied by their
+// quantity count towards item_total and tax_total
+func (b *AmountBuilder) AddItems(items ...Item) *AmountBuilder {
+	b.items = append(b.items, items...)
+	return b
+}
+
+// Tax adds a tax that is not attributed to an item to tax_total
+func (b *AmountBuilder) Tax(value string) *AmountBuilder {
+	if v := b.parse("tax_total", value); v != nil {
+		b.tax.Add(b.tax, v)
+	}
+	return b
+}
+
+// Shipping sets the shipping fee
+func (b *AmountBuilder) Shipping(value string) *AmountBuilder {
+	return b.set("shipping", value)
+}
+
+// Handling sets the handling fee
+func (b *AmountBuilder) Handling(value string) *AmountBuilder {
+	return b.set("handling", value)
+}
+
+// Insurance sets the insurance fee
+func (b *AmountBuilder) Insurance(value string) *AmountBuilder {
+	return b.set("insurance", value)
+}
+
+// ShippingDiscount sets the discount on the shipping fee, as a positive value
+func (b *AmountBuilder) ShippingDiscount(value string) *AmountBuilder {
+	return b.set("shipping_discount", value)
+}
+
+// Discount sets the discount on the item total, as a positive value
+func (b *AmountBuilder) Discount(value string) *AmountBuilder {
+	return b.set("discount", value)
+}
+
+// Items returns the items added to the builder, to set as purchase unit items
+func (b *AmountBuilder) Items() []Item {
+	return b.items
+}
+
+// Build returns the amount with its breakdown, the value being the sum of
+// the breakdown
+func (b *AmountBuilder) Build() (*PurchaseUnitAmount, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	itemTotal, taxTotal := new(big.Rat), new(big.Rat).Set(b.tax)
+	for i, item := range b.items {
+		if err := addItem(b.currency, item, itemTotal, taxTotal); err != nil {
+			return nil, fmt.Errorf("paypal: item %d: %v", i, err)
+		}
+	}
+
+	breakdown := &PurchaseUnitAmountBreakdown{}
+	total := new(big.Rat)
+	parts := []struct {
+		name  string
+		value *big.Rat
+		field **Money
+		sign  int
+	}{
+		{"item_total", itemTotal, &breakdown.ItemTotal, 1},
+		{"tax_total", taxTotal, &breakdown.TaxTotal, 1},
+		{"shipping", b.parts["shipping"], &breakdown.Shipping, 1},
+		{"handling", b.parts["handling"], &breakdown.Handling, 1},
+		{"insurance", b.parts["insurance"], &breakdown.Insurance, 1},
+		{"shipping_discount", b.parts["shipping_discount"], &breakdown.ShippingDiscount, -1},
+		{"discount", b.parts["discount"], &breakdown.Discount, -1},
+	}
+	for _, part := range parts {
+		if part.value == nil || (part.value.Sign() == 0 && (part.name != "item_total" || len(b.items) == 0)) {
+			continue
+		}
+		formatted, ok := formatRat(b.currency, part.value)
+		if !ok {
+			return nil, fmt.Errorf("paypal: %s %s has more than %d decimals", part.name, b.currency, CurrencyDecimals(b.currency))
+		}
+		*part.field = &Money{Currency: b.currency, Value: formatted}
+		if part.sign < 0 {
+			total.Sub(total, part.value)
+		} else {
+			total.Add(total, part.value)
+		}
+	}
+
+	if total.Sign() < 0 {
+		return nil, fmt.Errorf("paypal: discounts exceed the amount, total is %s %s",
+			total.FloatString(CurrencyDecimals(b.currency)), b.currency)
+	}
+
+	value, _ := formatRat(b.currency, total)
+	amount := &PurchaseUnitAmount{Currency: b.currency, Value: value, Breakdown: breakdown}
+	if err := amount.Validate(); err != nil {
+		return nil, err
+	}
+	return amount, nil
+}
+
+func (b *AmountBuilder) set(name, value string) *AmountBuilder {
+	if v := b.parse(name, value); v != nil {
+		b.parts[name] = v
+	}
+	return b
+}
+
+func (b *AmountBuilder) parse(name, value string) *big.Rat {
+	if b.err != nil {
+		return nil
+	}
+	v, err := parseAmount(value)
+	if err != nil {
+		b.err = fmt.Errorf("paypal: %s: %v", name, err)
+		return nil
+	}
+	if v.Sign() < 0 {
+		b.err = fmt.Errorf("paypal: %s must not be negative", name)
+		return nil
+	}
+	return v
+}
+
+// addItem adds the unit amount and tax of the item multiplied by its quantity
+// to itemTotal and taxTotal
+func addItem(currency string, item Item, itemTotal, taxTotal *big.Rat) error {
+	quantity, ok := new(big.Int).SetString(item.Quantity, 10)
+	if !ok || quantity.Sign() <= 0 {
+		return fmt.Errorf("invalid quantity %q", item.Quantity)
+	}
+	q := new(big.Rat).SetInt(quantity)
+
+	add := func(name string, m *Money, total *big.Rat) error {
+		if m == nil {
+			return nil
+		}
+		if m.Currency != currency {
+			return fmt.Errorf("%s currency %s does not match amount currency %s", name, m.Currency, currency)
+		}
+		v, err := parseAmount(m.Value)
+		if err != nil {
+			return err
+		}
+		total.Add(total, v.Mul(v, q))
+		return nil
+	}
+
+	if err := add("unit_amount", item.UnitAmount, itemTotal); err != nil {
+		return err
+	}
+	return add("tax", item.Tax, taxTotal)
+}
@@ -0,0 +1,100 @@
+package paypal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPurchaseUnitAmountBreakdown(t *testing.T) {
+	amount := &PurchaseUnitAmount{
+		Currency: "USD",
+		Value:    "107.50",
+		Breakdown: &PurchaseUnitAmountBreakdown{
+			ItemTotal: &Money{Currency: "USD", Value: "100.00"},
+			TaxTotal:  &Money{Currency: "USD", Value: "8.00"},
+			Shipping:  &Money{Currency: "USD", Value: "4.50"},
+			Discount:  &Money{Currency: "USD", Value: "5.00"},
+		},
+	}
+
+	if err := amount.Validate(); err != nil {
+		t.Errorf("expecting a matching breakdown to be valid, got %v", err)
+	}
+
+	amount.Value = "110.00"
+	err := PurchaseUnitRequest{Amount: amount}.Validate()
+	if err == nil || !strings.Contains(err.Error(), "adds up to 107.50 USD") {
+		t.Errorf("expecting a mismatch error, got %v", err)
+	}
+
+	amount.Value = "107.50"
+	amount.Breakdown.Shipping.Currency = "EUR"
+	if err := amount.Validate(); err == nil {
+		t.Errorf("expecting a currency mismatch error")
+	}
+
+	if err := (&PurchaseUnitAmount{Currency: "USD", Value: "1.00"}).Validate(); err != nil {
+		t.Errorf("expecting an amount without breakdown to be valid, got %v", err)
+	}
+
+	// Marshalling never validates, e.g. to store a decoded response
+	amount.Value = "110.00"
+	if _, err := json.Marshal(PurchaseUnitRequest{Amount: amount}); err != nil {
+		t.Errorf("expecting a mismatching breakdown to marshal, got %v", err)
+	}
+}
+
+func TestAmountBuilder(t *testing.T) {
+	items := []Item{
+		{Name: "T-Shirt", Quantity: "2", UnitAmount: &Money{Currency: "USD", Value: "15.00"}, Tax: &Money{Currency: "USD", Value: "1.20"}},
+		{Name: "Cap", Quantity: "1", UnitAmount: &Money{Currency: "USD", Value: "9.99"}},
+	}
+
+	b := NewAmountBuilder("USD").
+		AddItems(items...).
+		Shipping("4.5").
+		ShippingDiscount("4.50").
+		Discount("3")
+	amount, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := json.Marshal(amount)
+	expected := `{"currency_code":"USD","value":"39.39","breakdown":{"item_total":{"currency_code":"USD","value":"39.99"},"shipping":{"currency_code":"USD","value":"4.50"},"tax_total":{"currency_code":"USD","value":"2.40"},"shipping_discount":{"currency_code":"USD","value":"4.50"},"discount":{"currency_code":"USD","value":"3.00"}}}`
+	if string(got) != expected {
+		t.Errorf("unexpected amount\n%s\nexpected\n%s", got, expected)
+	}
+
+	unit := PurchaseUnitRequest{Amount: amount, Items: b.Items()}
+	if err := unit.Validate(); err != nil {
+		t.Errorf("expecting the built purchase unit to be valid, got %v", err)
+	}
+
+	unit.Items = items[:1]
+	err = unit.Validate()
+	if err == nil || !strings.Contains(err.Error(), "breakdown item_total is 39.99 USD") {
+		t.Errorf("expecting an item total mismatch, got %v", err)
+	}
+
+	invalid := []*AmountBuilder{
+		NewAmountBuilder("usd"),
+		NewAmountBuilder("USD").Shipping("abc"),
+		NewAmountBuilder("USD").Handling("-1.00"),
+		NewAmountBuilder("JPY").Shipping("1.50"),
+		NewAmountBuilder("USD").AddItems(Item{Name: "A", Quantity: "0", UnitAmount: &Money{Currency: "USD", Value: "1.00"}}),
+		NewAmountBuilder("USD").AddItems(Item{Name: "A", Quantity: "1", UnitAmount: &Money{Currency: "EUR", Value: "1.00"}}),
+		NewAmountBuilder("USD").AddItems(items...).Discount("100.00"),
+	}
+	for i, b := range invalid {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%d: expecting an error", i)
+		}
+	}
+
+	jpy, err := NewAmountBuilder("JPY").Shipping("500").Tax("40").Build()
+	if err != nil || jpy.Value != "540" || jpy.Breakdown.ItemTotal != nil {
+		t.Errorf("unexpected JPY amount %+v %v", jpy, err)
+	}
+}
@@ -0,0 +1,125 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker is open
+var ErrCircuitOpen = errors.New("paypal: circuit breaker is open")
+
+// Possible values for `CircuitState` in RequestMetrics
+const (
+	// CircuitStateClosed lets all calls through
+	CircuitStateClosed string = "closed"
+	// CircuitStateOpen fails all calls with ErrCircuitOpen until the cooldown elapsed
+	CircuitStateOpen string = "open"
+	// CircuitStateHalfOpen lets a single trial call through after the cooldown
+	CircuitStateHalfOpen string = "half_open"
+)
+
+// circuitBreaker counts consecutive failed calls and short-circuits new calls
+// for the cooldown period once maxFailures is reached. After the cooldown a
+// single trial call is let through; its result closes or reopens the circuit.
+type circuitBreaker struct {
+	sync.Mutex
+	maxFailures int
+	cooldown    time.Duration
+	failures    int
+	lastFailure time.Time
+	openUntil   time.Time
+	probing     bool
+}
+
+// SetCircuitBreaker enables a circuit breaker that trips after maxFailures consecutive
+// failures (network errors, 429 and 5xx responses) within the cooldown window.
+// While open, calls fail fast with ErrCircuitOpen until cooldown elapses.
+// A maxFailures of 0 disables the circuit breaker.
+func (c *Client) SetCircuitBreaker(maxFailures int, cooldown time.Duration) {
+	if maxFailures <= 0 {
+		c.breaker = nil
+		return
+	}
+	c.breaker = &circuitBreaker{
+		maxFailures: maxFailures,
+		cooldown:    cooldown,
+	}
+}
+
+// CircuitState returns the current state of the circuit breaker, one of the
+// CircuitState constants, or an empty string without SetCircuitBreaker
+func (c *Client) CircuitState() string {
+	if c.breaker == nil {
+		return ""
+	}
+	return c.breaker.state()
+}
+
+func (b *circuitBreaker) state() string {
+	b.Lock()
+	defer b.Unlock()
+
+	switch {
+	case b.openUntil.IsZero():
+		return CircuitStateClosed
+	case b.probing || !time.Now().Before(b.openUntil):
+		return CircuitStateHalfOpen
+	}
+	return CircuitStateOpen
+}
+
+// allow reports whether a call may be made
+func (b *circuitBreaker) allow() error {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.openUntil.IsZero() {
+		return nil
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return ErrCircuitOpen
+	}
+
+	b.probing = true
+	return nil
+}
+
+// record registers the outcome of a call. A call canceled by the caller says
+// nothing about PayPal's health: it neither closes nor reopens the circuit, a
+// canceled trial call lets the next call try again.
+func (b *circuitBreaker) record(resp *http.Response, err error) {
+	b.Lock()
+	defer b.Unlock()
+
+	probe := b.probing
+	b.probing = false
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if !isBreakerFailure(resp, err) {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(b.lastFailure) > b.cooldown {
+		b.failures = 0
+	}
+	b.failures++
+	b.lastFailure = now
+
+	if probe || b.failures >= b.maxFailures {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+func isBreakerFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
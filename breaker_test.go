@@ -0,0 +1,125 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/v1/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetCircuitBreaker(2, 50*time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetOrder(context.Background(), "ID"); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: circuit opened too early", i)
+		}
+	}
+
+	if _, err := c.GetOrder(context.Background(), "ID"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expecting ErrCircuitOpen, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expecting 2 calls to reach the server, got %d", calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	// The trial call after the cooldown fails again and reopens the circuit
+	if _, err := c.GetOrder(context.Background(), "ID"); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expecting a trial call after the cooldown")
+	}
+	if _, err := c.GetOrder(context.Background(), "ID"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expecting ErrCircuitOpen after a failed trial call, got %v", err)
+	}
+}
+
+func TestCircuitBreakerCanceledProbe(t *testing.T) {
+	b := &circuitBreaker{maxFailures: 1, cooldown: time.Millisecond}
+	b.record(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+	time.Sleep(2 * time.Millisecond)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("expecting a trial call after the cooldown, got %v", err)
+	}
+	b.record(nil, context.Canceled)
+	if state := b.state(); state != CircuitStateHalfOpen {
+		t.Errorf("expecting a canceled trial call to keep the circuit half open, got %s", state)
+	}
+	if err := b.allow(); err != nil {
+		t.Errorf("expecting another trial call after a canceled one, got %v", err)
+	}
+}
+
+func TestCircuitBreakerMetrics(t *testing.T) {
+	fail := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":"ID"}`))
+	}))
+	defer ts.Close()
+
+	var states []string
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+	c.SetMetricsHook(func(m RequestMetrics) {
+		states = append(states, m.CircuitState)
+	})
+
+	if state := c.CircuitState(); state != "" {
+		t.Fatalf("expecting no state without a circuit breaker, got %q", state)
+	}
+	c.GetOrder(context.Background(), "ID")
+
+	c.SetCircuitBreaker(1, 30*time.Millisecond)
+	if state := c.CircuitState(); state != CircuitStateClosed {
+		t.Fatalf("expecting a closed circuit, got %q", state)
+	}
+	c.GetOrder(context.Background(), "ID")
+	c.GetOrder(context.Background(), "ID")
+
+	time.Sleep(40 * time.Millisecond)
+	if state := c.CircuitState(); state != CircuitStateHalfOpen {
+		t.Fatalf("expecting a half open circuit after the cooldown, got %q", state)
+	}
+	fail = false
+	if _, err := c.GetOrder(context.Background(), "ID"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"", CircuitStateOpen, CircuitStateOpen, CircuitStateClosed}
+	if len(states) != len(expected) {
+		t.Fatalf("expecting states %q, got %q", expected, states)
+	}
+	for i := range expected {
+		if states[i] != expected[i] {
+			t.Errorf("call %d: expecting state %q, got %q", i, expected[i], states[i])
+		}
+	}
+}
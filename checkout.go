@@ -0,0 +1,163 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Checkout is the standard PayPal checkout of an order: StartCheckout creates
+// the order, the buyer approves it at ApprovalURL and Complete captures it.
+//
+//	co, err := c.StartCheckout(ctx, paypal.CreateOrderRequest{Intent: paypal.OrderIntentCapture, PurchaseUnits: units})
+//	// redirect the buyer to co.ApprovalURL(), then in the handler of the return URL
+//	co, err = c.ResumeCheckout(ctx, orderID)
+//	order, err := co.Complete(ctx)
+//	if errors.Is(err, paypal.ErrInstrumentDeclined) {
+//		// redirect the buyer to co.ApprovalURL() again to choose another funding source
+//	}
+type Checkout struct {
+	OrderID string
+	Intent  string
+	// Order is the order as last returned by PayPal
+	Order *Order
+
+	c           *Client
+	approvalURL string
+}
+
+// StartCheckout creates the order of a checkout
+// Endpoint: POST /v2/checkout/orders
+func (c *Client) StartCheckout(ctx context.Context, order CreateOrderRequest) (*Checkout, error) {
+	created, err := c.CreateOrderWithRequest(ctx, order, "")
+	if err != nil {
+		return nil, err
+	}
+	return newCheckout(c, created), nil
+}
+
+// ResumeCheckout returns the checkout of a created order, e.g. in the handler
+// of the return URL the buyer is sent to after approving it
+// Endpoint: GET /v2/checkout/orders/ID
+func (c *Client) ResumeCheckout(ctx context.Context, orderID string) (*Checkout, error) {
+	order, err := c.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return newCheckout(c, order), nil
+}
+
+func newCheckout(c *Client, order *Order) *Checkout {
+	co := &Checkout{OrderID: order.ID, Intent: order.Intent, Order: order, c: c}
+	co.setApprovalURL(order.Links, "approve", "payer-action")
+	return co
+}
+
+// ApprovalURL returns the link the buyer must be redirected to to approve the
+// order, or to choose another funding source after Complete failed with
+// ErrInstrumentDeclined or ErrPayerActionRequired. It is empty when PayPal
+// did not return one, e.g. for orders that are already approved.
+func (co *Checkout) ApprovalURL() string {
+	return co.approvalURL
+}
+
+// Complete captures the approved order. Orders with the AUTHORIZE intent are
+// authorized and the authorizations captured right away, calling Complete
+// again after a failed capture captures the remaining authorizations.
+//
+// When the funding source of the buyer is declined, Complete returns an error
+// matching ErrInstrumentDeclined: the order can still be paid, the buyer has to
+// be redirected to ApprovalURL to choose another funding source before Complete
+// is called again. An order captured before, e.g. by a submission of the return
+// page that timed out, is returned without error.
+//
+// Endpoint: POST /v2/checkout/orders/ID/capture
+// Endpoint: POST /v2/checkout/orders/ID/authorize
+// Endpoint: POST /v2/payments/authorizations/ID/capture
+func (co *Checkout) Complete(ctx context.Context) (*Order, error) {
+	var err error
+	switch co.Intent {
+	case OrderIntentCapture:
+		err = co.send(ctx, "capture")
+	case OrderIntentAuthorize:
+		err = co.send(ctx, "authorize")
+		if HasIssue(err, ErrorIssueOrderAlreadyAuthorized) {
+			// A previous Complete authorized the order but failed to capture it
+			co.Order, err = co.c.GetOrder(ctx, co.OrderID)
+		}
+		if err == nil {
+			err = co.captureAuthorizations(ctx)
+		}
+	default:
+		return nil, fmt.Errorf("paypal: unknown intent %q of order %s", co.Intent, co.OrderID)
+	}
+
+	if errors.Is(err, ErrOrderAlreadyCaptured) {
+		if co.Order, err = co.c.GetOrder(ctx, co.OrderID); err != nil {
+			return nil, err
+		}
+		return co.Order, nil
+	}
+	if err != nil {
+		var errResp *ErrorResponse
+		if errors.As(err, &errResp) && (errors.Is(err, ErrInstrumentDeclined) || errors.Is(err, ErrPayerActionRequired)) {
+			co.setApprovalURL(errResp.Links, "redirect", "payer-action")
+		}
+		return nil, err
+	}
+
+	return co.Order, nil
+}
+
+// send captures or authorizes the order
+func (co *Checkout) send(ctx context.Context, action string) error {
+	order := &Order{}
+
+	req, err := co.c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", co.c.APIBase, "/v2/checkout/orders/"+co.OrderID+"/"+action), struct{}{})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Prefer", "return=representation")
+
+	if err = co.c.SendWithAuth(req, order); err != nil {
+		return err
+	}
+
+	co.Order = order
+	return nil
+}
+
+// captureAuthorizations captures the authorizations of the order and gets the
+// order with its captures
+func (co *Checkout) captureAuthorizations(ctx context.Context) error {
+	for _, unit := range co.Order.PurchaseUnits {
+		if unit.Payments == nil {
+			continue
+		}
+		for _, auth := range unit.Payments.Authorizations {
+			if auth.Status != AuthorizationStatusCreated {
+				continue
+			}
+			if _, err := co.c.CaptureAuthorization(ctx, auth.ID, &PaymentCaptureRequest{FinalCapture: true}); err != nil {
+				return err
+			}
+		}
+	}
+
+	order, err := co.c.GetOrder(ctx, co.OrderID)
+	if err != nil {
+		return err
+	}
+	co.Order = order
+	return nil
+}
+
+// setApprovalURL sets the approval URL to the first of the links found
+func (co *Checkout) setApprovalURL(links []Link, rels ...string) {
+	for _, rel := range rels {
+		if link := FindLink(links, rel); link != nil {
+			co.approvalURL = link.Href
+			return
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckoutInstrumentDeclined(t *testing.T) {
+	captures := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v2/checkout/orders":
+			w.Write([]byte(`{"id":"O1","intent":"CAPTURE","status":"CREATED","links":[
+				{"href":"https://www.sandbox.paypal.com/checkoutnow?token=O1","rel":"approve","method":"GET"}]}`))
+		case "GET /v2/checkout/orders/O1":
+			w.Write([]byte(`{"id":"O1","intent":"CAPTURE","status":"APPROVED"}`))
+		case "POST /v2/checkout/orders/O1/capture":
+			if r.Header.Get("Prefer") != "return=representation" {
+				http.Error(w, "expecting the full representation to be requested", http.StatusBadRequest)
+				return
+			}
+			captures++
+			switch captures {
+			case 1:
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY","details":[{"issue":"INSTRUMENT_DECLINED"}],"links":[
+					{"href":"https://www.sandbox.paypal.com/checkoutnow?token=O1&restart","rel":"redirect","method":"GET"}]}`))
+			case 2:
+				w.Write([]byte(`{"id":"O1","intent":"CAPTURE","status":"COMPLETED","purchase_units":[{"reference_id":"default",
+					"payments":{"captures":[{"id":"C1","status":"COMPLETED"}]}}]}`))
+			default:
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY","details":[{"issue":"ORDER_ALREADY_CAPTURED"}]}`))
+			}
+		default:
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+	ctx := context.Background()
+
+	co, err := c.StartCheckout(ctx, CreateOrderRequest{
+		Intent:        OrderIntentCapture,
+		PurchaseUnits: []PurchaseUnitRequest{{Amount: &PurchaseUnitAmount{Currency: "USD", Value: "7.00"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if co.OrderID != "O1" || co.ApprovalURL() != "https://www.sandbox.paypal.com/checkoutnow?token=O1" {
+		t.Fatalf("unexpected checkout %s %s", co.OrderID, co.ApprovalURL())
+	}
+
+	co, err = c.ResumeCheckout(ctx, "O1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = co.Complete(ctx); !errors.Is(err, ErrInstrumentDeclined) {
+		t.Fatalf("expecting ErrInstrumentDeclined, got %v", err)
+	}
+	if co.ApprovalURL() != "https://www.sandbox.paypal.com/checkoutnow?token=O1&restart" {
+		t.Errorf("expecting the restart link, got %s", co.ApprovalURL())
+	}
+
+	order, err := co.Complete(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != OrderStatusCompleted || order.PurchaseUnits[0].Payments.Captures[0].ID != "C1" {
+		t.Errorf("unexpected order %+v", order)
+	}
+
+	// Completing again returns the captured order
+	if order, err = co.Complete(ctx); err != nil || order.ID != "O1" {
+		t.Errorf("expecting the captured order, got %+v, %v", order, err)
+	}
+}
+
+func TestCheckoutAuthorize(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v2/checkout/orders/O1":
+			if len(requests) == 1 {
+				w.Write([]byte(`{"id":"O1","intent":"AUTHORIZE","status":"APPROVED"}`))
+				return
+			}
+			w.Write([]byte(`{"id":"O1","intent":"AUTHORIZE","status":"COMPLETED","purchase_units":[{"reference_id":"default",
+				"payments":{"authorizations":[{"id":"A1","status":"CAPTURED"}],"captures":[{"id":"C1","status":"COMPLETED"}]}}]}`))
+		case "POST /v2/checkout/orders/O1/authorize":
+			w.Write([]byte(`{"id":"O1","intent":"AUTHORIZE","status":"COMPLETED","purchase_units":[{"reference_id":"default",
+				"payments":{"authorizations":[{"id":"A1","status":"CREATED"}]}}]}`))
+		case "POST /v2/payments/authorizations/A1/capture":
+			w.Write([]byte(`{"id":"C1","status":"COMPLETED"}`))
+		default:
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+	ctx := context.Background()
+
+	co, err := c.ResumeCheckout(ctx, "O1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := co.Complete(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if captures := order.PurchaseUnits[0].Payments.Captures; len(captures) != 1 || captures[0].ID != "C1" {
+		t.Errorf("expecting the order with its capture, got %+v", order.PurchaseUnits[0].Payments)
+	}
+	if len(requests) != 4 || requests[2] != "POST /v2/payments/authorizations/A1/capture" {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
@@ -3,6 +3,7 @@ package paypal
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,18 +11,102 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
 	"time"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+// ClientOption configures a Client created with NewClient
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the http.Client used to make requests, e.g. for proxies,
+// custom TLS or connection pooling, see SetHTTPClient
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.SetHTTPClient(client)
+	}
+}
+
+// WithTimeout sets the time limit of every request, including reading the response body.
+// It doesn't modify an http.Client set with WithHTTPClient.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		client := *c.baseHTTPClient()
+		client.Timeout = timeout
+		c.httpClient = &client
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to make requests.
+// It doesn't modify an http.Client set with WithHTTPClient.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		client := *c.baseHTTPClient()
+		client.Transport = transport
+		c.httpClient = &client
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the transport used to make
+// requests, including the access token requests, e.g. to trust a corporate CA
+// bundle or to present a client certificate to an egress proxy.
+// It applies to a clone of the *http.Transport of the client, any other
+// http.RoundTripper is replaced by a clone of http.DefaultTransport.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		transport := c.cloneTransport()
+		transport.TLSClientConfig = config
+		WithTransport(transport)(c)
+	}
+}
+
+// WithProxy sends all requests through the proxy at proxyURL instead of the
+// proxy of the environment, a nil proxyURL disables proxies.
+// It applies to the transport like WithTLSConfig.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		transport := c.cloneTransport()
+		transport.Proxy = nil
+		if proxyURL != nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+		WithTransport(transport)(c)
+	}
+}
+
+// cloneTransport returns a clone of the *http.Transport of the client for the
+// transport options to modify
+func (c *Client) cloneTransport() *http.Transport {
+	if transport, ok := c.baseHTTPClient().Transport.(*http.Transport); ok {
+		return transport.Clone()
+	}
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+// WithPartnerAttributionID sets the BN code sent with every request, see SetPartnerAttributionID
+func WithPartnerAttributionID(bnCode string) ClientOption {
+	return func(c *Client) {
+		c.SetPartnerAttributionID(bnCode)
+	}
+}
+
 // NewClient returns new Client struct
-// APIBase is a base API URL, for testing you can use paypal.APIBaseSandBox
-func NewClient(clientID string, secret string, APIBase string) (*Client, error) {
+// APIBase is a base API URL, for testing you can use paypal.APIBaseSandBox,
+// or an Environment such as string(paypal.Sandbox), see ParseEnvironment. An
+// APIBase that is neither fails instead of sending requests to a wrong URL.
+// Options are applied in order, e.g. WithHTTPClient before WithTimeout.
+func NewClient(clientID string, secret string, APIBase string, opts ...ClientOption) (*Client, error) {
 	if clientID == "" || secret == "" || APIBase == "" {
 		return nil, errors.New("ClientID, Secret and APIBase are required to create a Client")
 	}
-	return &Client{
+	env, err := ParseEnvironment(APIBase)
+	if err != nil {
+		return nil, err
+	}
+	APIBase = env.APIBase()
+	c := &Client{
 		ccCfg: &clientcredentials.Config{
 			ClientID:     clientID,
 			ClientSecret: secret,
@@ -30,82 +115,354 @@ func NewClient(clientID string, secret string, APIBase string) (*Client, error)
 		ClientID: clientID,
 		Secret:   secret,
 		APIBase:  APIBase,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
 }
 
-// SetAccessToken sets saved token to current client
+// SetAccessToken sets saved token to current client.
+// The expiry of the token is unknown, it is used until PayPal rejects it with
+// 401 Unauthorized, the client then falls back to getting its own tokens.
 func (c *Client) SetAccessToken(token string) {
+	c.SetAccessTokenWithExpiry(token, time.Time{})
+}
+
+// SetAccessTokenWithExpiry is like SetAccessToken for a token expiring at
+// expiry, e.g. TokenResponse.ExpiresAt. The client gets its own tokens once
+// it is about to expire.
+func (c *Client) SetAccessTokenWithExpiry(token string, expiry time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
 	c.Token = &TokenResponse{
-		Token: token,
+		Token:     token,
+		ExpiresAt: expiry,
 	}
-	c.tokenExpiresAt = time.Time{}
+	c.tokenExpiresAt = expiry
+	c.tokenInjected = true
 }
 
 // SetLog will set/change the output destination.
-// If log file is set paypal will log all requests and responses to this Writer
+// If log file is set paypal will log all requests and responses to this Writer.
+// Tokens, credentials, card numbers and emails are redacted, see SetLogUnredacted.
 func (c *Client) SetLog(log io.Writer) {
 	c.Log = log
 }
 
+// SetLogUnredacted disables the redaction of tokens, credentials, card numbers
+// and emails in the log, to debug requests against the sandbox. Logs of the
+// live API are always redacted.
+func (c *Client) SetLogUnredacted(enabled bool) {
+	c.logUnredacted = enabled
+}
+
+// SetHTTPClient sets the http.Client used to make requests, including the
+// OAuth2 token requests. By default http.DefaultClient is used.
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
 // SetReturnRepresentation enables verbose response
 // Verbose response: https://developer.paypal.com/docs/api/orders/v2/#orders-authorize-header-parameters
 func (c *Client) SetReturnRepresentation() {
 	c.returnRepresentation = true
 }
 
+// SetRequestValidation enables checking payloads implementing Validator, e.g.
+// orders, payouts and subscriptions, before they are sent, so invalid
+// requests fail locally with a descriptive error instead of a 400 from PayPal
+func (c *Client) SetRequestValidation(enabled bool) {
+	c.validateRequests = enabled
+}
+
+// SetPartnerAttributionID sends the PayPal-Partner-Attribution-Id header with
+// the given BN code on every request. Pass an empty ID to stop sending it.
+// Doc: https://developer.paypal.com/docs/api/reference/api-requests/#paypal-partner-attribution-id
+func (c *Client) SetPartnerAttributionID(bnCode string) {
+	c.partnerAttributionID = bnCode
+}
+
+// SetClientMetadataID sends the PayPal-Client-Metadata-Id header with the given
+// ID on every request, e.g. for a client serving a single buyer session. Set it
+// per request with WithClientMetadataID otherwise. Pass an empty ID to stop sending it.
+func (c *Client) SetClientMetadataID(id string) {
+	c.clientMetadataID = id
+}
+
+// Do makes an authenticated request to the API, the response body will be
+// unmarshaled into v, or if v is an io.Writer, the response will
+// be written to it without decoding.
+// The returned response has its headers intact but its body is already
+// consumed and closed. It is returned for API errors too, and is nil only
+// when no response was received.
+func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
+	client, err := c.authClient(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(client, req, v)
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized || !c.dropInjectedToken() ||
+		!canRewindBody(req) || rewindBody(req) != nil {
+		return resp, err
+	}
+
+	// The token set with SetAccessToken expired, retry with a token of the client
+	if client, err = c.authClient(req.Context()); err != nil {
+		return nil, err
+	}
+	return c.do(client, req, v)
+}
+
+// SetTokenRefreshTimeout bounds the time spent getting an access token
+// separately from the deadline of the request, so a slow token endpoint fails
+// fast instead of consuming the whole request deadline. 0 disables the bound.
+func (c *Client) SetTokenRefreshTimeout(d time.Duration) {
+	c.tokenRefreshTimeout = d
+}
+
+// authClient returns the http.Client adding the access token to requests
+func (c *Client) authClient(ctx context.Context) (*http.Client, error) {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("paypal: getting access token: %w", err)
+	}
+	if c.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	}
+	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(token))
+
+	// The oauth2 client only reuses the transport of the configured client
+	if c.httpClient != nil {
+		client.Timeout = c.httpClient.Timeout
+		client.CheckRedirect = c.httpClient.CheckRedirect
+		client.Jar = c.httpClient.Jar
+	}
+
+	return client, nil
+}
+
 // Send makes a request to the API, the response body will be
 // unmarshaled into v, or if v is an io.Writer, the response will
 // be written to it without decoding
 func (c *Client) Send(req *http.Request, v interface{}) error {
+	_, err := c.Do(req, v)
+	return err
+}
+
+// do performs the request with the given http.Client
+func (c *Client) do(client *http.Client, req *http.Request, v interface{}) (*http.Response, error) {
+	applyRequestOptions(req)
+
+	if c.responseCache != nil {
+		if resp, ok, err := c.responseCache.serve(c.cacheKey(req), req, v); ok {
+			storeResponseMetadata(req.Context(), resp)
+			return resp, err
+		}
+	}
+
+	req, cancel := c.applyRequestTimeout(req)
+	defer cancel()
+
+	start := time.Now()
+
+	req, span := c.startSpan(req)
+
+	var (
+		resp    *http.Response
+		err     error
+		retries int
+	)
+	for {
+		resp, err = c.sendRequest(client, req, v)
+		if c.readAfterWrite != nil {
+			c.readAfterWrite.record(req, resp, v, err)
+		}
+
+		delay, retry := c.nextRetry(req, resp, err, retries)
+		if !retry || !sleepContext(req.Context(), delay) || rewindBody(req) != nil {
+			break
+		}
+		retries++
+	}
+	if errResp, ok := err.(*ErrorResponse); ok {
+		errResp.Attempts = retries + 1
+	}
+
+	if c.responseCache != nil && req.Method != http.MethodGet && err == nil {
+		c.responseCache.invalidate(req.URL.Path)
+	}
+
+	m := newRequestMetrics(req, resp, err, time.Since(start), retries)
+	if c.breaker != nil {
+		m.CircuitState = c.breaker.state()
+	}
+	c.reportMetrics(req.Context(), m)
+	storeResponseMetadata(req.Context(), resp)
+	endSpan(span, resp, err, retries)
+
+	return resp, err
+}
+
+// sendRequest performs the request and returns the response with its body
+// consumed and closed, if a response was received
+func (c *Client) sendRequest(client *http.Client, req *http.Request, v interface{}) (*http.Response, error) {
 	var (
 		err  error
 		resp *http.Response
 		data []byte
 	)
 
-	// Set default headers
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en_US")
-
 	// Default values for headers
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if req.Header.Get("Accept-Language") == "" {
+		req.Header.Set("Accept-Language", "en_US")
+	}
+	acceptGzip(req)
 	if req.Header.Get("Content-type") == "" {
 		req.Header.Set("Content-type", "application/json")
 	}
-	if c.returnRepresentation {
+	if c.returnRepresentation && req.Header.Get("Prefer") == "" {
 		req.Header.Set("Prefer", "return=representation")
 	}
+	if c.partnerAttributionID != "" && req.Header.Get("PayPal-Partner-Attribution-Id") == "" {
+		req.Header.Set("PayPal-Partner-Attribution-Id", c.partnerAttributionID)
+	}
+	if c.clientMetadataID != "" && req.Header.Get("PayPal-Client-Metadata-Id") == "" {
+		req.Header.Set("PayPal-Client-Metadata-Id", c.clientMetadataID)
+	}
+
+	setContextRequestID(req)
+	c.setAuthAssertion(req)
+	if c.autoIdempotency {
+		c.setIdempotencyKey(req)
+	}
+
+	c.setMockResponse(req)
+
+	var cacheKey string
+	if c.etags != nil || c.responseCache != nil {
+		cacheKey = c.cacheKey(req)
+	}
+	if c.etags != nil {
+		c.etags.prepare(cacheKey, req)
+	}
 
-	// get client
-	client := c.ccCfg.Client(req.Context())
+	if c.limiter != nil {
+		var release func()
+		if release, err = c.limiter.acquire(req.Context()); err != nil {
+			return nil, err
+		}
+		defer release()
+	}
 
-	resp, err = client.Do(req)
+	if c.breaker != nil {
+		if err = c.breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	c.writeCurl(req)
+	sent := time.Now()
+	resp, err = c.withMiddlewares(client).Do(req)
+	decompressResponse(resp)
 	c.log(req, resp)
+	c.logEntry(req, resp, err, time.Since(sent))
+
+	if c.limiter != nil {
+		c.limiter.record(resp)
+	}
+
+	if c.recorder != nil && err == nil {
+		c.record(req, resp)
+	}
+
+	if c.breaker != nil {
+		c.breaker.record(resp, err)
+	}
 
 	if err != nil {
-		return err
+		return resp, err
 	}
 	defer resp.Body.Close()
+	captureRawBody(req, resp)
+
+	if resp.StatusCode == http.StatusNotModified && c.etags != nil {
+		if data, ok := c.etags.get(cacheKey); ok {
+			storeRawBody(req.Context(), data)
+			if v == nil {
+				return resp, nil
+			}
+			return resp, c.unmarshalJSON(data, v)
+		}
+	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		errResp := &ErrorResponse{Response: resp}
+		errResp := &ErrorResponse{Response: resp, Method: req.Method, URL: req.URL.String(), Attempts: 1}
+		errResp.RequestHeader, errResp.RequestBody = redactedRequest(req)
 		data, err = ioutil.ReadAll(resp.Body)
 
 		if err == nil && len(data) > 0 {
+			errResp.Body = data
 			json.Unmarshal(data, errResp)
 		}
+		if errResp.DebugID == "" {
+			errResp.DebugID = resp.Header.Get("Paypal-Debug-Id")
+		}
 
-		return errResp
+		return resp, errResp
 	}
-	if v == nil {
-		return nil
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+
+	if w, ok := v.(responseWriter); ok {
+		if err = w.beginResponse(resp); err != nil {
+			return resp, err
+		}
 	}
 
 	if w, ok := v.(io.Writer); ok {
-		io.Copy(w, resp.Body)
-		return nil
+		_, err = io.Copy(w, resp.Body)
+		return resp, err
+	}
+
+	etag := resp.Header.Get("ETag")
+	cacheETag := etag != "" && c.etags != nil && req.Method == http.MethodGet && !noStore(resp.Header)
+	cacheResponse := c.responseCache != nil && c.responseCache.cacheable(req)
+	if cacheETag || cacheResponse {
+		if data, err = ioutil.ReadAll(resp.Body); err != nil {
+			return resp, err
+		}
+		if cacheETag {
+			c.etags.put(cacheKey, etag, data)
+		}
+		if cacheResponse {
+			c.responseCache.put(cacheKey, req, resp, data)
+		}
+		return resp, c.unmarshalJSON(data, v)
 	}
 
-	return json.NewDecoder(resp.Body).Decode(v)
+	return resp, c.decodeJSON(resp.Body, v)
+}
+
+// sleepContext waits for d and reports whether ctx is still active afterwards
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
 }
 
 // SendWithAuth makes a request to the API and apply OAuth2 header automatically.
@@ -120,21 +477,36 @@ func (c *Client) SendWithAuth(req *http.Request, v interface{}) error {
 func (c *Client) SendWithBasicAuth(req *http.Request, v interface{}) error {
 	req.SetBasicAuth(c.ClientID, c.Secret)
 
-	return c.Send(req, v)
+	// The OAuth2 transport would replace the basic auth header with a bearer token
+	_, err := c.do(c.baseHTTPClient(), req, v)
+	return err
+}
+
+// baseHTTPClient returns the http.Client for requests without OAuth2
+func (c *Client) baseHTTPClient() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+	return http.DefaultClient
 }
 
 // NewRequest constructs a request
-// Convert payload to a JSON
-func (c *Client) NewRequest(ctx context.Context, method, url string, payload interface{}) (*http.Request, error) {
+// Convert payload to a JSON, opts are applied when the request is sent
+func (c *Client) NewRequest(ctx context.Context, method, url string, payload interface{}, opts ...RequestOption) (*http.Request, error) {
 	var buf io.Reader
 	if payload != nil {
+		if v, ok := payload.(Validator); ok && c.validateRequests {
+			if err := v.Validate(); err != nil {
+				return nil, err
+			}
+		}
 		b, err := json.Marshal(&payload)
 		if err != nil {
 			return nil, err
 		}
 		buf = bytes.NewBuffer(b)
 	}
-	return http.NewRequestWithContext(ctx, method, url, buf)
+	return http.NewRequestWithContext(WithRequestOptions(ctx, opts...), method, url, buf)
 }
 
 // log will dump request and response to the log file
@@ -145,11 +517,27 @@ func (c *Client) log(r *http.Request, resp *http.Response) {
 			respDump []byte
 		)
 
+		redact := !c.logUnredacted || c.APIBase == APIBaseLive
+
 		if r != nil {
 			reqDump = fmt.Sprintf("%s %s. Data: %s", r.Method, r.URL.String(), r.Form.Encode())
+			if r.GetBody != nil {
+				if body, err := r.GetBody(); err == nil {
+					data, _ := ioutil.ReadAll(body)
+					body.Close()
+					if redact {
+						data = redactBody(r.Header.Get("Content-Type"), data)
+					}
+					reqDump += " Body: " + string(data)
+				}
+			}
 		}
 		if resp != nil {
-			respDump, _ = httputil.DumpResponse(resp, true)
+			if redact {
+				respDump = dumpRedactedResponse(resp)
+			} else {
+				respDump, _ = httputil.DumpResponse(resp, true)
+			}
 		}
 
 		c.Log.Write([]byte(fmt.Sprintf("Request: %s\nResponse: %s\n", reqDump, string(respDump))))
@@ -0,0 +1,82 @@
+package paypal
+
+import "sync"
+
+type (
+	// MerchantCredentials identifies the merchant account a Client of a
+	// ClientPool makes requests for
+	MerchantCredentials struct {
+		ClientID string
+		Secret   string
+		// PartnerAttributionID is the BN code sent with every request, see SetPartnerAttributionID
+		PartnerAttributionID string
+		// SellerMerchantID or SellerEmail make the Client act on behalf of a
+		// seller, see SetAuthAssertion and SetAuthAssertionEmail
+		SellerMerchantID string
+		SellerEmail      string
+	}
+
+	// ClientPool creates and caches a Client per merchant, each with its own
+	// access token, for platforms managing many merchant accounts.
+	// It is safe for concurrent use.
+	ClientPool struct {
+		mu      sync.Mutex
+		apiBase string
+		opts    []ClientOption
+		clients map[MerchantCredentials]*Client
+	}
+)
+
+// NewClientPool returns a ClientPool creating clients for the API at APIBase,
+// opts are applied to every client before the options of its credentials
+func NewClientPool(APIBase string, opts ...ClientOption) *ClientPool {
+	return &ClientPool{
+		apiBase: APIBase,
+		opts:    opts,
+		clients: map[MerchantCredentials]*Client{},
+	}
+}
+
+// Client returns the Client of the merchant, creating it on first use.
+// Rotated credentials get a new Client, Remove drops the one of the old credentials.
+func (p *ClientPool) Client(creds MerchantCredentials) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[creds]; ok {
+		return c, nil
+	}
+
+	opts := append([]ClientOption{}, p.opts...)
+	if creds.PartnerAttributionID != "" {
+		opts = append(opts, WithPartnerAttributionID(creds.PartnerAttributionID))
+	}
+	if creds.SellerMerchantID != "" {
+		opts = append(opts, WithAuthAssertion(creds.SellerMerchantID))
+	} else if creds.SellerEmail != "" {
+		opts = append(opts, WithAuthAssertionEmail(creds.SellerEmail))
+	}
+
+	c, err := NewClient(creds.ClientID, creds.Secret, p.apiBase, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[creds] = c
+	return c, nil
+}
+
+// Remove drops the Client of the merchant, e.g. once it was offboarded
+func (p *ClientPool) Remove(creds MerchantCredentials) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, creds)
+}
+
+// Len returns the number of cached clients
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.clients)
+}
@@ -0,0 +1,88 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestClientPool(t *testing.T) {
+	var mu sync.Mutex
+	tokens := map[string]int{}
+	var bnCodes, assertions []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/v1/oauth2/token" {
+			clientID, _, _ := r.BasicAuth()
+			tokens[clientID]++
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "token-" + clientID, Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		bnCodes = append(bnCodes, r.Header.Get("PayPal-Partner-Attribution-Id"))
+		assertions = append(assertions, r.Header.Get("PayPal-Auth-Assertion"))
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	pool := NewClientPool(ts.URL, WithPartnerAttributionID("PLATFORM_BN"))
+	merchantA := MerchantCredentials{ClientID: "a", Secret: "secret-a"}
+	merchantB := MerchantCredentials{ClientID: "b", Secret: "secret-b", PartnerAttributionID: "SELLER_BN", SellerMerchantID: "SELLER-B"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		for _, creds := range []MerchantCredentials{merchantA, merchantB} {
+			wg.Add(1)
+			go func(creds MerchantCredentials) {
+				defer wg.Done()
+				c, err := pool.Client(creds)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := c.GetOrder(context.Background(), "O1"); err != nil {
+					t.Error(err)
+				}
+			}(creds)
+		}
+	}
+	wg.Wait()
+
+	if pool.Len() != 2 || tokens["a"] != 1 || tokens["b"] != 1 {
+		t.Errorf("expecting one client and one token per merchant, got %d clients and tokens %v", pool.Len(), tokens)
+	}
+
+	b, _ := pool.Client(merchantB)
+	if b.partnerAttributionID != "SELLER_BN" || b.seller == nil || b.seller.value != "SELLER-B" {
+		t.Errorf("expecting the client to use the options of the merchant, got BN code %q and seller %+v", b.partnerAttributionID, b.seller)
+	}
+	var seller int
+	for i := range bnCodes {
+		if assertions[i] != "" {
+			seller++
+			if bnCodes[i] != "SELLER_BN" || assertions[i] != b.AuthAssertion("SELLER-B") {
+				t.Errorf("unexpected headers of the seller request: %q %q", bnCodes[i], assertions[i])
+			}
+		} else if bnCodes[i] != "PLATFORM_BN" {
+			t.Errorf("expecting the BN code of the pool, got %q", bnCodes[i])
+		}
+	}
+	if seller != 4 {
+		t.Errorf("expecting 4 requests on behalf of the seller, got %d", seller)
+	}
+
+	pool.Remove(merchantA)
+	if pool.Len() != 1 {
+		t.Errorf("expecting the client to be removed, got %d clients", pool.Len())
+	}
+	if _, err := pool.Client(MerchantCredentials{ClientID: "c"}); err == nil {
+		t.Error("expecting an error without secret")
+	}
+}
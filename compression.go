@@ -0,0 +1,57 @@
+package paypal
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gzipBody decompresses a gzip encoded response body. The gzip reader is
+// created on the first read, so empty bodies, e.g. of 204 responses, don't fail.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil {
+		zr, err := gzip.NewReader(b.body)
+		if err != nil {
+			return 0, err
+		}
+		b.zr = zr
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
+
+// acceptGzip asks for a gzip compressed response. Setting the header disables
+// the transparent decompression of http.Transport, decompressResponse does it
+// instead, which also works with transports that don't decompress.
+// Like http.Transport, it is not asked for range and HEAD requests: a range of
+// the compressed representation cannot be decompressed on its own.
+func acceptGzip(req *http.Request) {
+	if req.Method == http.MethodHead || req.Header.Get("Range") != "" {
+		return
+	}
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+}
+
+// decompressResponse replaces the body of a gzip encoded response with its
+// decompressed content
+func decompressResponse(resp *http.Response) {
+	if resp == nil || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
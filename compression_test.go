@@ -0,0 +1,83 @@
+package paypal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGzipResponse(t *testing.T) {
+	var acceptEncoding string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v2/checkout/orders/EMPTY" {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`{"id":"O1","status":"COMPLETED"}`))
+		zw.Close()
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+
+	order, err := c.GetOrder(context.Background(), "O1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acceptEncoding != "gzip" {
+		t.Errorf("expecting Accept-Encoding gzip, got %q", acceptEncoding)
+	}
+	if order.ID != "O1" || order.Status != OrderStatusCompleted {
+		t.Errorf("unexpected order %+v", order)
+	}
+
+	if _, err := c.GetOrder(context.Background(), "EMPTY"); err != nil {
+		t.Errorf("expecting an empty gzip response to succeed, got %v", err)
+	}
+}
+
+func TestGzipRange(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+	var acceptEncoding string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		body := content
+		if acceptEncoding == "gzip" {
+			// The range applies to the gzip representation
+			buf := &bytes.Buffer{}
+			zw := gzip.NewWriter(buf)
+			zw.Write(content)
+			zw.Close()
+			body = buf.Bytes()
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+		w.Header().Set("Content-Range", "bytes 10-19/20")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(body[10:])
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+
+	req, _ := c.NewRequest(context.Background(), http.MethodGet, ts.URL+"/report", nil)
+	buf := &bytes.Buffer{}
+	if _, err := c.DownloadRange(req, buf, 10); err != nil {
+		t.Fatal(err)
+	}
+	if acceptEncoding != "" {
+		t.Errorf("expecting no Accept-Encoding for a range request, got %q", acceptEncoding)
+	}
+	if buf.String() != "abcdefghij" {
+		t.Errorf("unexpected download %q", buf)
+	}
+}
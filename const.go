@@ -14,6 +14,13 @@ const (
 	BillingPlanStatusActive BillingPlanStatus = "ACTIVE"
 )
 
+type PricingModel string
+
+const (
+	PricingModelVolume PricingModel = "VOLUME"
+	PricingModelTiered PricingModel = "TIERED"
+)
+
 type IntervalUnit string
 
 const (
@@ -45,11 +52,28 @@ const (
 	ShippingPreferenceSetProvidedAddress ShippingPreference = "SET_PROVIDED_ADDRESS"
 )
 
+type LandingPage string
+
+const (
+	LandingPageLogin         LandingPage = "LOGIN"
+	LandingPageBilling       LandingPage = "BILLING"
+	LandingPageNoPreference  LandingPage = "NO_PREFERENCE"
+	LandingPageGuestCheckout LandingPage = "GUEST_CHECKOUT"
+)
+
+type PayeePreferred string
+
+const (
+	PayeePreferredUnrestricted             PayeePreferred = "UNRESTRICTED"
+	PayeePreferredImmediatePaymentRequired PayeePreferred = "IMMEDIATE_PAYMENT_REQUIRED"
+)
+
 type UserAction string
 
 const (
-	UserActionContinue UserAction = "CONTINUE"
-	UserActionPayNow   UserAction = "PAY_NOW"
+	UserActionContinue     UserAction = "CONTINUE"
+	UserActionPayNow       UserAction = "PAY_NOW"
+	UserActionSubscribeNow UserAction = "SUBSCRIBE_NOW"
 )
 
 type SubscriptionStatus string
@@ -101,3 +125,53 @@ const (
 	ProductCategorySoftwareOther                             ProductCategory = "OTHER"
 	ProductCategorySoftwareServices                          ProductCategory = "SERVICES"
 )
+
+//Doc: https://developer.paypal.com/docs/api/tracking/v1/#definition-tracker_status
+type ShipmentStatus string
+
+const (
+	ShipmentStatusShipped   ShipmentStatus = "SHIPPED"
+	ShipmentStatusOnHold    ShipmentStatus = "ON_HOLD"
+	ShipmentStatusDelivered ShipmentStatus = "DELIVERED"
+	ShipmentStatusCancelled ShipmentStatus = "CANCELLED"
+	// ShipmentStatusLocalPickup is only accepted by the v1 Tracking API
+	ShipmentStatusLocalPickup ShipmentStatus = "LOCAL_PICKUP"
+)
+
+// Carrier is a carrier code recognized by PayPal. Only the most common codes are
+// listed here, any other code from the PayPal list can be used as Carrier("CODE").
+// When CarrierOther is used the carrier name has to be set separately.
+//Doc: https://developer.paypal.com/docs/tracking/reference/carriers/
+type Carrier string
+
+const (
+	CarrierUPS           Carrier = "UPS"
+	CarrierUSPS          Carrier = "USPS"
+	CarrierFedEx         Carrier = "FEDEX"
+	CarrierOnTrac        Carrier = "ONTRAC"
+	CarrierDHL           Carrier = "DHL"
+	CarrierDHLExpress    Carrier = "DHL_API"
+	CarrierDHLGlobalMail Carrier = "DHL_GLOBAL_MAIL"
+	CarrierCanadaPost    Carrier = "CANADA_POST"
+	CarrierRoyalMail     Carrier = "ROYAL_MAIL"
+	CarrierParcelforce   Carrier = "PARCELFORCE"
+	CarrierDPD           Carrier = "DPD"
+	CarrierDPDUK         Carrier = "DPD_UK"
+	CarrierGLS           Carrier = "GLS"
+	CarrierHermes        Carrier = "HERMES"
+	CarrierTNT           Carrier = "TNT"
+	CarrierAramex        Carrier = "ARAMEX"
+	CarrierPostNL        Carrier = "POSTNL"
+	CarrierBpost         Carrier = "BPOST"
+	CarrierAustraliaPost Carrier = "AUSTRALIA_POST"
+	CarrierChinaPost     Carrier = "CHINA_POST"
+	CarrierChinaEMS      Carrier = "CHINA_EMS"
+	CarrierJapanPost     Carrier = "JAPAN_POST"
+	CarrierYamato        Carrier = "YAMATO"
+	CarrierSagawa        Carrier = "SAGAWA"
+	CarrierKoreaPost     Carrier = "KOREA_POST"
+	CarrierIndiaPost     Carrier = "INDIA_POST"
+	CarrierBlueDart      Carrier = "BLUEDART"
+	CarrierDelhivery     Carrier = "DELHIVERY"
+	CarrierOther         Carrier = "OTHER"
+)
@@ -0,0 +1,519 @@
+package paypal
+
+// Country codes PayPal accepts in addresses, ISO 3166-1 alpha-2 codes plus C2,
+// the code PayPal uses for China worldwide when the merchant is not in China
+//
+// https://developer.paypal.com/reference/country-codes/
+const (
+	CountryAD string = "AD" // Andorra
+	CountryAE string = "AE" // United Arab Emirates
+	CountryAF string = "AF" // Afghanistan
+	CountryAG string = "AG" // Antigua and Barbuda
+	CountryAI string = "AI" // Anguilla
+	CountryAL string = "AL" // Albania
+	CountryAM string = "AM" // Armenia
+	CountryAO string = "AO" // Angola
+	CountryAQ string = "AQ" // Antarctica
+	CountryAR string = "AR" // Argentina
+	CountryAS string = "AS" // American Samoa
+	CountryAT string = "AT" // Austria
+	CountryAU string = "AU" // Australia
+	CountryAW string = "AW" // Aruba
+	CountryAX string = "AX" // Åland Islands
+	CountryAZ string = "AZ" // Azerbaijan
+	CountryBA string = "BA" // Bosnia and Herzegovina
+	CountryBB string = "BB" // Barbados
+	CountryBD string = "BD" // Bangladesh
+	CountryBE string = "BE" // Belgium
+	CountryBF string = "BF" // Burkina Faso
+	CountryBG string = "BG" // Bulgaria
+	CountryBH string = "BH" // Bahrain
+	CountryBI string = "BI" // Burundi
+	CountryBJ string = "BJ" // Benin
+	CountryBL string = "BL" // Saint Barthélemy
+	CountryBM string = "BM" // Bermuda
+	CountryBN string = "BN" // Brunei Darussalam
+	CountryBO string = "BO" // Bolivia
+	CountryBQ string = "BQ" // Bonaire, Sint Eustatius and Saba
+	CountryBR string = "BR" // Brazil
+	CountryBS string = "BS" // Bahamas
+	CountryBT string = "BT" // Bhutan
+	CountryBV string = "BV" // Bouvet Island
+	CountryBW string = "BW" // Botswana
+	CountryBY string = "BY" // Belarus
+	CountryBZ string = "BZ" // Belize
+	CountryC2 string = "C2" // China worldwide
+	CountryCA string = "CA" // Canada
+	CountryCC string = "CC" // Cocos (Keeling) Islands
+	CountryCD string = "CD" // Congo, Democratic Republic of the
+	CountryCF string = "CF" // Central African Republic
+	CountryCG string = "CG" // Congo
+	CountryCH string = "CH" // Switzerland
+	CountryCI string = "CI" // Côte d'Ivoire
+	CountryCK string = "CK" // Cook Islands
+	CountryCL string = "CL" // Chile
+	CountryCM string = "CM" // Cameroon
+	CountryCN string = "CN" // China
+	CountryCO string = "CO" // Colombia
+	CountryCR string = "CR" // Costa Rica
+	CountryCU string = "CU" // Cuba
+	CountryCV string = "CV" // Cabo Verde
+	CountryCW string = "CW" // Curaçao
+	CountryCX string = "CX" // Christmas Island
+	CountryCY string = "CY" // Cyprus
+	CountryCZ string = "CZ" // Czechia
+	CountryDE string = "DE" // Germany
+	CountryDJ string = "DJ" // Djibouti
+	CountryDK string = "DK" // Denmark
+	CountryDM string = "DM" // Dominica
+	CountryDO string = "DO" // Dominican Republic
+	CountryDZ string = "DZ" // Algeria
+	CountryEC string = "EC" // Ecuador
+	CountryEE string = "EE" // Estonia
+	CountryEG string = "EG" // Egypt
+	CountryEH string = "EH" // Western Sahara
+	CountryER string = "ER" // Eritrea
+	CountryES string = "ES" // Spain
+	CountryET string = "ET" // Ethiopia
+	CountryFI string = "FI" // Finland
+	CountryFJ string = "FJ" // Fiji
+	CountryFK string = "FK" // Falkland Islands
+	CountryFM string = "FM" // Micronesia
+	CountryFO string = "FO" // Faroe Islands
+	CountryFR string = "FR" // France
+	CountryGA string = "GA" // Gabon
+	CountryGB string = "GB" // United Kingdom
+	CountryGD string = "GD" // Grenada
+	CountryGE string = "GE" // Georgia
+	CountryGF string = "GF" // French Guiana
+	CountryGG string = "GG" // Guernsey
+	CountryGH string = "GH" // Ghana
+	CountryGI string = "GI" // Gibraltar
+	CountryGL string = "GL" // Greenland
+	CountryGM string = "GM" // Gambia
+	CountryGN string = "GN" // Guinea
+	CountryGP string = "GP" // Guadeloupe
+	CountryGQ string = "GQ" // Equatorial Guinea
+	CountryGR string = "GR" // Greece
+	CountryGS string = "GS" // South Georgia and the South Sandwich Islands
+	CountryGT string = "GT" // Guatemala
+	CountryGU string = "GU" // Guam
+	CountryGW string = "GW" // Guinea-Bissau
+	CountryGY string = "GY" // Guyana
+	CountryHK string = "HK" // Hong Kong
+	CountryHM string = "HM" // Heard Island and McDonald Islands
+	CountryHN string = "HN" // Honduras
+	CountryHR string = "HR" // Croatia
+	CountryHT string = "HT" // Haiti
+	CountryHU string = "HU" // Hungary
+	CountryID string = "ID" // Indonesia
+	CountryIE string = "IE" // Ireland
+	CountryIL string = "IL" // Israel
+	CountryIM string = "IM" // Isle of Man
+	CountryIN string = "IN" // India
+	CountryIO string = "IO" // British Indian Ocean Territory
+	CountryIQ string = "IQ" // Iraq
+	CountryIR string = "IR" // Iran
+	CountryIS string = "IS" // Iceland
+	CountryIT string = "IT" // Italy
+	CountryJE string = "JE" // Jersey
+	CountryJM string = "JM" // Jamaica
+	CountryJO string = "JO" // Jordan
+	CountryJP string = "JP" // Japan
+	CountryKE string = "KE" // Kenya
+	CountryKG string = "KG" // Kyrgyzstan
+	CountryKH string = "KH" // Cambodia
+	CountryKI string = "KI" // Kiribati
+	CountryKM string = "KM" // Comoros
+	CountryKN string = "KN" // Saint Kitts and Nevis
+	CountryKP string = "KP" // Korea, Democratic People's Republic of
+	CountryKR string = "KR" // Korea, Republic of
+	CountryKW string = "KW" // Kuwait
+	CountryKY string = "KY" // Cayman Islands
+	CountryKZ string = "KZ" // Kazakhstan
+	CountryLA string = "LA" // Lao People's Democratic Republic
+	CountryLB string = "LB" // Lebanon
+	CountryLC string = "LC" // Saint Lucia
+	CountryLI string = "LI" // Liechtenstein
+	CountryLK string = "LK" // Sri Lanka
+	CountryLR string = "LR" // Liberia
+	CountryLS string = "LS" // Lesotho
+	CountryLT string = "LT" // Lithuania
+	CountryLU string = "LU" // Luxembourg
+	CountryLV string = "LV" // Latvia
+	CountryLY string = "LY" // Libya
+	CountryMA string = "MA" // Morocco
+	CountryMC string = "MC" // Monaco
+	CountryMD string = "MD" // Moldova
+	CountryME string = "ME" // Montenegro
+	CountryMF string = "MF" // Saint Martin (French part)
+	CountryMG string = "MG" // Madagascar
+	CountryMH string = "MH" // Marshall Islands
+	CountryMK string = "MK" // North Macedonia
+	CountryML string = "ML" // Mali
+	CountryMM string = "MM" // Myanmar
+	CountryMN string = "MN" // Mongolia
+	CountryMO string = "MO" // Macao
+	CountryMP string = "MP" // Northern Mariana Islands
+	CountryMQ string = "MQ" // Martinique
+	CountryMR string = "MR" // Mauritania
+	CountryMS string = "MS" // Montserrat
+	CountryMT string = "MT" // Malta
+	CountryMU string = "MU" // Mauritius
+	CountryMV string = "MV" // Maldives
+	CountryMW string = "MW" // Malawi
+	CountryMX string = "MX" // Mexico
+	CountryMY string = "MY" // Malaysia
+	CountryMZ string = "MZ" // Mozambique
+	CountryNA string = "NA" // Namibia
+	CountryNC string = "NC" // New Caledonia
+	CountryNE string = "NE" // Niger
+	CountryNF string = "NF" // Norfolk Island
+	CountryNG string = "NG" // Nigeria
+	CountryNI string = "NI" // Nicaragua
+	CountryNL string = "NL" // Netherlands
+	CountryNO string = "NO" // Norway
+	CountryNP string = "NP" // Nepal
+	CountryNR string = "NR" // Nauru
+	CountryNU string = "NU" // Niue
+	CountryNZ string = "NZ" // New Zealand
+	CountryOM string = "OM" // Oman
+	CountryPA string = "PA" // Panama
+	CountryPE string = "PE" // Peru
+	CountryPF string = "PF" // French Polynesia
+	CountryPG string = "PG" // Papua New Guinea
+	CountryPH string = "PH" // Philippines
+	CountryPK string = "PK" // Pakistan
+	CountryPL string = "PL" // Poland
+	CountryPM string = "PM" // Saint Pierre and Miquelon
+	CountryPN string = "PN" // Pitcairn
+	CountryPR string = "PR" // Puerto Rico
+	CountryPS string = "PS" // Palestine
+	CountryPT string = "PT" // Portugal
+	CountryPW string = "PW" // Palau
+	CountryPY string = "PY" // Paraguay
+	CountryQA string = "QA" // Qatar
+	CountryRE string = "RE" // Réunion
+	CountryRO string = "RO" // Romania
+	CountryRS string = "RS" // Serbia
+	CountryRU string = "RU" // Russian Federation
+	CountryRW string = "RW" // Rwanda
+	CountrySA string = "SA" // Saudi Arabia
+	CountrySB string = "SB" // Solomon Islands
+	CountrySC string = "SC" // Seychelles
+	CountrySD string = "SD" // Sudan
+	CountrySE string = "SE" // Sweden
+	CountrySG string = "SG" // Singapore
+	CountrySH string = "SH" // Saint Helena, Ascension and Tristan da Cunha
+	CountrySI string = "SI" // Slovenia
+	CountrySJ string = "SJ" // Svalbard and Jan Mayen
+	CountrySK string = "SK" // Slovakia
+	CountrySL string = "SL" // Sierra Leone
+	CountrySM string = "SM" // San Marino
+	CountrySN string = "SN" // Senegal
+	CountrySO string = "SO" // Somalia
+	CountrySR string = "SR" // Suriname
+	CountrySS string = "SS" // South Sudan
+	CountryST string = "ST" // Sao Tome and Principe
+	CountrySV string = "SV" // El Salvador
+	CountrySX string = "SX" // Sint Maarten (Dutch part)
+	CountrySY string = "SY" // Syrian Arab Republic
+	CountrySZ string = "SZ" // Eswatini
+	CountryTC string = "TC" // Turks and Caicos Islands
+	CountryTD string = "TD" // Chad
+	CountryTF string = "TF" // French Southern Territories
+	CountryTG string = "TG" // Togo
+	CountryTH string = "TH" // Thailand
+	CountryTJ string = "TJ" // Tajikistan
+	CountryTK string = "TK" // Tokelau
+	CountryTL string = "TL" // Timor-Leste
+	CountryTM string = "TM" // Turkmenistan
+	CountryTN string = "TN" // Tunisia
+	CountryTO string = "TO" // Tonga
+	CountryTR string = "TR" // Türkiye
+	CountryTT string = "TT" // Trinidad and Tobago
+	CountryTV string = "TV" // Tuvalu
+	CountryTW string = "TW" // Taiwan
+	CountryTZ string = "TZ" // Tanzania
+	CountryUA string = "UA" // Ukraine
+	CountryUG string = "UG" // Uganda
+	CountryUM string = "UM" // United States Minor Outlying Islands
+	CountryUS string = "US" // United States
+	CountryUY string = "UY" // Uruguay
+	CountryUZ string = "UZ" // Uzbekistan
+	CountryVA string = "VA" // Holy See
+	CountryVC string = "VC" // Saint Vincent and the Grenadines
+	CountryVE string = "VE" // Venezuela
+	CountryVG string = "VG" // Virgin Islands (British)
+	CountryVI string = "VI" // Virgin Islands (U.S.)
+	CountryVN string = "VN" // Viet Nam
+	CountryVU string = "VU" // Vanuatu
+	CountryWF string = "WF" // Wallis and Futuna
+	CountryWS string = "WS" // Samoa
+	CountryXK string = "XK" // Kosovo
+	CountryYE string = "YE" // Yemen
+	CountryYT string = "YT" // Mayotte
+	CountryZA string = "ZA" // South Africa
+	CountryZM string = "ZM" // Zambia
+	CountryZW string = "ZW" // Zimbabwe
+)
+
+var supportedCountries = map[string]bool{
+	CountryAD: true,
+	CountryAE: true,
+	CountryAF: true,
+	CountryAG: true,
+	CountryAI: true,
+	CountryAL: true,
+	CountryAM: true,
+	CountryAO: true,
+	CountryAQ: true,
+	CountryAR: true,
+	CountryAS: true,
+	CountryAT: true,
+	CountryAU: true,
+	CountryAW: true,
+	CountryAX: true,
+	CountryAZ: true,
+	CountryBA: true,
+	CountryBB: true,
+	CountryBD: true,
+	CountryBE: true,
+	CountryBF: true,
+	CountryBG: true,
+	CountryBH: true,
+	CountryBI: true,
+	CountryBJ: true,
+	CountryBL: true,
+	CountryBM: true,
+	CountryBN: true,
+	CountryBO: true,
+	CountryBQ: true,
+	CountryBR: true,
+	CountryBS: true,
+	CountryBT: true,
+	CountryBV: true,
+	CountryBW: true,
+	CountryBY: true,
+	CountryBZ: true,
+	CountryC2: true,
+	CountryCA: true,
+	CountryCC: true,
+	CountryCD: true,
+	CountryCF: true,
+	CountryCG: true,
+	CountryCH: true,
+	CountryCI: true,
+	CountryCK: true,
+	CountryCL: true,
+	CountryCM: true,
+	CountryCN: true,
+	CountryCO: true,
+	CountryCR: true,
+	CountryCU: true,
+	CountryCV: true,
+	CountryCW: true,
+	CountryCX: true,
+	CountryCY: true,
+	CountryCZ: true,
+	CountryDE: true,
+	CountryDJ: true,
+	CountryDK: true,
+	CountryDM: true,
+	CountryDO: true,
+	CountryDZ: true,
+	CountryEC: true,
+	CountryEE: true,
+	CountryEG: true,
+	CountryEH: true,
+	CountryER: true,
+	CountryES: true,
+	CountryET: true,
+	CountryFI: true,
+	CountryFJ: true,
+	CountryFK: true,
+	CountryFM: true,
+	CountryFO: true,
+	CountryFR: true,
+	CountryGA: true,
+	CountryGB: true,
+	CountryGD: true,
+	CountryGE: true,
+	CountryGF: true,
+	CountryGG: true,
+	CountryGH: true,
+	CountryGI: true,
+	CountryGL: true,
+	CountryGM: true,
+	CountryGN: true,
+	CountryGP: true,
+	CountryGQ: true,
+	CountryGR: true,
+	CountryGS: true,
+	CountryGT: true,
+	CountryGU: true,
+	CountryGW: true,
+	CountryGY: true,
+	CountryHK: true,
+	CountryHM: true,
+	CountryHN: true,
+	CountryHR: true,
+	CountryHT: true,
+	CountryHU: true,
+	CountryID: true,
+	CountryIE: true,
+	CountryIL: true,
+	CountryIM: true,
+	CountryIN: true,
+	CountryIO: true,
+	CountryIQ: true,
+	CountryIR: true,
+	CountryIS: true,
+	CountryIT: true,
+	CountryJE: true,
+	CountryJM: true,
+	CountryJO: true,
+	CountryJP: true,
+	CountryKE: true,
+	CountryKG: true,
+	CountryKH: true,
+	CountryKI: true,
+	CountryKM: true,
+	CountryKN: true,
+	CountryKP: true,
+	CountryKR: true,
+	CountryKW: true,
+	CountryKY: true,
+	CountryKZ: true,
+	CountryLA: true,
+	CountryLB: true,
+	CountryLC: true,
+	CountryLI: true,
+	CountryLK: true,
+	CountryLR: true,
+	CountryLS: true,
+	CountryLT: true,
+	CountryLU: true,
+	CountryLV: true,
+	CountryLY: true,
+	CountryMA: true,
+	CountryMC: true,
+	CountryMD: true,
+	CountryME: true,
+	CountryMF: true,
+	CountryMG: true,
+	CountryMH: true,
+	CountryMK: true,
+	CountryML: true,
+	CountryMM: true,
+	CountryMN: true,
+	CountryMO: true,
+	CountryMP: true,
+	CountryMQ: true,
+	CountryMR: true,
+	CountryMS: true,
+	CountryMT: true,
+	CountryMU: true,
+	CountryMV: true,
+	CountryMW: true,
+	CountryMX: true,
+	CountryMY: true,
+	CountryMZ: true,
+	CountryNA: true,
+	CountryNC: true,
+	CountryNE: true,
+	CountryNF: true,
+	CountryNG: true,
+	CountryNI: true,
+	CountryNL: true,
+	CountryNO: true,
+	CountryNP: true,
+	CountryNR: true,
+	CountryNU: true,
+	CountryNZ: true,
+	CountryOM: true,
+	CountryPA: true,
+	CountryPE: true,
+	CountryPF: true,
+	CountryPG: true,
+	CountryPH: true,
+	CountryPK: true,
+	CountryPL: true,
+	CountryPM: true,
+	CountryPN: true,
+	CountryPR: true,
+	CountryPS: true,
+	CountryPT: true,
+	CountryPW: true,
+	CountryPY: true,
+	CountryQA: true,
+	CountryRE: true,
+	CountryRO: true,
+	CountryRS: true,
+	CountryRU: true,
+	CountryRW: true,
+	CountrySA: true,
+	CountrySB: true,
+	CountrySC: true,
+	CountrySD: true,
+	CountrySE: true,
+	CountrySG: true,
+	CountrySH: true,
+	CountrySI: true,
+	CountrySJ: true,
+	CountrySK: true,
+	CountrySL: true,
+	CountrySM: true,
+	CountrySN: true,
+	CountrySO: true,
+	CountrySR: true,
+	CountrySS: true,
+	CountryST: true,
+	CountrySV: true,
+	CountrySX: true,
+	CountrySY: true,
+	CountrySZ: true,
+	CountryTC: true,
+	CountryTD: true,
+	CountryTF: true,
+	CountryTG: true,
+	CountryTH: true,
+	CountryTJ: true,
+	CountryTK: true,
+	CountryTL: true,
+	CountryTM: true,
+	CountryTN: true,
+	CountryTO: true,
+	CountryTR: true,
+	CountryTT: true,
+	CountryTV: true,
+	CountryTW: true,
+	CountryTZ: true,
+	CountryUA: true,
+	CountryUG: true,
+	CountryUM: true,
+	CountryUS: true,
+	CountryUY: true,
+	CountryUZ: true,
+	CountryVA: true,
+	CountryVC: true,
+	CountryVE: true,
+	CountryVG: true,
+	CountryVI: true,
+	CountryVN: true,
+	CountryVU: true,
+	CountryWF: true,
+	CountryWS: true,
+	CountryXK: true,
+	CountryYE: true,
+	CountryYT: true,
+	CountryZA: true,
+	CountryZM: true,
+	CountryZW: true,
+}
+
+// IsValidCountry reports whether country is a two-character country code
+// PayPal accepts, codes are case-sensitive and upper case
+func IsValidCountry(country string) bool {
+	return supportedCountries[country]
+}
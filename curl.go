@@ -0,0 +1,79 @@
+package paypal
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// SetCurlLog writes every request sent to PayPal to w as a curl command, e.g.
+// to reproduce a call when escalating an issue to PayPal support. The access
+// token is replaced by $PAYPAL_ACCESS_TOKEN and basic auth by $PAYPAL_CLIENT_ID
+// and $PAYPAL_SECRET; bodies are redacted like the SetLog output.
+// Pass nil to stop writing commands.
+func (c *Client) SetCurlLog(w io.Writer) {
+	c.curlLog = w
+}
+
+// writeCurl writes the curl command of the request to the curl log
+func (c *Client) writeCurl(req *http.Request) {
+	if c.curlLog != nil {
+		fmt.Fprintln(c.curlLog, CurlCommand(req))
+	}
+}
+
+// CurlCommand returns a curl command sending the request, with its credentials
+// replaced by environment variables and its body redacted, see SetCurlLog
+func CurlCommand(req *http.Request) string {
+	args := []string{"curl", "-X", req.Method, shellQuote(req.URL.String())}
+
+	keys := make([]string, 0, len(req.Header))
+	for key := range req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch key {
+		case "Authorization":
+			continue
+		case "Accept-Encoding":
+			// --compressed asks for and decompresses gzip responses
+			args = append(args, "--compressed")
+			continue
+		}
+		for _, value := range req.Header[key] {
+			if key == "Paypal-Auth-Assertion" {
+				value = redacted
+			}
+			args = append(args, "-H", shellQuote(key+": "+value))
+		}
+	}
+
+	// The OAuth2 transport sets the bearer token after the request was logged
+	if strings.HasPrefix(req.Header.Get("Authorization"), "Basic ") {
+		args = append(args, "-u", `"$PAYPAL_CLIENT_ID:$PAYPAL_SECRET"`)
+	} else {
+		args = append(args, "-H", `"Authorization: Bearer $PAYPAL_ACCESS_TOKEN"`)
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := ioutil.ReadAll(body)
+			body.Close()
+			if len(data) > 0 {
+				args = append(args, "-d", shellQuote(string(redactBody(req.Header.Get("Content-Type"), data))))
+			}
+		}
+	}
+
+	return strings.Join(args, " ")
+}
+
+// shellQuote quotes s for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,56 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCurlLog(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("secret-token")
+	c.SetCurlLog(&buf)
+	c.SetAuthAssertion("SELLER-1")
+
+	_, err := c.CaptureOrder(context.Background(), "O1", CaptureOrderRequest{
+		PaymentSource: &PaymentSource{Card: &PaymentSourceCard{Name: "O'Brien", Number: "4111111111111111", Expiry: "2030-01"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := strings.TrimSpace(buf.String())
+	expected := []string{
+		"curl -X POST '" + ts.URL + "/v2/checkout/orders/O1/capture'",
+		"--compressed",
+		"-H 'Content-Type: application/json'",
+		"-H 'Prefer: return=representation'",
+		"-H 'Paypal-Auth-Assertion: REDACTED'",
+		`-H "Authorization: Bearer $PAYPAL_ACCESS_TOKEN"`,
+		`"name":"O'\''Brien"`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(cmd, e) {
+			t.Errorf("expecting %s in %s", e, cmd)
+		}
+	}
+	if strings.Contains(cmd, "secret-token") || strings.Contains(cmd, "4111111111111111") || strings.Contains(cmd, "SELLER-1") {
+		t.Errorf("expecting credentials and the card number to be redacted: %s", cmd)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/identity/generate-token", nil)
+	req.SetBasicAuth("foo", "bar")
+	if cmd := CurlCommand(req); cmd != "curl -X GET '"+ts.URL+`/v1/identity/generate-token' -u "$PAYPAL_CLIENT_ID:$PAYPAL_SECRET"` {
+		t.Errorf("unexpected command %s", cmd)
+	}
+}
@@ -0,0 +1,69 @@
+package paypal
+
+// Currency codes PayPal supports for payments, balances and payouts
+//
+// https://developer.paypal.com/reference/currency-codes/
+const (
+	CurrencyAUD string = "AUD" // Australian dollar
+	CurrencyBRL string = "BRL" // Brazilian real
+	CurrencyCAD string = "CAD" // Canadian dollar
+	CurrencyCHF string = "CHF" // Swiss franc
+	CurrencyCNY string = "CNY" // Chinese Renmenbi
+	CurrencyCZK string = "CZK" // Czech koruna
+	CurrencyDKK string = "DKK" // Danish krone
+	CurrencyEUR string = "EUR" // Euro
+	CurrencyGBP string = "GBP" // Pound sterling
+	CurrencyHKD string = "HKD" // Hong Kong dollar
+	CurrencyHUF string = "HUF" // Hungarian forint
+	CurrencyILS string = "ILS" // Israeli new shekel
+	CurrencyJPY string = "JPY" // Japanese yen
+	CurrencyMXN string = "MXN" // Mexican peso
+	CurrencyMYR string = "MYR" // Malaysian ringgit
+	CurrencyNOK string = "NOK" // Norwegian krone
+	CurrencyNZD string = "NZD" // New Zealand dollar
+	CurrencyPHP string = "PHP" // Philippine peso
+	CurrencyPLN string = "PLN" // Polish złoty
+	CurrencyRUB string = "RUB" // Russian ruble
+	CurrencySEK string = "SEK" // Swedish krona
+	CurrencySGD string = "SGD" // Singapore dollar
+	CurrencyTHB string = "THB" // Thai baht
+	CurrencyTWD string = "TWD" // New Taiwan dollar
+	CurrencyUSD string = "USD" // United States dollar
+)
+
+// supportedCurrencies maps the currencies PayPal supports to the number of
+// decimals of their amounts
+var supportedCurrencies = map[string]int{
+	CurrencyAUD: 2,
+	CurrencyBRL: 2,
+	CurrencyCAD: 2,
+	CurrencyCHF: 2,
+	CurrencyCNY: 2,
+	CurrencyCZK: 2,
+	CurrencyDKK: 2,
+	CurrencyEUR: 2,
+	CurrencyGBP: 2,
+	CurrencyHKD: 2,
+	CurrencyHUF: 0,
+	CurrencyILS: 2,
+	CurrencyJPY: 0,
+	CurrencyMXN: 2,
+	CurrencyMYR: 2,
+	CurrencyNOK: 2,
+	CurrencyNZD: 2,
+	CurrencyPHP: 2,
+	CurrencyPLN: 2,
+	CurrencyRUB: 2,
+	CurrencySEK: 2,
+	CurrencySGD: 2,
+	CurrencyTHB: 2,
+	CurrencyTWD: 0,
+	CurrencyUSD: 2,
+}
+
+// IsValidCurrency reports whether currency is the ISO 4217 code of a currency
+// PayPal supports, codes are case-sensitive and upper case
+func IsValidCurrency(currency string) bool {
+	_, ok := supportedCurrencies[currency]
+	return ok
+}
@@ -0,0 +1,59 @@
+package paypal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCurrencyAndCountryCodes(t *testing.T) {
+	for _, code := range []string{CurrencyUSD, CurrencyEUR, CurrencyJPY, "GBP"} {
+		if !IsValidCurrency(code) {
+			t.Errorf("expecting %s to be a valid currency", code)
+		}
+	}
+	for _, code := range []string{"", "usd", "US", "XXX", "EURO"} {
+		if IsValidCurrency(code) {
+			t.Errorf("expecting %q to be an invalid currency", code)
+		}
+	}
+
+	// Currency codes are case-sensitive everywhere
+	if CurrencyDecimals(CurrencyJPY) != 0 || CurrencyDecimals("jpy") != 2 {
+		t.Errorf("expecting only JPY to have no decimals, got %d and %d", CurrencyDecimals(CurrencyJPY), CurrencyDecimals("jpy"))
+	}
+
+	for _, code := range []string{CountryUS, CountryDE, CountryC2, "GB"} {
+		if !IsValidCountry(code) {
+			t.Errorf("expecting %s to be a valid country", code)
+		}
+	}
+	for _, code := range []string{"", "us", "UK", "USA"} {
+		if IsValidCountry(code) {
+			t.Errorf("expecting %q to be an invalid country", code)
+		}
+	}
+
+	if err := (&PurchaseUnitAmount{Currency: "EUD", Value: "1.00"}).Validate(); err == nil {
+		t.Error("expecting an unsupported currency to be invalid")
+	}
+	unit := PurchaseUnitRequest{
+		Amount:   &PurchaseUnitAmount{Currency: CurrencyGBP, Value: "1.00"},
+		Shipping: &ShippingDetail{Address: &ShippingDetailAddressPortable{CountryCode: "UK"}},
+	}
+	if err := unit.Validate(); err == nil {
+		t.Error("expecting an invalid shipping country to be invalid")
+	}
+	unit.Shipping.Address.CountryCode = CountryGB
+	if err := unit.Validate(); err != nil {
+		t.Errorf("expecting a valid purchase unit, got %v", err)
+	}
+
+	// Currencies PayPal adds later can still be decoded and marshalled
+	var order Order
+	if err := json.Unmarshal([]byte(`{"id":"O1","purchase_units":[{"amount":{"currency_code":"XTS","value":"1.00"}}]}`), &order); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := json.Marshal(order); err != nil {
+		t.Errorf("expecting a decoded order to marshal, got %v", err)
+	}
+}
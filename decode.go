@@ -0,0 +1,37 @@
+package paypal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SetStrictDecoding makes decoding a response fail when it has a field the
+// response struct doesn't have, to detect when the structs have drifted from
+// the live API. Error responses are always decoded leniently.
+func (c *Client) SetStrictDecoding(strict bool) {
+	c.strictDecoding = strict
+}
+
+// decodeJSON decodes the JSON response body read from r into v
+func (c *Client) decodeJSON(r io.Reader, v interface{}) error {
+	if !c.strictDecoding {
+		return json.NewDecoder(r).Decode(v)
+	}
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("paypal: strict decoding into %T: %w", v, err)
+	}
+	return nil
+}
+
+// unmarshalJSON is decodeJSON for a response body already read
+func (c *Client) unmarshalJSON(data []byte, v interface{}) error {
+	if !c.strictDecoding {
+		return json.Unmarshal(data, v)
+	}
+	return c.decodeJSON(bytes.NewReader(data), v)
+}
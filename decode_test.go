@@ -0,0 +1,36 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Write([]byte(`{"id":"O1","status":"CREATED","new_field":true}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	if _, err := c.GetOrder(context.Background(), "O1"); err != nil {
+		t.Fatalf("expecting unknown fields to be ignored by default, got %v", err)
+	}
+
+	c.SetStrictDecoding(true)
+	_, err := c.GetOrder(context.Background(), "O1")
+	if err == nil || !strings.Contains(err.Error(), `"new_field"`) {
+		t.Errorf("expecting an unknown field error, got %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package paypal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Limits of the documents uploaded with dispute evidence
+//
+// https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_provide-evidence
+const (
+	MaxDisputeFileSize  = 10 << 20
+	MaxDisputeFilesSize = 50 << 20
+)
+
+var (
+	ErrDisputeFileTooLarge = errors.New("paypal: dispute evidence file is too large")
+	ErrDisputeFileType     = errors.New("paypal: dispute evidence file must be a PDF, JPEG or PNG")
+)
+
+// DisputeFile is a document uploaded with evidence, such as a receipt or a
+// delivery confirmation. Set one of Data, Open or Reader. Open and Reader are
+// streamed into the request body and rejected beyond MaxDisputeFileSize.
+// Open is called for every attempt so that a retried request sends the file
+// again, a Reader can only be sent once. The upload closes what Open returns
+// and a Reader that is an io.Closer.
+type DisputeFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+	Reader      io.Reader
+	Open        func() (io.ReadCloser, error)
+}
+
+// disputeFileTypes are the content types PayPal accepts for evidence documents
+var disputeFileTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// OpenDisputeFile returns the file at path as a DisputeFile, the upload opens
+// and closes it
+func OpenDisputeFile(path string) (DisputeFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return DisputeFile{}, err
+	}
+	if info.Size() > MaxDisputeFileSize {
+		return DisputeFile{}, fmt.Errorf("%w: %s has %d bytes", ErrDisputeFileTooLarge, filepath.Base(path), info.Size())
+	}
+
+	return DisputeFile{
+		Name: filepath.Base(path),
+		Open: func() (io.ReadCloser, error) { return os.Open(path) },
+	}, nil
+}
+
+// sendDisputeEvidence posts the evidences as the JSON "input" part of a
+// multipart/related body, followed by a part streamed from each file
+func (c *Client) sendDisputeEvidence(ctx context.Context, disputeID, action string, evidences []DisputeEvidence, files []DisputeFile) (*DisputeActionResponse, error) {
+	response := &DisputeActionResponse{}
+	if len(evidences) == 0 {
+		return response, errors.New("paypal: dispute evidence needs at least one evidence")
+	}
+
+	body, err := newDisputeEvidenceBody(evidences, files)
+	if err != nil {
+		return response, err
+	}
+	defer body.Close()
+
+	first, err := body.open(true)
+	if err != nil {
+		return response, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s%s%s%s", c.APIBase, "/v1/customer/disputes/", disputeID, action), first)
+	if err != nil {
+		return response, err
+	}
+	req.GetBody = func() (io.ReadCloser, error) { return body.open(false) }
+	req.Header.Set("Content-Type", body.contentType())
+
+	err = c.SendWithAuth(req, response)
+	if fileErr := body.err(); fileErr != nil {
+		// The request failed because a file was rejected while it was streamed
+		err = fileErr
+	}
+	return response, err
+}
+
+// disputeEvidenceBody streams the multipart body of dispute evidence, every
+// open starts writing a new copy of the body into a pipe
+type disputeEvidenceBody struct {
+	input    []byte
+	files    []DisputeFile
+	boundary string
+
+	mu      sync.Mutex
+	pipes   []*io.PipeReader
+	fileErr error
+	writers sync.WaitGroup
+}
+
+// newDisputeEvidenceBody returns the body of the evidences and files, the
+// files with Data are validated right away
+func newDisputeEvidenceBody(evidences []DisputeEvidence, files []DisputeFile) (*disputeEvidenceBody, error) {
+	input, err := json.Marshal(map[string][]DisputeEvidence{"evidences": evidences})
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, file := range files {
+		if file.Open != nil || file.Reader != nil {
+			continue
+		}
+		if len(file.Data) > MaxDisputeFileSize {
+			return nil, fmt.Errorf("%w: %s has more than %d bytes", ErrDisputeFileTooLarge, file.Name, MaxDisputeFileSize)
+		}
+		if total += int64(len(file.Data)); total > MaxDisputeFilesSize {
+			return nil, fmt.Errorf("%w: the files have more than %d bytes", ErrDisputeFileTooLarge, MaxDisputeFilesSize)
+		}
+	}
+
+	return &disputeEvidenceBody{
+		input:    input,
+		files:    files,
+		boundary: multipart.NewWriter(ioutil.Discard).Boundary(),
+	}, nil
+}
+
+func (b *disputeEvidenceBody) contentType() string {
+	return "multipart/related; boundary=" + b.boundary
+}
+
+// open opens the files and returns a reader of the body, Reader files are only
+// used by the first body. The content type of every file is checked before
+// the body is returned.
+func (b *disputeEvidenceBody) open(first bool) (io.ReadCloser, error) {
+	var closers []io.Closer
+	closeFiles := func() {
+		for _, closer := range closers {
+			closer.Close()
+		}
+	}
+
+	readers := make([]io.Reader, len(b.files))
+	contentTypes := make([]string, len(b.files))
+	for i, file := range b.files {
+		var r io.Reader
+		switch {
+		case file.Open != nil:
+			rc, err := file.Open()
+			if err != nil {
+				closeFiles()
+				return nil, err
+			}
+			closers = append(closers, rc)
+			r = rc
+		case file.Reader != nil:
+			if !first {
+				closeFiles()
+				return nil, fmt.Errorf("paypal: dispute evidence file %s can't be sent again, set Open instead of Reader", file.Name)
+			}
+			if closer, ok := file.Reader.(io.Closer); ok {
+				closers = append(closers, closer)
+			}
+			r = file.Reader
+		default:
+			r = bytes.NewReader(file.Data)
+		}
+
+		// Peek at the start of the file to detect its content type
+		br := bufio.NewReaderSize(r, 512)
+		head, err := br.Peek(512)
+		if err != nil && err != io.EOF {
+			closeFiles()
+			return nil, err
+		}
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = http.DetectContentType(head)
+		}
+		if !disputeFileTypes[contentType] {
+			closeFiles()
+			return nil, fmt.Errorf("%w: %s is %s", ErrDisputeFileType, file.Name, contentType)
+		}
+		readers[i], contentTypes[i] = br, contentType
+	}
+
+	pr, pw := io.Pipe()
+	b.mu.Lock()
+	b.pipes = append(b.pipes, pr)
+	b.mu.Unlock()
+
+	b.writers.Add(1)
+	go func() {
+		defer b.writers.Done()
+		defer closeFiles()
+		pw.CloseWithError(b.write(pw, readers, contentTypes))
+	}()
+	return pr, nil
+}
+
+// write writes the body into w, a file beyond the size limits fails it
+func (b *disputeEvidenceBody) write(w io.Writer, readers []io.Reader, contentTypes []string) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="input"`)
+	header.Set("Content-Type", "application/json")
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(b.input); err != nil {
+		return err
+	}
+
+	var total int64
+	for i, file := range b.files {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file%d"; filename=%q`, i+1, file.Name))
+		header.Set("Content-Type", contentTypes[i])
+		if part, err = mw.CreatePart(header); err != nil {
+			return err
+		}
+
+		n, err := io.Copy(part, io.LimitReader(readers[i], MaxDisputeFileSize+1))
+		if err != nil {
+			return err
+		}
+		if n > MaxDisputeFileSize {
+			return b.fail(fmt.Errorf("%w: %s has more than %d bytes", ErrDisputeFileTooLarge, file.Name, MaxDisputeFileSize))
+		}
+		if total += n; total > MaxDisputeFilesSize {
+			return b.fail(fmt.Errorf("%w: the files have more than %d bytes", ErrDisputeFileTooLarge, MaxDisputeFilesSize))
+		}
+	}
+
+	return mw.Close()
+}
+
+// fail records the first file rejected while the body was streamed
+func (b *disputeEvidenceBody) fail(err error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.fileErr == nil {
+		b.fileErr = err
+	}
+	return err
+}
+
+func (b *disputeEvidenceBody) err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.fileErr
+}
+
+// Close stops the bodies not read to the end and waits until their files are
+// closed
+func (b *disputeEvidenceBody) Close() error {
+	b.mu.Lock()
+	for _, pr := range b.pipes {
+		pr.Close()
+	}
+	b.mu.Unlock()
+
+	b.writers.Wait()
+	return nil
+}
+
+// Documents returns the documents of all evidences of the dispute
+func (d *Dispute) Documents() []DisputeDocument {
+	var documents []DisputeDocument
+	for _, evidence := range d.Evidences {
+		documents = append(documents, evidence.Documents...)
+	}
+	return documents
+}
+
+// DownloadDisputeDocument streams an evidence document of a dispute to w, e.g.
+// to archive it, and returns the number of bytes written. Like FollowLink it
+// only sends the access token to the APIBase host.
+func (c *Client) DownloadDisputeDocument(ctx context.Context, document DisputeDocument, w io.Writer) (int64, error) {
+	href, err := c.resolveLink(document.URL)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, href, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	return c.DownloadRange(req, w, 0)
+}
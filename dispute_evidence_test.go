@@ -0,0 +1,206 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readDisputeEvidenceBody returns the first body of the evidences and files
+func readDisputeEvidenceBody(evidences []DisputeEvidence, files []DisputeFile) ([]byte, string, error) {
+	body, err := newDisputeEvidenceBody(evidences, files)
+	if err != nil {
+		return nil, "", err
+	}
+	defer body.Close()
+
+	r, err := body.open(true)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := ioutil.ReadAll(r)
+	return data, body.contentType(), err
+}
+
+func TestDisputeEvidenceFileValidation(t *testing.T) {
+	evidences := []DisputeEvidence{{EvidenceType: EvidenceTypeProofOfRefund}}
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	body, contentType, err := readDisputeEvidenceBody(evidences, []DisputeFile{
+		{Name: "refund.png", Data: png},
+		{Name: "receipt.pdf", Reader: strings.NewReader("%PDF-1.4 receipt")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(contentType, "multipart/related; boundary=") {
+		t.Errorf("unexpected content type %s", contentType)
+	}
+	for _, part := range []string{`name="input"`, `filename="refund.png"`, "Content-Type: image/png", "Content-Type: application/pdf", "%PDF-1.4 receipt"} {
+		if !bytes.Contains(body, []byte(part)) {
+			t.Errorf("expecting the body to contain %q", part)
+		}
+	}
+
+	_, _, err = readDisputeEvidenceBody(evidences, []DisputeFile{{Name: "notes.txt", Data: []byte("plain text")}})
+	if !errors.Is(err, ErrDisputeFileType) {
+		t.Errorf("expecting ErrDisputeFileType, got %v", err)
+	}
+	_, _, err = readDisputeEvidenceBody(evidences, []DisputeFile{{Name: "notes.txt", Reader: strings.NewReader("plain text")}})
+	if !errors.Is(err, ErrDisputeFileType) {
+		t.Errorf("expecting ErrDisputeFileType for a streamed file, got %v", err)
+	}
+
+	large := bytes.NewReader(append(png, make([]byte, MaxDisputeFileSize)...))
+	_, _, err = readDisputeEvidenceBody(evidences, []DisputeFile{{Name: "large.png", Reader: large}})
+	if !errors.Is(err, ErrDisputeFileTooLarge) {
+		t.Errorf("expecting ErrDisputeFileTooLarge, got %v", err)
+	}
+
+	file := DisputeFile{Name: "scan.png", Data: append(png, make([]byte, 9<<20)...)}
+	_, _, err = readDisputeEvidenceBody(evidences, []DisputeFile{file, file, file, file, file, file})
+	if !errors.Is(err, ErrDisputeFileTooLarge) {
+		t.Errorf("expecting ErrDisputeFileTooLarge for the total size, got %v", err)
+	}
+}
+
+// closeRecorder records whether the reader was closed
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestDisputeEvidenceStreaming(t *testing.T) {
+	dir, err := ioutil.TempDir("", "paypal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "label.pdf")
+	if err := ioutil.WriteFile(path, []byte("%PDF-1.4 label"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"links":[]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	c.SetAccessToken("123")
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond})
+	ctx := WithRequestID(context.Background(), "evidence-1")
+	evidences := []DisputeEvidence{{EvidenceType: EvidenceTypeProofOfFulfillment}}
+
+	label, err := OpenDisputeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ProvideDisputeEvidence(ctx, "PP-D-1", evidences, label); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || !strings.Contains(bodies[0], "%PDF-1.4 label") || bodies[0] != bodies[1] {
+		t.Errorf("expecting the file to be opened again for the retry, got %q", bodies)
+	}
+
+	// A Reader is sent once and closed
+	bodies = nil
+	receipt := &closeRecorder{Reader: strings.NewReader("%PDF-1.4 receipt")}
+	if _, err := c.ProvideDisputeEvidence(ctx, "PP-D-1", evidences, DisputeFile{Name: "receipt.pdf", Reader: receipt}); err == nil {
+		t.Error("expecting a Reader not to be sent again")
+	}
+	if len(bodies) != 1 || !receipt.closed {
+		t.Errorf("expecting the reader to be sent once and closed, got %d requests, closed %v", len(bodies), receipt.closed)
+	}
+}
+
+func TestOpenDisputeFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "paypal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "label.pdf")
+	if err := ioutil.WriteFile(path, []byte("%PDF-1.4 label"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := OpenDisputeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Name != "label.pdf" || file.Open == nil {
+		t.Fatalf("unexpected file %+v", file)
+	}
+	f, err := file.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if _, err := OpenDisputeFile(filepath.Join(dir, "missing.pdf")); err == nil {
+		t.Error("expecting an error for a missing file")
+	}
+}
+
+func TestDownloadDisputeDocument(t *testing.T) {
+	pdf := []byte("%PDF-1.4 tracking receipt")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer 123" {
+			http.Error(w, "expecting the access token", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(pdf)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	c.SetAccessToken("123")
+
+	dispute := &Dispute{Evidences: []DisputeEvidence{
+		{EvidenceType: EvidenceTypeProofOfFulfillment, Documents: []DisputeDocument{{Name: "receipt.pdf", URL: ts.URL + "/v1/customer/disputes/PP-D-1/documents/1"}}},
+		{EvidenceType: EvidenceTypeOther, Documents: []DisputeDocument{{Name: "other.pdf", URL: "https://example.com/other.pdf"}}},
+	}}
+	documents := dispute.Documents()
+	if len(documents) != 2 {
+		t.Fatalf("expecting 2 documents, got %+v", documents)
+	}
+
+	var buf bytes.Buffer
+	n, err := c.DownloadDisputeDocument(context.Background(), documents[0], &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(pdf)) || !bytes.Equal(buf.Bytes(), pdf) {
+		t.Errorf("unexpected document %d %q", n, buf.Bytes())
+	}
+
+	if _, err := c.DownloadDisputeDocument(context.Background(), documents[1], &buf); err == nil {
+		t.Error("expecting a document on another host not to be downloaded")
+	}
+}
@@ -0,0 +1,439 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type (
+	// Dispute struct
+	// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#definition-dispute
+	Dispute struct {
+		ID                    string                `json:"dispute_id"`
+		CreateTime            *time.Time            `json:"create_time,omitempty"`
+		UpdateTime            *time.Time            `json:"update_time,omitempty"`
+		Reason                string                `json:"reason,omitempty"`
+		Status                string                `json:"status,omitempty"`
+		DisputeState          string                `json:"dispute_state,omitempty"`
+		DisputeAmount         *Money                `json:"dispute_amount,omitempty"`
+		DisputeOutcome        *DisputeOutcome       `json:"dispute_outcome,omitempty"`
+		DisputeLifeCycleStage string                `json:"dispute_life_cycle_stage,omitempty"`
+		DisputeChannel        string                `json:"dispute_channel,omitempty"`
+		SellerResponseDueDate *time.Time            `json:"seller_response_due_date,omitempty"`
+		DisputedTransactions  []DisputedTransaction `json:"disputed_transactions,omitempty"`
+		Evidences             []DisputeEvidence     `json:"evidences,omitempty"`
+		Messages              []DisputeMessage      `json:"messages,omitempty"`
+		Offer                 *DisputeOffer         `json:"offer,omitempty"`
+		Links                 Links                 `json:"links,omitempty"`
+	}
+
+	// DisputeMessage is a message between the buyer and the seller of a dispute
+	DisputeMessage struct {
+		PostedBy   string     `json:"posted_by,omitempty"`
+		TimePosted *time.Time `json:"time_posted,omitempty"`
+		Content    string     `json:"content,omitempty"`
+	}
+
+	// DisputeOffer is the state of the negotiation of a dispute
+	// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#definition-offer
+	DisputeOffer struct {
+		BuyerRequestedAmount *Money                `json:"buyer_requested_amount,omitempty"`
+		SellerOfferedAmount  *Money                `json:"seller_offered_amount,omitempty"`
+		OfferType            string                `json:"offer_type,omitempty"`
+		History              []DisputeOfferHistory `json:"history,omitempty"`
+	}
+
+	// DisputeOfferHistory is an offer of a dispute, or the response to it
+	DisputeOfferHistory struct {
+		OfferTime   *time.Time `json:"offer_time,omitempty"`
+		Actor       string     `json:"actor,omitempty"`
+		EventType   string     `json:"event_type,omitempty"`
+		OfferType   string     `json:"offer_type,omitempty"`
+		OfferAmount *Money     `json:"offer_amount,omitempty"`
+		Notes       string     `json:"notes,omitempty"`
+	}
+
+	// DisputeOutcome struct
+	DisputeOutcome struct {
+		OutcomeCode    string `json:"outcome_code,omitempty"`
+		AmountRefunded *Money `json:"amount_refunded,omitempty"`
+	}
+
+	// DisputedTransaction is a transaction the buyer disputes
+	// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#definition-transaction_info
+	DisputedTransaction struct {
+		BuyerTransactionID  string        `json:"buyer_transaction_id,omitempty"`
+		SellerTransactionID string        `json:"seller_transaction_id,omitempty"`
+		CreateTime          *time.Time    `json:"create_time,omitempty"`
+		TransactionStatus   string        `json:"transaction_status,omitempty"`
+		GrossAmount         *Money        `json:"gross_amount,omitempty"`
+		InvoiceNumber       string        `json:"invoice_number,omitempty"`
+		Custom              string        `json:"custom,omitempty"`
+		Buyer               *DisputeParty `json:"buyer,omitempty"`
+		Seller              *DisputeParty `json:"seller,omitempty"`
+	}
+
+	// DisputeParty is the buyer or seller of a disputed transaction
+	DisputeParty struct {
+		Email      string `json:"email,omitempty"`
+		MerchantID string `json:"merchant_id,omitempty"`
+		Name       string `json:"name,omitempty"`
+	}
+
+	// DisputeEvidence supports the seller's position in a dispute
+	// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#definition-evidence
+	DisputeEvidence struct {
+		EvidenceType string               `json:"evidence_type"`
+		EvidenceInfo *DisputeEvidenceInfo `json:"evidence_info,omitempty"`
+		Documents    []DisputeDocument    `json:"documents,omitempty"`
+		Notes        string               `json:"notes,omitempty"`
+		ItemID       string               `json:"item_id,omitempty"`
+	}
+
+	// DisputeEvidenceInfo struct
+	DisputeEvidenceInfo struct {
+		TrackingInfo []DisputeTrackingInfo `json:"tracking_info,omitempty"`
+		RefundIDs    []string              `json:"refund_ids,omitempty"`
+	}
+
+	// DisputeTrackingInfo struct
+	DisputeTrackingInfo struct {
+		CarrierName      string `json:"carrier_name"`
+		CarrierNameOther string `json:"carrier_name_other,omitempty"`
+		TrackingURL      string `json:"tracking_url,omitempty"`
+		TrackingNumber   string `json:"tracking_number"`
+	}
+
+	// DisputeDocument struct
+	DisputeDocument struct {
+		Name string `json:"name,omitempty"`
+		URL  string `json:"url,omitempty"`
+	}
+
+	// DisputeListParams filters ListDisputes, empty fields match all disputes
+	DisputeListParams struct {
+		StartTime             *time.Time
+		DisputedTransactionID string
+		DisputeState          string
+		PageSize              string
+		NextPageToken         string
+	}
+
+	// ListDisputesResponse is a page of disputes, follow the "next" link for the next one
+	ListDisputesResponse struct {
+		Items []Dispute `json:"items"`
+		Links Links     `json:"links,omitempty"`
+	}
+
+	// AcceptDisputeClaimRequest struct
+	// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_accept-claim
+	AcceptDisputeClaimRequest struct {
+		Note                  string                         `json:"note"`
+		AcceptClaimReason     string                         `json:"accept_claim_reason,omitempty"`
+		AcceptClaimType       string                         `json:"accept_claim_type,omitempty"`
+		InvoiceID             string                         `json:"invoice_id,omitempty"`
+		RefundAmount          *Money                         `json:"refund_amount,omitempty"`
+		ReturnShippingAddress *ShippingDetailAddressPortable `json:"return_shipping_address,omitempty"`
+	}
+
+	// AcknowledgeReturnItemRequest struct
+	// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_acknowledge-return-item
+	AcknowledgeReturnItemRequest struct {
+		Note                string `json:"note,omitempty"`
+		AcknowledgementType string `json:"acknowledgement_type,omitempty"`
+	}
+
+	// MakeDisputeOfferRequest struct
+	// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_make-offer
+	MakeDisputeOfferRequest struct {
+		Note                  string                         `json:"note"`
+		OfferType             string                         `json:"offer_type"`
+		OfferAmount           *Money                         `json:"offer_amount,omitempty"`
+		InvoiceID             string                         `json:"invoice_id,omitempty"`
+		ReturnShippingAddress *ShippingDetailAddressPortable `json:"return_shipping_address,omitempty"`
+	}
+
+	// DisputeActionResponse has the link to the dispute after an action
+	DisputeActionResponse struct {
+		Links Links `json:"links,omitempty"`
+	}
+)
+
+// Possible values for `dispute_state` in DisputeListParams
+const (
+	DisputeStateRequiredAction           string = "REQUIRED_ACTION"
+	DisputeStateRequiredOtherPartyAction string = "REQUIRED_OTHER_PARTY_ACTION"
+	DisputeStateUnderPaypalReview        string = "UNDER_PAYPAL_REVIEW"
+	DisputeStateResolved                 string = "RESOLVED"
+	DisputeStateOpenInquiries            string = "OPEN_INQUIRIES"
+	DisputeStateAppealable               string = "APPEALABLE"
+)
+
+// Possible values for `evidence_type` in DisputeEvidence
+//
+// https://developer.paypal.com/docs/api/customer-disputes/v1/#definition-evidence_type
+const (
+	EvidenceTypeProofOfFulfillment         string = "PROOF_OF_FULFILLMENT"
+	EvidenceTypeProofOfRefund              string = "PROOF_OF_REFUND"
+	EvidenceTypeProofOfDeliverySignature   string = "PROOF_OF_DELIVERY_SIGNATURE"
+	EvidenceTypeProofOfReceiptCopy         string = "PROOF_OF_RECEIPT_COPY"
+	EvidenceTypeReturnPolicy               string = "RETURN_POLICY"
+	EvidenceTypeBillingAgreement           string = "BILLING_AGREEMENT"
+	EvidenceTypeProofOfReshipment          string = "PROOF_OF_RESHIPMENT"
+	EvidenceTypeItemDescription            string = "ITEM_DESCRIPTION"
+	EvidenceTypeCopyOfContract             string = "COPY_OF_CONTRACT"
+	EvidenceTypeProofOfRefundOutsidePaypal string = "PROOF_OF_REFUND_OUTSIDE_PAYPAL"
+	EvidenceTypeProofOfReturn              string = "PROOF_OF_RETURN"
+	EvidenceTypeOther                      string = "OTHER"
+)
+
+// Possible values for `accept_claim_type` in AcceptDisputeClaimRequest
+const (
+	AcceptClaimTypeRefund                        string = "REFUND"
+	AcceptClaimTypeRefundWithReturn              string = "REFUND_WITH_RETURN"
+	AcceptClaimTypePartialRefund                 string = "PARTIAL_REFUND"
+	AcceptClaimTypeRefundWithReturnShipmentLabel string = "REFUND_WITH_RETURN_SHIPMENT_LABEL"
+)
+
+// Possible values for `offer_type` in MakeDisputeOfferRequest and DisputeOffer
+const (
+	DisputeOfferTypeRefund                   string = "REFUND"
+	DisputeOfferTypeRefundWithReturn         string = "REFUND_WITH_RETURN"
+	DisputeOfferTypeRefundWithReplacement    string = "REFUND_WITH_REPLACEMENT"
+	DisputeOfferTypeReplacementWithoutRefund string = "REPLACEMENT_WITHOUT_REFUND"
+)
+
+// Possible values for `acknowledgement_type` in AcknowledgeReturnItemRequest
+const (
+	AcknowledgementTypeItemReceived            string = "ITEM_RECEIVED"
+	AcknowledgementTypeItemNotReceived         string = "ITEM_NOT_RECEIVED"
+	AcknowledgementTypeDamaged                 string = "DAMAGED"
+	AcknowledgementTypeEmptyPackageOrDifferent string = "EMPTY_PACKAGE_OR_DIFFERENT"
+	AcknowledgementTypeMissingItems            string = "MISSING_ITEMS"
+)
+
+// Possible values for `rel` in Dispute links. PayPal only returns the link
+// of an action while the seller is allowed to perform it.
+//
+// https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_get
+const (
+	DisputeLinkRelProvideEvidence       string = "provide_evidence"
+	DisputeLinkRelSendMessage           string = "send_message"
+	DisputeLinkRelAcceptClaim           string = "accept_claim"
+	DisputeLinkRelAppeal                string = "appeal"
+	DisputeLinkRelAcknowledgeReturnItem string = "acknowledge_return_item"
+	DisputeLinkRelMakeOffer             string = "make_offer"
+	DisputeLinkRelAcceptOffer           string = "accept_offer"
+	DisputeLinkRelDenyOffer             string = "deny_offer"
+	DisputeLinkRelEscalate              string = "escalate"
+)
+
+// Possible values for `status` in Dispute
+//
+// https://developer.paypal.com/docs/api/customer-disputes/v1/#definition-status
+const (
+	DisputeStatusOpen                     string = "OPEN"
+	DisputeStatusWaitingForBuyerResponse  string = "WAITING_FOR_BUYER_RESPONSE"
+	DisputeStatusWaitingForSellerResponse string = "WAITING_FOR_SELLER_RESPONSE"
+	DisputeStatusUnderReview              string = "UNDER_REVIEW"
+	DisputeStatusResolved                 string = "RESOLVED"
+	DisputeStatusOther                    string = "OTHER"
+)
+
+// IsResolved reports whether the dispute is resolved and its outcome is known
+func (d *Dispute) IsResolved() bool {
+	return d.Status == DisputeStatusResolved && d.DisputeOutcome != nil
+}
+
+// CanProvideMoreEvidence reports whether PayPal still accepts evidence for the dispute
+func (d *Dispute) CanProvideMoreEvidence() bool {
+	return d.hasLink(DisputeLinkRelProvideEvidence)
+}
+
+// CanSendMessage reports whether PayPal still accepts a message to the other party
+func (d *Dispute) CanSendMessage() bool {
+	return d.hasLink(DisputeLinkRelSendMessage)
+}
+
+// CanAppeal reports whether the dispute outcome can still be appealed
+func (d *Dispute) CanAppeal() bool {
+	return d.hasLink(DisputeLinkRelAppeal)
+}
+
+// CanMakeOffer reports whether the seller can still make an offer to resolve the dispute
+func (d *Dispute) CanMakeOffer() bool {
+	return d.hasLink(DisputeLinkRelMakeOffer)
+}
+
+// CanEscalate reports whether the dispute can still be escalated to a PayPal claim
+func (d *Dispute) CanEscalate() bool {
+	return d.hasLink(DisputeLinkRelEscalate)
+}
+
+func (d *Dispute) hasLink(rel string) bool {
+	return FindLink(d.Links, rel) != nil
+}
+
+// GetDispute shows details for a dispute, by ID.
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_get
+// Endpoint: GET /v1/customer/disputes/{id}
+func (c *Client) GetDispute(ctx context.Context, disputeID string) (*Dispute, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/customer/disputes/", disputeID), nil)
+	response := &Dispute{}
+	if err != nil {
+		return response, err
+	}
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// WaitForDisputeOutcome polls GetDispute every interval until the dispute is
+// resolved with an outcome. When ctx expires or a poll fails first, the last
+// fetched dispute is returned with the error. The interval must be positive.
+func (c *Client) WaitForDisputeOutcome(ctx context.Context, disputeID string, interval time.Duration) (*Dispute, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("paypal: dispute poll interval must be positive, got %s", interval)
+	}
+
+	var last *Dispute
+	for {
+		dispute, err := c.GetDispute(ctx, disputeID)
+		if err != nil {
+			if last == nil {
+				last = dispute
+			}
+			return last, err
+		}
+		if dispute.IsResolved() {
+			return dispute, nil
+		}
+		last = dispute
+
+		if !sleepContext(ctx, interval) {
+			return last, ctx.Err()
+		}
+	}
+}
+
+// ListDisputes returns the first page of disputes matching params, use
+// NewDisputePaginator to iterate all of them
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_list
+// Endpoint: GET /v1/customer/disputes
+func (c *Client) ListDisputes(ctx context.Context, params *DisputeListParams) (*ListDisputesResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v1/customer/disputes"), nil)
+	response := &ListDisputesResponse{}
+	if err != nil {
+		return response, err
+	}
+
+	req.URL.RawQuery = params.query().Encode()
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// NewDisputePaginator returns a Paginator over all pages of disputes,
+// decode each page into a ListDisputesResponse
+func (c *Client) NewDisputePaginator(params *DisputeListParams) *Paginator {
+	return c.NewPaginator("/v1/customer/disputes", params.query())
+}
+
+// AcceptDisputeClaim accepts liability for a dispute, PayPal refunds the buyer
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_accept-claim
+// Endpoint: POST /v1/customer/disputes/{id}/accept-claim
+func (c *Client) AcceptDisputeClaim(ctx context.Context, disputeID string, accept AcceptDisputeClaimRequest) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/accept-claim", accept)
+}
+
+// ProvideDisputeEvidence sends evidence for a dispute waiting for the seller's response,
+// the files are uploaded with the evidence
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_provide-evidence
+// Endpoint: POST /v1/customer/disputes/{id}/provide-evidence
+func (c *Client) ProvideDisputeEvidence(ctx context.Context, disputeID string, evidences []DisputeEvidence, files ...DisputeFile) (*DisputeActionResponse, error) {
+	return c.sendDisputeEvidence(ctx, disputeID, "/provide-evidence", evidences, files)
+}
+
+// AppealDispute appeals a resolved dispute with new evidence
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_appeal
+// Endpoint: POST /v1/customer/disputes/{id}/appeal
+func (c *Client) AppealDispute(ctx context.Context, disputeID string, evidences []DisputeEvidence, files ...DisputeFile) (*DisputeActionResponse, error) {
+	return c.sendDisputeEvidence(ctx, disputeID, "/appeal", evidences, files)
+}
+
+// AcknowledgeReturnItem acknowledges that the buyer returned the item of the dispute
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_acknowledge-return-item
+// Endpoint: POST /v1/customer/disputes/{id}/acknowledge-return-item
+func (c *Client) AcknowledgeReturnItem(ctx context.Context, disputeID string, acknowledge AcknowledgeReturnItemRequest) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/acknowledge-return-item", acknowledge)
+}
+
+// SendDisputeMessage sends a message to the other party of the dispute
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_send-message
+// Endpoint: POST /v1/customer/disputes/{id}/send-message
+func (c *Client) SendDisputeMessage(ctx context.Context, disputeID string, message string) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/send-message", map[string]string{"message": message})
+}
+
+// MakeDisputeOffer offers the buyer a refund, a replacement or both to resolve the dispute
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_make-offer
+// Endpoint: POST /v1/customer/disputes/{id}/make-offer
+func (c *Client) MakeDisputeOffer(ctx context.Context, disputeID string, offer MakeDisputeOfferRequest) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/make-offer", offer)
+}
+
+// AcceptDisputeOffer accepts the offer of the other party, which resolves the dispute
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_accept-offer
+// Endpoint: POST /v1/customer/disputes/{id}/accept-offer
+func (c *Client) AcceptDisputeOffer(ctx context.Context, disputeID string, note string) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/accept-offer", map[string]string{"note": note})
+}
+
+// DenyDisputeOffer denies the offer of the other party
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_deny-offer
+// Endpoint: POST /v1/customer/disputes/{id}/deny-offer
+func (c *Client) DenyDisputeOffer(ctx context.Context, disputeID string, note string) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/deny-offer", map[string]string{"note": note})
+}
+
+// EscalateDispute escalates the dispute to a claim reviewed by PayPal
+// Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_escalate
+// Endpoint: POST /v1/customer/disputes/{id}/escalate
+func (c *Client) EscalateDispute(ctx context.Context, disputeID string, note string) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/escalate", map[string]string{"note": note})
+}
+
+// disputeAction posts the JSON payload to an action of the dispute
+func (c *Client) disputeAction(ctx context.Context, disputeID, action string, payload interface{}) (*DisputeActionResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s%s%s", c.APIBase, "/v1/customer/disputes/", disputeID, action), payload)
+	response := &DisputeActionResponse{}
+	if err != nil {
+		return response, err
+	}
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// query returns the dispute list parameters as query, omitting empty ones
+func (p *DisputeListParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.StartTime != nil {
+		q.Set("start_time", p.StartTime.UTC().Format(time.RFC3339))
+	}
+	for key, value := range map[string]string{
+		"disputed_transaction_id": p.DisputedTransactionID,
+		"dispute_state":           p.DisputeState,
+		"page_size":               p.PageSize,
+		"next_page_token":         p.NextPageToken,
+	} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	return q
+}
@@ -0,0 +1,212 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestDisputeEvidenceUpload(t *testing.T) {
+	var input map[string][]DisputeEvidence
+	files := map[string]string{}
+	var contentType string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.URL.Path != "/v1/customer/disputes/PP-D-1/provide-evidence" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		contentType = mediaType
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			data, _ := ioutil.ReadAll(part)
+			if part.FormName() == "input" {
+				json.Unmarshal(data, &input)
+				continue
+			}
+			files[part.FileName()] = part.Header.Get("Content-Type") + " " + string(data)
+		}
+		w.Write([]byte(`{"links":[{"href":"https://api.paypal.com/v1/customer/disputes/PP-D-1","rel":"self","method":"GET"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	if _, err := c.ProvideDisputeEvidence(ctx, "PP-D-1", nil); err == nil {
+		t.Error("expecting an error without evidence")
+	}
+
+	resp, err := c.ProvideDisputeEvidence(ctx, "PP-D-1", []DisputeEvidence{{
+		EvidenceType: EvidenceTypeProofOfFulfillment,
+		EvidenceInfo: &DisputeEvidenceInfo{TrackingInfo: []DisputeTrackingInfo{{CarrierName: "UPS", TrackingNumber: "1Z999"}}},
+		Notes:        "Delivered on time",
+	}}, DisputeFile{Name: "receipt.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "multipart/related" {
+		t.Errorf("expecting a multipart request, got %s", contentType)
+	}
+	if len(input["evidences"]) != 1 || input["evidences"][0].EvidenceInfo.TrackingInfo[0].TrackingNumber != "1Z999" {
+		t.Errorf("unexpected input %+v", input)
+	}
+	if files["receipt.pdf"] != "application/pdf %PDF-1.4" {
+		t.Errorf("unexpected files %v", files)
+	}
+	if FindLink(resp.Links, "self") == nil {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestDisputeActions(t *testing.T) {
+	var requests []string
+	var accept AcceptDisputeClaimRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/v1/customer/disputes":
+			w.Write([]byte(`{"items":[{"dispute_id":"PP-D-1","status":"WAITING_FOR_SELLER_RESPONSE",` +
+				`"disputed_transactions":[{"seller_transaction_id":"TX-1","gross_amount":{"currency_code":"USD","value":"9.99"}}]}]}`))
+		case "/v1/customer/disputes/PP-D-1/accept-claim":
+			json.NewDecoder(r.Body).Decode(&accept)
+			w.Write([]byte(`{"links":[]}`))
+		default:
+			w.Write([]byte(`{"links":[]}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	disputes, err := c.ListDisputes(ctx, &DisputeListParams{DisputeState: DisputeStateRequiredAction, PageSize: "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disputes.Items) != 1 || disputes.Items[0].DisputedTransactions[0].SellerTransactionID != "TX-1" {
+		t.Errorf("unexpected disputes %+v", disputes)
+	}
+
+	if _, err := c.AcceptDisputeClaim(ctx, "PP-D-1", AcceptDisputeClaimRequest{
+		Note:            "Sorry",
+		AcceptClaimType: AcceptClaimTypePartialRefund,
+		RefundAmount:    &Money{Currency: "USD", Value: "5.00"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if accept.AcceptClaimType != AcceptClaimTypePartialRefund || accept.RefundAmount.Value != "5.00" {
+		t.Errorf("unexpected accept claim %+v", accept)
+	}
+
+	if _, err := c.AcknowledgeReturnItem(ctx, "PP-D-1", AcknowledgeReturnItemRequest{AcknowledgementType: AcknowledgementTypeItemReceived}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AppealDispute(ctx, "PP-D-1", []DisputeEvidence{{EvidenceType: EvidenceTypeOther, Notes: "New proof"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"GET /v1/customer/disputes?dispute_state=REQUIRED_ACTION&page_size=10",
+		"POST /v1/customer/disputes/PP-D-1/accept-claim",
+		"POST /v1/customer/disputes/PP-D-1/acknowledge-return-item",
+		"POST /v1/customer/disputes/PP-D-1/appeal",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expecting requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("request %d: expecting %s, got %s", i, expected[i], requests[i])
+		}
+	}
+}
+
+func TestDisputeNegotiation(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/customer/disputes/PP-D-1" {
+			w.Write([]byte(`{"dispute_id":"PP-D-1","status":"WAITING_FOR_SELLER_RESPONSE",
+				"messages":[{"posted_by":"BUYER","time_posted":"2024-01-02T10:00:00Z","content":"Where is my order?"}],
+				"offer":{"buyer_requested_amount":{"currency_code":"USD","value":"9.99"},
+					"history":[{"actor":"BUYER","event_type":"PROPOSED","offer_type":"REFUND","offer_amount":{"currency_code":"USD","value":"9.99"}}]},
+				"links":[{"href":"https://api-m.paypal.com/v1/customer/disputes/PP-D-1/make-offer","rel":"make_offer","method":"POST"}]}`))
+			return
+		}
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		w.Write([]byte(`{"links":[{"href":"https://api-m.paypal.com/v1/customer/disputes/PP-D-1","rel":"self","method":"GET"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	c.SetAccessToken("123")
+	ctx := context.Background()
+
+	dispute, err := c.GetDispute(ctx, "PP-D-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dispute.Messages) != 1 || dispute.Messages[0].PostedBy != "BUYER" || dispute.Offer.History[0].OfferType != DisputeOfferTypeRefund {
+		t.Errorf("unexpected dispute %+v", dispute)
+	}
+	if !dispute.CanMakeOffer() || dispute.CanEscalate() {
+		t.Errorf("expecting only an offer to be possible")
+	}
+
+	if _, err := c.SendDisputeMessage(ctx, "PP-D-1", "Shipped yesterday"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.MakeDisputeOffer(ctx, "PP-D-1", MakeDisputeOfferRequest{
+		Note:        "Half back and keep the item",
+		OfferType:   DisputeOfferTypeRefund,
+		OfferAmount: &Money{Currency: "USD", Value: "5.00"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AcceptDisputeOffer(ctx, "PP-D-1", "Fine"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DenyDisputeOffer(ctx, "PP-D-1", "No"); err != nil {
+		t.Fatal(err)
+	}
+	if response, err := c.EscalateDispute(ctx, "PP-D-1", "Please review"); err != nil || len(response.Links) != 1 {
+		t.Fatalf("unexpected escalation response %+v, %v", response, err)
+	}
+
+	if m := bodies["/v1/customer/disputes/PP-D-1/send-message"]["message"]; m != "Shipped yesterday" {
+		t.Errorf("unexpected message %v", m)
+	}
+	offer := bodies["/v1/customer/disputes/PP-D-1/make-offer"]
+	if offer["offer_type"] != DisputeOfferTypeRefund || offer["offer_amount"].(map[string]interface{})["value"] != "5.00" {
+		t.Errorf("unexpected offer %v", offer)
+	}
+	for action, note := range map[string]string{"accept-offer": "Fine", "deny-offer": "No", "escalate": "Please review"} {
+		if n := bodies["/v1/customer/disputes/PP-D-1/"+action]["note"]; n != note {
+			t.Errorf("%s: expecting note %q, got %v", action, note, n)
+		}
+	}
+}
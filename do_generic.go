@@ -0,0 +1,30 @@
+//go:build go1.18
+// +build go1.18
+
+package paypal
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// Do sends an authenticated request to an API path (relative to APIBase) and
+// decodes the JSON response into a T. It is meant for endpoints this package
+// does not cover yet, and gets auth, retries, logging and the other client
+// features like any built-in endpoint. An empty response body leaves T at its
+// zero value.
+func Do[T any](ctx context.Context, c *Client, method, path string, payload interface{}, opts ...RequestOption) (T, error) {
+	var v T
+
+	req, err := c.NewRequest(ctx, method, c.APIBase+path, payload, opts...)
+	if err != nil {
+		return v, err
+	}
+
+	if err = c.SendWithAuth(req, &v); errors.Is(err, io.EOF) {
+		err = nil
+	}
+
+	return v, err
+}
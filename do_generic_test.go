@@ -0,0 +1,73 @@
+//go:build go1.18
+// +build go1.18
+
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestDoGeneric(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "/v1/custom/thing":
+			if r.Header.Get("Authorization") != "Bearer 123" {
+				t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte(`{"id":"T-1","count":2}`))
+		case "/v1/custom/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"name":"RESOURCE_NOT_FOUND","message":"not found"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+
+	type thing struct {
+		ID    string `json:"id"`
+		Count int    `json:"count"`
+	}
+
+	got, err := Do[thing](context.Background(), c, http.MethodGet, "/v1/custom/thing", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "T-1" || got.Count != 2 {
+		t.Errorf("got %+v", got)
+	}
+
+	ptr, err := Do[*thing](context.Background(), c, http.MethodGet, "/v1/custom/thing", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ptr == nil || ptr.ID != "T-1" {
+		t.Errorf("got %+v", ptr)
+	}
+
+	empty, err := Do[*thing](context.Background(), c, http.MethodDelete, "/v1/custom/empty", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty != nil {
+		t.Errorf("expected nil result for empty body, got %+v", empty)
+	}
+
+	_, err = Do[thing](context.Background(), c, http.MethodGet, "/v1/custom/missing", nil)
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestDownloadRange(t *testing.T) {
+	const report = "0123456789abcdefghij"
+	ignoreRange := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/v1/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		var start int
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start); err != nil || ignoreRange {
+			w.Write([]byte(report))
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(report)-1, len(report)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(report[start:]))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	for _, ignore := range []bool{false, true} {
+		ignoreRange = ignore
+		buf := bytes.NewBufferString(report[:8])
+
+		req, _ := c.NewRequest(context.Background(), http.MethodGet, ts.URL+"/v1/reports/settlement.csv", nil)
+		n, err := c.DownloadRange(req, buf, int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != report || n != int64(len(report)-8) {
+			t.Errorf("ignoreRange=%v: expecting the download to resume, got %q (%d bytes)", ignore, buf.String(), n)
+		}
+	}
+
+	req, _ := c.NewRequest(context.Background(), http.MethodGet, ts.URL+"/v1/reports/settlement.csv", nil)
+	buf := &strings.Builder{}
+	if n, err := c.DownloadRange(req, buf, 0); err != nil || buf.String() != report || n != int64(len(report)) {
+		t.Errorf("expecting the full download, got %q %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,89 @@
+package paypal
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Environment selects the PayPal API a Client calls, either Live, Sandbox or
+// the base URL of another API, e.g. a mock server in tests
+type Environment string
+
+// Possible values of Environment besides custom base URLs
+const (
+	Live    Environment = "live"
+	Sandbox Environment = "sandbox"
+)
+
+// ParseEnvironment returns the Environment named "live" or "sandbox", in any
+// case, or the custom environment of an http or https base URL
+func ParseEnvironment(s string) (Environment, error) {
+	env := Environment(s)
+	switch strings.ToLower(s) {
+	case string(Live):
+		env = Live
+	case string(Sandbox):
+		env = Sandbox
+	}
+	if _, err := env.baseURL(); err != nil {
+		return "", err
+	}
+	return env, nil
+}
+
+// APIBase returns the base URL of the API of the environment
+func (e Environment) APIBase() string {
+	base, _ := e.baseURL()
+	return base
+}
+
+// TokenURL returns the URL access tokens of the environment are requested from
+func (e Environment) TokenURL() string {
+	return e.APIBase() + "/v1/oauth2/token"
+}
+
+func (e Environment) baseURL() (string, error) {
+	switch e {
+	case Live:
+		return APIBaseLive, nil
+	case Sandbox:
+		return APIBaseSandBox, nil
+	}
+	u, err := url.Parse(string(e))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("paypal: unknown environment %q, expecting live, sandbox or an http(s) base URL", string(e))
+	}
+	return strings.TrimSuffix(string(e), "/"), nil
+}
+
+// NewClientForEnvironment is NewClient for an Environment, it fails for an
+// unknown environment instead of sending requests to a wrong URL
+//
+//	c, err := paypal.NewClientForEnvironment(clientID, secret, paypal.Sandbox)
+func NewClientForEnvironment(clientID, secret string, env Environment, opts ...ClientOption) (*Client, error) {
+	return NewClient(clientID, secret, string(env), opts...)
+}
+
+// NewClientFromEnv returns a Client with the credentials of the environment
+// variables PAYPAL_CLIENT_ID and PAYPAL_SECRET, for the API selected by
+// PAYPAL_ENV, see ParseEnvironment. The sandbox is used when PAYPAL_ENV
+// is not set, so a missing variable never moves real money.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	clientID, secret := os.Getenv("PAYPAL_CLIENT_ID"), os.Getenv("PAYPAL_SECRET")
+	if clientID == "" || secret == "" {
+		return nil, errors.New("paypal: PAYPAL_CLIENT_ID and PAYPAL_SECRET are required")
+	}
+
+	env := Sandbox
+	if s := os.Getenv("PAYPAL_ENV"); s != "" {
+		var err error
+		if env, err = ParseEnvironment(s); err != nil {
+			return nil, fmt.Errorf("paypal: PAYPAL_ENV must be live, sandbox or an http(s) base URL, got %q", s)
+		}
+	}
+
+	return NewClientForEnvironment(clientID, secret, env, opts...)
+}
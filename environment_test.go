@@ -0,0 +1,76 @@
+package paypal
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	defer os.Unsetenv("PAYPAL_CLIENT_ID")
+	defer os.Unsetenv("PAYPAL_SECRET")
+	defer os.Unsetenv("PAYPAL_ENV")
+
+	if _, err := NewClientFromEnv(); err == nil {
+		t.Error("expecting an error without credentials")
+	}
+
+	os.Setenv("PAYPAL_CLIENT_ID", "client")
+	os.Setenv("PAYPAL_SECRET", "secret")
+	c, err := NewClientFromEnv(WithPartnerAttributionID("BN"))
+	if err != nil || c.ClientID != "client" || c.Secret != "secret" || c.APIBase != APIBaseSandBox || c.partnerAttributionID != "BN" {
+		t.Errorf("expecting a sandbox client by default, got %+v %v", c, err)
+	}
+
+	os.Setenv("PAYPAL_ENV", "Live")
+	if c, err = NewClientFromEnv(); err != nil || c.APIBase != APIBaseLive {
+		t.Errorf("expecting a live client, got %+v %v", c, err)
+	}
+
+	os.Setenv("PAYPAL_ENV", "production")
+	if _, err = NewClientFromEnv(); err == nil {
+		t.Error("expecting an error for an unknown environment")
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	tests := []struct {
+		in       string
+		env      Environment
+		apiBase  string
+		tokenURL string
+	}{
+		{"live", Live, APIBaseLive, APIBaseLive + "/v1/oauth2/token"},
+		{"SANDBOX", Sandbox, APIBaseSandBox, APIBaseSandBox + "/v1/oauth2/token"},
+		{"http://127.0.0.1:8080/", "http://127.0.0.1:8080/", "http://127.0.0.1:8080", "http://127.0.0.1:8080/v1/oauth2/token"},
+	}
+	for _, tt := range tests {
+		env, err := ParseEnvironment(tt.in)
+		if err != nil || env != tt.env || env.APIBase() != tt.apiBase || env.TokenURL() != tt.tokenURL {
+			t.Errorf("%s: unexpected environment %q %s %s %v", tt.in, env, env.APIBase(), env.TokenURL(), err)
+		}
+	}
+
+	for _, in := range []string{"", "production", "api.paypal.com", "ftp://api.paypal.com"} {
+		if _, err := ParseEnvironment(in); err == nil {
+			t.Errorf("%q: expecting an error", in)
+		}
+	}
+
+	c, err := NewClientForEnvironment("foo", "bar", Live)
+	if err != nil || c.APIBase != APIBaseLive || c.ccCfg.TokenURL != Live.TokenURL() {
+		t.Errorf("expecting a live client, got %+v %v", c, err)
+	}
+	if _, err := NewClientForEnvironment("foo", "bar", "prod"); err == nil {
+		t.Error("expecting an error for an unknown environment")
+	}
+
+	c, err = NewClient("foo", "bar", string(Sandbox))
+	if err != nil || c.APIBase != APIBaseSandBox || c.ccCfg.TokenURL != Sandbox.TokenURL() {
+		t.Errorf("expecting a sandbox client, got %+v %v", c, err)
+	}
+	for _, apiBase := range []string{"sandobx", "api-m.paypal.com", "ftp://api-m.paypal.com"} {
+		if _, err := NewClient("foo", "bar", apiBase); err == nil {
+			t.Errorf("%q: expecting an error for a wrong APIBase", apiBase)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Error names and issues PayPal returns when the seller needs to take action
+//
+// https://developer.paypal.com/docs/api/errors/
+const (
+	ErrorNamePermissionDenied     string = "PERMISSION_DENIED"
+	ErrorNameNotAuthorized        string = "NOT_AUTHORIZED"
+	ErrorIssueComplianceViolation string = "COMPLIANCE_VIOLATION"
+)
+
+// Error name and common issues of 422 UNPROCESSABLE_ENTITY responses
+//
+// https://developer.paypal.com/api/rest/reference/orders/v2/errors/
+const (
+	ErrorNameUnprocessableEntity         string = "UNPROCESSABLE_ENTITY"
+	ErrorIssueCannotBeNegative           string = "CANNOT_BE_NEGATIVE"
+	ErrorIssueDuplicateInvoiceID         string = "DUPLICATE_INVOICE_ID"
+	ErrorIssueDecimalPrecision           string = "DECIMAL_PRECISION"
+	ErrorIssueAmountMismatch             string = "AMOUNT_MISMATCH"
+	ErrorIssueOrderAlreadyCaptured       string = "ORDER_ALREADY_CAPTURED"
+	ErrorIssueOrderNotApproved           string = "ORDER_NOT_APPROVED"
+	ErrorIssueOrderAlreadyAuthorized     string = "ORDER_ALREADY_AUTHORIZED"
+	ErrorIssueInstrumentDeclined         string = "INSTRUMENT_DECLINED"
+	ErrorIssuePayerActionRequired        string = "PAYER_ACTION_REQUIRED"
+	ErrorIssueTransactionRefused         string = "TRANSACTION_REFUSED"
+	ErrorIssueMaxNumberOfRefundsExceeded string = "MAX_NUMBER_OF_REFUNDS_EXCEEDED"
+)
+
+// Errors matching an *ErrorResponse with errors.Is, e.g.
+//
+//	if errors.Is(err, paypal.ErrInstrumentDeclined) {
+//		// ask the buyer for another funding source
+//	}
+var (
+	ErrInstrumentDeclined   = errors.New("paypal: instrument declined")
+	ErrOrderAlreadyCaptured = errors.New("paypal: order already captured")
+	ErrOrderNotApproved     = errors.New("paypal: order not approved")
+	ErrPayerActionRequired  = errors.New("paypal: payer action required")
+	ErrResourceNotFound     = errors.New("paypal: resource not found")
+	ErrUnauthorized         = errors.New("paypal: unauthorized")
+	ErrPermissionDenied     = errors.New("paypal: permission denied")
+	ErrRateLimited          = errors.New("paypal: rate limited")
+	ErrPayoutBatchExists    = errors.New("paypal: payout batch already exists")
+)
+
+// issueErrors are the sentinel errors of error detail issues, the most specific first
+var issueErrors = []struct {
+	issue string
+	err   error
+}{
+	{ErrorIssueInstrumentDeclined, ErrInstrumentDeclined},
+	{ErrorIssueOrderAlreadyCaptured, ErrOrderAlreadyCaptured},
+	{ErrorIssueOrderNotApproved, ErrOrderNotApproved},
+	{ErrorIssuePayerActionRequired, ErrPayerActionRequired},
+}
+
+// Is reports whether the error matches one of the sentinel errors, e.g. ErrRateLimited
+func (r *ErrorResponse) Is(target error) bool {
+	for _, ie := range issueErrors {
+		if target == ie.err {
+			return r.HasIssue(ie.issue)
+		}
+	}
+
+	switch target {
+	case ErrResourceNotFound:
+		return r.StatusCode() == http.StatusNotFound || r.Name == "RESOURCE_NOT_FOUND"
+	case ErrUnauthorized:
+		return r.StatusCode() == http.StatusUnauthorized || r.Category() == ErrorCategoryAuthentication
+	case ErrPermissionDenied:
+		return r.StatusCode() == http.StatusForbidden || r.Category() == ErrorCategoryAuthorization ||
+			r.HasIssue(ErrorNamePermissionDenied)
+	case ErrRateLimited:
+		return r.Category() == ErrorCategoryRateLimit
+	case ErrPayoutBatchExists:
+		return r.payoutBatchExists() != nil
+	}
+	return false
+}
+
+// Unwrap returns the most specific sentinel error matching the error, or nil
+func (r *ErrorResponse) Unwrap() error {
+	for _, ie := range issueErrors {
+		if r.HasIssue(ie.issue) {
+			return ie.err
+		}
+	}
+	for _, err := range []error{ErrPayoutBatchExists, ErrResourceNotFound, ErrUnauthorized, ErrPermissionDenied, ErrRateLimited} {
+		if r.Is(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrorCategory groups PayPal error names by how they should be handled
+type ErrorCategory string
+
+// Possible values for ErrorCategory
+const (
+	// ErrorCategoryAuthentication errors are caused by invalid or expired credentials
+	ErrorCategoryAuthentication ErrorCategory = "AUTHENTICATION"
+	// ErrorCategoryAuthorization errors are caused by missing permissions or scopes
+	ErrorCategoryAuthorization ErrorCategory = "AUTHORIZATION"
+	// ErrorCategoryValidation errors are caused by malformed or invalid requests
+	ErrorCategoryValidation ErrorCategory = "VALIDATION"
+	// ErrorCategoryBusinessRule errors are valid requests PayPal refuses, e.g. a declined instrument
+	ErrorCategoryBusinessRule ErrorCategory = "BUSINESS_RULE"
+	// ErrorCategoryRateLimit errors are caused by too many requests and can be retried later
+	ErrorCategoryRateLimit ErrorCategory = "RATE_LIMIT"
+	// ErrorCategoryServer errors are failures on the PayPal side and can be retried
+	ErrorCategoryServer ErrorCategory = "SERVER"
+	// ErrorCategoryUnknown is used for errors which cannot be categorized
+	ErrorCategoryUnknown ErrorCategory = "UNKNOWN"
+)
+
+// errorCategories maps PayPal error names to their category
+//
+// https://developer.paypal.com/api/rest/responses/
+var errorCategories = map[string]ErrorCategory{
+	"AUTHENTICATION_FAILURE":      ErrorCategoryAuthentication,
+	"INVALID_TOKEN":               ErrorCategoryAuthentication,
+	"invalid_client":              ErrorCategoryAuthentication,
+	"invalid_token":               ErrorCategoryAuthentication,
+	ErrorNameNotAuthorized:        ErrorCategoryAuthorization,
+	ErrorNamePermissionDenied:     ErrorCategoryAuthorization,
+	"INVALID_REQUEST":             ErrorCategoryValidation,
+	"MALFORMED_REQUEST":           ErrorCategoryValidation,
+	"VALIDATION_ERROR":            ErrorCategoryValidation,
+	"INVALID_RESOURCE_ID":         ErrorCategoryValidation,
+	"RESOURCE_NOT_FOUND":          ErrorCategoryValidation,
+	"METHOD_NOT_SUPPORTED":        ErrorCategoryValidation,
+	"MEDIA_TYPE_NOT_ACCEPTABLE":   ErrorCategoryValidation,
+	"UNSUPPORTED_MEDIA_TYPE":      ErrorCategoryValidation,
+	ErrorNameUnprocessableEntity:  ErrorCategoryBusinessRule,
+	"RESOURCE_CONFLICT":           ErrorCategoryBusinessRule,
+	"DUPLICATE_REQUEST_ID":        ErrorCategoryBusinessRule,
+	"DUPLICATE_TRANSACTION":       ErrorCategoryBusinessRule,
+	"INSTRUMENT_DECLINED":         ErrorCategoryBusinessRule,
+	"TRANSACTION_REFUSED":         ErrorCategoryBusinessRule,
+	ErrorIssueComplianceViolation: ErrorCategoryBusinessRule,
+	"RATE_LIMIT_REACHED":          ErrorCategoryRateLimit,
+	"INTERNAL_SERVER_ERROR":       ErrorCategoryServer,
+	"INTERNAL_SERVICE_ERROR":      ErrorCategoryServer,
+	"SERVICE_UNAVAILABLE":         ErrorCategoryServer,
+}
+
+// Category returns the category of the error. Names PayPal does not document
+// are categorized by the HTTP status code of the response.
+func (r *ErrorResponse) Category() ErrorCategory {
+	if category, ok := errorCategories[r.Name]; ok {
+		return category
+	}
+	if r.Response == nil {
+		return ErrorCategoryUnknown
+	}
+
+	switch code := r.Response.StatusCode; {
+	case code == http.StatusUnauthorized:
+		return ErrorCategoryAuthentication
+	case code == http.StatusForbidden:
+		return ErrorCategoryAuthorization
+	case code == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimit
+	case code == http.StatusUnprocessableEntity || code == http.StatusConflict:
+		return ErrorCategoryBusinessRule
+	case code >= 500:
+		return ErrorCategoryServer
+	case code >= 400:
+		return ErrorCategoryValidation
+	}
+	return ErrorCategoryUnknown
+}
+
+// ErrorCategoryOf returns the category of err, or ErrorCategoryUnknown when err
+// is not a PayPal error
+func ErrorCategoryOf(err error) ErrorCategory {
+	errResp, ok := asErrorResponse(err)
+	if !ok {
+		return ErrorCategoryUnknown
+	}
+	return errResp.Category()
+}
+
+// IsPermissionDenied reports whether err is a PayPal error caused by a missing
+// permission or scope, e.g. a partner acting for a seller that has not granted it.
+func IsPermissionDenied(err error) bool {
+	errResp, ok := asErrorResponse(err)
+	if !ok {
+		return false
+	}
+	return errResp.Name == ErrorNamePermissionDenied ||
+		errResp.Name == ErrorNameNotAuthorized ||
+		errResp.HasIssue(ErrorNamePermissionDenied)
+}
+
+// IsComplianceViolation reports whether err is a PayPal error caused by a possible
+// compliance violation. These require action from the seller and must not be retried.
+func IsComplianceViolation(err error) bool {
+	errResp, ok := asErrorResponse(err)
+	if !ok {
+		return false
+	}
+	return errResp.Name == ErrorIssueComplianceViolation || errResp.HasIssue(ErrorIssueComplianceViolation)
+}
+
+// Is422 reports whether err is a PayPal 422 UNPROCESSABLE_ENTITY error. Its
+// details, see HasIssue, describe which business rule the request violated.
+func Is422(err error) bool {
+	errResp, ok := asErrorResponse(err)
+	if !ok {
+		return false
+	}
+	if errResp.Response != nil && errResp.Response.StatusCode == http.StatusUnprocessableEntity {
+		return true
+	}
+	return errResp.Name == ErrorNameUnprocessableEntity
+}
+
+// HasIssue reports whether err is a PayPal error with the given issue in its details
+func HasIssue(err error, issue string) bool {
+	errResp, ok := asErrorResponse(err)
+	return ok && errResp.HasIssue(issue)
+}
+
+// IsRetryable reports whether sending the failed request again may succeed:
+// network errors, ErrCircuitOpen and PayPal errors that are Retryable. Errors
+// of a canceled or expired context are not retryable.
+//
+// Only retry requests that are idempotent or carry a PayPal-Request-Id,
+// see WithRequestID, or a retry may create a duplicate.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errResp, ok := asErrorResponse(err); ok {
+		return errResp.Retryable()
+	}
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Retryable reports whether sending the request again may succeed: rate
+// limits, server errors and 409 Conflict responses to requests with a
+// PayPal-Request-Id, which PayPal returns while the first request with the
+// same ID is still being processed. Other errors fail again until the request
+// is changed.
+func (r *ErrorResponse) Retryable() bool {
+	switch r.Category() {
+	case ErrorCategoryRateLimit, ErrorCategoryServer:
+		return true
+	}
+	if r.StatusCode() == http.StatusConflict && r.Response.Request != nil {
+		return r.Response.Request.Header.Get("PayPal-Request-Id") != ""
+	}
+	return false
+}
+
+func asErrorResponse(err error) (*ErrorResponse, bool) {
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp, true
+	}
+	return nil, false
+}
+
+// StatusCode returns the HTTP status code of the response, or 0 when unknown
+func (r *ErrorResponse) StatusCode() int {
+	if r.Response == nil {
+		return 0
+	}
+	return r.Response.StatusCode
+}
+
+// Issues returns the issues of the error details, e.g. INSTRUMENT_DECLINED
+func (r *ErrorResponse) Issues() []string {
+	issues := make([]string, 0, len(r.Details))
+	for _, d := range r.Details {
+		issues = append(issues, d.Issue)
+	}
+	return issues
+}
+
+// HasIssue reports whether one of the error details has the given issue
+func (r *ErrorResponse) HasIssue(issue string) bool {
+	for _, d := range r.Details {
+		if d.Issue == issue {
+			return true
+		}
+	}
+	return false
+}
+
+// friendlyFieldNames are the names shown for the last segment of a field path
+var friendlyFieldNames = map[string]string{
+	"address_line_1":  "address line 1",
+	"address_line_2":  "address line 2",
+	"admin_area_1":    "state",
+	"admin_area_2":    "city",
+	"postal_code":     "postal code",
+	"country_code":    "country",
+	"national_number": "phone number",
+	"email_address":   "email",
+	"given_name":      "first name",
+	"surname":         "last name",
+	"full_name":       "name",
+	"birth_date":      "date of birth",
+	"expiry":          "expiry date",
+	"security_code":   "security code",
+	"unit_amount":     "item price",
+}
+
+// fieldContexts are the path segments naming what a field belongs to
+var fieldContexts = map[string]string{
+	"shipping":        "shipping",
+	"billing_address": "billing",
+	"payer":           "payer",
+	"payee":           "payee",
+	"card":            "card",
+	"items":           "item",
+}
+
+// FriendlyFieldName maps the JSON path of a failing field, as found in
+// ErrorResponseDetail.Field, to a name that can be shown to buyers, e.g.
+// "/purchase_units/0/shipping/address/postal_code" becomes "shipping postal code".
+func FriendlyFieldName(path string) string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		// Skip array indexes and selectors such as @reference_id=='default'
+		if segment == "" || strings.HasPrefix(segment, "@") || strings.Trim(segment, "0123456789") == "" {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 0 {
+		return path
+	}
+
+	leaf := segments[len(segments)-1]
+	parents := segments[:len(segments)-1]
+
+	// Money fields are named after the amount, e.g. amount/value
+	if (leaf == "value" || leaf == "currency_code") && len(parents) > 0 {
+		parent := parents[len(parents)-1]
+		parents = parents[:len(parents)-1]
+		name := humanizeField(parent)
+		if leaf == "currency_code" {
+			name += " currency"
+		}
+		leaf = name
+	} else {
+		leaf = humanizeField(leaf)
+	}
+
+	for i := len(parents) - 1; i >= 0; i-- {
+		if prefix, ok := fieldContexts[parents[i]]; ok && !strings.HasPrefix(leaf, prefix) {
+			return prefix + " " + leaf
+		}
+	}
+
+	return leaf
+}
+
+// FriendlyFieldName returns the name of the failing field to show to buyers
+func (d ErrorResponseDetail) FriendlyFieldName() string {
+	return FriendlyFieldName(d.Field)
+}
+
+func humanizeField(name string) string {
+	if friendly, ok := friendlyFieldNames[name]; ok {
+		return friendly
+	}
+	return strings.Replace(name, "_", " ", -1)
+}
@@ -0,0 +1,266 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestIsPermissionDenied(t *testing.T) {
+	if !IsPermissionDenied(&ErrorResponse{Name: "PERMISSION_DENIED"}) {
+		t.Errorf("expecting PERMISSION_DENIED to be detected")
+	}
+	if !IsPermissionDenied(fmt.Errorf("capture: %w", &ErrorResponse{Name: "NOT_AUTHORIZED"})) {
+		t.Errorf("expecting a wrapped NOT_AUTHORIZED to be detected")
+	}
+	if IsPermissionDenied(&ErrorResponse{Name: "RESOURCE_NOT_FOUND"}) {
+		t.Errorf("expecting RESOURCE_NOT_FOUND not to be detected")
+	}
+}
+
+func TestIsComplianceViolation(t *testing.T) {
+	err := &ErrorResponse{
+		Name:    "UNPROCESSABLE_ENTITY",
+		Details: []ErrorResponseDetail{{Issue: "COMPLIANCE_VIOLATION"}},
+	}
+	if !IsComplianceViolation(err) {
+		t.Errorf("expecting COMPLIANCE_VIOLATION issue to be detected")
+	}
+	if IsComplianceViolation(&ErrorResponse{Name: "UNPROCESSABLE_ENTITY"}) || IsComplianceViolation(nil) {
+		t.Errorf("expecting no compliance violation to be detected")
+	}
+}
+
+func TestIs422(t *testing.T) {
+	data := []byte(`{
+		"name": "UNPROCESSABLE_ENTITY",
+		"details": [{
+			"field": "/purchase_units/@reference_id=='default'/amount/value",
+			"value": "-10.00",
+			"location": "body",
+			"issue": "CANNOT_BE_NEGATIVE",
+			"description": "Must be greater than or equal to 0."
+		}],
+		"message": "The requested action could not be performed, semantically incorrect, or failed business validation.",
+		"debug_id": "90957fca61718"
+	}`)
+
+	errResp := &ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}}
+	if err := json.Unmarshal(data, errResp); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error = fmt.Errorf("create order: %w", errResp)
+	if !Is422(err) {
+		t.Errorf("expecting a 422 error to be detected")
+	}
+	if !HasIssue(err, ErrorIssueCannotBeNegative) || HasIssue(err, ErrorIssueDuplicateInvoiceID) {
+		t.Errorf("expecting only CANNOT_BE_NEGATIVE issue to be detected")
+	}
+
+	detail := errResp.Details[0]
+	if detail.Value != "-10.00" || detail.Location != "body" || detail.Description == "" {
+		t.Errorf("expecting all detail fields to be parsed, got %+v", detail)
+	}
+
+	if Is422(&ErrorResponse{Name: "RESOURCE_NOT_FOUND"}) || Is422(nil) {
+		t.Errorf("expecting no 422 error to be detected")
+	}
+}
+
+func TestFriendlyFieldName(t *testing.T) {
+	tests := map[string]string{
+		"/purchase_units/0/shipping/address/postal_code":        "shipping postal code",
+		"/purchase_units/@reference_id=='default'/amount/value": "amount",
+		"/purchase_units/0/items/1/unit_amount/currency_code":   "item price currency",
+		"/payer/phone/phone_number/national_number":             "payer phone number",
+		"/payment_source/card/billing_address/admin_area_2":     "billing city",
+		"/payment_source/card/expiry":                           "card expiry date",
+		"/purchase_units/0/shipping/name/full_name":             "shipping name",
+		"/application_context/return_url":                       "return url",
+		"":                                                      "",
+	}
+	for path, expected := range tests {
+		if got := FriendlyFieldName(path); got != expected {
+			t.Errorf("%s: expecting %q, got %q", path, expected, got)
+		}
+	}
+}
+
+func TestErrorResponseCategory(t *testing.T) {
+	tests := []struct {
+		err      *ErrorResponse
+		category ErrorCategory
+	}{
+		{&ErrorResponse{Name: "AUTHENTICATION_FAILURE"}, ErrorCategoryAuthentication},
+		{&ErrorResponse{Name: "PERMISSION_DENIED"}, ErrorCategoryAuthorization},
+		{&ErrorResponse{Name: "INVALID_REQUEST"}, ErrorCategoryValidation},
+		{&ErrorResponse{Name: "UNPROCESSABLE_ENTITY"}, ErrorCategoryBusinessRule},
+		{&ErrorResponse{Name: "RATE_LIMIT_REACHED"}, ErrorCategoryRateLimit},
+		{&ErrorResponse{Name: "INTERNAL_SERVER_ERROR"}, ErrorCategoryServer},
+		{&ErrorResponse{Name: "NEW_ERROR", Response: &http.Response{StatusCode: http.StatusBadGateway}}, ErrorCategoryServer},
+		{&ErrorResponse{Name: "NEW_ERROR", Response: &http.Response{StatusCode: http.StatusBadRequest}}, ErrorCategoryValidation},
+		{&ErrorResponse{Name: "NEW_ERROR"}, ErrorCategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		if category := tt.err.Category(); category != tt.category {
+			t.Errorf("%s: expecting category %s got %s", tt.err.Name, tt.category, category)
+		}
+	}
+
+	if category := ErrorCategoryOf(fmt.Errorf("capture: %w", &ErrorResponse{Name: "RATE_LIMIT_REACHED"})); category != ErrorCategoryRateLimit {
+		t.Errorf("expecting the category of a wrapped error, got %s", category)
+	}
+	if category := ErrorCategoryOf(fmt.Errorf("network")); category != ErrorCategoryUnknown {
+		t.Errorf("expecting unknown category for non PayPal errors, got %s", category)
+	}
+}
+
+func TestErrorResponseDetails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Header().Set("Paypal-Debug-Id", "b1d1f06c7246c")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY","message":"The requested action could not be performed.","details":[{"issue":"INSTRUMENT_DECLINED","description":"The instrument presented was declined."}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	_, err := c.CaptureOrder(context.Background(), "O1", CaptureOrderRequest{})
+
+	errResp, ok := err.(*ErrorResponse)
+	if !ok {
+		t.Fatalf("expecting an ErrorResponse, got %v", err)
+	}
+	if errResp.DebugID != "b1d1f06c7246c" || errResp.StatusCode() != http.StatusUnprocessableEntity {
+		t.Errorf("unexpected error %+v", errResp)
+	}
+	if issues := errResp.Issues(); len(issues) != 1 || !errResp.HasIssue(ErrorIssueInstrumentDeclined) {
+		t.Errorf("unexpected issues %q", issues)
+	}
+	if !strings.Contains(errResp.Error(), "debug_id b1d1f06c7246c") {
+		t.Errorf("expecting the debug id in the message, got %q", errResp.Error())
+	}
+
+	// Errors built without a response can be printed
+	errResp = &ErrorResponse{Name: "RESOURCE_NOT_FOUND", Message: "not found"}
+	if msg := errResp.Error(); msg != "paypal: 0 RESOURCE_NOT_FOUND not found, []" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestErrorResponseIs(t *testing.T) {
+	declined := fmt.Errorf("capture: %w", &ErrorResponse{
+		Name:     "UNPROCESSABLE_ENTITY",
+		Response: &http.Response{StatusCode: http.StatusUnprocessableEntity},
+		Details:  []ErrorResponseDetail{{Issue: "INSTRUMENT_DECLINED"}},
+	})
+	if !errors.Is(declined, ErrInstrumentDeclined) {
+		t.Errorf("expecting ErrInstrumentDeclined to match")
+	}
+	if errors.Is(declined, ErrOrderAlreadyCaptured) || errors.Is(declined, ErrRateLimited) {
+		t.Errorf("expecting other sentinels not to match")
+	}
+	if unwrapped := errors.Unwrap(errors.Unwrap(declined)); unwrapped != ErrInstrumentDeclined {
+		t.Errorf("expecting Unwrap to return ErrInstrumentDeclined, got %v", unwrapped)
+	}
+
+	tests := []struct {
+		err      *ErrorResponse
+		sentinel error
+	}{
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}, ErrResourceNotFound},
+		{&ErrorResponse{Name: "AUTHENTICATION_FAILURE"}, ErrUnauthorized},
+		{&ErrorResponse{Name: "NOT_AUTHORIZED"}, ErrPermissionDenied},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusTooManyRequests}}, ErrRateLimited},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.sentinel) {
+			t.Errorf("expecting %v to match %v", tt.err.Name, tt.sentinel)
+		}
+		if tt.err.Unwrap() != tt.sentinel {
+			t.Errorf("expecting %v to unwrap to %v, got %v", tt.err.Name, tt.sentinel, tt.err.Unwrap())
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	withRequestID := httptest.NewRequest(http.MethodPost, "/v2/checkout/orders", nil)
+	withRequestID.Header.Set("PayPal-Request-Id", "abc")
+
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusTooManyRequests}}, true},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadGateway}}, true},
+		{fmt.Errorf("capture: %w", &ErrorResponse{Name: "INTERNAL_SERVICE_ERROR"}), true},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusConflict, Request: withRequestID}}, true},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusConflict, Request: httptest.NewRequest(http.MethodPost, "/", nil)}}, false},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}, Details: []ErrorResponseDetail{{Issue: "INSTRUMENT_DECLINED"}}}, false},
+		{&ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadRequest}}, false},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{&url.Error{Op: "Post", URL: "https://api-m.paypal.com", Err: io.ErrUnexpectedEOF}, true},
+		{&url.Error{Op: "Post", URL: "https://api-m.paypal.com", Err: context.Canceled}, false},
+		{context.DeadlineExceeded, false},
+		{ErrCircuitOpen, true},
+		{errors.New("paypal: invalid amount"), false},
+	}
+	for i, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.retryable {
+			t.Errorf("%d: expected IsRetryable(%v) to be %v", i, tt.err, tt.retryable)
+		}
+	}
+}
+
+func TestErrorResponseRequestSnapshot(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY","details":[{"issue":"INSTRUMENT_DECLINED"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL, WithAuthAssertion("SELLER-1"))
+	c.SetAccessToken("123")
+
+	_, err := c.CaptureOrder(context.Background(), "O1", CaptureOrderRequest{
+		PaymentSource: &PaymentSource{Card: &PaymentSourceCard{
+			Number:       "4111111111111111",
+			Expiry:       "2030-01",
+			SecurityCode: "123",
+		}},
+	})
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("expecting an *ErrorResponse, got %v", err)
+	}
+
+	body := string(errResp.RequestBody)
+	if strings.Contains(body, "4111111111111111") || strings.Contains(body, `"123"`) || !strings.Contains(body, `"expiry":"2030-01"`) {
+		t.Errorf("expecting the card number and security code to be redacted, got %s", body)
+	}
+	if v := errResp.RequestHeader.Get("Paypal-Auth-Assertion"); v != redacted {
+		t.Errorf("expecting the auth assertion to be redacted, got %q", v)
+	}
+	if errResp.RequestHeader.Get("Content-Type") != "application/json" {
+		t.Errorf("expecting the other headers to be kept, got %v", errResp.RequestHeader)
+	}
+}
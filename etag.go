@@ -0,0 +1,87 @@
+package paypal
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// etagCacheSize bounds the number of responses kept for conditional requests
+const etagCacheSize = 1000
+
+type (
+	// etagCache keeps the last ETag and body of GET responses, keyed by cacheKey
+	etagCache struct {
+		sync.Mutex
+		entries map[string]etagEntry
+	}
+
+	etagEntry struct {
+		etag string
+		body []byte
+	}
+)
+
+// SetConditionalRequests enables conditional GET requests. The ETag of GET responses
+// is remembered and sent as If-None-Match on the next GET of the same URL; when PayPal
+// answers 304 Not Modified the previously received body is decoded instead.
+// Resources without an ETag are not affected.
+func (c *Client) SetConditionalRequests(enabled bool) {
+	if !enabled {
+		c.etags = nil
+		return
+	}
+	if c.etags == nil {
+		c.etags = &etagCache{entries: make(map[string]etagEntry)}
+	}
+}
+
+// prepare sets If-None-Match when a response is cached under key
+func (e *etagCache) prepare(key string, req *http.Request) {
+	if req.Method != http.MethodGet {
+		return
+	}
+
+	e.Lock()
+	entry, ok := e.entries[key]
+	e.Unlock()
+
+	if ok {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+}
+
+// get returns the body cached under key
+func (e *etagCache) get(key string) ([]byte, bool) {
+	e.Lock()
+	defer e.Unlock()
+
+	entry, ok := e.entries[key]
+	return entry.body, ok
+}
+
+// put caches the body of a GET response carrying an ETag under key
+func (e *etagCache) put(key string, etag string, body []byte) {
+	e.Lock()
+	defer e.Unlock()
+
+	if len(e.entries) >= etagCacheSize {
+		for key := range e.entries {
+			delete(e.entries, key)
+			break
+		}
+	}
+	e.entries[key] = etagEntry{etag: etag, body: body}
+}
+
+// noStore reports whether the Cache-Control header forbids keeping the response
+func noStore(header http.Header) bool {
+	for _, value := range header["Cache-Control"] {
+		for _, directive := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+				return true
+			}
+		}
+	}
+	return false
+}
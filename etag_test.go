@@ -0,0 +1,49 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	notModified := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":"5O190127TN364715T","status":"APPROVED"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetConditionalRequests(true)
+
+	for i := 0; i < 2; i++ {
+		order, err := c.GetOrder(context.Background(), "5O190127TN364715T")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != OrderStatusApproved {
+			t.Fatalf("call %d: expecting status APPROVED got %q", i, order.Status)
+		}
+	}
+
+	if notModified != 1 {
+		t.Errorf("expecting the second call to be answered with 304, got %d", notModified)
+	}
+}
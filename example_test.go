@@ -2,6 +2,7 @@ package paypal_test
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/optiopay/paypal/v4"
 )
@@ -14,7 +15,7 @@ func Example() {
 	}
 }
 
-func ExampleClient_CreatePayout_Venmo() {
+func ExampleClient_CreatePayout_venmo() {
 	// Initialize client
 	c, err := paypal.NewClient("clientID", "secretID", paypal.APIBaseSandBox)
 	if err != nil {
@@ -45,3 +46,17 @@ func ExampleClient_CreatePayout_Venmo() {
 
 	c.CreatePayout(context.Background(), payout)
 }
+
+func ExampleClient_SetMetricsHook() {
+	c, err := paypal.NewClient("clientID", "secretID", paypal.APIBaseSandBox)
+	if err != nil {
+		panic(err)
+	}
+
+	// Count calls by endpoint and status, e.g. with Prometheus counters and
+	// histograms labelled with m.Endpoint, m.Method and m.StatusCode
+	calls := map[string]int{}
+	c.SetMetricsHook(func(m paypal.RequestMetrics) {
+		calls[fmt.Sprintf("%s %s %d", m.Method, m.Endpoint, m.StatusCode)]++
+	})
+}
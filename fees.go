@@ -0,0 +1,52 @@
+package paypal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// TotalPlatformFees returns the sum of the platform fees collected on a capture,
+// in the currency of the fees. It returns nil when no platform fees were collected.
+func (b *SellerReceivableBreakdown) TotalPlatformFees() (*Money, error) {
+	if b == nil {
+		return nil, nil
+	}
+	return totalPlatformFees(b.PlatformFees)
+}
+
+// TotalPlatformFees returns the sum of the platform fees to collect on a purchase
+// unit or a capture, to check them against the amount before sending the order.
+// It returns nil when there are no platform fees.
+func (i *PaymentInstruction) TotalPlatformFees() (*Money, error) {
+	if i == nil {
+		return nil, nil
+	}
+	return totalPlatformFees(i.PlatformFees)
+}
+
+func totalPlatformFees(fees []PlatformFee) (*Money, error) {
+	if len(fees) == 0 {
+		return nil, nil
+	}
+
+	total := new(big.Rat)
+	currency := ""
+	for _, fee := range fees {
+		if fee.Amount == nil {
+			return nil, errors.New("paypal: platform fee without amount")
+		}
+		if currency != "" && fee.Amount.Currency != currency {
+			return nil, fmt.Errorf("paypal: platform fees in %s and %s can't be added", currency, fee.Amount.Currency)
+		}
+		currency = fee.Amount.Currency
+
+		value, err := parseAmount(fee.Amount.Value)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, value)
+	}
+
+	return &Money{Currency: currency, Value: total.FloatString(CurrencyDecimals(currency))}, nil
+}
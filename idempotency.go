@@ -0,0 +1,99 @@
+package paypal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// requestIDKey is the context key of the PayPal-Request-Id set with WithRequestID
+type requestIDKey struct{}
+
+// WithRequestID returns a context sending requestID as the PayPal-Request-Id
+// header, so PayPal processes a retried request only once. It works with every
+// endpoint, e.g. captures, refunds, payouts and order creation:
+//
+//	ctx = paypal.WithRequestID(ctx, paypal.NewRequestID())
+//	c.CreatePayout(ctx, payout)
+//
+// Store the id with the operation and reuse it when retrying it. Use a new
+// context for every operation: requests with the same id and a different
+// payload are rejected or answered with the first response.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// NewRequestID returns a random UUID to use as PayPal-Request-Id
+func NewRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("paypal: reading random bytes: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// setContextRequestID sets the PayPal-Request-Id header from the request context
+func setContextRequestID(req *http.Request) {
+	requestID, _ := req.Context().Value(requestIDKey{}).(string)
+	if requestID == "" || req.Header.Get("PayPal-Request-Id") != "" {
+		return
+	}
+	if strings.HasSuffix(req.URL.Path, "/v1/oauth2/token") {
+		return
+	}
+	req.Header.Set("PayPal-Request-Id", requestID)
+}
+
+// SetAutoIdempotency sets a PayPal-Request-Id derived from a hash of the
+// method, URL, body, client ID and seller of the PayPal-Auth-Assertion on every
+// POST that doesn't already have one, so identical retried payloads are
+// deduplicated by PayPal.
+//
+// Intended repeats of the same call are merged into one as well, e.g. two
+// equal partial refunds or partial captures of a capture, or charging a billing
+// agreement twice with the same order. Send such calls with their own id:
+//
+//	ctx = paypal.WithRequestID(ctx, paypal.NewRequestID())
+//
+// Payloads containing a timestamp or nonce hash differently on every call and
+// are not deduplicated. An id set explicitly, with WithRequestID or the
+// *WithPaypalRequestId methods, always takes precedence.
+func (c *Client) SetAutoIdempotency(enabled bool) {
+	c.autoIdempotency = enabled
+}
+
+// setIdempotencyKey sets the PayPal-Request-Id header from the request hash,
+// requests of different clients and sellers never share an id
+func (c *Client) setIdempotencyKey(req *http.Request) {
+	if req.Method != http.MethodPost || req.Header.Get("PayPal-Request-Id") != "" {
+		return
+	}
+	if strings.HasSuffix(req.URL.Path, "/v1/oauth2/token") {
+		return
+	}
+
+	h := sha256.New()
+	h.Write([]byte(req.Method + "\n" + req.URL.String() + "\n" + c.ClientID + "\n" + c.requestAuthAssertion(req) + "\n"))
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return
+		}
+		data, err := ioutil.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return
+		}
+		h.Write(data)
+	}
+
+	req.Header.Set("PayPal-Request-Id", hex.EncodeToString(h.Sum(nil)))
+}
@@ -0,0 +1,95 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestAutoIdempotency(t *testing.T) {
+	var ids []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/v1/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		ids = append(ids, r.Header.Get("PayPal-Request-Id"))
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAutoIdempotency(true)
+
+	ctx := context.Background()
+	c.CreateWebhook(ctx, &CreateWebhookRequest{URL: "https://example.com/a"})
+	c.CreateWebhook(ctx, &CreateWebhookRequest{URL: "https://example.com/a"})
+	c.CreateWebhook(ctx, &CreateWebhookRequest{URL: "https://example.com/b"})
+	c.RefundCaptureWithPaypalRequestId(ctx, "C1", RefundCaptureRequest{}, "explicit")
+	c.GetOrder(ctx, "O1")
+	c.CreateWebhook(WithSellerMerchantID(ctx, "SELLER1"), &CreateWebhookRequest{URL: "https://example.com/a"})
+	c.CreateWebhook(WithSellerMerchantID(ctx, "SELLER2"), &CreateWebhookRequest{URL: "https://example.com/a"})
+	other, _ := NewClient("other", "bar", ts.URL)
+	other.SetAutoIdempotency(true)
+	other.CreateWebhook(ctx, &CreateWebhookRequest{URL: "https://example.com/a"})
+
+	if len(ids) != 8 {
+		t.Fatalf("expecting 8 requests, got %d", len(ids))
+	}
+	if ids[0] == "" || ids[0] != ids[1] {
+		t.Errorf("expecting identical payloads to share an id, got %q and %q", ids[0], ids[1])
+	}
+	if ids[0] == ids[2] {
+		t.Errorf("expecting different payloads to get different ids")
+	}
+	if ids[3] != "explicit" {
+		t.Errorf("expecting the explicit id to be kept, got %q", ids[3])
+	}
+	if ids[4] != "" {
+		t.Errorf("expecting no id on GET requests, got %q", ids[4])
+	}
+	if ids[5] == ids[0] || ids[6] == ids[0] || ids[5] == ids[6] {
+		t.Errorf("expecting requests for different sellers to get different ids, got %q", ids[5:7])
+	}
+	if ids[7] == ids[0] {
+		t.Errorf("expecting requests of different clients to get different ids")
+	}
+}
+
+func TestWithRequestID(t *testing.T) {
+	var ids []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/v1/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		ids = append(ids, r.Header.Get("PayPal-Request-Id"))
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAutoIdempotency(true)
+
+	ctx := WithRequestID(context.Background(), "payout-1")
+	c.CreatePayout(ctx, Payout{})
+	c.RefundCaptureWithPaypalRequestId(ctx, "C1", RefundCaptureRequest{}, "explicit")
+
+	if len(ids) != 2 || ids[0] != "payout-1" || ids[1] != "explicit" {
+		t.Errorf("unexpected request ids %q", ids)
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	id := NewRequestID()
+	if len(id) != 36 || id[14] != '4' || id == NewRequestID() {
+		t.Errorf("expecting a random UUID v4, got %q", id)
+	}
+}
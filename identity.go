@@ -2,12 +2,19 @@ package paypal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 )
 
+// Possible values for the tokenTypeHint of RevokeToken
+const (
+	TokenTypeHintAccessToken  string = "ACCESS_TOKEN"
+	TokenTypeHintRefreshToken string = "REFRESH_TOKEN"
+)
+
 // GrantNewAccessTokenFromAuthCode - Use this call to grant a new access token, using the previously obtained authorization code.
 // Endpoint: POST /v1/identity/openidconnect/tokenservice
 func (c *Client) GrantNewAccessTokenFromAuthCode(ctx context.Context, code, redirectURI string) (*TokenResponse, error) {
@@ -32,17 +39,87 @@ func (c *Client) GrantNewAccessTokenFromAuthCode(ctx context.Context, code, redi
 	return token, nil
 }
 
+// ExchangeSellerAuthCode - Use this call to exchange the authCode and sharedId returned after seller onboarding
+// for the seller's access token. The nonce is the code verifier sent with the partner referral.
+// Endpoint: POST /v1/oauth2/token
+func (c *Client) ExchangeSellerAuthCode(ctx context.Context, authCode, sharedID, nonce string) (*TokenResponse, error) {
+	token := &TokenResponse{}
+
+	q := url.Values{}
+	q.Set("grant_type", "authorization_code")
+	q.Set("code", authCode)
+	q.Set("code_verifier", nonce)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/oauth2/token"), strings.NewReader(q.Encode()))
+	if err != nil {
+		return token, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	// The sharedId is used as the username with an empty password
+	req.SetBasicAuth(sharedID, "")
+
+	if _, err = c.do(c.baseHTTPClient(), req, token); err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
 // GrantNewAccessTokenFromRefreshToken - Use this call to grant a new access token, using a refresh token.
 // Endpoint: POST /v1/identity/openidconnect/tokenservice
 func (c *Client) GrantNewAccessTokenFromRefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error) {
+	token := &TokenResponse{}
+
+	q := url.Values{}
+	q.Set("grant_type", "refresh_token")
+	q.Set("refresh_token", refreshToken)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/identity/openidconnect/tokenservice"), strings.NewReader(q.Encode()))
+	if err != nil {
+		return token, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	if err = c.SendWithBasicAuth(req, token); err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
+// RevokeToken - Use this call to revoke an access token or a refresh token of a
+// user, e.g. when they disconnect their PayPal account. Pass TokenTypeHintAccessToken
+// or TokenTypeHintRefreshToken as tokenTypeHint.
+// Endpoint: POST /v1/oauth2/token/terminate
+func (c *Client) RevokeToken(ctx context.Context, token, tokenTypeHint string) error {
+	q := url.Values{}
+	q.Set("token", token)
+	q.Set("token_type_hint", tokenTypeHint)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/oauth2/token/terminate"), strings.NewReader(q.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.SendWithBasicAuth(req, nil)
+}
+
+// GenerateClientToken - Use this call to generate the client token the JavaScript
+// SDK needs for advanced card fields and hosted components. Pass the ID of a
+// vault customer to show the payment methods saved for them, or an empty customerID.
+// Endpoint: POST /v1/identity/generate-token
+func (c *Client) GenerateClientToken(ctx context.Context, customerID string) (*ClientToken, error) {
 	type request struct {
-		GrantType    string `json:"grant_type"`
-		RefreshToken string `json:"refresh_token"`
+		CustomerID string `json:"customer_id,omitempty"`
 	}
 
-	token := &TokenResponse{}
+	token := &ClientToken{}
 
-	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/identity/openidconnect/tokenservice"), request{GrantType: "refresh_token", RefreshToken: refreshToken})
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/identity/generate-token"), request{CustomerID: customerID})
 	if err != nil {
 		return token, err
 	}
@@ -71,3 +148,60 @@ func (c *Client) GetUserInfo(ctx context.Context, schema string) (*UserInfo, err
 
 	return u, nil
 }
+
+// GetPaypalUserInfo - Use this call to retrieve the profile of a buyer who
+// logged in with PayPal, in the paypalv1.1 schema. Pass the access token from
+// GrantNewAccessTokenFromAuthCode, or an empty accessToken for the profile of
+// the account the API credentials belong to.
+// Endpoint: GET /v1/identity/oauth2/userinfo?schema=paypalv1.1
+func (c *Client) GetPaypalUserInfo(ctx context.Context, accessToken string) (*PaypalUserInfo, error) {
+	u := &PaypalUserInfo{}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v1/identity/oauth2/userinfo?schema=paypalv1.1"), nil)
+	if err != nil {
+		return u, err
+	}
+
+	if accessToken == "" {
+		err = c.SendWithAuth(req, u)
+		return u, err
+	}
+
+	// The OAuth2 transport would replace the buyer token with the client token
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	_, err = c.do(c.baseHTTPClient(), req, u)
+	return u, err
+}
+
+// PrimaryEmail returns the primary email address of the user, or the first one
+func (u *PaypalUserInfo) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// UnmarshalJSON decodes verified_account, PayPal sends it as a boolean or a string
+func (u *PaypalUserInfo) UnmarshalJSON(b []byte) error {
+	type userInfo PaypalUserInfo
+	aux := struct {
+		*userInfo
+		VerifiedAccount interface{} `json:"verified_account"`
+	}{userInfo: (*userInfo)(u)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	switch verified := aux.VerifiedAccount.(type) {
+	case bool:
+		u.VerifiedAccount = verified
+	case string:
+		u.VerifiedAccount = verified == "true"
+	}
+	return nil
+}
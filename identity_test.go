@@ -0,0 +1,150 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestGetPaypalUserInfo(t *testing.T) {
+	var auth []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.URL.Path != "/v1/identity/oauth2/userinfo" || r.URL.Query().Get("schema") != "paypalv1.1" {
+			t.Errorf("unexpected request %s", r.RequestURI)
+		}
+		auth = append(auth, r.Header.Get("Authorization"))
+		if len(auth) == 1 {
+			w.Write([]byte(`{"user_id":"https://www.paypal.com/webapps/auth/identity/user/U1","payer_id":"PAYER-1","name":"Jane Doe","verified_account":"true","emails":[{"value":"jane@work.example.com"},{"value":"jane@example.com","primary":true,"confirmed":true}],"address":{"street_address":"1 Main St","locality":"San Jose","region":"CA","postal_code":"95131","country":"US"}}`))
+			return
+		}
+		w.Write([]byte(`{"user_id":"U2","payer_id":"PAYER-2","verified_account":false}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	u, err := c.GetPaypalUserInfo(ctx, "buyer-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.PayerID != "PAYER-1" || !u.VerifiedAccount || u.PrimaryEmail() != "jane@example.com" || u.Address.Locality != "San Jose" {
+		t.Errorf("unexpected user info %+v", u)
+	}
+
+	u, err = c.GetPaypalUserInfo(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.PayerID != "PAYER-2" || u.VerifiedAccount || u.PrimaryEmail() != "" {
+		t.Errorf("unexpected user info %+v", u)
+	}
+
+	if auth[0] != "Bearer buyer-token" || auth[1] != "Bearer 123" {
+		t.Errorf("unexpected authorization headers %v", auth)
+	}
+}
+
+func TestGenerateClientToken(t *testing.T) {
+	var bodies []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/identity/generate-token" {
+			t.Errorf("unexpected request %s %s", r.Method, r.RequestURI)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"client_token":"CLIENT-TOKEN","expires_in":3600}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	token, err := c.GenerateClientToken(ctx, "CUST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ClientToken != "CLIENT-TOKEN" || token.ExpiresIn != 3600 {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if _, err := c.GenerateClientToken(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 || bodies[0]["customer_id"] != "CUST-1" || len(bodies[1]) != 0 {
+		t.Errorf("unexpected request bodies %v", bodies)
+	}
+}
+
+func TestUserTokenGrants(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "foo" || pass != "bar" {
+			t.Errorf("expecting basic auth with the client credentials, got %q:%q", user, pass)
+		}
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Errorf("expecting a form body, got %s", r.Header.Get("Content-Type"))
+		}
+		r.ParseForm()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.PostForm.Encode())
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token/terminate" {
+			return
+		}
+		w.Write([]byte(`{"access_token":"user-token","refresh_token":"refresh","token_type":"Bearer","expires_in":28800,"scope":"openid email"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	token, err := c.GrantNewAccessTokenFromAuthCode(ctx, "auth-code", "https://example.com/return")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != "user-token" || token.RefreshToken != "refresh" || token.Scope != "openid email" {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	token, err = c.GrantNewAccessTokenFromRefreshToken(ctx, "refresh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != "user-token" || token.ExpiresIn != 28800 {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	if err := c.RevokeToken(ctx, "refresh", TokenTypeHintRefreshToken); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"POST /v1/identity/openidconnect/tokenservice code=auth-code&grant_type=authorization_code&redirect_uri=https%3A%2F%2Fexample.com%2Freturn",
+		"POST /v1/identity/openidconnect/tokenservice grant_type=refresh_token&refresh_token=refresh",
+		"POST /v1/oauth2/token/terminate token=refresh&token_type_hint=REFRESH_TOKEN",
+	}
+	for i := range expected {
+		if i >= len(requests) || requests[i] != expected[i] {
+			t.Errorf("unexpected requests %v", requests)
+			break
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type (
+	// InvoiceTemplate is a reusable set of invoice defaults
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#definition-template
+	InvoiceTemplate struct {
+		ID               string               `json:"id,omitempty"`
+		Name             string               `json:"name"`
+		DefaultTemplate  bool                 `json:"default_template,omitempty"`
+		StandardTemplate bool                 `json:"standard_template,omitempty"`
+		TemplateInfo     *InvoiceTemplateInfo `json:"template_info,omitempty"`
+		UnitOfMeasure    string               `json:"unit_of_measure,omitempty"`
+		Links            Links                `json:"links,omitempty"`
+	}
+
+	// InvoiceTemplateInfo has the fields copied into invoices created from the template
+	InvoiceTemplateInfo struct {
+		Detail               *InvoiceDetail         `json:"detail,omitempty"`
+		Invoicer             *InvoicerInfo          `json:"invoicer,omitempty"`
+		PrimaryRecipients    []InvoiceRecipientInfo `json:"primary_recipients,omitempty"`
+		AdditionalRecipients []InvoiceEmailAddress  `json:"additional_recipients,omitempty"`
+		Items                []InvoiceItem          `json:"items,omitempty"`
+		Configuration        *InvoiceConfiguration  `json:"configuration,omitempty"`
+		Amount               *Money                 `json:"amount,omitempty"`
+		DueAmount            *Money                 `json:"due_amount,omitempty"`
+	}
+
+	// ListInvoiceTemplatesResponse struct
+	ListInvoiceTemplatesResponse struct {
+		Templates []InvoiceTemplate `json:"templates"`
+		Links     Links             `json:"links,omitempty"`
+	}
+)
+
+// CreateInvoiceTemplate creates an invoice template, use its ID as the
+// Configuration.TemplateID of invoices
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#templates_create
+// Endpoint: POST /v2/invoicing/templates
+func (c *Client) CreateInvoiceTemplate(ctx context.Context, template InvoiceTemplate) (*InvoiceTemplate, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v2/invoicing/templates"), template)
+	response := &InvoiceTemplate{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// ListInvoiceTemplates returns a page of the merchant's templates
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#templates_list
+// Endpoint: GET /v2/invoicing/templates
+func (c *Client) ListInvoiceTemplates(ctx context.Context, params *ListParams) (*ListInvoiceTemplatesResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v2/invoicing/templates"), nil)
+	response := &ListInvoiceTemplatesResponse{}
+	if err != nil {
+		return response, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.query().Encode()
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// GetInvoiceTemplate shows details for a template, by ID
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#templates_get
+// Endpoint: GET /v2/invoicing/templates/{id}
+func (c *Client) GetInvoiceTemplate(ctx context.Context, templateID string) (*InvoiceTemplate, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.APIBase, "/v2/invoicing/templates/", templateID), nil)
+	response := &InvoiceTemplate{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// UpdateInvoiceTemplate replaces a template, fields missing in the template are cleared
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#templates_update
+// Endpoint: PUT /v2/invoicing/templates/{id}
+func (c *Client) UpdateInvoiceTemplate(ctx context.Context, template InvoiceTemplate) (*InvoiceTemplate, error) {
+	req, err := c.NewRequest(ctx, http.MethodPut, fmt.Sprintf("%s%s%s", c.APIBase, "/v2/invoicing/templates/", template.ID), template)
+	response := &InvoiceTemplate{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// DeleteInvoiceTemplate deletes a template, invoices created from it are unchanged
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#templates_delete
+// Endpoint: DELETE /v2/invoicing/templates/{id}
+func (c *Client) DeleteInvoiceTemplate(ctx context.Context, templateID string) error {
+	req, err := c.NewRequest(ctx, http.MethodDelete, fmt.Sprintf("%s%s%s", c.APIBase, "/v2/invoicing/templates/", templateID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
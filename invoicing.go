@@ -0,0 +1,544 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type (
+	// ListInvoicesResponse is a page of invoices
+	ListInvoicesResponse struct {
+		Items []Invoice `json:"items"`
+		SharedListResponse
+	}
+
+	// Invoice struct
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#definition-invoice
+	Invoice struct {
+		ID                   string                 `json:"id,omitempty"`
+		Status               string                 `json:"status,omitempty"`
+		Detail               *InvoiceDetail         `json:"detail,omitempty"`
+		Invoicer             *InvoicerInfo          `json:"invoicer,omitempty"`
+		PrimaryRecipients    []InvoiceRecipientInfo `json:"primary_recipients,omitempty"`
+		AdditionalRecipients []InvoiceEmailAddress  `json:"additional_recipients,omitempty"`
+		Items                []InvoiceItem          `json:"items,omitempty"`
+		Configuration        *InvoiceConfiguration  `json:"configuration,omitempty"`
+		Amount               *Money                 `json:"amount,omitempty"`
+		DueAmount            *Money                 `json:"due_amount,omitempty"`
+		Payments             *InvoicePayments       `json:"payments,omitempty"`
+		Refunds              *InvoiceRefunds        `json:"refunds,omitempty"`
+		Links                Links                  `json:"links,omitempty"`
+	}
+
+	// InvoiceConfiguration struct
+	InvoiceConfiguration struct {
+		AllowTip                   bool                   `json:"allow_tip,omitempty"`
+		TaxCalculatedAfterDiscount bool                   `json:"tax_calculated_after_discount,omitempty"`
+		TaxInclusive               bool                   `json:"tax_inclusive,omitempty"`
+		PartialPayment             *InvoicePartialPayment `json:"partial_payment,omitempty"`
+		TemplateID                 string                 `json:"template_id,omitempty"`
+	}
+
+	// InvoicePartialPayment struct
+	InvoicePartialPayment struct {
+		AllowPartialPayment bool   `json:"allow_partial_payment,omitempty"`
+		MinimumAmountDue    *Money `json:"minimum_amount_due,omitempty"`
+	}
+
+	// InvoiceDetail struct
+	InvoiceDetail struct {
+		InvoiceNumber      string                 `json:"invoice_number,omitempty"`
+		Reference          string                 `json:"reference,omitempty"`
+		InvoiceDate        *JSONDate              `json:"invoice_date,omitempty"`
+		CurrencyCode       string                 `json:"currency_code"`
+		Note               string                 `json:"note,omitempty"`
+		TermsAndConditions string                 `json:"terms_and_conditions,omitempty"`
+		Memo               string                 `json:"memo,omitempty"`
+		PaymentTerm        *InvoicePaymentTerm    `json:"payment_term,omitempty"`
+		Metadata           *InvoiceMetadata       `json:"metadata,omitempty"`
+		Attachments        []InvoiceFileReference `json:"attachments,omitempty"`
+	}
+
+	// InvoiceFileReference is a file attached to an invoice, such as a receipt
+	// or a contract. Files can't be uploaded with the client, references read
+	// from an invoice can be sent again with it.
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#definition-file_reference
+	InvoiceFileReference struct {
+		ID           string     `json:"id,omitempty"`
+		ReferenceURL string     `json:"reference_url,omitempty"`
+		ContentType  string     `json:"content_type,omitempty"`
+		CreateTime   *time.Time `json:"create_time,omitempty"`
+		Size         string     `json:"size,omitempty"`
+	}
+
+	// InvoicePaymentTerm struct
+	InvoicePaymentTerm struct {
+		TermType string    `json:"term_type,omitempty"`
+		DueDate  *JSONDate `json:"due_date,omitempty"`
+	}
+
+	// InvoiceMetadata struct
+	InvoiceMetadata struct {
+		CreateTime    *time.Time `json:"create_time,omitempty"`
+		LastSentTime  *time.Time `json:"last_sent_time,omitempty"`
+		RecipientView string     `json:"recipient_view_url,omitempty"`
+		InvoicerView  string     `json:"invoicer_view_url,omitempty"`
+	}
+
+	// InvoicerInfo struct
+	InvoicerInfo struct {
+		BusinessName string                         `json:"business_name,omitempty"`
+		Name         *Name                          `json:"name,omitempty"`
+		Address      *ShippingDetailAddressPortable `json:"address,omitempty"`
+		EmailAddress string                         `json:"email_address,omitempty"`
+		Website      string                         `json:"website,omitempty"`
+		Phones       []Phone                        `json:"phones,omitempty"`
+		TaxID        string                         `json:"tax_id,omitempty"`
+		LogoURL      string                         `json:"logo_url,omitempty"`
+	}
+
+	// InvoiceRecipientInfo struct
+	InvoiceRecipientInfo struct {
+		BillingInfo  *InvoiceBillingInfo `json:"billing_info,omitempty"`
+		ShippingInfo *InvoiceContactInfo `json:"shipping_info,omitempty"`
+	}
+
+	// InvoiceBillingInfo struct
+	InvoiceBillingInfo struct {
+		BusinessName   string                         `json:"business_name,omitempty"`
+		Name           *Name                          `json:"name,omitempty"`
+		Address        *ShippingDetailAddressPortable `json:"address,omitempty"`
+		EmailAddress   string                         `json:"email_address,omitempty"`
+		Phones         []Phone                        `json:"phones,omitempty"`
+		AdditionalInfo string                         `json:"additional_info,omitempty"`
+		Language       string                         `json:"language,omitempty"`
+	}
+
+	// InvoiceContactInfo struct
+	InvoiceContactInfo struct {
+		BusinessName string                         `json:"business_name,omitempty"`
+		Name         *Name                          `json:"name,omitempty"`
+		Address      *ShippingDetailAddressPortable `json:"address,omitempty"`
+	}
+
+	// InvoiceEmailAddress is an additional (CC) recipient of an invoice
+	InvoiceEmailAddress struct {
+		EmailAddress string `json:"email_address"`
+	}
+
+	// InvoiceItem struct
+	InvoiceItem struct {
+		ID            string           `json:"id,omitempty"`
+		Name          string           `json:"name"`
+		Description   string           `json:"description,omitempty"`
+		Quantity      string           `json:"quantity"`
+		UnitAmount    *Money           `json:"unit_amount"`
+		Tax           *InvoiceTax      `json:"tax,omitempty"`
+		Discount      *InvoiceDiscount `json:"discount,omitempty"`
+		ItemDate      *JSONDate        `json:"item_date,omitempty"`
+		UnitOfMeasure string           `json:"unit_of_measure,omitempty"`
+	}
+
+	// InvoiceTax is the tax of an item, PayPal computes the amount from the percent
+	InvoiceTax struct {
+		Name    string `json:"name"`
+		Percent string `json:"percent"`
+		Amount  *Money `json:"amount,omitempty"`
+	}
+
+	// InvoiceDiscount is either a percent or an amount of discount
+	InvoiceDiscount struct {
+		Percent string `json:"percent,omitempty"`
+		Amount  *Money `json:"amount,omitempty"`
+	}
+
+	// InvoicePayments struct
+	InvoicePayments struct {
+		PaidAmount   *Money                 `json:"paid_amount,omitempty"`
+		Transactions []InvoicePaymentDetail `json:"transactions,omitempty"`
+	}
+
+	// InvoicePaymentDetail is a payment of an invoice. Payments received outside
+	// of PayPal are recorded with RecordInvoicePayment.
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#definition-payment_detail
+	InvoicePaymentDetail struct {
+		Type         string              `json:"type,omitempty"`
+		PaymentID    string              `json:"payment_id,omitempty"`
+		PaymentDate  *JSONDate           `json:"payment_date,omitempty"`
+		Method       string              `json:"method"`
+		Note         string              `json:"note,omitempty"`
+		Amount       *Money              `json:"amount,omitempty"`
+		ShippingInfo *InvoiceContactInfo `json:"shipping_info,omitempty"`
+	}
+
+	// InvoiceRefunds struct
+	InvoiceRefunds struct {
+		RefundAmount *Money                `json:"refund_amount,omitempty"`
+		Transactions []InvoiceRefundDetail `json:"transactions,omitempty"`
+	}
+
+	// InvoiceRefundDetail is a refund of an invoice payment
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#definition-refund_detail
+	InvoiceRefundDetail struct {
+		Type       string    `json:"type,omitempty"`
+		RefundID   string    `json:"refund_id,omitempty"`
+		RefundDate *JSONDate `json:"refund_date,omitempty"`
+		Method     string    `json:"method"`
+		Amount     *Money    `json:"amount,omitempty"`
+	}
+
+	// InvoiceQRCodeRequest struct
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_generate-qr-code
+	InvoiceQRCodeRequest struct {
+		// Width and Height are in pixels, between 150 and 500, PayPal uses 500 when they are 0
+		Width  int    `json:"width,omitempty"`
+		Height int    `json:"height,omitempty"`
+		Action string `json:"action,omitempty"`
+	}
+
+	// InvoiceQRCode is a QR code of an invoice
+	InvoiceQRCode struct {
+		// Image is the base64 encoded PNG image
+		Image string
+	}
+
+	// InvoiceSearch filters the invoices of SearchInvoices, empty fields match all invoices
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#definition-search_data
+	InvoiceSearch struct {
+		RecipientEmail        string              `json:"recipient_email,omitempty"`
+		RecipientFirstName    string              `json:"recipient_first_name,omitempty"`
+		RecipientLastName     string              `json:"recipient_last_name,omitempty"`
+		RecipientBusinessName string              `json:"recipient_business_name,omitempty"`
+		InvoiceNumber         string              `json:"invoice_number,omitempty"`
+		Status                []string            `json:"status,omitempty"`
+		Reference             string              `json:"reference,omitempty"`
+		CurrencyCode          string              `json:"currency_code,omitempty"`
+		Memo                  string              `json:"memo,omitempty"`
+		TotalAmountRange      *InvoiceAmountRange `json:"total_amount_range,omitempty"`
+		InvoiceDateRange      *InvoiceDateRange   `json:"invoice_date_range,omitempty"`
+		DueDateRange          *InvoiceDateRange   `json:"due_date_range,omitempty"`
+		PaymentDateRange      *InvoiceTimeRange   `json:"payment_date_range,omitempty"`
+		CreationDateRange     *InvoiceTimeRange   `json:"creation_date_range,omitempty"`
+		Archived              *bool               `json:"archived,omitempty"`
+		Fields                []string            `json:"fields,omitempty"`
+	}
+
+	// InvoiceAmountRange struct
+	InvoiceAmountRange struct {
+		LowerAmount *Money `json:"lower_amount"`
+		UpperAmount *Money `json:"upper_amount"`
+	}
+
+	// InvoiceDateRange is a range of dates
+	InvoiceDateRange struct {
+		Start JSONDate `json:"start"`
+		End   JSONDate `json:"end"`
+	}
+
+	// InvoiceTimeRange struct
+	InvoiceTimeRange struct {
+		Start *time.Time `json:"start"`
+		End   *time.Time `json:"end"`
+	}
+
+	// InvoiceNumber struct
+	InvoiceNumber struct {
+		InvoiceNumber string `json:"invoice_number"`
+	}
+
+	// InvoicePaymentReference struct
+	InvoicePaymentReference struct {
+		PaymentID string `json:"payment_id"`
+	}
+
+	// InvoiceRefundReference struct
+	InvoiceRefundReference struct {
+		RefundID string `json:"refund_id"`
+	}
+
+	// SendInvoiceRequest struct
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_send
+	SendInvoiceRequest struct {
+		Subject string `json:"subject,omitempty"`
+		Note    string `json:"note,omitempty"`
+		// SendToInvoicer and SendToRecipient default to false and true when nil
+		SendToInvoicer  *bool `json:"send_to_invoicer,omitempty"`
+		SendToRecipient *bool `json:"send_to_recipient,omitempty"`
+		// AdditionalRecipients are email addresses CCed on the notification
+		AdditionalRecipients []string `json:"additional_recipients,omitempty"`
+	}
+
+	// InvoiceNotificationRequest is the notification sent with a reminder or a
+	// cancellation of a sent invoice, it has the fields of SendInvoiceRequest
+	// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#definition-notification
+	InvoiceNotificationRequest = SendInvoiceRequest
+)
+
+// Possible values for `status` in Invoice
+//
+// https://developer.paypal.com/docs/api/invoicing/v2/#definition-invoice_status
+const (
+	InvoiceStatusDraft             string = "DRAFT"
+	InvoiceStatusSent              string = "SENT"
+	InvoiceStatusScheduled         string = "SCHEDULED"
+	InvoiceStatusPaid              string = "PAID"
+	InvoiceStatusMarkedAsPaid      string = "MARKED_AS_PAID"
+	InvoiceStatusCancelled         string = "CANCELLED"
+	InvoiceStatusRefunded          string = "REFUNDED"
+	InvoiceStatusPartiallyPaid     string = "PARTIALLY_PAID"
+	InvoiceStatusPartiallyRefunded string = "PARTIALLY_REFUNDED"
+	InvoiceStatusMarkedAsRefunded  string = "MARKED_AS_REFUNDED"
+	InvoiceStatusUnpaid            string = "UNPAID"
+	InvoiceStatusPaymentPending    string = "PAYMENT_PENDING"
+)
+
+// Possible values for `method` in InvoicePaymentDetail and InvoiceRefundDetail
+//
+// https://developer.paypal.com/docs/api/invoicing/v2/#definition-payment_method
+const (
+	InvoicePaymentMethodBankTransfer string = "BANK_TRANSFER"
+	InvoicePaymentMethodCash         string = "CASH"
+	InvoicePaymentMethodCheck        string = "CHECK"
+	InvoicePaymentMethodCreditCard   string = "CREDIT_CARD"
+	InvoicePaymentMethodDebitCard    string = "DEBIT_CARD"
+	InvoicePaymentMethodPaypal       string = "PAYPAL"
+	InvoicePaymentMethodWireTransfer string = "WIRE_TRANSFER"
+	InvoicePaymentMethodOther        string = "OTHER"
+)
+
+// Possible values for `action` in InvoiceQRCodeRequest
+const (
+	InvoiceQRCodeActionPay     string = "pay"
+	InvoiceQRCodeActionDetails string = "details"
+)
+
+// CreateDraftInvoice creates a draft invoice, send it with SendInvoice
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_create
+// Endpoint: POST /v2/invoicing/invoices
+func (c *Client) CreateDraftInvoice(ctx context.Context, invoice Invoice) (*Invoice, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v2/invoicing/invoices"), invoice)
+	response := &Invoice{}
+	if err != nil {
+		return response, err
+	}
+
+	req.Header.Set("Prefer", "return=representation")
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// GenerateNextInvoiceNumber reserves the next invoice number of the merchant's sequence,
+// set it as the Detail.InvoiceNumber of the next draft
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_generate-next-invoice-number
+// Endpoint: POST /v2/invoicing/generate-next-invoice-number
+func (c *Client) GenerateNextInvoiceNumber(ctx context.Context) (*InvoiceNumber, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v2/invoicing/generate-next-invoice-number"), nil)
+	response := &InvoiceNumber{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// GetInvoice shows details for an invoice, by ID.
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_get
+// Endpoint: GET /v2/invoicing/invoices/{id}
+func (c *Client) GetInvoice(ctx context.Context, invoiceID string) (*Invoice, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID), nil)
+	response := &Invoice{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// ListInvoices returns a page of invoices, use NewInvoicePaginator to iterate all of them
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_list
+// Endpoint: GET /v2/invoicing/invoices
+func (c *Client) ListInvoices(ctx context.Context, params *ListParams) (*ListInvoicesResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v2/invoicing/invoices"), nil)
+	response := &ListInvoicesResponse{}
+	if err != nil {
+		return response, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.query().Encode()
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// SearchInvoices returns a page of the invoices matching the search
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#search-invoices_search-invoices
+// Endpoint: POST /v2/invoicing/search-invoices
+func (c *Client) SearchInvoices(ctx context.Context, search InvoiceSearch, params *ListParams) (*ListInvoicesResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v2/invoicing/search-invoices"), search)
+	response := &ListInvoicesResponse{}
+	if err != nil {
+		return response, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.query().Encode()
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// DeleteInvoice deletes a draft or scheduled invoice, sent invoices must be cancelled instead
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_delete
+// Endpoint: DELETE /v2/invoicing/invoices/{id}
+func (c *Client) DeleteInvoice(ctx context.Context, invoiceID string) error {
+	req, err := c.NewRequest(ctx, http.MethodDelete, fmt.Sprintf("%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// RecordInvoicePayment marks an invoice as paid, fully or partially, with a payment
+// received outside of PayPal
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_payments
+// Endpoint: POST /v2/invoicing/invoices/{id}/payments
+func (c *Client) RecordInvoicePayment(ctx context.Context, invoiceID string, payment InvoicePaymentDetail) (*InvoicePaymentReference, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID, "/payments"), payment)
+	response := &InvoicePaymentReference{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// RecordInvoiceRefund marks an invoice as refunded, fully or partially, with a refund
+// made outside of PayPal
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_refunds
+// Endpoint: POST /v2/invoicing/invoices/{id}/refunds
+func (c *Client) RecordInvoiceRefund(ctx context.Context, invoiceID string, refund InvoiceRefundDetail) (*InvoiceRefundReference, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID, "/refunds"), refund)
+	response := &InvoiceRefundReference{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// GenerateInvoiceQRCode returns a QR code the buyer scans to pay or view a sent invoice
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_generate-qr-code
+// Endpoint: POST /v2/invoicing/invoices/{id}/generate-qr-code
+func (c *Client) GenerateInvoiceQRCode(ctx context.Context, invoiceID string, qrCodeRequest InvoiceQRCodeRequest) (*InvoiceQRCode, error) {
+	response := &InvoiceQRCode{}
+	for _, size := range []int{qrCodeRequest.Width, qrCodeRequest.Height} {
+		if size != 0 && (size < 150 || size > 500) {
+			return response, fmt.Errorf("paypal: QR code size must be between 150 and 500 pixels, got %d", size)
+		}
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID, "/generate-qr-code"), qrCodeRequest)
+	if err != nil {
+		return response, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err = c.SendWithAuth(req, buf); err != nil {
+		return response, err
+	}
+
+	response.Image = qrCodeImage(buf.String())
+	return response, nil
+}
+
+// PNG returns the decoded PNG image of the QR code
+func (q *InvoiceQRCode) PNG() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(q.Image)
+}
+
+// qrCodeImage returns the base64 image of a QR code response. PayPal sends it
+// either as the plain body or as the only part of a multipart body.
+func qrCodeImage(body string) string {
+	body = strings.TrimSpace(body)
+	if !strings.HasPrefix(body, "--") {
+		return body
+	}
+
+	// Skip the boundary and part headers, the image ends at the closing boundary
+	if i := strings.Index(body, "\r\n\r\n"); i >= 0 {
+		body = body[i+4:]
+	} else if i := strings.Index(body, "\n\n"); i >= 0 {
+		body = body[i+2:]
+	}
+	if i := strings.Index(body, "\n--"); i >= 0 {
+		body = body[:i]
+	}
+	return strings.TrimSpace(body)
+}
+
+// NewInvoicePaginator returns a Paginator over all pages of invoices,
+// decode each page into a ListInvoicesResponse
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_list
+// Endpoint: GET /v2/invoicing/invoices
+func (c *Client) NewInvoicePaginator(params *ListParams) *Paginator {
+	q := url.Values{}
+	if params != nil {
+		q = params.query()
+	}
+	return c.NewPaginator("/v2/invoicing/invoices", q)
+}
+
+// SendInvoice sends an invoice to its primary recipients, CCing the additional
+// recipients of the invoice and of the request.
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_send
+// Endpoint: POST /v2/invoicing/invoices/{id}/send
+func (c *Client) SendInvoice(ctx context.Context, invoiceID string, sendInvoiceRequest SendInvoiceRequest) (*Link, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID, "/send"), sendInvoiceRequest)
+	response := &Link{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// SendInvoiceReminder reminds the recipients of a sent invoice that it is due
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_remind
+// Endpoint: POST /v2/invoicing/invoices/{id}/remind
+func (c *Client) SendInvoiceReminder(ctx context.Context, invoiceID string, notification InvoiceNotificationRequest) error {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID, "/remind"), notification)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// CancelSentInvoice cancels a sent invoice and notifies its recipients,
+// drafts and scheduled invoices are deleted with DeleteInvoice instead
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_cancel
+// Endpoint: POST /v2/invoicing/invoices/{id}/cancel
+func (c *Client) CancelSentInvoice(ctx context.Context, invoiceID string, notification InvoiceNotificationRequest) error {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID, "/cancel"), notification)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
@@ -0,0 +1,338 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestInvoiceRecordPaymentAndRefund(t *testing.T) {
+	var requests []string
+	var payment InvoicePaymentDetail
+	var refund InvoiceRefundDetail
+	var notifications []InvoiceNotificationRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/v2/invoicing/invoices":
+			w.Write([]byte(`{"items":[{"id":"INV2-1","status":"SENT","items":[{"name":"Hours","quantity":"2",` +
+				`"unit_amount":{"currency_code":"USD","value":"50.00"},"tax":{"name":"VAT","percent":"20"},"discount":{"percent":"10"}}]}],"total_items":1}`))
+		case "/v2/invoicing/invoices/INV2-1/payments":
+			json.NewDecoder(r.Body).Decode(&payment)
+			w.Write([]byte(`{"payment_id":"EXTR-1"}`))
+		case "/v2/invoicing/invoices/INV2-1/refunds":
+			json.NewDecoder(r.Body).Decode(&refund)
+			w.Write([]byte(`{"refund_id":"EXTR-2"}`))
+		case "/v2/invoicing/invoices/INV2-1/remind", "/v2/invoicing/invoices/INV2-1/cancel":
+			var notification InvoiceNotificationRequest
+			json.NewDecoder(r.Body).Decode(&notification)
+			notifications = append(notifications, notification)
+			w.WriteHeader(http.StatusNoContent)
+		case "/v2/invoicing/invoices/INV2-2":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	invoices, err := c.ListInvoices(ctx, &ListParams{PageSize: "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invoices.Items) != 1 || invoices.TotalItems != 1 {
+		t.Fatalf("unexpected invoices %+v", invoices)
+	}
+	item := invoices.Items[0].Items[0]
+	if item.Tax == nil || item.Tax.Percent != "20" || item.Discount == nil || item.Discount.Percent != "10" {
+		t.Errorf("unexpected item %+v", item)
+	}
+
+	paymentDate := JSONDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	paid, err := c.RecordInvoicePayment(ctx, "INV2-1", InvoicePaymentDetail{
+		Method:      InvoicePaymentMethodBankTransfer,
+		PaymentDate: &paymentDate,
+		Amount:      &Money{Currency: "USD", Value: "90.00"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paid.PaymentID != "EXTR-1" || payment.Method != InvoicePaymentMethodBankTransfer || payment.Amount.Value != "90.00" ||
+		payment.PaymentDate == nil || payment.PaymentDate.String() != "2024-03-01" {
+		t.Errorf("unexpected payment %+v, request %+v", paid, payment)
+	}
+
+	refunded, err := c.RecordInvoiceRefund(ctx, "INV2-1", InvoiceRefundDetail{Method: InvoicePaymentMethodCash, Amount: &Money{Currency: "USD", Value: "10.00"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refunded.RefundID != "EXTR-2" || refund.Method != InvoicePaymentMethodCash {
+		t.Errorf("unexpected refund %+v, request %+v", refunded, refund)
+	}
+
+	if err := c.SendInvoiceReminder(ctx, "INV2-1", InvoiceNotificationRequest{Subject: "Reminder", Note: "Due tomorrow"}); err != nil {
+		t.Fatal(err)
+	}
+	sendToRecipient := false
+	if err := c.CancelSentInvoice(ctx, "INV2-1", InvoiceNotificationRequest{Note: "Cancelled", SendToRecipient: &sendToRecipient}); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 2 || notifications[0].Subject != "Reminder" || notifications[1].Note != "Cancelled" ||
+		notifications[1].SendToRecipient == nil || *notifications[1].SendToRecipient {
+		t.Errorf("unexpected notifications %+v", notifications)
+	}
+
+	if err := c.DeleteInvoice(ctx, "INV2-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"GET /v2/invoicing/invoices?page_size=10",
+		"POST /v2/invoicing/invoices/INV2-1/payments",
+		"POST /v2/invoicing/invoices/INV2-1/refunds",
+		"POST /v2/invoicing/invoices/INV2-1/remind",
+		"POST /v2/invoicing/invoices/INV2-1/cancel",
+		"DELETE /v2/invoicing/invoices/INV2-2",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expecting requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("request %d: expecting %s, got %s", i, expected[i], requests[i])
+		}
+	}
+}
+
+func TestGenerateInvoiceQRCode(t *testing.T) {
+	png := base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n"))
+	var body InvoiceQRCodeRequest
+	multipart := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if multipart {
+			w.Header().Set("Content-Type", "multipart/related; boundary=abc")
+			w.Write([]byte("--abc\r\nContent-Type: image/png\r\n\r\n" + png + "\r\n--abc--\r\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(png))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	if _, err := c.GenerateInvoiceQRCode(ctx, "INV2-1", InvoiceQRCodeRequest{Width: 100}); err == nil {
+		t.Error("expecting an error for a QR code below 150 pixels")
+	}
+
+	for _, multipart = range []bool{false, true} {
+		qr, err := c.GenerateInvoiceQRCode(ctx, "INV2-1", InvoiceQRCodeRequest{Width: 300, Height: 300, Action: InvoiceQRCodeActionPay})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if qr.Image != png {
+			t.Errorf("multipart %v: expecting image %q, got %q", multipart, png, qr.Image)
+		}
+		data, err := qr.PNG()
+		if err != nil || string(data[:4]) != "\x89PNG" {
+			t.Errorf("multipart %v: unexpected PNG %q, %v", multipart, data, err)
+		}
+	}
+	if body.Width != 300 || body.Action != InvoiceQRCodeActionPay {
+		t.Errorf("unexpected request %+v", body)
+	}
+}
+
+func TestInvoiceTemplates(t *testing.T) {
+	var requests []string
+	var created InvoiceTemplate
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost, http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&created)
+			created.ID = "TEMP-1"
+			json.NewEncoder(w).Encode(created)
+		case http.MethodGet:
+			w.Write([]byte(`{"templates":[{"id":"TEMP-1","name":"Consulting","default_template":true}]}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	template, err := c.CreateInvoiceTemplate(ctx, InvoiceTemplate{
+		Name: "Consulting",
+		TemplateInfo: &InvoiceTemplateInfo{
+			Detail:   &InvoiceDetail{CurrencyCode: "USD", TermsAndConditions: "Net 30"},
+			Invoicer: &InvoicerInfo{LogoURL: "https://example.com/logo.png"},
+			Amount:   &Money{Currency: "USD", Value: "100.00"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.ID != "TEMP-1" || template.TemplateInfo.Detail.TermsAndConditions != "Net 30" || template.TemplateInfo.Invoicer.LogoURL == "" {
+		t.Errorf("unexpected template %+v", template)
+	}
+
+	template.Name = "Consulting (EU)"
+	if _, err := c.UpdateInvoiceTemplate(ctx, *template); err != nil {
+		t.Fatal(err)
+	}
+	if created.Name != "Consulting (EU)" {
+		t.Errorf("expecting the template to be replaced, got %+v", created)
+	}
+
+	templates, err := c.ListInvoiceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates.Templates) != 1 || !templates.Templates[0].DefaultTemplate {
+		t.Errorf("unexpected templates %+v", templates)
+	}
+
+	if err := c.DeleteInvoiceTemplate(ctx, "TEMP-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"POST /v2/invoicing/templates",
+		"PUT /v2/invoicing/templates/TEMP-1",
+		"GET /v2/invoicing/templates",
+		"DELETE /v2/invoicing/templates/TEMP-1",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expecting requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("request %d: expecting %s, got %s", i, expected[i], requests[i])
+		}
+	}
+}
+
+func TestGenerateNextInvoiceNumber(t *testing.T) {
+	var request string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		request = r.Method + " " + r.URL.Path
+		w.Write([]byte(`{"invoice_number":"ivdev-0042"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	number, err := c.GenerateNextInvoiceNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request != "POST /v2/invoicing/generate-next-invoice-number" {
+		t.Errorf("unexpected request %s", request)
+	}
+	if number.InvoiceNumber != "ivdev-0042" {
+		t.Errorf("unexpected invoice number %+v", number)
+	}
+}
+
+func TestSearchInvoices(t *testing.T) {
+	var query string
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		query = r.Method + " " + r.URL.RequestURI()
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"items":[{"id":"INV2-1","status":"UNPAID"}],"total_items":1,"total_pages":1}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	archived := false
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	invoices, err := c.SearchInvoices(context.Background(), InvoiceSearch{
+		RecipientEmail: "buyer@example.com",
+		Status:         []string{InvoiceStatusSent, InvoiceStatusUnpaid},
+		TotalAmountRange: &InvoiceAmountRange{
+			LowerAmount: &Money{Currency: "USD", Value: "10"},
+			UpperAmount: &Money{Currency: "USD", Value: "100"},
+		},
+		DueDateRange:      &InvoiceDateRange{Start: JSONDate(start), End: JSONDate(end)},
+		CreationDateRange: &InvoiceTimeRange{Start: &start, End: &end},
+		Archived:          &archived,
+	}, &ListParams{Page: "1", PageSize: "50", TotalRequired: "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "POST /v2/invoicing/search-invoices?page=1&page_size=50&total_required=true" {
+		t.Errorf("unexpected request %s", query)
+	}
+	if body["recipient_email"] != "buyer@example.com" || body["archived"] != false || len(body["status"].([]interface{})) != 2 {
+		t.Errorf("unexpected search %v", body)
+	}
+	if r := body["creation_date_range"].(map[string]interface{}); r["start"] != "2024-01-01T00:00:00Z" {
+		t.Errorf("unexpected date range %v", r)
+	}
+	if r := body["due_date_range"].(map[string]interface{}); r["start"] != "2024-01-01" || r["end"] != "2024-02-01" {
+		t.Errorf("unexpected due date range %v", r)
+	}
+	if _, ok := body["invoice_date_range"]; ok {
+		t.Errorf("expecting unset filters to be omitted, got %v", body)
+	}
+	if len(invoices.Items) != 1 || invoices.TotalPages != 1 {
+		t.Errorf("unexpected invoices %+v", invoices)
+	}
+}
+
+func TestInvoiceAttachments(t *testing.T) {
+	var invoice Invoice
+	err := json.Unmarshal([]byte(`{"id":"INV2-1","detail":{"currency_code":"USD","attachments":[{"id":"FILE-1",
+		"reference_url":"https://www.paypal.com/invoice/payerView/attachments/FILE-1","content_type":"application/pdf","size":"24"}]}}`), &invoice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attachments := invoice.Detail.Attachments
+	if len(attachments) != 1 || attachments[0].ID != "FILE-1" || attachments[0].ContentType != "application/pdf" {
+		t.Fatalf("unexpected attachments %+v", attachments)
+	}
+
+	detail, _ := json.Marshal(InvoiceDetail{CurrencyCode: "USD", Attachments: attachments})
+	if !bytes.Contains(detail, []byte(`"attachments":[{"id":"FILE-1",`)) {
+		t.Errorf("expecting the attachment in the invoice detail, got %s", detail)
+	}
+}
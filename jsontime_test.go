@@ -0,0 +1,62 @@
+package paypal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSONTimeFormats(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected time.Time
+	}{
+		{`"2023-03-12T12:00:00Z"`, time.Date(2023, 3, 12, 12, 0, 0, 0, time.UTC)},
+		{`"2023-03-12T12:00:00+0000"`, time.Date(2023, 3, 12, 12, 0, 0, 0, time.UTC)},
+		{`"2023-03-12T14:00:00+02:00"`, time.Date(2023, 3, 12, 12, 0, 0, 0, time.UTC)},
+		{`"2023-03-12T12:00:00.123Z"`, time.Date(2023, 3, 12, 12, 0, 0, 123000000, time.UTC)},
+		{`"2023-03-12T12:00:00.5-0100"`, time.Date(2023, 3, 12, 13, 0, 0, 500000000, time.UTC)},
+		{`null`, time.Time{}},
+		{`""`, time.Time{}},
+	}
+	for _, tt := range tests {
+		var got JSONTime
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if !time.Time(got).Equal(tt.expected) {
+			t.Errorf("%s: expecting %s, got %s", tt.in, tt.expected, got)
+		}
+	}
+
+	var invalid JSONTime
+	if err := json.Unmarshal([]byte(`"12/03/2023"`), &invalid); err == nil {
+		t.Error("expecting an error for an unsupported format")
+	}
+
+	b, _ := json.Marshal(JSONTime(time.Date(2023, 3, 12, 14, 0, 0, 0, time.FixedZone("", 7200))))
+	if string(b) != `"2023-03-12T12:00:00Z"` {
+		t.Errorf("unexpected JSONTime encoding %s", b)
+	}
+}
+
+func TestJSONDateFormats(t *testing.T) {
+	var v struct {
+		InvoiceDate JSONDate `json:"invoice_date"`
+		Expiry      JSONDate `json:"expiry"`
+		CreateTime  JSONDate `json:"create_time"`
+	}
+	data := `{"invoice_date":"2023-03-12","expiry":"2030-12","create_time":"2023-03-12T23:30:00Z"}`
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.InvoiceDate.String() != "2023-03-12" || v.Expiry.String() != "2030-12-01" || v.CreateTime.String() != "2023-03-12" {
+		t.Errorf("unexpected dates %s %s %s", v.InvoiceDate, v.Expiry, v.CreateTime)
+	}
+
+	b, _ := json.Marshal(v.InvoiceDate)
+	if string(b) != `"2023-03-12"` {
+		t.Errorf("unexpected JSONDate encoding %s", b)
+	}
+}
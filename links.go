@@ -0,0 +1,93 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FindLink returns the first link with the given rel, or nil if there is none
+func FindLink(links []Link, rel string) *Link {
+	return Links(links).Find(rel)
+}
+
+// Find returns the first link with the given rel, or nil if there is none,
+// e.g. order.Links.Find("approve")
+func (l Links) Find(rel string) *Link {
+	for i := range l {
+		if l[i].Rel == rel {
+			return &l[i]
+		}
+	}
+	return nil
+}
+
+// Has reports whether there is a link with the given rel, e.g. whether an
+// action such as "capture" is possible on the resource
+func (l Links) Has(rel string) bool {
+	return l.Find(rel) != nil
+}
+
+// Href returns the href of the first link with the given rel, or an empty string
+func (l Links) Href(rel string) string {
+	if link := l.Find(rel); link != nil {
+		return link.Href
+	}
+	return ""
+}
+
+// FollowRel follows the first link with the given rel, see FollowLink, e.g.
+//
+//	err := c.FollowRel(ctx, auth.Links, "capture", &paypal.PaymentCaptureRequest{FinalCapture: true}, capture)
+func (c *Client) FollowRel(ctx context.Context, links Links, rel string, body interface{}, v interface{}) error {
+	link := links.Find(rel)
+	if link == nil {
+		return fmt.Errorf("paypal: no %q link to follow", rel)
+	}
+	return c.FollowLink(ctx, *link, body, v)
+}
+
+// FollowLink issues the request described by a HATEOAS link returned by PayPal,
+// using its method (GET when empty) and href. body is sent as JSON when not nil
+// and the response is decoded into v.
+// Only links on the APIBase host are followed; links for the buyer, such as the
+// "approve" link of an order, are rejected.
+func (c *Client) FollowLink(ctx context.Context, link Link, body interface{}, v interface{}) error {
+	href, err := c.resolveLink(link.Href)
+	if err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(link.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := c.NewRequest(ctx, method, href, body)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, v)
+}
+
+// resolveLink resolves href against APIBase and checks it stays on the APIBase host
+func (c *Client) resolveLink(href string) (string, error) {
+	base, err := url.Parse(c.APIBase)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := base.Parse(href)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.EqualFold(u.Scheme, base.Scheme) || !strings.EqualFold(u.Host, base.Host) {
+		return "", fmt.Errorf("paypal: refusing to follow link to %s, it is not on %s", u.Host, base.Host)
+	}
+
+	return u.String(), nil
+}
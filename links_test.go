@@ -0,0 +1,90 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestFollowLink(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.Method != http.MethodPost || r.RequestURI != "/v2/checkout/orders/O1/capture" {
+			t.Errorf("unexpected request %s %s", r.Method, r.RequestURI)
+		}
+		w.Write([]byte(`{"id":"O1","status":"COMPLETED"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	links := []Link{
+		{Href: "https://www.sandbox.paypal.com/checkoutnow?token=O1", Rel: "approve", Method: "GET"},
+		{Href: ts.URL + "/v2/checkout/orders/O1/capture", Rel: "capture", Method: "POST"},
+	}
+
+	capture := FindLink(links, "capture")
+	if capture == nil {
+		t.Fatal("expecting the capture link to be found")
+	}
+	if FindLink(links, "self") != nil {
+		t.Error("expecting no self link")
+	}
+
+	order := &CaptureOrderResponse{}
+	if err := c.FollowLink(context.Background(), *capture, nil, order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != "COMPLETED" {
+		t.Errorf("expecting COMPLETED, got %s", order.Status)
+	}
+
+	if err := c.FollowLink(context.Background(), *FindLink(links, "approve"), nil, nil); err == nil {
+		t.Error("expecting links outside APIBase to be rejected")
+	}
+}
+
+func TestFollowRel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/payments/authorizations/A1/capture" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"C1","status":"COMPLETED"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+
+	auth := Authorization{}
+	json.Unmarshal([]byte(`{"id":"A1","links":[
+		{"href":"`+ts.URL+`/v2/payments/authorizations/A1","rel":"self","method":"GET"},
+		{"href":"`+ts.URL+`/v2/payments/authorizations/A1/capture","rel":"capture","method":"POST"}]}`), &auth)
+
+	if !auth.Links.Has("capture") || auth.Links.Has("void") || auth.Links.Href("self") != ts.URL+"/v2/payments/authorizations/A1" {
+		t.Errorf("unexpected links %+v", auth.Links)
+	}
+
+	capture := &PaymentCaptureResponse{}
+	if err := c.FollowRel(context.Background(), auth.Links, "capture", &PaymentCaptureRequest{FinalCapture: true}, capture); err != nil {
+		t.Fatal(err)
+	}
+	if capture.ID != "C1" {
+		t.Errorf("unexpected capture %+v", capture)
+	}
+
+	if err := c.FollowRel(context.Background(), auth.Links, "void", nil, nil); err == nil {
+		t.Error("expecting an error without a void link")
+	}
+}
@@ -0,0 +1,104 @@
+package paypal
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LocaleFromAcceptLanguage returns the preferred locale of an Accept-Language
+// header in the format PayPal accepts, e.g. "de-AT" for "de-at,de;q=0.8".
+// Languages without a region get countryCode as their region, usually the
+// country derived from the buyer IP address, so PayPal offers the funding
+// sources of that market. It returns "" when no language of the header is valid.
+func LocaleFromAcceptLanguage(header string, countryCode string) string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		lang := language{tag: strings.TrimSpace(fields[0]), quality: 1}
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(param[2:], 64)
+				if err != nil {
+					q = 0
+				}
+				lang.quality = q
+			}
+		}
+		if lang.tag != "" && lang.tag != "*" && lang.quality > 0 {
+			languages = append(languages, lang)
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	for _, lang := range languages {
+		locale := normalizeLocale(lang.tag)
+		if !hasRegion(locale) && len(countryCode) == 2 {
+			locale += "-" + strings.ToUpper(countryCode)
+		}
+		if localeRegexp.MatchString(locale) {
+			return locale
+		}
+	}
+
+	return ""
+}
+
+// CountryFromLocale returns the two letter country code of a locale, e.g. "US" for "en-US"
+func CountryFromLocale(locale string) string {
+	parts := strings.Split(normalizeLocale(locale), "-")
+	if len(parts) < 2 {
+		return ""
+	}
+	if region := parts[len(parts)-1]; len(region) == 2 {
+		return region
+	}
+	return ""
+}
+
+// PayerWithCountryCode returns a CreateOrderPayer with the country of the buyer,
+// which PayPal uses with the locale to decide which funding sources are eligible
+func PayerWithCountryCode(countryCode string) *CreateOrderPayer {
+	return &CreateOrderPayer{
+		Address: &ShippingDetailAddressPortable{CountryCode: strings.ToUpper(countryCode)},
+	}
+}
+
+// WithBuyerLocale sets the locale from the Accept-Language header of the buyer
+// and the country derived from their IP address, see LocaleFromAcceptLanguage
+func WithBuyerLocale(acceptLanguage string, countryCode string) AppCtxOption {
+	return func(a *ApplicationContext) {
+		a.Locale = LocaleFromAcceptLanguage(acceptLanguage, countryCode)
+	}
+}
+
+// hasRegion reports whether a normalized locale ends with a region, e.g. "US" or "419"
+func hasRegion(locale string) bool {
+	parts := strings.Split(locale, "-")
+	return len(parts) > 1 && len(parts[len(parts)-1]) != 4
+}
+
+// normalizeLocale converts a language tag to the casing PayPal expects, e.g. "zh_hant_hk" to "zh-Hant-HK"
+func normalizeLocale(tag string) string {
+	parts := strings.Split(strings.Replace(tag, "_", "-", -1), "-")
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		default:
+			parts[i] = strings.ToUpper(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,48 @@
+package paypal
+
+import "testing"
+
+func TestLocaleFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header      string
+		countryCode string
+		locale      string
+	}{
+		{"de-at,de;q=0.8,en;q=0.5", "", "de-AT"},
+		{"fr;q=0.5,nl-BE", "", "nl-BE"},
+		{"de", "at", "de-AT"},
+		{"es", "", "es"},
+		{"zh_hant_hk", "", "zh-Hant-HK"},
+		{"es-419", "US", "es-419"},
+		{"english, *;q=0.1, it", "IT", "it-IT"},
+		{"", "US", ""},
+	}
+
+	for _, tt := range tests {
+		if locale := LocaleFromAcceptLanguage(tt.header, tt.countryCode); locale != tt.locale {
+			t.Errorf("%q %q: expecting locale %q got %q", tt.header, tt.countryCode, tt.locale, locale)
+		}
+	}
+}
+
+func TestCountryFromLocale(t *testing.T) {
+	for locale, country := range map[string]string{"en-US": "US", "zh-Hant-HK": "HK", "es-419": "", "da": ""} {
+		if c := CountryFromLocale(locale); c != country {
+			t.Errorf("%s: expecting country %q got %q", locale, country, c)
+		}
+	}
+}
+
+func TestWithBuyerLocale(t *testing.T) {
+	appCtx, err := NewApplicationContext("Shop", "", "", WithBuyerLocale("pl", "PL"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if appCtx.Locale != "pl-PL" {
+		t.Errorf("expecting locale pl-PL got %q", appCtx.Locale)
+	}
+
+	if payer := PayerWithCountryCode("pl"); payer.Address.CountryCode != "PL" {
+		t.Errorf("expecting payer country PL got %q", payer.Address.CountryCode)
+	}
+}
@@ -0,0 +1,117 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// logBodyLimit is the number of bytes of request and response bodies in a LogEntry
+const logBodyLimit = 4096
+
+// logBodyReadLimit is the number of bytes of a body read for a LogEntry, longer
+// bodies such as downloads are not buffered and can't be redacted
+const logBodyReadLimit = 64 << 10
+
+type (
+	// LogEntry describes a single request sent to PayPal, including every retry
+	LogEntry struct {
+		Method string
+		URL    string
+		// StatusCode is 0 when no response was received
+		StatusCode int
+		Duration   time.Duration
+		// DebugID is the Paypal-Debug-Id of the response, include it when contacting PayPal support
+		DebugID string
+		// RequestBody and ResponseBody are redacted like the SetLog output
+		// and truncated to 4096 bytes. Bodies longer than 64 KiB are only
+		// logged when redaction is disabled.
+		RequestBody  string
+		ResponseBody string
+		// Err is the error sending the request, API errors only have a non-2xx StatusCode
+		Err error
+	}
+
+	// Logger receives structured entries of the requests sent to PayPal
+	Logger interface {
+		Log(ctx context.Context, entry LogEntry)
+	}
+
+	// LoggerFunc is a Logger implemented by a function, e.g. an adapter to a
+	// structured logging library:
+	//
+	//	c.SetLogger(paypal.LoggerFunc(func(ctx context.Context, e paypal.LogEntry) {
+	//		logger.Info("paypal request", "method", e.Method, "url", e.URL,
+	//			"status", e.StatusCode, "latency", e.Duration, "debug_id", e.DebugID)
+	//	}))
+	LoggerFunc func(ctx context.Context, entry LogEntry)
+)
+
+// Log calls f(ctx, entry)
+func (f LoggerFunc) Log(ctx context.Context, entry LogEntry) {
+	f(ctx, entry)
+}
+
+// SetLogger sets a Logger receiving an entry for every request. It can be used
+// with or instead of SetLog. Pass nil to remove it.
+func (c *Client) SetLogger(logger Logger) {
+	c.logger = logger
+}
+
+// logEntry sends the entry of a request to the logger
+func (c *Client) logEntry(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	redact := !c.logUnredacted || c.APIBase == APIBaseLive
+	entry := LogEntry{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Duration: duration,
+		Err:      err,
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := ioutil.ReadAll(io.LimitReader(body, logBodyReadLimit+1))
+			body.Close()
+			entry.RequestBody = logBody(req.Header.Get("Content-Type"), data, redact)
+		}
+	}
+
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+		entry.DebugID = resp.Header.Get("Paypal-Debug-Id")
+		if resp.Body != nil {
+			data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, logBodyReadLimit+1))
+			// The read bytes are put back in front of the rest of the body, so
+			// that streamed responses are not buffered
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+			entry.ResponseBody = logBody(resp.Header.Get("Content-Type"), data, redact)
+		}
+	}
+
+	c.logger.Log(req.Context(), entry)
+}
+
+// logBody returns the body of a LogEntry from up to logBodyReadLimit+1 bytes of
+// a body, a partial body can't be parsed to be redacted so it is left out
+func logBody(contentType string, data []byte, redact bool) string {
+	if redact {
+		if len(data) > logBodyReadLimit {
+			return ""
+		}
+		data = redactBody(contentType, data)
+	}
+	if len(data) > logBodyLimit {
+		data = data[:logBodyLimit]
+	}
+	return string(data)
+}
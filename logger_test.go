@@ -0,0 +1,103 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestSetLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Header().Set("Paypal-Debug-Id", "f05063556a338")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"name":"RESOURCE_NOT_FOUND","details":[{"issue":"INVALID_RESOURCE_ID","description":"` + strings.Repeat("x", 5000) + `"}]}`))
+	}))
+	defer ts.Close()
+
+	var entries []LogEntry
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetLogger(LoggerFunc(func(ctx context.Context, entry LogEntry) {
+		entries = append(entries, entry)
+	}))
+
+	_, err := c.ConfirmOrderPaymentSource(context.Background(), "O1", &PaymentSource{Card: &PaymentSourceCard{Number: "4111111111111111"}})
+	if !HasIssue(err, "INVALID_RESOURCE_ID") {
+		t.Errorf("expecting the response to be decoded after logging, got %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expecting 1 log entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Method != http.MethodPost || e.URL != ts.URL+"/v2/checkout/orders/O1/confirm-payment-source" ||
+		e.StatusCode != http.StatusNotFound || e.DebugID != "f05063556a338" || e.Duration <= 0 {
+		t.Errorf("unexpected log entry %+v", e)
+	}
+	if strings.Contains(e.RequestBody, "4111111111111111") || !strings.Contains(e.RequestBody, "REDACTED") {
+		t.Errorf("expecting a redacted request body, got %s", e.RequestBody)
+	}
+	if len(e.ResponseBody) != logBodyLimit {
+		t.Errorf("expecting a truncated response body, got %d bytes", len(e.ResponseBody))
+	}
+}
+
+// countingReader counts the bytes read from it
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += n
+	return n, err
+}
+
+func TestSetLoggerLargeBody(t *testing.T) {
+	var entries []LogEntry
+	c, _ := NewClient("foo", "bar", APIBaseSandBox)
+	c.SetLogger(LoggerFunc(func(ctx context.Context, entry LogEntry) {
+		entries = append(entries, entry)
+	}))
+
+	data := bytes.Repeat([]byte("x"), 1<<20)
+	for _, unredacted := range []bool{false, true} {
+		c.SetLogUnredacted(unredacted)
+		body := &countingReader{Reader: bytes.NewReader(data)}
+		req, _ := http.NewRequest(http.MethodGet, APIBaseSandBox+"/v1/reporting/download", nil)
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(body)}
+
+		c.logEntry(req, resp, nil, time.Millisecond)
+		if body.n > logBodyReadLimit+1 {
+			t.Errorf("expecting at most %d bytes to be read for the log, got %d", logBodyReadLimit+1, body.n)
+		}
+		got, _ := ioutil.ReadAll(resp.Body)
+		if !bytes.Equal(got, data) {
+			t.Errorf("expecting the whole body to remain readable, got %d bytes", len(got))
+		}
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expecting 2 log entries, got %d", len(entries))
+	}
+	if entries[0].ResponseBody != "" {
+		t.Errorf("expecting a body too long to redact to be left out, got %d bytes", len(entries[0].ResponseBody))
+	}
+	if len(entries[1].ResponseBody) != logBodyLimit {
+		t.Errorf("expecting a truncated unredacted body, got %d bytes", len(entries[1].ResponseBody))
+	}
+}
@@ -0,0 +1,128 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type (
+	// MerchantIntegration is the onboarding status of a seller
+	// Doc: https://developer.paypal.com/docs/api/partner-referrals/v1/#merchant-integration_status
+	MerchantIntegration struct {
+		MerchantID            string                       `json:"merchant_id"`
+		TrackingID            string                       `json:"tracking_id,omitempty"`
+		LegalName             string                       `json:"legal_name,omitempty"`
+		PrimaryEmail          string                       `json:"primary_email,omitempty"`
+		PrimaryEmailConfirmed bool                         `json:"primary_email_confirmed"`
+		PaymentsReceivable    bool                         `json:"payments_receivable"`
+		Products              []MerchantIntegrationProduct `json:"products,omitempty"`
+		Capabilities          []MerchantCapability         `json:"capabilities,omitempty"`
+		OAuthIntegrations     []MerchantOAuthIntegration   `json:"oauth_integrations,omitempty"`
+		Links                 Links                        `json:"links,omitempty"`
+	}
+
+	// MerchantIntegrationProduct is a product the seller signed up for
+	MerchantIntegrationProduct struct {
+		Name          string   `json:"name"`
+		VettingStatus string   `json:"vetting_status,omitempty"`
+		Capabilities  []string `json:"capabilities,omitempty"`
+	}
+
+	// MerchantCapability is a capability of the seller account, e.g. CUSTOM_CARD_PROCESSING
+	MerchantCapability struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	}
+
+	// MerchantOAuthIntegration lists the permissions the seller granted to partners
+	MerchantOAuthIntegration struct {
+		IntegrationType   string                    `json:"integration_type"`
+		IntegrationMethod string                    `json:"integration_method,omitempty"`
+		OAuthThirdParty   []MerchantOAuthThirdParty `json:"oauth_third_party,omitempty"`
+	}
+
+	// MerchantOAuthThirdParty holds the scopes granted to a partner
+	MerchantOAuthThirdParty struct {
+		PartnerClientID  string   `json:"partner_client_id"`
+		MerchantClientID string   `json:"merchant_client_id,omitempty"`
+		Scopes           []string `json:"scopes"`
+	}
+
+	// MerchantIntegrationReference is the merchant ID of a seller found by tracking ID
+	MerchantIntegrationReference struct {
+		MerchantID string `json:"merchant_id"`
+		TrackingID string `json:"tracking_id"`
+		Links      Links  `json:"links,omitempty"`
+	}
+)
+
+// Possible values for `status` in MerchantCapability and `vetting_status` in MerchantIntegrationProduct
+const (
+	MerchantCapabilityStatusActive   string = "ACTIVE"
+	MerchantCapabilityStatusInactive string = "INACTIVE"
+
+	VettingStatusSubscribed   string = "SUBSCRIBED"
+	VettingStatusInReview     string = "IN_REVIEW"
+	VettingStatusNeedMoreData string = "NEED_MORE_DATA"
+	VettingStatusDenied       string = "DENIED"
+)
+
+// GetMerchantIntegration - Shows the onboarding status of a seller, by merchant ID.
+// Doc: https://developer.paypal.com/docs/api/partner-referrals/v1/#merchant-integration_status
+// Endpoint: GET /v1/customer/partners/ID/merchant-integrations/ID
+func (c *Client) GetMerchantIntegration(ctx context.Context, partnerID, merchantID string) (*MerchantIntegration, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/customer/partners/%s/merchant-integrations/%s", c.APIBase, partnerID, merchantID), nil)
+	response := &MerchantIntegration{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// FindMerchantIntegration - Finds the merchant ID of a seller by the tracking ID
+// of its partner referral, e.g. when the seller didn't return to the partner site.
+// Doc: https://developer.paypal.com/docs/api/partner-referrals/v1/#merchant-integration_find
+// Endpoint: GET /v1/customer/partners/ID/merchant-integrations?tracking_id=ID
+func (c *Client) FindMerchantIntegration(ctx context.Context, partnerID, trackingID string) (*MerchantIntegrationReference, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/customer/partners/%s/merchant-integrations?tracking_id=%s", c.APIBase, partnerID, url.QueryEscape(trackingID)), nil)
+	response := &MerchantIntegrationReference{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// ReadyToTransact reports whether the seller can receive payments: payments are
+// receivable and the primary email address is confirmed
+func (m *MerchantIntegration) ReadyToTransact() bool {
+	return m.PaymentsReceivable && m.PrimaryEmailConfirmed
+}
+
+// GrantedScopes returns the scopes the seller granted to the partner with the given client ID
+func (m *MerchantIntegration) GrantedScopes(partnerClientID string) []string {
+	var scopes []string
+	for _, integration := range m.OAuthIntegrations {
+		for _, thirdParty := range integration.OAuthThirdParty {
+			if thirdParty.PartnerClientID == partnerClientID {
+				scopes = append(scopes, thirdParty.Scopes...)
+			}
+		}
+	}
+	return scopes
+}
+
+// CapabilityActive reports whether the capability of the seller account is active
+func (m *MerchantIntegration) CapabilityActive(name string) bool {
+	for _, capability := range m.Capabilities {
+		if capability.Name == name {
+			return capability.Status == MerchantCapabilityStatusActive
+		}
+	}
+	return false
+}
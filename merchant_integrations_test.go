@@ -0,0 +1,71 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestMerchantIntegrations(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if r.URL.Query().Get("tracking_id") != "" {
+			w.Write([]byte(`{"merchant_id":"MERCHANT-1","tracking_id":"seller 1"}`))
+			return
+		}
+		w.Write([]byte(`{
+			"merchant_id": "MERCHANT-1",
+			"tracking_id": "seller 1",
+			"payments_receivable": true,
+			"primary_email_confirmed": true,
+			"products": [{"name": "PPCP_CUSTOM", "vetting_status": "SUBSCRIBED", "capabilities": ["CUSTOM_CARD_PROCESSING"]}],
+			"capabilities": [{"name": "CUSTOM_CARD_PROCESSING", "status": "ACTIVE"}, {"name": "PAYPAL_WALLET_VAULTING_ADVANCED", "status": "INACTIVE"}],
+			"oauth_integrations": [{"integration_type": "OAUTH_THIRD_PARTY", "integration_method": "PAYPAL", "oauth_third_party": [
+				{"partner_client_id": "PARTNER-CLIENT", "merchant_client_id": "MERCHANT-CLIENT", "scopes": ["https://uri.paypal.com/services/payments/realtimepayment"]}
+			]}]
+		}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	ref, err := c.FindMerchantIntegration(ctx, "PARTNER", "seller 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merchant, err := c.GetMerchantIntegration(ctx, "PARTNER", ref.MerchantID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !merchant.ReadyToTransact() || merchant.Products[0].VettingStatus != VettingStatusSubscribed {
+		t.Errorf("unexpected merchant integration %+v", merchant)
+	}
+	if !merchant.CapabilityActive("CUSTOM_CARD_PROCESSING") || merchant.CapabilityActive("PAYPAL_WALLET_VAULTING_ADVANCED") || merchant.CapabilityActive("APPLE_PAY") {
+		t.Errorf("unexpected capabilities %+v", merchant.Capabilities)
+	}
+	if scopes := merchant.GrantedScopes("PARTNER-CLIENT"); len(scopes) != 1 || len(merchant.GrantedScopes("OTHER")) != 0 {
+		t.Errorf("unexpected scopes %v", scopes)
+	}
+
+	expected := []string{
+		"GET /v1/customer/partners/PARTNER/merchant-integrations?tracking_id=seller+1",
+		"GET /v1/customer/partners/PARTNER/merchant-integrations/MERCHANT-1",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests\n%s", strings.Join(requests, "\n"))
+	}
+}
@@ -0,0 +1,50 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type (
+	// ResponseMetadata describes the HTTP response of an API call, e.g. to log
+	// the debug ID PayPal support asks for
+	ResponseMetadata struct {
+		StatusCode int
+		// DebugID is the Paypal-Debug-Id header
+		DebugID string
+		// Date is the Date header, zero when missing
+		Date   time.Time
+		Header http.Header
+	}
+
+	responseMetadataKey struct{}
+)
+
+// WithResponseMetadata returns a context that stores the response metadata of
+// the API call made with it into m. m is left unchanged when no response was received.
+//
+//	var meta paypal.ResponseMetadata
+//	order, err := c.CaptureOrder(paypal.WithResponseMetadata(ctx, &meta), orderID, req)
+//	log.Printf("captured %s, debug id %s", order.ID, meta.DebugID)
+func WithResponseMetadata(ctx context.Context, m *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, responseMetadataKey{}, m)
+}
+
+// storeResponseMetadata stores the metadata of resp into the request context
+func storeResponseMetadata(ctx context.Context, resp *http.Response) {
+	dst, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	if !ok || dst == nil || resp == nil {
+		return
+	}
+
+	m := ResponseMetadata{
+		StatusCode: resp.StatusCode,
+		DebugID:    resp.Header.Get("Paypal-Debug-Id"),
+		Header:     resp.Header.Clone(),
+	}
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		m.Date = date
+	}
+	*dst = m
+}
@@ -0,0 +1,55 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestWithResponseMetadata(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Header().Set("Paypal-Debug-Id", "f1a2b3c4d5e6")
+		w.Header().Set("Date", "Wed, 14 Oct 2026 10:00:00 GMT")
+		if r.URL.Path == "/v2/checkout/orders/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"name":"RESOURCE_NOT_FOUND"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	var meta ResponseMetadata
+	if _, err := c.GetOrder(WithResponseMetadata(context.Background(), &meta), "O1"); err != nil {
+		t.Fatal(err)
+	}
+	if meta.StatusCode != http.StatusOK || meta.DebugID != "f1a2b3c4d5e6" {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+	if !meta.Date.Equal(time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %v", meta.Date)
+	}
+	if meta.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expecting the raw headers, got %v", meta.Header)
+	}
+
+	meta = ResponseMetadata{}
+	if _, err := c.GetOrder(WithResponseMetadata(context.Background(), &meta), "missing"); err == nil {
+		t.Fatal("expecting an error")
+	}
+	if meta.StatusCode != http.StatusNotFound || meta.DebugID != "f1a2b3c4d5e6" {
+		t.Errorf("expecting the metadata of failed calls, got %+v", meta)
+	}
+}
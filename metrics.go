@@ -0,0 +1,92 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type (
+	// RequestMetrics describes a single API call, reported to the hook set with SetMetricsHook
+	RequestMetrics struct {
+		// Endpoint is the path with resource IDs replaced by {id}, e.g. /v2/checkout/orders/{id}/capture
+		Endpoint string
+		Method   string
+		// StatusCode is 0 when no response was received
+		StatusCode int
+		Duration   time.Duration
+		// Retries is the number of attempts made after the first one
+		Retries     int
+		RateLimited bool
+		// CircuitState is the state of the circuit breaker after the call, one of
+		// the CircuitState constants, or empty without SetCircuitBreaker
+		CircuitState string
+		Err          error
+	}
+
+	// MetricsHook receives the metrics of every API call
+	MetricsHook func(m RequestMetrics)
+
+	requestMetricsKey struct{}
+)
+
+// versionSegment matches the API version segments of a path, e.g. v1
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// SetMetricsHook sets a hook called once per API call, after it completed.
+// Pass nil to remove it.
+func (c *Client) SetMetricsHook(hook MetricsHook) {
+	c.metricsHook = hook
+}
+
+// WithRequestMetrics returns a context that stores the metrics of the API call
+// made with it into m, e.g. to check whether a single call needed retries.
+// Unlike SetMetricsHook nothing has to be set on the client.
+func WithRequestMetrics(ctx context.Context, m *RequestMetrics) context.Context {
+	return context.WithValue(ctx, requestMetricsKey{}, m)
+}
+
+// reportMetrics sends the metrics of a call to the hook and the request context
+func (c *Client) reportMetrics(ctx context.Context, m RequestMetrics) {
+	if c.metricsHook != nil {
+		c.metricsHook(m)
+	}
+	if dst, ok := ctx.Value(requestMetricsKey{}).(*RequestMetrics); ok && dst != nil {
+		*dst = m
+	}
+}
+
+func newRequestMetrics(req *http.Request, resp *http.Response, err error, duration time.Duration, retries int) RequestMetrics {
+	m := RequestMetrics{
+		Endpoint: endpointTemplate(req.URL.Path),
+		Method:   req.Method,
+		Duration: duration,
+		Retries:  retries,
+		Err:      err,
+	}
+	if resp != nil {
+		m.StatusCode = resp.StatusCode
+		m.RateLimited = resp.StatusCode == http.StatusTooManyRequests
+	}
+	return m
+}
+
+// endpointTemplate replaces the resource IDs of a path with {id} to keep the
+// cardinality of metrics low. PayPal paths are lowercase, so segments with an
+// uppercase letter or a digit are considered IDs.
+func endpointTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "" || versionSegment.MatchString(segment) || segment == "oauth2" {
+			continue
+		}
+		if strings.IndexFunc(segment, func(r rune) bool {
+			return (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		}) >= 0 {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,56 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/v2/checkout/orders/5O190127TN364715T/capture":    "/v2/checkout/orders/{id}/capture",
+		"/v1/billing/subscriptions/I-BW452GLLEP1G":         "/v1/billing/subscriptions/{id}",
+		"/v1/notifications/webhooks/8PT597110X687430LKGEC": "/v1/notifications/webhooks/{id}",
+		"/v1/oauth2/token":           "/v1/oauth2/token",
+		"/v1/reporting/transactions": "/v1/reporting/transactions",
+	}
+	for path, expected := range tests {
+		if got := endpointTemplate(path); got != expected {
+			t.Errorf("%s: expecting %s, got %s", path, expected, got)
+		}
+	}
+}
+
+func TestMetricsHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"name":"RATE_LIMIT_REACHED"}`))
+	}))
+	defer ts.Close()
+
+	var metrics []RequestMetrics
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetMetricsHook(func(m RequestMetrics) {
+		metrics = append(metrics, m)
+	})
+
+	c.GetOrder(context.Background(), "5O190127TN364715T")
+
+	if len(metrics) != 1 {
+		t.Fatalf("expecting 1 metric, got %d", len(metrics))
+	}
+	m := metrics[0]
+	if m.Endpoint != "/v2/checkout/orders/{id}" || m.Method != http.MethodGet || m.StatusCode != http.StatusTooManyRequests || !m.RateLimited || m.Err == nil {
+		t.Errorf("unexpected metrics %+v", m)
+	}
+}
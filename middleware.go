@@ -0,0 +1,55 @@
+package paypal
+
+import "net/http"
+
+type (
+	// Middleware wraps the http.RoundTripper sending API requests, e.g. to add
+	// headers, audit requests or record metrics
+	Middleware func(next http.RoundTripper) http.RoundTripper
+
+	// RoundTripperFunc is an http.RoundTripper implemented by a function
+	RoundTripperFunc func(req *http.Request) (*http.Response, error)
+)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Use adds middlewares wrapping every API request. The first middleware added
+// is the outermost and sees the request first and the response last:
+//
+//	c.Use(func(next http.RoundTripper) http.RoundTripper {
+//		return paypal.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			req = req.Clone(req.Context())
+//			req.Header.Set("X-Audit-Id", auditID(req.Context()))
+//			return next.RoundTrip(req)
+//		})
+//	})
+//
+// Middlewares run after the client set its headers, e.g. PayPal-Request-Id,
+// and before the OAuth2 Authorization header is added. Token requests are not
+// passed to them. Like any http.RoundTripper they must not modify the request
+// but may replace it with a clone.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// withMiddlewares returns a copy of client sending requests through the middlewares
+func (c *Client) withMiddlewares(client *http.Client) *http.Client {
+	if len(c.middlewares) == 0 {
+		return client
+	}
+
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+
+	wrapped := *client
+	wrapped.Transport = transport
+	return &wrapped
+}
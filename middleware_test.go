@@ -0,0 +1,51 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestClientUse(t *testing.T) {
+	var audit string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		audit = r.Header.Get("X-Audit")
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.URL.Path)
+				req = req.Clone(req.Context())
+				req.Header.Set("X-Audit", req.Header.Get("X-Audit")+name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.Use(middleware("a"), middleware("b"))
+
+	if _, err := c.GetOrder(context.Background(), "O1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || calls[0] != "a /v2/checkout/orders/O1" || calls[1] != "b /v2/checkout/orders/O1" {
+		t.Errorf("unexpected middleware calls %q", calls)
+	}
+	if audit != "ab" {
+		t.Errorf("expecting the middlewares to modify the request, got %q", audit)
+	}
+}
@@ -0,0 +1,160 @@
+package paypal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// CurrencyDecimals returns the number of decimals PayPal accepts for the currency,
+// 2 for currencies missing from the supported currencies. Like IsValidCurrency
+// it expects upper case codes, e.g. "jpy" is not JPY.
+func CurrencyDecimals(currency string) int {
+	if d, ok := supportedCurrencies[currency]; ok {
+		return d
+	}
+	return 2
+}
+
+// NewMoney returns Money with the value formatted to the decimals of the currency,
+// e.g. "100.00" JPY becomes "100". Invalid currency codes and values that would
+// lose precision return an error.
+func NewMoney(currency, value string) (*Money, error) {
+	if err := validateCurrency(currency); err != nil {
+		return nil, err
+	}
+	formatted, err := formatAmount(currency, value)
+	if err != nil {
+		return nil, err
+	}
+	return &Money{Currency: currency, Value: formatted}, nil
+}
+
+// MoneyFromMinorUnits returns Money from an amount in the currency's minor units,
+// e.g. 1050 USD cents becomes "10.50"
+func MoneyFromMinorUnits(currency string, minor int64) *Money {
+	r := new(big.Rat).SetFrac(big.NewInt(minor), decimalScale(CurrencyDecimals(currency)))
+	return &Money{Currency: currency, Value: r.FloatString(CurrencyDecimals(currency))}
+}
+
+// MinorUnits returns the value in the currency's minor units, e.g. "10.50" USD
+// is 1050 cents
+func (m Money) MinorUnits() (int64, error) {
+	r, err := parseAmount(m.Value)
+	if err != nil {
+		return 0, err
+	}
+	minor := new(big.Rat).Mul(r, new(big.Rat).SetInt(decimalScale(CurrencyDecimals(m.Currency))))
+	if !minor.IsInt() || !minor.Num().IsInt64() {
+		return 0, fmt.Errorf("paypal: %s %s can't be expressed in minor units", m.Value, m.Currency)
+	}
+	return minor.Num().Int64(), nil
+}
+
+// Add returns the sum of m and other, which must have the same currency
+func (m Money) Add(other Money) (*Money, error) {
+	return m.combine(other, (*big.Rat).Add)
+}
+
+// Sub returns m minus other, which must have the same currency
+func (m Money) Sub(other Money) (*Money, error) {
+	return m.combine(other, (*big.Rat).Sub)
+}
+
+// Percent returns percent of m rounded half away from zero to the decimals of
+// the currency, e.g. Percent("19") of "9.99" EUR is "1.90" EUR.
+// percent is a decimal string so rates like "7.25" are exact.
+func (m Money) Percent(percent string) (*Money, error) {
+	r, err := parseAmount(m.Value)
+	if err != nil {
+		return nil, err
+	}
+	p, err := parseAmount(percent)
+	if err != nil {
+		return nil, fmt.Errorf("paypal: invalid percentage %q", percent)
+	}
+	r.Mul(r, p)
+	r.Quo(r, big.NewRat(100, 1))
+	// FloatString rounds halves away from zero
+	return &Money{Currency: m.Currency, Value: r.FloatString(CurrencyDecimals(m.Currency))}, nil
+}
+
+func (m Money) combine(other Money, op func(z, x, y *big.Rat) *big.Rat) (*Money, error) {
+	if !strings.EqualFold(m.Currency, other.Currency) {
+		return nil, fmt.Errorf("paypal: currency mismatch %s and %s", m.Currency, other.Currency)
+	}
+	x, err := parseAmount(m.Value)
+	if err != nil {
+		return nil, err
+	}
+	y, err := parseAmount(other.Value)
+	if err != nil {
+		return nil, err
+	}
+	formatted, ok := formatRat(m.Currency, op(new(big.Rat), x, y))
+	if !ok {
+		return nil, fmt.Errorf("paypal: %s and %s have more than %d decimals for %s", m.Value, other.Value, CurrencyDecimals(m.Currency), m.Currency)
+	}
+	return &Money{Currency: m.Currency, Value: formatted}, nil
+}
+
+// String returns the value formatted to the decimals of the currency followed by the currency code
+func (m Money) String() string {
+	value, err := formatAmount(m.Currency, m.Value)
+	if err != nil {
+		value = m.Value
+	}
+	return strings.TrimSpace(value + " " + m.Currency)
+}
+
+// MarshalJSON formats the value to the decimals of the currency. Values that
+// can't be formatted without losing precision are sent unchanged.
+func (m Money) MarshalJSON() ([]byte, error) {
+	type money Money
+	if value, err := formatAmount(m.Currency, m.Value); err == nil {
+		m.Value = value
+	}
+	return json.Marshal(money(m))
+}
+
+// formatAmount formats value with the decimals of the currency
+func formatAmount(currency, value string) (string, error) {
+	r, err := parseAmount(value)
+	if err != nil {
+		return "", err
+	}
+	formatted, ok := formatRat(currency, r)
+	if !ok {
+		return "", fmt.Errorf("paypal: %s %s has more than %d decimals", value, currency, CurrencyDecimals(currency))
+	}
+	return formatted, nil
+}
+
+// formatRat formats r with the decimals of the currency, ok is false when r
+// has more decimals
+func formatRat(currency string, r *big.Rat) (formatted string, ok bool) {
+	decimals := CurrencyDecimals(currency)
+	minor := new(big.Rat).Mul(r, new(big.Rat).SetInt(decimalScale(decimals)))
+	if !minor.IsInt() {
+		return "", false
+	}
+	return r.FloatString(decimals), true
+}
+
+// validateCurrency checks that currency is a three letter ISO 4217 code
+func validateCurrency(currency string) error {
+	if len(currency) != 3 {
+		return fmt.Errorf("paypal: invalid currency code %q", currency)
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("paypal: invalid currency code %q", currency)
+		}
+	}
+	return nil
+}
+
+func decimalScale(decimals int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
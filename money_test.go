@@ -0,0 +1,105 @@
+package paypal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMoneyCurrencyDecimals(t *testing.T) {
+	tests := []struct {
+		currency, value, expected string
+		valid                     bool
+	}{
+		{"USD", "100", "100.00", true},
+		{"USD", "10.5", "10.50", true},
+		{"JPY", "100.00", "100", true},
+		{"JPY", "100.50", "", false},
+		{"HUF", "1500.00", "1500", true},
+		{"EUR", "1.005", "", false},
+		{"EUR", "1e3", "", false},
+	}
+
+	for _, tt := range tests {
+		m, err := NewMoney(tt.currency, tt.value)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s %s: expecting valid=%v, got %v", tt.value, tt.currency, tt.valid, err)
+			continue
+		}
+		if err == nil && m.Value != tt.expected {
+			t.Errorf("%s %s: expecting %s, got %s", tt.value, tt.currency, tt.expected, m.Value)
+		}
+	}
+
+	if m := MoneyFromMinorUnits("USD", 1050); m.Value != "10.50" {
+		t.Errorf("expecting 10.50, got %s", m.Value)
+	}
+	if m := MoneyFromMinorUnits("JPY", 1050); m.Value != "1050" {
+		t.Errorf("expecting 1050, got %s", m.Value)
+	}
+	if s := (Money{Currency: "JPY", Value: "500.00"}).String(); s != "500 JPY" {
+		t.Errorf("expecting 500 JPY, got %s", s)
+	}
+
+	b, _ := json.Marshal(&Item{UnitAmount: &Money{Currency: "JPY", Value: "100.00"}})
+	var decoded Item
+	json.Unmarshal(b, &decoded)
+	if decoded.UnitAmount.Value != "100" {
+		t.Errorf("expecting JPY amounts to be sent without decimals, got %s", b)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	if _, err := NewMoney("usd", "1.00"); err == nil {
+		t.Error("expecting lower case currency codes to be rejected")
+	}
+	if _, err := NewMoney("EURO", "1.00"); err == nil {
+		t.Error("expecting four letter currency codes to be rejected")
+	}
+
+	price := Money{Currency: "EUR", Value: "9.99"}
+	shipping := Money{Currency: "EUR", Value: "4.5"}
+
+	sum, err := price.Add(shipping)
+	if err != nil || sum.Value != "14.49" {
+		t.Errorf("expecting 14.49, got %v %v", sum, err)
+	}
+	diff, err := price.Sub(shipping)
+	if err != nil || diff.Value != "5.49" {
+		t.Errorf("expecting 5.49, got %v %v", diff, err)
+	}
+	if _, err := price.Add(Money{Currency: "USD", Value: "1.00"}); err == nil {
+		t.Error("expecting a currency mismatch error")
+	}
+	if _, err := price.Add(Money{Currency: "EUR", Value: "0.001"}); err == nil {
+		t.Error("expecting an error for a result with too many decimals")
+	}
+
+	tests := []struct {
+		money    Money
+		percent  string
+		expected string
+	}{
+		{price, "19", "1.90"},
+		{Money{Currency: "USD", Value: "10.00"}, "7.25", "0.73"},
+		{Money{Currency: "USD", Value: "0.10"}, "5", "0.01"},
+		{Money{Currency: "JPY", Value: "1000"}, "8.5", "85"},
+		{Money{Currency: "JPY", Value: "1001"}, "50", "501"},
+	}
+	for _, tt := range tests {
+		got, err := tt.money.Percent(tt.percent)
+		if err != nil || got.Value != tt.expected {
+			t.Errorf("%s%% of %s: expecting %s, got %v %v", tt.percent, tt.money, tt.expected, got, err)
+		}
+	}
+	if _, err := price.Percent("abc"); err == nil {
+		t.Error("expecting an invalid percentage error")
+	}
+
+	minor, err := Money{Currency: "USD", Value: "10.5"}.MinorUnits()
+	if err != nil || minor != 1050 {
+		t.Errorf("expecting 1050, got %d %v", minor, err)
+	}
+	if _, err := (Money{Currency: "JPY", Value: "1.5"}).MinorUnits(); err == nil {
+		t.Error("expecting an error for JPY with decimals")
+	}
+}
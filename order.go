@@ -3,6 +3,7 @@ package paypal
 import (
 	"context"
 	"fmt"
+	"sort"
 )
 
 // GetOrder retrieves order by ID
@@ -22,6 +23,112 @@ func (c *Client) GetOrder(ctx context.Context, orderID string) (*Order, error) {
 	return order, nil
 }
 
+// GetOrderPayments retrieves an order and returns all authorizations, captures
+// and refunds of its purchase units as a single list, oldest first
+// Endpoint: GET /v2/checkout/orders/ID
+func (c *Client) GetOrderPayments(ctx context.Context, orderID string) (*OrderPayments, error) {
+	order, err := c.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return order.Payments(), nil
+}
+
+// Payments returns all authorizations, captures and refunds of the order, oldest first
+func (o *Order) Payments() *OrderPayments {
+	payments := &OrderPayments{OrderID: o.ID, Payments: []OrderPayment{}}
+
+	for _, unit := range o.PurchaseUnits {
+		if unit.Payments == nil {
+			continue
+		}
+		for _, a := range unit.Payments.Authorizations {
+			payments.Payments = append(payments.Payments, OrderPayment{
+				Type: OrderPaymentTypeAuthorization, ID: a.ID, Status: a.Status, ReferenceID: unit.ReferenceID,
+				Amount: a.Amount, CreateTime: a.CreateTime, UpdateTime: a.UpdateTime,
+			})
+		}
+		for _, c := range unit.Payments.Captures {
+			payments.Payments = append(payments.Payments, OrderPayment{
+				Type: OrderPaymentTypeCapture, ID: c.ID, Status: c.Status, ReferenceID: unit.ReferenceID,
+				Amount: c.Amount, CreateTime: c.CreateTime, UpdateTime: c.UpdateTime,
+			})
+		}
+		for _, r := range unit.Payments.Refunds {
+			payments.Payments = append(payments.Payments, OrderPayment{
+				Type: OrderPaymentTypeRefund, ID: r.ID, Status: r.Status, ReferenceID: unit.ReferenceID,
+				Amount: r.Amount, CreateTime: r.CreateTime, UpdateTime: r.UpdateTime,
+			})
+		}
+	}
+
+	// Payments without a create time keep their order at the end
+	sort.SliceStable(payments.Payments, func(i, j int) bool {
+		a, b := payments.Payments[i].CreateTime, payments.Payments[j].CreateTime
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+
+	return payments
+}
+
+// PurchaseUnit returns the purchase unit of the order with the given reference_id, or nil
+func (o *Order) PurchaseUnit(referenceID string) *PurchaseUnit {
+	for i := range o.PurchaseUnits {
+		if o.PurchaseUnits[i].ReferenceID == referenceID {
+			return &o.PurchaseUnits[i]
+		}
+	}
+	return nil
+}
+
+// CapturableAuthorization returns the authorization of the purchase unit that
+// can still be captured, or nil
+func (u *PurchaseUnit) CapturableAuthorization() *AuthorizationAmount {
+	if u.Payments == nil {
+		return nil
+	}
+	for i := range u.Payments.Authorizations {
+		switch u.Payments.Authorizations[i].Status {
+		case AuthorizationStatusCreated, AuthorizationStatusPartiallyCaptured:
+			return &u.Payments.Authorizations[i]
+		}
+	}
+	return nil
+}
+
+// CapturePurchaseUnit captures the authorization of a single purchase unit of an
+// authorized order, so purchase units of multiple sellers can be captured as each ships.
+// The order must have been created with the AUTHORIZE intent and authorized.
+// A partial capture is made when paymentCaptureRequest has an amount and FinalCapture is false.
+// Endpoint: POST /v2/payments/authorizations/ID/capture
+func (c *Client) CapturePurchaseUnit(ctx context.Context,
+	orderID string,
+	referenceID string,
+	paymentCaptureRequest *PaymentCaptureRequest,
+	requestID string,
+) (*PaymentCaptureResponse, error) {
+	order, err := c.GetOrder(ctx, orderID)
+	if err != nil {
+		return &PaymentCaptureResponse{}, err
+	}
+
+	unit := order.PurchaseUnit(referenceID)
+	if unit == nil {
+		return &PaymentCaptureResponse{}, fmt.Errorf("paypal: order %s has no purchase unit %q", orderID, referenceID)
+	}
+
+	auth := unit.CapturableAuthorization()
+	if auth == nil {
+		return &PaymentCaptureResponse{}, fmt.Errorf("paypal: purchase unit %q of order %s has no capturable authorization", referenceID, orderID)
+	}
+
+	return c.CaptureAuthorizationWithPaypalRequestId(ctx, auth.ID, paymentCaptureRequest, requestID)
+}
+
 // CreateOrder - Use this call to create an order
 // Endpoint: POST /v2/checkout/orders
 func (c *Client) CreateOrder(ctx context.Context, intent string, purchaseUnits []PurchaseUnitRequest, payer *CreateOrderPayer, appContext *ApplicationContext) (*Order, error) {
@@ -37,16 +144,21 @@ func (c *Client) CreateOrderWithPaypalRequestID(ctx context.Context,
 	appContext *ApplicationContext,
 	requestID string,
 ) (*Order, error) {
-	type createOrderRequest struct {
-		Intent             string                `json:"intent"`
-		Payer              *CreateOrderPayer     `json:"payer,omitempty"`
-		PurchaseUnits      []PurchaseUnitRequest `json:"purchase_units"`
-		ApplicationContext *ApplicationContext   `json:"application_context,omitempty"`
-	}
+	return c.CreateOrderWithRequest(ctx, CreateOrderRequest{
+		Intent:             intent,
+		PurchaseUnits:      purchaseUnits,
+		Payer:              payer,
+		ApplicationContext: appContext,
+	}, requestID)
+}
 
+// CreateOrderWithRequest - Use this call to create an order with all the fields of the request,
+// e.g. a processing instruction, and an optional PayPal-Request-Id for idempotency
+// Endpoint: POST /v2/checkout/orders
+func (c *Client) CreateOrderWithRequest(ctx context.Context, createOrderRequest CreateOrderRequest, requestID string) (*Order, error) {
 	order := &Order{}
 
-	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders"), createOrderRequest{Intent: intent, PurchaseUnits: purchaseUnits, Payer: payer, ApplicationContext: appContext})
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders"), createOrderRequest)
 	if err != nil {
 		return order, err
 	}
@@ -79,6 +191,32 @@ func (c *Client) UpdateOrder(ctx context.Context, orderID string, purchaseUnits
 	return order, nil
 }
 
+// ConfirmOrderPaymentSource - https://developer.paypal.com/docs/api/orders/v2/#orders_confirm
+// Endpoint: POST /v2/checkout/orders/ID/confirm-payment-source
+func (c *Client) ConfirmOrderPaymentSource(ctx context.Context, orderID string, paymentSource *PaymentSource) (*Order, error) {
+	return c.ConfirmPaymentSource(ctx, orderID, ConfirmOrderRequest{PaymentSource: paymentSource})
+}
+
+// ConfirmPaymentSource confirms the payment source of an order, e.g. a PayPal wallet,
+// card or an APM like iDEAL. The returned order has a payer-action link when
+// the buyer still has to approve the payment.
+// Doc: https://developer.paypal.com/docs/api/orders/v2/#orders_confirm
+// Endpoint: POST /v2/checkout/orders/ID/confirm-payment-source
+func (c *Client) ConfirmPaymentSource(ctx context.Context, orderID string, confirmOrderRequest ConfirmOrderRequest) (*Order, error) {
+	order := &Order{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/confirm-payment-source"), confirmOrderRequest)
+	if err != nil {
+		return order, err
+	}
+
+	if err = c.SendWithAuth(req, order); err != nil {
+		return order, err
+	}
+
+	return order, nil
+}
+
 // AuthorizeOrder - https://developer.paypal.com/docs/api/orders/v2/#orders_authorize
 // Endpoint: POST /v2/checkout/orders/ID/authorize
 func (c *Client) AuthorizeOrder(ctx context.Context, orderID string, authorizeOrderRequest AuthorizeOrderRequest) (*Authorization, error) {
@@ -112,11 +250,12 @@ func (c *Client) CaptureOrderWithPaypalRequestId(ctx context.Context,
 ) (*CaptureOrderResponse, error) {
 	capture := &CaptureOrderResponse{}
 
-	c.SetReturnRepresentation()
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/capture"), captureOrderRequest)
 	if err != nil {
 		return capture, err
 	}
+	// Per request, so concurrent captures don't modify the client
+	req.Header.Set("Prefer", "return=representation")
 
 	if requestID != "" {
 		req.Header.Set("PayPal-Request-Id", requestID)
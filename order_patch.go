@@ -0,0 +1,170 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Possible values for `op` in Patch
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-patch
+const (
+	PatchOperationAdd     string = "add"
+	PatchOperationRemove  string = "remove"
+	PatchOperationReplace string = "replace"
+)
+
+// orderPatchPaths are the patchable fields of an order with their allowed
+// operations, purchase unit fields are relative to the purchase unit
+//
+// https://developer.paypal.com/docs/api/orders/v2/#orders_patch
+var (
+	orderPatchPaths = map[string][]string{
+		"/intent":         {PatchOperationReplace},
+		"/payer":          {PatchOperationReplace, PatchOperationAdd},
+		"/purchase_units": {PatchOperationReplace, PatchOperationAdd},
+		"/application_context/client_configuration": {PatchOperationReplace, PatchOperationAdd},
+	}
+	purchaseUnitPatchPaths = map[string][]string{
+		"/custom_id":                             {PatchOperationReplace, PatchOperationAdd, PatchOperationRemove},
+		"/description":                           {PatchOperationReplace, PatchOperationAdd, PatchOperationRemove},
+		"/payee/email":                           {PatchOperationReplace},
+		"/shipping/name":                         {PatchOperationReplace, PatchOperationAdd},
+		"/shipping/address":                      {PatchOperationReplace, PatchOperationAdd},
+		"/shipping/type":                         {PatchOperationReplace, PatchOperationAdd},
+		"/shipping/options":                      {PatchOperationReplace, PatchOperationAdd},
+		"/soft_descriptor":                       {PatchOperationReplace, PatchOperationRemove},
+		"/amount":                                {PatchOperationReplace},
+		"/items":                                 {PatchOperationReplace, PatchOperationAdd, PatchOperationRemove},
+		"/invoice_id":                            {PatchOperationReplace, PatchOperationAdd, PatchOperationRemove},
+		"/payment_instruction":                   {PatchOperationReplace},
+		"/payment_instruction/disbursement_mode": {PatchOperationReplace},
+		"/payment_instruction/platform_fees":     {PatchOperationReplace, PatchOperationAdd, PatchOperationRemove},
+	}
+)
+
+// OrderPatchBuilder builds the JSON Patch document of UpdateOrderWithPatches,
+// validating paths and operations against the fields PayPal allows to patch:
+//
+//	patches, err := paypal.NewOrderPatchBuilder().
+//		ReplaceAmount("default", &paypal.PurchaseUnitAmount{Currency: "USD", Value: "12.00"}).
+//		SetDescription("default", "Blue T-shirt").
+//		Build()
+type OrderPatchBuilder struct {
+	patches []Patch
+	err     error
+}
+
+// NewOrderPatchBuilder returns an empty OrderPatchBuilder
+func NewOrderPatchBuilder() *OrderPatchBuilder {
+	return &OrderPatchBuilder{}
+}
+
+// PurchaseUnitPath returns the patch path of a field of the purchase unit with
+// the given reference ID, e.g. /purchase_units/@reference_id=='default'/amount
+func PurchaseUnitPath(referenceID, field string) string {
+	return "/purchase_units/@reference_id=='" + referenceID + "'" + field
+}
+
+// Op adds an operation on any patchable path, the first invalid operation
+// makes Build fail
+func (b *OrderPatchBuilder) Op(op, path string, value interface{}) *OrderPatchBuilder {
+	if b.err != nil {
+		return b
+	}
+	if err := validateOrderPatch(op, path, value); err != nil {
+		b.err = err
+		return b
+	}
+
+	b.patches = append(b.patches, Patch{Operation: op, Path: path, Value: value})
+	return b
+}
+
+// ReplaceAmount replaces the amount of a purchase unit, including its breakdown
+func (b *OrderPatchBuilder) ReplaceAmount(referenceID string, amount *PurchaseUnitAmount) *OrderPatchBuilder {
+	return b.Op(PatchOperationReplace, PurchaseUnitPath(referenceID, "/amount"), amount)
+}
+
+// ReplaceItems replaces the items of a purchase unit, the amount breakdown must be replaced to match
+func (b *OrderPatchBuilder) ReplaceItems(referenceID string, items []Item) *OrderPatchBuilder {
+	return b.Op(PatchOperationReplace, PurchaseUnitPath(referenceID, "/items"), items)
+}
+
+// AddShippingAddress sets the shipping address of a purchase unit
+func (b *OrderPatchBuilder) AddShippingAddress(referenceID string, address *ShippingDetailAddressPortable) *OrderPatchBuilder {
+	return b.Op(PatchOperationAdd, PurchaseUnitPath(referenceID, "/shipping/address"), address)
+}
+
+// SetDescription sets the description of a purchase unit
+func (b *OrderPatchBuilder) SetDescription(referenceID, description string) *OrderPatchBuilder {
+	return b.Op(PatchOperationAdd, PurchaseUnitPath(referenceID, "/description"), description)
+}
+
+// SetInvoiceID sets the invoice ID of a purchase unit
+func (b *OrderPatchBuilder) SetInvoiceID(referenceID, invoiceID string) *OrderPatchBuilder {
+	return b.Op(PatchOperationAdd, PurchaseUnitPath(referenceID, "/invoice_id"), invoiceID)
+}
+
+// SetCustomID sets the custom ID of a purchase unit
+func (b *OrderPatchBuilder) SetCustomID(referenceID, customID string) *OrderPatchBuilder {
+	return b.Op(PatchOperationAdd, PurchaseUnitPath(referenceID, "/custom_id"), customID)
+}
+
+// Remove removes an optional field of a purchase unit, e.g. /description
+func (b *OrderPatchBuilder) Remove(referenceID, field string) *OrderPatchBuilder {
+	return b.Op(PatchOperationRemove, PurchaseUnitPath(referenceID, field), nil)
+}
+
+// Build returns the patches, or the error of the first invalid operation
+func (b *OrderPatchBuilder) Build() ([]Patch, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.patches) == 0 {
+		return nil, fmt.Errorf("paypal: order patch has no operations")
+	}
+	return b.patches, nil
+}
+
+// validateOrderPatch checks an operation against the patchable fields of an order
+func validateOrderPatch(op, path string, value interface{}) error {
+	ops, ok := orderPatchPaths[path]
+	if !ok {
+		prefix := "/purchase_units/@reference_id=='"
+		if strings.HasPrefix(path, prefix) {
+			rest := path[len(prefix):]
+			if i := strings.Index(rest, "'"); i > 0 {
+				ops, ok = purchaseUnitPatchPaths[rest[i+1:]]
+			}
+		}
+	}
+	if !ok {
+		return fmt.Errorf("paypal: order field %s cannot be patched", path)
+	}
+
+	for _, allowed := range ops {
+		if op == allowed {
+			if op != PatchOperationRemove && value == nil {
+				return fmt.Errorf("paypal: %s of order field %s requires a value", op, path)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("paypal: operation %s is not allowed on order field %s", op, path)
+}
+
+// UpdateOrderWithPatches updates an order with a JSON Patch document, see OrderPatchBuilder.
+// Only orders with the CREATED or APPROVED status can be updated.
+// Doc: https://developer.paypal.com/docs/api/orders/v2/#orders_patch
+// Endpoint: PATCH /v2/checkout/orders/ID
+func (c *Client) UpdateOrderWithPatches(ctx context.Context, orderID string, patches []Patch) error {
+	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s%s%s", c.APIBase, "/v2/checkout/orders/", orderID), patches)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
@@ -0,0 +1,74 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestOrderPatchBuilder(t *testing.T) {
+	patches, err := NewOrderPatchBuilder().
+		ReplaceAmount("default", &PurchaseUnitAmount{Currency: "USD", Value: "12.00"}).
+		AddShippingAddress("default", &ShippingDetailAddressPortable{AddressLine1: "1 Main St", CountryCode: "US"}).
+		SetDescription("default", "Blue T-shirt").
+		Remove("default", "/soft_descriptor").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := json.Marshal(patches)
+	expected := `[{"op":"replace","path":"/purchase_units/@reference_id=='default'/amount","value":{"currency_code":"USD","value":"12.00"}},` +
+		`{"op":"add","path":"/purchase_units/@reference_id=='default'/shipping/address","value":{"address_line_1":"1 Main St","country_code":"US"}},` +
+		`{"op":"add","path":"/purchase_units/@reference_id=='default'/description","value":"Blue T-shirt"},` +
+		`{"op":"remove","path":"/purchase_units/@reference_id=='default'/soft_descriptor"}]`
+	if string(data) != expected {
+		t.Errorf("unexpected patch document %s", data)
+	}
+
+	invalid := map[string]*OrderPatchBuilder{
+		"unknown field":      NewOrderPatchBuilder().Op(PatchOperationReplace, "/status", "APPROVED"),
+		"unknown unit field": NewOrderPatchBuilder().Op(PatchOperationReplace, PurchaseUnitPath("default", "/reference_id"), "x"),
+		"disallowed op":      NewOrderPatchBuilder().Remove("default", "/amount"),
+		"missing value":      NewOrderPatchBuilder().Op(PatchOperationReplace, "/intent", nil),
+		"empty":              NewOrderPatchBuilder(),
+	}
+	for name, b := range invalid {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%s: expecting an error", name)
+		}
+	}
+}
+
+func TestUpdateOrderWithPatches(t *testing.T) {
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/checkout/orders/O1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	patches, _ := NewOrderPatchBuilder().SetInvoiceID("default", "INV-1").Build()
+	if err := c.UpdateOrderWithPatches(context.Background(), "O1", patches); err != nil {
+		t.Fatal(err)
+	}
+	if body != `[{"op":"add","path":"/purchase_units/@reference_id=='default'/invoice_id","value":"INV-1"}]` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
@@ -0,0 +1,126 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+)
+
+// Possible values for `type` in ShippingDetail
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-shipping_detail
+const (
+	ShippingTypeShipping         string = "SHIPPING"
+	ShippingTypePickupInPerson   string = "PICKUP_IN_PERSON"
+	ShippingTypePickupInStore    string = "PICKUP_IN_STORE"
+	ShippingTypePickupFromPerson string = "PICKUP_FROM_PERSON"
+)
+
+// Possible values for `type` in ShippingOption
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-shipping_option
+const (
+	ShippingOptionTypeShipping string = "SHIPPING"
+	ShippingOptionTypePickup   string = "PICKUP"
+)
+
+// SelectedShippingOption returns the selected shipping option, or nil
+func (s *ShippingDetail) SelectedShippingOption() *ShippingOption {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Options {
+		if s.Options[i].Selected {
+			return &s.Options[i]
+		}
+	}
+	return nil
+}
+
+// SelectShippingOption returns the options with only optionID selected and the
+// amount with the shipping of the breakdown replaced by the amount of the
+// option, the value is recomputed so the breakdown still adds up. The options
+// and amount passed are not modified.
+func SelectShippingOption(amount *PurchaseUnitAmount, options []ShippingOption, optionID string) ([]ShippingOption, *PurchaseUnitAmount, error) {
+	var selected *ShippingOption
+	options = append([]ShippingOption(nil), options...)
+	for i := range options {
+		options[i].Selected = options[i].ID == optionID
+		if options[i].Selected {
+			selected = &options[i]
+		}
+	}
+	if selected == nil {
+		return nil, nil, fmt.Errorf("paypal: unknown shipping option %q", optionID)
+	}
+	if amount == nil || amount.Breakdown == nil {
+		return nil, nil, fmt.Errorf("paypal: amount breakdown is required to select shipping option %q", optionID)
+	}
+
+	shipping := MoneyFromMinorUnits(amount.Currency, 0)
+	if selected.Amount != nil {
+		shipping = selected.Amount
+	}
+
+	value := &Money{Currency: amount.Currency, Value: amount.Value}
+	var err error
+	if previous := amount.Breakdown.Shipping; previous != nil {
+		if value, err = value.Sub(*previous); err != nil {
+			return nil, nil, err
+		}
+	}
+	if value, err = value.Add(*shipping); err != nil {
+		return nil, nil, err
+	}
+
+	breakdown := *amount.Breakdown
+	breakdown.Shipping = &Money{Currency: shipping.Currency, Value: shipping.Value}
+	return options, &PurchaseUnitAmount{Currency: amount.Currency, Value: value.Value, Breakdown: &breakdown}, nil
+}
+
+// ReplaceShippingOptions replaces the shipping options of a purchase unit, see SelectShippingOption
+func (b *OrderPatchBuilder) ReplaceShippingOptions(referenceID string, options []ShippingOption) *OrderPatchBuilder {
+	return b.Op(PatchOperationReplace, PurchaseUnitPath(referenceID, "/shipping/options"), options)
+}
+
+// UpdateOrderShippingOption selects the shipping option optionID of a purchase
+// unit and updates the amount to its shipping cost, e.g. from the shipping
+// callback of the buyer changing the shipping at approval. It returns the
+// updated order.
+// Endpoint: GET /v2/checkout/orders/ID
+// Endpoint: PATCH /v2/checkout/orders/ID
+func (c *Client) UpdateOrderShippingOption(ctx context.Context, orderID, referenceID, optionID string) (*Order, error) {
+	order, err := c.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var unit *PurchaseUnit
+	for i := range order.PurchaseUnits {
+		if order.PurchaseUnits[i].ReferenceID == referenceID {
+			unit = &order.PurchaseUnits[i]
+		}
+	}
+	if unit == nil || unit.Shipping == nil {
+		return nil, fmt.Errorf("paypal: order %s has no shipping options for purchase unit %q", orderID, referenceID)
+	}
+
+	options, amount, err := SelectShippingOption(unit.Amount, unit.Shipping.Options, optionID)
+	if err != nil {
+		return nil, err
+	}
+
+	patches, err := NewOrderPatchBuilder().
+		ReplaceShippingOptions(referenceID, options).
+		ReplaceAmount(referenceID, amount).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	if err = c.UpdateOrderWithPatches(ctx, orderID, patches); err != nil {
+		return nil, err
+	}
+
+	unit.Shipping.Options = options
+	unit.Amount = amount
+	return order, nil
+}
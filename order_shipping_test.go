@@ -0,0 +1,108 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateOrderShippingOption(t *testing.T) {
+	var patch string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v2/checkout/orders/O1":
+			w.Write([]byte(`{"id":"O1","status":"APPROVED","purchase_units":[{"reference_id":"default",
+				"amount":{"currency_code":"USD","value":"25.00","breakdown":{"item_total":{"currency_code":"USD","value":"20.00"},
+					"shipping":{"currency_code":"USD","value":"5.00"}}},
+				"shipping":{"type":"SHIPPING","options":[
+					{"id":"STANDARD","label":"Standard","type":"SHIPPING","amount":{"currency_code":"USD","value":"5.00"},"selected":true},
+					{"id":"EXPRESS","label":"Express","type":"SHIPPING","amount":{"currency_code":"USD","value":"12.50"},"selected":false},
+					{"id":"STORE","label":"Pick up in store","type":"PICKUP","selected":false}]}}]}`))
+		case "PATCH /v2/checkout/orders/O1":
+			data, _ := ioutil.ReadAll(r.Body)
+			patch = string(data)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+	ctx := context.Background()
+
+	order, err := c.UpdateOrderShippingOption(ctx, "O1", "default", "EXPRESS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unit := order.PurchaseUnits[0]
+	if selected := unit.Shipping.SelectedShippingOption(); selected == nil || selected.ID != "EXPRESS" {
+		t.Errorf("expecting EXPRESS to be selected, got %+v", selected)
+	}
+	if unit.Amount.Value != "32.50" || unit.Amount.Breakdown.Shipping.Value != "12.50" {
+		t.Errorf("unexpected amount %+v %+v", unit.Amount, unit.Amount.Breakdown.Shipping)
+	}
+
+	var patches []struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(patch), &patches); err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 2 || patches[0].Path != "/purchase_units/@reference_id=='default'/shipping/options" ||
+		patches[1].Path != "/purchase_units/@reference_id=='default'/amount" {
+		t.Fatalf("unexpected patches %s", patch)
+	}
+	if string(patches[1].Value) != `{"currency_code":"USD","value":"32.50","breakdown":{"item_total":{"currency_code":"USD","value":"20.00"},"shipping":{"currency_code":"USD","value":"12.50"}}}` {
+		t.Errorf("unexpected amount patch %s", patches[1].Value)
+	}
+
+	order, err = c.UpdateOrderShippingOption(ctx, "O1", "default", "STORE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount := order.PurchaseUnits[0].Amount; amount.Value != "20.00" || amount.Breakdown.Shipping.Value != "0.00" {
+		t.Errorf("expecting free pickup, got %+v %+v", amount, amount.Breakdown.Shipping)
+	}
+
+	if _, err = c.UpdateOrderShippingOption(ctx, "O1", "default", "DRONE"); err == nil {
+		t.Error("expecting an error for an unknown shipping option")
+	}
+}
+
+func TestPurchaseUnitShippingOptions(t *testing.T) {
+	amount := &PurchaseUnitAmount{Currency: "USD", Value: "25.00", Breakdown: &PurchaseUnitAmountBreakdown{
+		ItemTotal: &Money{Currency: "USD", Value: "20.00"},
+		Shipping:  &Money{Currency: "USD", Value: "5.00"},
+	}}
+	options := []ShippingOption{
+		{ID: "STANDARD", Label: "Standard", Amount: &Money{Currency: "USD", Value: "5.00"}, Selected: true},
+		{ID: "EXPRESS", Label: "Express", Amount: &Money{Currency: "USD", Value: "12.50"}},
+	}
+	unit := PurchaseUnitRequest{Amount: amount, Shipping: &ShippingDetail{Options: options}}
+	if err := unit.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	options[1].Selected = true
+	if err := unit.Validate(); err == nil {
+		t.Error("expecting an error for two selected options")
+	}
+
+	options[0].Selected = false
+	if err := unit.Validate(); err == nil {
+		t.Error("expecting an error for a selected option not matching the breakdown shipping")
+	}
+
+	unit.Shipping.Options = []ShippingOption{options[0], options[0]}
+	if err := unit.Validate(); err == nil {
+		t.Error("expecting an error for a duplicate option ID")
+	}
+}
@@ -0,0 +1,57 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AddOrderTracker adds the tracking information of a shipment to a captured
+// order, which makes the seller eligible for PayPal seller protection.
+// The trackers of the order are in the shipping of its purchase units.
+// Doc: https://developer.paypal.com/docs/api/orders/v2/#orders_track_create
+// Endpoint: POST /v2/checkout/orders/ID/track
+func (c *Client) AddOrderTracker(ctx context.Context, orderID string, tracker AddOrderTrackerRequest) (*Order, error) {
+	order := &Order{}
+
+	if tracker.CaptureID == "" {
+		return order, fmt.Errorf("paypal: capture ID is required to track a shipment")
+	}
+	if tracker.Carrier == CarrierOther && tracker.CarrierNameOther == "" {
+		return order, fmt.Errorf("paypal: carrier name is required with carrier %s", CarrierOther)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/track"), tracker)
+	if err != nil {
+		return order, err
+	}
+
+	if err = c.SendWithAuth(req, order); err != nil {
+		return order, err
+	}
+
+	return order, nil
+}
+
+// UpdateOrderTracker updates the tracker of an order with a JSON Patch document,
+// e.g. to replace /tracking_number, /carrier, /notify_payer or /items
+// Doc: https://developer.paypal.com/docs/api/orders/v2/#orders_trackers_patch
+// Endpoint: PATCH /v2/checkout/orders/ID/trackers/ID
+func (c *Client) UpdateOrderTracker(ctx context.Context, orderID, trackerID string, patches []Patch) error {
+	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/trackers/"+trackerID), patches)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// UpdateOrderTrackerStatus sets the status of the tracker of an order, e.g. ShipmentStatusCancelled
+// Endpoint: PATCH /v2/checkout/orders/ID/trackers/ID
+func (c *Client) UpdateOrderTrackerStatus(ctx context.Context, orderID, trackerID string, status ShipmentStatus) error {
+	return c.UpdateOrderTracker(ctx, orderID, trackerID, []Patch{{
+		Operation: PatchOperationReplace,
+		Path:      "/status",
+		Value:     status,
+	}})
+}
@@ -0,0 +1,73 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestOrderTrackers(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+
+		if r.Method == http.MethodPatch {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"O1","status":"COMPLETED","purchase_units":[{"reference_id":"default","shipping":{"trackers":[{"id":"C1-1Z999","status":"SHIPPED"}]}}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	order, err := c.AddOrderTracker(ctx, "O1", AddOrderTrackerRequest{
+		CaptureID:      "C1",
+		TrackingNumber: "1Z999",
+		Carrier:        CarrierUPS,
+		NotifyPayer:    true,
+		Items:          []OrderTrackerItem{{Name: "T-Shirt", Quantity: "1", SKU: "TS-1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	trackers := order.PurchaseUnits[0].Shipping.Trackers
+	if len(trackers) != 1 || trackers[0].ID != "C1-1Z999" || trackers[0].Status != ShipmentStatusShipped {
+		t.Errorf("unexpected trackers %+v", trackers)
+	}
+
+	if err = c.UpdateOrderTrackerStatus(ctx, "O1", "C1-1Z999", ShipmentStatusCancelled); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`POST /v2/checkout/orders/O1/track {"capture_id":"C1","tracking_number":"1Z999","carrier":"UPS","notify_payer":true,"items":[{"name":"T-Shirt","quantity":"1","sku":"TS-1"}]}`,
+		`PATCH /v2/checkout/orders/O1/trackers/C1-1Z999 [{"op":"replace","path":"/status","value":"CANCELLED"}]`,
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("unexpected requests %q", requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("expecting %s, got %s", expected[i], requests[i])
+		}
+	}
+
+	if _, err = c.AddOrderTracker(ctx, "O1", AddOrderTrackerRequest{CaptureID: "C1", Carrier: CarrierOther}); err == nil {
+		t.Errorf("expecting an error without the name of another carrier")
+	}
+}
@@ -0,0 +1,144 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type (
+	// Paginator iterates the pages of a list endpoint. It follows the "next" link
+	// of each page and falls back to incrementing the page parameter while pages
+	// remain. Its position can be saved with Cursor and resumed with ResumePaginator.
+	Paginator struct {
+		client *Client
+		next   string
+		done   bool
+	}
+
+	// paginatorCursor is the serialized position of a Paginator
+	paginatorCursor struct {
+		Next string `json:"next,omitempty"`
+		Done bool   `json:"done,omitempty"`
+	}
+
+	// pageInfo has the pagination fields shared by list responses
+	pageInfo struct {
+		Page       int   `json:"page"`
+		TotalPages int   `json:"total_pages"`
+		Links      Links `json:"links"`
+	}
+)
+
+// NewPaginator returns a Paginator starting at the given API path and query,
+// e.g. NewPaginator("/v1/billing/plans", url.Values{"page_size": {"20"}})
+func (c *Client) NewPaginator(path string, query url.Values) *Paginator {
+	next := c.APIBase + path
+	if len(query) > 0 {
+		next += "?" + query.Encode()
+	}
+	return &Paginator{client: c, next: next}
+}
+
+// ResumePaginator returns a Paginator continuing from a position saved with Cursor
+func (c *Client) ResumePaginator(cursor string) (*Paginator, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("paypal: invalid paginator cursor")
+	}
+
+	var pc paginatorCursor
+	if err := json.Unmarshal(data, &pc); err != nil {
+		return nil, errors.New("paypal: invalid paginator cursor")
+	}
+
+	p := &Paginator{client: c, next: pc.Next, done: pc.Done || pc.Next == ""}
+	if !p.done {
+		// The cursor may come from untrusted storage, never follow it off the API host
+		if p.next, err = c.resolveLink(pc.Next); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// query returns the list parameters as query, omitting empty ones
+func (p ListParams) query() url.Values {
+	q := url.Values{}
+	for key, value := range map[string]string{"page": p.Page, "page_size": p.PageSize, "total_required": p.TotalRequired} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	return q
+}
+
+// HasNext reports whether there are pages left
+func (p *Paginator) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page and decodes it into v.
+// It returns false when there are no pages left.
+func (p *Paginator) Next(ctx context.Context, v interface{}) (bool, error) {
+	if p.done {
+		return false, nil
+	}
+
+	req, err := p.client.NewRequest(ctx, http.MethodGet, p.next, nil)
+	if err != nil {
+		return false, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err = p.client.SendWithAuth(req, buf); err != nil {
+		return false, err
+	}
+
+	if v != nil {
+		if err = json.Unmarshal(buf.Bytes(), v); err != nil {
+			return false, err
+		}
+	}
+
+	var info pageInfo
+	json.Unmarshal(buf.Bytes(), &info)
+	p.advance(req.URL, info)
+
+	return true, nil
+}
+
+// Cursor returns the position of the paginator, the URL of the next page
+// including its filters, as an opaque string for ResumePaginator
+func (p *Paginator) Cursor() string {
+	data, _ := json.Marshal(paginatorCursor{Next: p.next, Done: p.done})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// advance moves the paginator past the page fetched from current
+func (p *Paginator) advance(current *url.URL, info pageInfo) {
+	if link := FindLink(info.Links, "next"); link != nil {
+		if next, err := p.client.resolveLink(link.Href); err == nil {
+			p.next = next
+			return
+		}
+	}
+
+	if info.Page > 0 && info.Page < info.TotalPages {
+		u := *current
+		q := u.Query()
+		q.Set("page", strconv.Itoa(info.Page+1))
+		u.RawQuery = q.Encode()
+		p.next = u.String()
+		return
+	}
+
+	p.next = ""
+	p.done = true
+}
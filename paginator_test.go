@@ -0,0 +1,116 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestPaginatorResume(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.URL.Query().Get("transaction_status") != "S" {
+			t.Errorf("expecting filters to be kept, got %s", r.URL.RawQuery)
+		}
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "", "1":
+			// Next page announced by a link
+			fmt.Fprintf(w, `{"page":1,"total_pages":3,"links":[{"href":"%s/v1/reporting/transactions?transaction_status=S&page=2","rel":"next"}]}`, ts.URL)
+		default:
+			// Next page derived from the page number
+			fmt.Fprintf(w, `{"page":%s,"total_pages":3}`, page)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	p := c.NewPaginator("/v1/reporting/transactions", url.Values{"transaction_status": {"S"}})
+
+	var page TransactionSearchResponse
+	if ok, err := p.Next(context.Background(), &page); !ok || err != nil || page.Page != 1 {
+		t.Fatalf("expecting page 1, got %d %v %v", page.Page, ok, err)
+	}
+
+	// Restart from the saved position
+	p, err := c.ResumePaginator(p.Cursor())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pages []int
+	for p.HasNext() {
+		if _, err := p.Next(context.Background(), &page); err != nil {
+			t.Fatal(err)
+		}
+		pages = append(pages, page.Page)
+	}
+	if fmt.Sprint(pages) != "[2 3]" {
+		t.Errorf("expecting pages 2 and 3 after resuming, got %v", pages)
+	}
+
+	if ok, _ := p.Next(context.Background(), &page); ok {
+		t.Errorf("expecting no more pages")
+	}
+
+	other, _ := NewClient("foo", "bar", "https://api.example.com")
+	if _, err := other.ResumePaginator(c.NewPaginator("/v1/reporting/transactions", nil).Cursor()); err == nil {
+		t.Errorf("expecting cursors for another host to be rejected")
+	}
+}
+
+func TestWebhookEventPaginator(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.URL.Path != "/v1/notifications/webhooks-events" || r.URL.Query().Get("event_type") != EventPaymentCaptureCompleted {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if r.URL.Query().Get("next_page_token") == "" {
+			fmt.Fprintf(w, `{"events":[{"id":"WH-1"}],"count":1,"links":[{"href":"%s/v1/notifications/webhooks-events?event_type=%s&next_page_token=2","rel":"next"}]}`, ts.URL, EventPaymentCaptureCompleted)
+			return
+		}
+		w.Write([]byte(`{"events":[{"id":"WH-2"}],"count":1}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	p := c.NewWebhookEventPaginator(&ListWebhookEventsParams{EventType: EventPaymentCaptureCompleted})
+
+	var ids []string
+	for p.HasNext() {
+		var page ListWebhookEventsResponse
+		if _, err := p.Next(context.Background(), &page); err != nil {
+			t.Fatal(err)
+		}
+		for _, event := range page.Events {
+			ids = append(ids, event.ID)
+		}
+	}
+	if fmt.Sprint(ids) != "[WH-1 WH-2]" {
+		t.Errorf("expecting the events of both pages, got %v", ids)
+	}
+}
+
+func TestListParamsQuery(t *testing.T) {
+	q := ListParams{PageSize: "20", TotalRequired: "true"}.query()
+	if q.Encode() != "page_size=20&total_required=true" {
+		t.Errorf("expecting empty parameters to be omitted, got %s", q.Encode())
+	}
+}
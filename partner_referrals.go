@@ -0,0 +1,68 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CreatePartnerReferral - Creates the referral of a seller to onboard, redirect
+// the seller to ActionURL to sign up or log in and grant the requested permissions.
+// Doc: https://developer.paypal.com/docs/api/partner-referrals/v2/#partner-referrals_create
+// Endpoint: POST /v2/customer/partner-referrals
+func (c *Client) CreatePartnerReferral(ctx context.Context, referral ReferralRequest) (*ReferralResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v2/customer/partner-referrals"), referral)
+	response := &ReferralResponse{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// GetPartnerReferral - Shows the data of a partner referral, by ID.
+// Doc: https://developer.paypal.com/docs/api/partner-referrals/v2/#partner-referrals_read
+// Endpoint: GET /v2/customer/partner-referrals/ID
+func (c *Client) GetPartnerReferral(ctx context.Context, referralID string) (*PartnerReferral, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v2/customer/partner-referrals/%s", c.APIBase, referralID), nil)
+	response := &PartnerReferral{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// ActionURL returns the link the seller is redirected to for onboarding
+func (r *ReferralResponse) ActionURL() string {
+	if link := FindLink(r.Links, "action_url"); link != nil {
+		return link.Href
+	}
+	return ""
+}
+
+// ReferralID returns the ID of the referral for GetPartnerReferral, from its self link
+func (r *ReferralResponse) ReferralID() string {
+	if link := FindLink(r.Links, "self"); link != nil {
+		return link.Href[strings.LastIndex(link.Href, "/")+1:]
+	}
+	return ""
+}
+
+// MarshalJSON leaves out third_party_details without features, first party
+// integrations only send first_party_details
+func (i RestAPIIntegration) MarshalJSON() ([]byte, error) {
+	type integration RestAPIIntegration
+	if len(i.ThirdPartyDetails.Features) > 0 {
+		return json.Marshal(integration(i))
+	}
+
+	return json.Marshal(struct {
+		integration
+		ThirdPartyDetails *ThirdPartyDetails `json:"third_party_details,omitempty"`
+	}{integration: integration(i)})
+}
@@ -0,0 +1,94 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestPartnerReferrals(t *testing.T) {
+	var requests []string
+	var sent map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost {
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"links":[
+				{"href":"https://api-m.sandbox.paypal.com/v2/customer/partner-referrals/REF-1","rel":"self","method":"GET"},
+				{"href":"https://www.sandbox.paypal.com/bizsignup/partner/entry?referralToken=TOKEN","rel":"action_url","method":"GET"}
+			]}`))
+			return
+		}
+		w.Write([]byte(`{"partner_referral_id":"REF-1","submitter_payer_id":"PARTNER","referral_data":{"tracking_id":"seller-1","products":["PPCP"]}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	referral, err := c.CreatePartnerReferral(ctx, ReferralRequest{
+		TrackingID: "seller-1",
+		Operations: []Operation{{
+			Operation: OperationAPIIntegration,
+			APIIntegrationPreference: &IntegrationDetails{
+				RestAPIIntegration: &RestAPIIntegration{
+					IntegrationMethod: IntegrationMethodPayPal,
+					IntegrationType:   IntegrationTypeFirstParty,
+					FirstPartyDetails: &FirstPartyDetails{
+						Features:    []string{FeaturePayment, FeatureRefund},
+						SellerNonce: "nonce",
+					},
+				},
+			},
+		}},
+		Products:      []string{ProductPPCP},
+		LegalConsents: []Consent{{Type: ConsentShareData, Granted: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if referral.ReferralID() != "REF-1" || !strings.Contains(referral.ActionURL(), "referralToken=TOKEN") {
+		t.Errorf("unexpected referral %+v", referral)
+	}
+
+	integration := sent["operations"].([]interface{})[0].(map[string]interface{})["api_integration_preference"].(map[string]interface{})["rest_api_integration"].(map[string]interface{})
+	if _, ok := integration["third_party_details"]; ok {
+		t.Errorf("expecting no third party details for a first party integration, got %v", integration)
+	}
+	if integration["first_party_details"].(map[string]interface{})["seller_nonce"] != "nonce" {
+		t.Errorf("unexpected integration %v", integration)
+	}
+
+	data, err := c.GetPartnerReferral(ctx, referral.ReferralID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.PartnerReferralID != "REF-1" || data.ReferralData.TrackingID != "seller-1" {
+		t.Errorf("unexpected referral data %+v", data)
+	}
+
+	expected := []string{"POST /v2/customer/partner-referrals", "GET /v2/customer/partner-referrals/REF-1"}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests\n%s", strings.Join(requests, "\n"))
+	}
+
+	b, _ := json.Marshal(RestAPIIntegration{
+		IntegrationType:   IntegrationTypeThirdParty,
+		ThirdPartyDetails: ThirdPartyDetails{Features: []string{FeaturePayment}},
+	})
+	if !strings.Contains(string(b), `"third_party_details":{"features":["PAYMENT"]}`) {
+		t.Errorf("expecting third party details, got %s", b)
+	}
+}
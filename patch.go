@@ -3,5 +3,5 @@ package paypal
 type Patch struct {
 	Operation string      `json:"op"`
 	Path      string      `json:"path"`
-	Value     interface{} `json:"value"`
+	Value     interface{} `json:"value,omitempty"`
 }
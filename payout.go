@@ -2,7 +2,13 @@ package paypal
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // CreatePayout submits a payout with an asynchronous API call, which immediately returns the results of a PayPal payment.
@@ -23,6 +29,99 @@ func (c *Client) CreatePayout(ctx context.Context, p Payout) (*PayoutResponse, e
 	return response, nil
 }
 
+// CreatePayoutSync submits a payout in sync_mode, which processes the items before
+// responding and returns their transaction status. PayPal only allows sync_mode for
+// accounts that have it enabled and for a single item, CreatePayout works everywhere.
+// Endpoint: POST /v1/payments/payouts?sync_mode=true
+func (c *Client) CreatePayoutSync(ctx context.Context, p Payout) (*PayoutResponse, error) {
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/payouts?sync_mode=true"), p)
+	response := &PayoutResponse{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// CreatePayoutOnce creates the payout of a job identified by key at most once:
+// the sender_batch_id is derived from the key with SenderBatchID and items
+// without a SenderItemID get one derived from the key and their index. When a
+// retried job finds the batch already created, the existing batch is returned
+// instead of ErrPayoutBatchExists.
+// Endpoint: POST /v1/payments/payouts
+func (c *Client) CreatePayoutOnce(ctx context.Context, key string, p Payout) (*PayoutResponse, error) {
+	header := SenderBatchHeader{}
+	if p.SenderBatchHeader != nil {
+		header = *p.SenderBatchHeader
+	}
+	header.SenderBatchID = SenderBatchID(key)
+	p.SenderBatchHeader = &header
+
+	p.Items = append([]PayoutItem(nil), p.Items...)
+	for i := range p.Items {
+		if p.Items[i].SenderItemID == "" {
+			p.Items[i].SenderItemID = SenderItemID(key + "/" + strconv.Itoa(i))
+		}
+	}
+
+	response, err := c.CreatePayout(ctx, p)
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		return response, err
+	}
+	detail := errResp.payoutBatchExists()
+	if detail == nil {
+		return response, err
+	}
+	link := FindLink(detail.Links, "self")
+	if link == nil {
+		return response, err
+	}
+
+	existing := &PayoutResponse{}
+	if err := c.FollowLink(ctx, *link, nil, existing); err != nil {
+		return existing, err
+	}
+	return existing, nil
+}
+
+// SenderBatchID derives a stable sender_batch_id from the key of a payout job,
+// e.g. "payroll-2021-03". PayPal rejects a second batch with the same
+// sender_batch_id, which makes retried jobs safe.
+func SenderBatchID(key string) string {
+	return senderID(key)
+}
+
+// SenderItemID derives a stable sender_item_id from the key of a payout item,
+// e.g. the ID of the commission paid out
+func SenderItemID(key string) string {
+	return senderID(key)
+}
+
+// senderID hashes the key so that any key fits the length and character set
+// PayPal allows for sender IDs
+func senderID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// payoutBatchExists returns the detail of the error PayPal returns for a
+// sender_batch_id used before, it links to the existing batch
+func (r *ErrorResponse) payoutBatchExists() *ErrorResponseDetail {
+	for i, d := range r.Details {
+		if strings.EqualFold(d.Field, "sender_batch_id") && strings.Contains(strings.ToLower(d.Issue), "already exists") {
+			return &r.Details[i]
+		}
+	}
+	return nil
+}
+
 // CreateSinglePayout is deprecated, use CreatePayout instead.
 func (c *Client) CreateSinglePayout(ctx context.Context, p Payout) (*PayoutResponse, error) {
 	return c.CreatePayout(ctx, p)
@@ -46,6 +145,35 @@ func (c *Client) GetPayout(ctx context.Context, payoutBatchID string) (*PayoutRe
 	return response, nil
 }
 
+// GetPayoutPage returns the batch header and one page of the items of a batch payout,
+// use NewPayoutItemPaginator to iterate all of them.
+// Endpoint: GET /v1/payments/payouts/ID?page=N&page_size=N
+func (c *Client) GetPayoutPage(ctx context.Context, payoutBatchID string, params *PayoutListParams) (*PayoutResponse, error) {
+	path := "/v1/payments/payouts/" + payoutBatchID
+	if q := params.query(); len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, path), nil)
+	response := &PayoutResponse{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// NewPayoutItemPaginator returns a Paginator over all pages of the items of a batch payout,
+// decode each page into a PayoutResponse
+func (c *Client) NewPayoutItemPaginator(payoutBatchID string, params *PayoutListParams) *Paginator {
+	return c.NewPaginator("/v1/payments/payouts/"+payoutBatchID, params.query())
+}
+
 // GetPayoutItem shows the details for a payout item.
 // Use this call to review the current status of a previously unclaimed, or pending, payout item.
 // Endpoint: GET /v1/payments/payouts-item/ID
@@ -81,3 +209,15 @@ func (c *Client) CancelPayoutItem(ctx context.Context, payoutItemID string) (*Pa
 
 	return response, nil
 }
+
+// query returns the payout list parameters as query, omitting empty ones
+func (p *PayoutListParams) query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+	q := p.ListParams.query()
+	if p.Fields != "" {
+		q.Set("fields", p.Fields)
+	}
+	return q
+}
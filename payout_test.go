@@ -0,0 +1,132 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestPayoutItemPagination(t *testing.T) {
+	var queries []string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		queries = append(queries, r.URL.RawQuery)
+		resp := PayoutResponse{
+			BatchHeader: &BatchHeader{PayoutBatchID: "BATCH-1", BatchStatus: BatchStatusSuccess},
+			Items:       []PayoutItemResponse{{PayoutItemID: "ITEM-" + r.URL.Query().Get("page")}},
+		}
+		if r.URL.Query().Get("page") == "1" {
+			resp.Links = []Link{{Rel: "next", Href: ts.URL + "/v1/payments/payouts/BATCH-1?page=2&page_size=1"}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	page, err := c.GetPayoutPage(ctx, "BATCH-1", &PayoutListParams{
+		ListParams: ListParams{Page: "1", PageSize: "1", TotalRequired: "true"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queries[0] != "page=1&page_size=1&total_required=true" {
+		t.Errorf("unexpected query %q", queries[0])
+	}
+	if page.BatchHeader.PayoutBatchID != "BATCH-1" || page.Items[0].PayoutItemID != "ITEM-1" {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	var items []string
+	pages := c.NewPayoutItemPaginator("BATCH-1", &PayoutListParams{ListParams: ListParams{Page: "1", PageSize: "1"}})
+	for pages.HasNext() {
+		var page PayoutResponse
+		if _, err := pages.Next(ctx, &page); err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range page.Items {
+			items = append(items, item.PayoutItemID)
+		}
+	}
+	if len(items) != 2 || items[0] != "ITEM-1" || items[1] != "ITEM-2" {
+		t.Errorf("unexpected items %v", items)
+	}
+}
+
+func TestCreatePayoutSync(t *testing.T) {
+	var query string
+	var body Payout
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		query = r.URL.RawQuery
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"batch_header":{"payout_batch_id":"BATCH-1","batch_status":"SUCCESS","funding_source":"BALANCE"},` +
+			`"items":[{"payout_item_id":"ITEM-1","transaction_status":"SUCCESS"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	resp, err := c.CreatePayoutSync(context.Background(), Payout{
+		SenderBatchHeader: &SenderBatchHeader{SenderBatchID: "batch-1", RecipientType: PhoneRecipientType},
+		Items: []PayoutItem{{
+			Receiver: "4085551234",
+			Amount:   &AmountPayout{Currency: "USD", Value: "1.00"},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "sync_mode=true" {
+		t.Errorf("expecting sync_mode=true, got %q", query)
+	}
+	if body.SenderBatchHeader.RecipientType != PhoneRecipientType {
+		t.Errorf("expecting a PHONE recipient type in the batch header, got %+v", body.SenderBatchHeader)
+	}
+	if resp.BatchHeader.FundingSource != "BALANCE" || resp.Items[0].TransactionStatus != PayoutItemStatusSuccess {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestCreatePayoutOnceWithoutLink(t *testing.T) {
+	var senderBatchIDs []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var p Payout
+		json.NewDecoder(r.Body).Decode(&p)
+		senderBatchIDs = append(senderBatchIDs, p.SenderBatchHeader.SenderBatchID)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"name":"USER_BUSINESS_ERROR","message":"User business error.","details":[
+			{"field":"SENDER_BATCH_ID","location":"body","issue":"Batch with given sender_batch_id already exists"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+
+	_, err := c.CreatePayoutOnce(context.Background(), "job-1", Payout{Items: []PayoutItem{{Receiver: "a@example.com"}}})
+	if !errors.Is(err, ErrPayoutBatchExists) {
+		t.Errorf("expecting ErrPayoutBatchExists, got %v", err)
+	}
+	if len(senderBatchIDs) != 1 || senderBatchIDs[0] != SenderBatchID("job-1") || len(senderBatchIDs[0]) != 32 {
+		t.Errorf("unexpected sender_batch_id %v", senderBatchIDs)
+	}
+	if SenderBatchID("job-1") == SenderBatchID("job-2") {
+		t.Error("expecting different keys to derive different IDs")
+	}
+}
@@ -0,0 +1,728 @@
+// Package paypaltest provides a fake PayPal API server for tests.
+//
+// The server issues OAuth tokens and implements the common Orders, Payments
+// and Payouts endpoints with in-memory state, so code using the paypal client
+// can be tested without the sandbox:
+//
+//	srv := paypaltest.NewServer()
+//	defer srv.Close()
+//
+//	c := srv.Client()
+//	order, _ := c.CreateOrder(ctx, paypal.OrderIntentCapture, units, nil, nil)
+//	srv.ApproveOrder(order.ID)
+//	c.CaptureOrder(ctx, order.ID, paypal.CaptureOrderRequest{})
+//
+//	srv.AssertRequested(t, http.MethodPost, "/v2/checkout/orders/"+order.ID+"/capture")
+//
+// NewEventFixture and WebhookSigner build signed webhook events for testing
+// webhook handlers.
+package paypaltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/optiopay/paypal/v4"
+)
+
+// Credentials accepted by the server
+const (
+	ClientID    = "paypaltest-client-id"
+	Secret      = "paypaltest-secret"
+	AccessToken = "paypaltest-access-token"
+)
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// Decode unmarshals the JSON body of the request into v
+func (r Request) Decode(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Server is a fake PayPal API server backed by httptest.Server
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	seq      int
+	orders   map[string]*paypal.Order
+	payouts  map[string]*paypal.PayoutResponse
+	requests []Request
+}
+
+// NewServer starts a new fake PayPal API server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		orders:  make(map[string]*paypal.Order),
+		payouts: make(map[string]*paypal.PayoutResponse),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a paypal client using the server as its API base
+func (s *Server) Client() *paypal.Client {
+	c, err := paypal.NewClient(ClientID, Secret, s.URL)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// SeedOrder stores an order as if it had been created through the API and returns a copy.
+// A missing ID, status or intent defaults to a generated ID, CREATED and CAPTURE.
+func (s *Server) SeedOrder(order paypal.Order) *paypal.Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if order.ID == "" {
+		order.ID = s.newID("ORDER")
+	}
+	if order.Status == "" {
+		order.Status = paypal.OrderStatusCreated
+	}
+	if order.Intent == "" {
+		order.Intent = paypal.OrderIntentCapture
+	}
+	if order.CreateTime == nil {
+		now := time.Now().UTC()
+		order.CreateTime = &now
+	}
+	s.orders[order.ID] = &order
+	return copyOrder(&order)
+}
+
+// ApproveOrder marks an order as approved by the buyer, which is required
+// before it can be authorized or captured
+func (s *Server) ApproveOrder(orderID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[orderID]
+	if !ok {
+		return fmt.Errorf("paypaltest: order %s not found", orderID)
+	}
+	order.Status = paypal.OrderStatusApproved
+	return nil
+}
+
+// Order returns a copy of the current state of an order
+func (s *Server) Order(orderID string) (*paypal.Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[orderID]
+	if !ok {
+		return nil, false
+	}
+	return copyOrder(order), true
+}
+
+// SetPayoutItemStatus sets the transaction status of a payout item, e.g. UNCLAIMED
+func (s *Server) SetPayoutItemStatus(payoutItemID, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.findPayoutItem(payoutItemID)
+	if item == nil {
+		return fmt.Errorf("paypaltest: payout item %s not found", payoutItemID)
+	}
+	item.TransactionStatus = status
+	return nil
+}
+
+// Requests returns all requests received by the server, oldest first
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received for a method and path, oldest first
+func (s *Server) RequestsTo(method, path string) []Request {
+	var reqs []Request
+	for _, r := range s.Requests() {
+		if r.Method == method && r.Path == path {
+			reqs = append(reqs, r)
+		}
+	}
+	return reqs
+}
+
+// AssertRequested fails the test unless the server received a request for
+// method and path, and returns the last such request
+func (s *Server) AssertRequested(t testing.TB, method, path string) Request {
+	t.Helper()
+
+	reqs := s.RequestsTo(method, path)
+	if len(reqs) == 0 {
+		t.Errorf("paypaltest: expected a %s %s request, got none", method, path)
+		return Request{}
+	}
+	return reqs[len(reqs)-1]
+}
+
+// Reset removes all orders, payouts and recorded requests
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.orders = make(map[string]*paypal.Order)
+	s.payouts = make(map[string]*paypal.PayoutResponse)
+	s.requests = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if r.URL.Path == "/v1/oauth2/token" {
+		s.token(w, r)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+AccessToken {
+		writeError(w, http.StatusUnauthorized, "AUTHENTICATION_FAILURE", "", "Authentication failed due to invalid authentication credentials or a missing Authorization header.")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	route := r.Method + " " + routePattern(parts)
+	id := ""
+	if len(parts) > 3 {
+		id = parts[3]
+	}
+
+	switch route {
+	case "POST /v2/checkout/orders":
+		s.createOrder(w, body)
+	case "GET /v2/checkout/orders/ID":
+		s.getOrder(w, id)
+	case "POST /v2/checkout/orders/ID/authorize":
+		s.authorizeOrder(w, id)
+	case "POST /v2/checkout/orders/ID/capture":
+		s.captureOrder(w, id)
+	case "GET /v2/payments/authorizations/ID":
+		s.getAuthorization(w, id)
+	case "POST /v2/payments/authorizations/ID/capture":
+		s.captureAuthorization(w, id, body)
+	case "POST /v2/payments/authorizations/ID/void":
+		s.voidAuthorization(w, id)
+	case "GET /v2/payments/captures/ID":
+		s.getCapture(w, id)
+	case "POST /v2/payments/captures/ID/refund":
+		s.refundCapture(w, id, body)
+	case "POST /v1/payments/payouts":
+		s.createPayout(w, body)
+	case "GET /v1/payments/payouts/ID":
+		s.getPayout(w, id)
+	case "GET /v1/payments/payouts-item/ID":
+		s.getPayoutItem(w, id)
+	case "POST /v1/payments/payouts-item/ID/cancel":
+		s.cancelPayoutItem(w, id)
+	default:
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "", "The specified resource does not exist.")
+	}
+}
+
+// routePattern replaces the resource ID of an API path with ID,
+// e.g. /v2/checkout/orders/5O190127TN364715T/capture becomes /v2/checkout/orders/ID/capture
+func routePattern(parts []string) string {
+	p := append([]string(nil), parts...)
+	if len(p) > 3 {
+		p[3] = "ID"
+	}
+	return "/" + strings.Join(p, "/")
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != ClientID || secret != Secret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{
+			"error":             "invalid_client",
+			"error_description": "Client Authentication failed",
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": AccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   32400,
+	})
+}
+
+func (s *Server) createOrder(w http.ResponseWriter, body []byte) {
+	var req paypal.CreateOrderRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "MALFORMED_REQUEST_JSON", err.Error())
+		return
+	}
+	if len(req.PurchaseUnits) == 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "MISSING_REQUIRED_PARAMETER", "purchase_units is required.")
+		return
+	}
+
+	now := time.Now().UTC()
+	order := &paypal.Order{
+		ID:            s.newID("ORDER"),
+		Status:        paypal.OrderStatusCreated,
+		Intent:        req.Intent,
+		PaymentSource: req.PaymentSource,
+		CreateTime:    &now,
+	}
+	for i, pu := range req.PurchaseUnits {
+		if pu.ReferenceID == "" {
+			pu.ReferenceID = "default"
+			if i > 0 {
+				pu.ReferenceID = fmt.Sprintf("default-%d", i)
+			}
+		}
+		order.PurchaseUnits = append(order.PurchaseUnits, paypal.PurchaseUnit{
+			ReferenceID:    pu.ReferenceID,
+			Amount:         pu.Amount,
+			Payee:          pu.Payee,
+			Description:    pu.Description,
+			CustomID:       pu.CustomID,
+			InvoiceID:      pu.InvoiceID,
+			SoftDescriptor: pu.SoftDescriptor,
+			Items:          pu.Items,
+			Shipping:       pu.Shipping,
+		})
+	}
+	order.Links = []paypal.Link{
+		{Href: s.URL + "/v2/checkout/orders/" + order.ID, Rel: "self", Method: http.MethodGet},
+		{Href: s.URL + "/checkoutnow?token=" + order.ID, Rel: "approve", Method: http.MethodGet},
+	}
+	s.orders[order.ID] = order
+
+	writeJSON(w, http.StatusCreated, order)
+}
+
+func (s *Server) getOrder(w http.ResponseWriter, id string) {
+	order, ok := s.orders[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "INVALID_RESOURCE_ID", "Specified resource ID does not exist.")
+		return
+	}
+	writeJSON(w, http.StatusOK, order)
+}
+
+// approvedOrder returns the order if it can be authorized or captured with intent,
+// or writes the error PayPal would return
+func (s *Server) approvedOrder(w http.ResponseWriter, id, intent string) *paypal.Order {
+	order, ok := s.orders[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "INVALID_RESOURCE_ID", "Specified resource ID does not exist.")
+		return nil
+	}
+	switch {
+	case order.Status == paypal.OrderStatusCompleted:
+		writeError(w, http.StatusUnprocessableEntity, paypal.ErrorNameUnprocessableEntity, paypal.ErrorIssueOrderAlreadyCaptured, "Order already captured.")
+		return nil
+	case order.Status != paypal.OrderStatusApproved:
+		writeError(w, http.StatusUnprocessableEntity, paypal.ErrorNameUnprocessableEntity, paypal.ErrorIssueOrderNotApproved, "Payer has not yet approved the Order for payment.")
+		return nil
+	case order.Intent != intent:
+		writeError(w, http.StatusUnprocessableEntity, paypal.ErrorNameUnprocessableEntity, "ACTION_DOES_NOT_MATCH_INTENT", "Order was created with an intent of "+order.Intent+".")
+		return nil
+	}
+	return order
+}
+
+func (s *Server) authorizeOrder(w http.ResponseWriter, id string) {
+	order := s.approvedOrder(w, id, paypal.OrderIntentAuthorize)
+	if order == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	expires := now.Add(29 * 24 * time.Hour)
+	for i := range order.PurchaseUnits {
+		pu := &order.PurchaseUnits[i]
+		if pu.Payments == nil {
+			pu.Payments = &paypal.CapturedPayments{}
+		}
+		pu.Payments.Authorizations = append(pu.Payments.Authorizations, paypal.AuthorizationAmount{
+			ID:             s.newID("AUTH"),
+			Status:         paypal.AuthorizationStatusCreated,
+			Amount:         pu.Amount,
+			CustomID:       pu.CustomID,
+			InvoiceID:      pu.InvoiceID,
+			ExpirationTime: &expires,
+			CreateTime:     &now,
+			UpdateTime:     &now,
+		})
+	}
+	order.Status = paypal.OrderStatusCompleted
+	order.UpdateTime = &now
+
+	writeJSON(w, http.StatusCreated, order)
+}
+
+func (s *Server) captureOrder(w http.ResponseWriter, id string) {
+	order := s.approvedOrder(w, id, paypal.OrderIntentCapture)
+	if order == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	for i := range order.PurchaseUnits {
+		pu := &order.PurchaseUnits[i]
+		if pu.Payments == nil {
+			pu.Payments = &paypal.CapturedPayments{}
+		}
+		pu.Payments.Captures = append(pu.Payments.Captures, paypal.CaptureAmount{
+			ID:           s.newID("CAPTURE"),
+			Status:       "COMPLETED",
+			Amount:       pu.Amount,
+			CustomID:     pu.CustomID,
+			InvoiceID:    pu.InvoiceID,
+			FinalCapture: true,
+			CreateTime:   &now,
+			UpdateTime:   &now,
+		})
+	}
+	order.Status = paypal.OrderStatusCompleted
+	order.UpdateTime = &now
+
+	writeJSON(w, http.StatusCreated, order)
+}
+
+func (s *Server) getAuthorization(w http.ResponseWriter, id string) {
+	_, auth := s.findAuthorization(id)
+	if auth == nil {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "INVALID_RESOURCE_ID", "Specified resource ID does not exist.")
+		return
+	}
+	writeJSON(w, http.StatusOK, auth)
+}
+
+func (s *Server) captureAuthorization(w http.ResponseWriter, id string, body []byte) {
+	pu, auth := s.findAuthorization(id)
+	if auth == nil {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "INVALID_RESOURCE_ID", "Specified resource ID does not exist.")
+		return
+	}
+	if auth.Status != paypal.AuthorizationStatusCreated && auth.Status != paypal.AuthorizationStatusPartiallyCaptured {
+		writeError(w, http.StatusUnprocessableEntity, paypal.ErrorNameUnprocessableEntity, "AUTHORIZATION_ALREADY_CAPTURED", "Authorization has been previously captured.")
+		return
+	}
+
+	var req paypal.PaymentCaptureRequest
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "MALFORMED_REQUEST_JSON", err.Error())
+			return
+		}
+	}
+
+	amount := auth.Amount
+	final := true
+	if req.Amount != nil {
+		amount = &paypal.PurchaseUnitAmount{Currency: req.Amount.Currency, Value: req.Amount.Value}
+		final = req.FinalCapture
+	}
+
+	now := time.Now().UTC()
+	capture := paypal.CaptureAmount{
+		ID:           s.newID("CAPTURE"),
+		Status:       "COMPLETED",
+		Amount:       amount,
+		CustomID:     auth.CustomID,
+		InvoiceID:    auth.InvoiceID,
+		FinalCapture: final,
+		CreateTime:   &now,
+		UpdateTime:   &now,
+	}
+	pu.Payments.Captures = append(pu.Payments.Captures, capture)
+
+	auth.Status = paypal.AuthorizationStatusPartiallyCaptured
+	if final {
+		auth.Status = paypal.AuthorizationStatusCaptured
+	}
+	auth.UpdateTime = &now
+
+	writeJSON(w, http.StatusCreated, capture)
+}
+
+func (s *Server) voidAuthorization(w http.ResponseWriter, id string) {
+	_, auth := s.findAuthorization(id)
+	if auth == nil {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "INVALID_RESOURCE_ID", "Specified resource ID does not exist.")
+		return
+	}
+	if auth.Status != paypal.AuthorizationStatusCreated {
+		writeError(w, http.StatusUnprocessableEntity, paypal.ErrorNameUnprocessableEntity, "CANNOT_BE_VOIDED", "A reauthorization cannot be voided. Please void the original parent authorization.")
+		return
+	}
+
+	now := time.Now().UTC()
+	auth.Status = paypal.AuthorizationStatusVoided
+	auth.UpdateTime = &now
+
+	writeJSON(w, http.StatusOK, auth)
+}
+
+func (s *Server) getCapture(w http.ResponseWriter, id string) {
+	_, capture := s.findCapture(id)
+	if capture == nil {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "INVALID_RESOURCE_ID", "Specified resource ID does not exist.")
+		return
+	}
+	writeJSON(w, http.StatusOK, capture)
+}
+
+func (s *Server) refundCapture(w http.ResponseWriter, id string, body []byte) {
+	pu, capture := s.findCapture(id)
+	if capture == nil {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "INVALID_RESOURCE_ID", "Specified resource ID does not exist.")
+		return
+	}
+	if capture.Status == "REFUNDED" {
+		writeError(w, http.StatusUnprocessableEntity, paypal.ErrorNameUnprocessableEntity, "CAPTURE_FULLY_REFUNDED", "The capture has already been fully refunded.")
+		return
+	}
+
+	var req paypal.RefundCaptureRequest
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "MALFORMED_REQUEST_JSON", err.Error())
+			return
+		}
+	}
+
+	amount := capture.Amount
+	status := "REFUNDED"
+	if req.Amount != nil {
+		amount = &paypal.PurchaseUnitAmount{Currency: req.Amount.Currency, Value: req.Amount.Value}
+		if capture.Amount == nil || req.Amount.Value != capture.Amount.Value {
+			status = "PARTIALLY_REFUNDED"
+		}
+	}
+
+	now := time.Now().UTC()
+	refund := paypal.RefundAmount{
+		ID:          s.newID("REFUND"),
+		Status:      "COMPLETED",
+		Amount:      amount,
+		InvoiceID:   req.InvoiceID,
+		NoteToPayer: req.NoteToPayer,
+		CreateTime:  &now,
+		UpdateTime:  &now,
+	}
+	pu.Payments.Refunds = append(pu.Payments.Refunds, refund)
+	capture.Status = status
+	capture.UpdateTime = &now
+
+	writeJSON(w, http.StatusCreated, paypal.RefundResponse{
+		ID:        refund.ID,
+		Amount:    refund.Amount,
+		InvoiceID: refund.InvoiceID,
+		CustomID:  req.CustomID,
+		Status:    refund.Status,
+	})
+}
+
+func (s *Server) createPayout(w http.ResponseWriter, body []byte) {
+	var req paypal.Payout
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "MALFORMED_REQUEST_JSON", err.Error())
+		return
+	}
+	if len(req.Items) == 0 {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "REQUIRED_FIELD_MISSING", "items is required.")
+		return
+	}
+	if existing := s.findPayoutBySenderBatchID(req.SenderBatchHeader); existing != nil {
+		// like PayPal, link the batch created before with the same sender_batch_id
+		writeJSON(w, http.StatusBadRequest, paypal.ErrorResponse{
+			Name:    "USER_BUSINESS_ERROR",
+			Message: "User business error.",
+			DebugID: "paypaltest",
+			Details: []paypal.ErrorResponseDetail{{
+				Field:    "SENDER_BATCH_ID",
+				Location: "body",
+				Issue:    "Batch with given sender_batch_id already exists",
+				Links: []paypal.Link{{
+					Href:   s.URL + "/v1/payments/payouts/" + existing.BatchHeader.PayoutBatchID,
+					Rel:    "self",
+					Method: http.MethodGet,
+				}},
+			}},
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	payout := &paypal.PayoutResponse{
+		BatchHeader: &paypal.BatchHeader{
+			PayoutBatchID:     s.newID("PAYOUT"),
+			BatchStatus:       "PENDING",
+			TimeCreated:       &now,
+			SenderBatchHeader: req.SenderBatchHeader,
+		},
+	}
+	for i := range req.Items {
+		item := req.Items[i]
+		payout.Items = append(payout.Items, paypal.PayoutItemResponse{
+			PayoutItemID:      s.newID("PAYOUTITEM"),
+			TransactionStatus: "PENDING",
+			PayoutBatchID:     payout.BatchHeader.PayoutBatchID,
+			PayoutItem:        &item,
+		})
+	}
+	s.payouts[payout.BatchHeader.PayoutBatchID] = payout
+
+	// like PayPal, the asynchronous create call only returns the batch header
+	writeJSON(w, http.StatusCreated, paypal.PayoutResponse{BatchHeader: payout.BatchHeader})
+}
+
+func (s *Server) getPayout(w http.ResponseWriter, id string) {
+	payout, ok := s.payouts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "INVALID_RESOURCE_ID", "", "Requested resource ID was not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, payout)
+}
+
+func (s *Server) getPayoutItem(w http.ResponseWriter, id string) {
+	item := s.findPayoutItem(id)
+	if item == nil {
+		writeError(w, http.StatusNotFound, "INVALID_RESOURCE_ID", "", "Requested resource ID was not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) cancelPayoutItem(w http.ResponseWriter, id string) {
+	item := s.findPayoutItem(id)
+	if item == nil {
+		writeError(w, http.StatusNotFound, "INVALID_RESOURCE_ID", "", "Requested resource ID was not found.")
+		return
+	}
+	if item.TransactionStatus != "UNCLAIMED" {
+		writeError(w, http.StatusBadRequest, "ITEM_CANCELLATION_FAILED", "", "Only unclaimed payout items can be cancelled.")
+		return
+	}
+
+	item.TransactionStatus = "RETURNED"
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) findAuthorization(id string) (*paypal.PurchaseUnit, *paypal.AuthorizationAmount) {
+	for _, order := range s.orders {
+		for i := range order.PurchaseUnits {
+			pu := &order.PurchaseUnits[i]
+			if pu.Payments == nil {
+				continue
+			}
+			for j := range pu.Payments.Authorizations {
+				if pu.Payments.Authorizations[j].ID == id {
+					return pu, &pu.Payments.Authorizations[j]
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) findCapture(id string) (*paypal.PurchaseUnit, *paypal.CaptureAmount) {
+	for _, order := range s.orders {
+		for i := range order.PurchaseUnits {
+			pu := &order.PurchaseUnits[i]
+			if pu.Payments == nil {
+				continue
+			}
+			for j := range pu.Payments.Captures {
+				if pu.Payments.Captures[j].ID == id {
+					return pu, &pu.Payments.Captures[j]
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) findPayoutBySenderBatchID(header *paypal.SenderBatchHeader) *paypal.PayoutResponse {
+	if header == nil || header.SenderBatchID == "" {
+		return nil
+	}
+	for _, payout := range s.payouts {
+		if h := payout.BatchHeader.SenderBatchHeader; h != nil && h.SenderBatchID == header.SenderBatchID {
+			return payout
+		}
+	}
+	return nil
+}
+
+func (s *Server) findPayoutItem(id string) *paypal.PayoutItemResponse {
+	for _, payout := range s.payouts {
+		for i := range payout.Items {
+			if payout.Items[i].PayoutItemID == id {
+				return &payout.Items[i]
+			}
+		}
+	}
+	return nil
+}
+
+// newID returns a unique resource ID, s.mu must be held
+func (s *Server) newID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-%08d", prefix, s.seq)
+}
+
+// copyOrder returns a deep copy of an order, so callers cannot modify the server state
+func copyOrder(order *paypal.Order) *paypal.Order {
+	data, _ := json.Marshal(order)
+	o := &paypal.Order{}
+	json.Unmarshal(data, o)
+	return o
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(v)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+func writeError(w http.ResponseWriter, status int, name, issue, message string) {
+	resp := paypal.ErrorResponse{
+		Name:    name,
+		Message: message,
+		DebugID: "paypaltest",
+	}
+	if issue != "" {
+		resp.Details = []paypal.ErrorResponseDetail{{Issue: issue, Description: message}}
+	}
+	w.Header().Set("Paypal-Debug-Id", resp.DebugID)
+	writeJSON(w, status, resp)
+}
@@ -0,0 +1,213 @@
+package paypaltest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/optiopay/paypal/v4"
+)
+
+func TestServerOrderFlow(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := srv.Client()
+
+	units := []paypal.PurchaseUnitRequest{{
+		ReferenceID: "ref-1",
+		Amount:      &paypal.PurchaseUnitAmount{Currency: "EUR", Value: "7.00"},
+	}}
+	order, err := c.CreateOrder(ctx, paypal.OrderIntentCapture, units, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID == "" || order.Status != paypal.OrderStatusCreated {
+		t.Fatalf("unexpected order %+v", order)
+	}
+
+	_, err = c.CaptureOrder(ctx, order.ID, paypal.CaptureOrderRequest{})
+	if !errors.Is(err, paypal.ErrOrderNotApproved) {
+		t.Fatalf("expected ErrOrderNotApproved, got %v", err)
+	}
+
+	if err := srv.ApproveOrder(order.ID); err != nil {
+		t.Fatal(err)
+	}
+	captured, err := c.CaptureOrder(ctx, order.ID, paypal.CaptureOrderRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if captured.Status != paypal.OrderStatusCompleted {
+		t.Errorf("expected COMPLETED, got %s", captured.Status)
+	}
+	captureID := captured.PurchaseUnits[0].Payments.Captures[0].ID
+
+	_, err = c.CaptureOrder(ctx, order.ID, paypal.CaptureOrderRequest{})
+	if !errors.Is(err, paypal.ErrOrderAlreadyCaptured) {
+		t.Errorf("expected ErrOrderAlreadyCaptured, got %v", err)
+	}
+
+	refund, err := c.RefundCapture(ctx, captureID, paypal.RefundCaptureRequest{
+		Amount: &paypal.Money{Currency: "EUR", Value: "2.00"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.Amount.Value != "2.00" {
+		t.Errorf("expected refund of 2.00, got %s", refund.Amount.Value)
+	}
+
+	stored, ok := srv.Order(order.ID)
+	if !ok {
+		t.Fatal("order not stored")
+	}
+	if got := stored.PurchaseUnits[0].Payments.Captures[0].Status; got != "PARTIALLY_REFUNDED" {
+		t.Errorf("expected PARTIALLY_REFUNDED capture, got %s", got)
+	}
+
+	req := srv.AssertRequested(t, http.MethodPost, "/v2/payments/captures/"+captureID+"/refund")
+	var body paypal.RefundCaptureRequest
+	if err := req.Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Amount == nil || body.Amount.Value != "2.00" {
+		t.Errorf("unexpected refund request %+v", body)
+	}
+	if len(srv.RequestsTo(http.MethodPost, "/v1/oauth2/token")) != 1 {
+		t.Errorf("expected the token to be requested once")
+	}
+}
+
+func TestServerAuthorizeFlow(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := srv.Client()
+
+	seeded := srv.SeedOrder(paypal.Order{
+		Intent: paypal.OrderIntentAuthorize,
+		Status: paypal.OrderStatusApproved,
+		PurchaseUnits: []paypal.PurchaseUnit{{
+			ReferenceID: "seller-1",
+			Amount:      &paypal.PurchaseUnitAmount{Currency: "USD", Value: "10.00"},
+		}},
+	})
+
+	if _, err := c.AuthorizeOrder(ctx, seeded.ID, paypal.AuthorizeOrderRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	capture, err := c.CapturePurchaseUnit(ctx, seeded.ID, "seller-1", &paypal.PaymentCaptureRequest{
+		Amount: &paypal.Money{Currency: "USD", Value: "4.00"},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capture.Amount.Value != "4.00" || capture.Status != "COMPLETED" {
+		t.Errorf("unexpected capture %+v", capture)
+	}
+
+	order, err := c.GetOrder(ctx, seeded.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := order.PurchaseUnits[0].Payments.Authorizations[0]
+	if auth.Status != paypal.AuthorizationStatusPartiallyCaptured {
+		t.Errorf("expected PARTIALLY_CAPTURED authorization, got %s", auth.Status)
+	}
+
+	if _, err := c.GetOrder(ctx, "missing"); !errors.Is(err, paypal.ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+}
+
+func TestServerPayouts(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := srv.Client()
+
+	resp, err := c.CreatePayout(ctx, paypal.Payout{
+		SenderBatchHeader: &paypal.SenderBatchHeader{EmailSubject: "You got paid"},
+		Items: []paypal.PayoutItem{{
+			RecipientType: "EMAIL",
+			Receiver:      "buyer@example.com",
+			Amount:        &paypal.AmountPayout{Currency: "USD", Value: "5.00"},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payout, err := c.GetPayout(ctx, resp.BatchHeader.PayoutBatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payout.Items) != 1 {
+		t.Fatalf("expected 1 payout item, got %d", len(payout.Items))
+	}
+	itemID := payout.Items[0].PayoutItemID
+
+	if _, err := c.CancelPayoutItem(ctx, itemID); err == nil {
+		t.Error("expected cancelling a pending item to fail")
+	}
+
+	if err := srv.SetPayoutItemStatus(itemID, "UNCLAIMED"); err != nil {
+		t.Fatal(err)
+	}
+	item, err := c.CancelPayoutItem(ctx, itemID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.TransactionStatus != "RETURNED" {
+		t.Errorf("expected RETURNED, got %s", item.TransactionStatus)
+	}
+}
+
+func TestServerPayoutDeduplication(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := srv.Client()
+
+	payout := paypal.Payout{
+		SenderBatchHeader: &paypal.SenderBatchHeader{EmailSubject: "You got paid"},
+		Items: []paypal.PayoutItem{{
+			RecipientType: "EMAIL",
+			Receiver:      "buyer@example.com",
+			Amount:        &paypal.AmountPayout{Currency: "USD", Value: "5.00"},
+		}},
+	}
+	first, err := c.CreatePayoutOnce(ctx, "payroll-2021-03", payout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payout.SenderBatchHeader.SenderBatchID != "" || payout.Items[0].SenderItemID != "" {
+		t.Error("expected the payout of the caller to be left unchanged")
+	}
+
+	retried, err := c.CreatePayoutOnce(ctx, "payroll-2021-03", payout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retried.BatchHeader.PayoutBatchID != first.BatchHeader.PayoutBatchID || len(retried.Items) != 1 {
+		t.Errorf("expected the existing batch %s, got %+v", first.BatchHeader.PayoutBatchID, retried.BatchHeader)
+	}
+	if id := retried.Items[0].PayoutItem.SenderItemID; id != paypal.SenderItemID("payroll-2021-03/0") {
+		t.Errorf("unexpected sender_item_id %q", id)
+	}
+
+	payout.SenderBatchHeader.SenderBatchID = paypal.SenderBatchID("payroll-2021-03")
+	if _, err := c.CreatePayout(ctx, payout); !errors.Is(err, paypal.ErrPayoutBatchExists) {
+		t.Errorf("expected ErrPayoutBatchExists, got %v", err)
+	}
+	if len(srv.RequestsTo(http.MethodPost, "/v1/payments/payouts")) != 3 {
+		t.Errorf("unexpected requests %v", srv.Requests())
+	}
+}
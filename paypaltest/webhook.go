@@ -0,0 +1,457 @@
+package paypaltest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/optiopay/paypal/v4"
+)
+
+// EventFixture is a webhook event payload for handler tests, built by NewEventFixture
+type EventFixture struct {
+	ID           string
+	EventType    string
+	ResourceType string
+	Summary      string
+	CreateTime   time.Time
+	// Resource is the resource of the event as decoded from JSON, set its
+	// fields with Set
+	Resource map[string]interface{}
+}
+
+// Resource types and resource templates of the events, by event type prefix;
+// the templates take the status the event implies
+var eventResources = []struct {
+	prefix       string
+	resourceType string
+	resource     func(status string) map[string]interface{}
+}{
+	{"PAYMENT.CAPTURE.REFUNDED", paypal.ResourceTypeRefund, refundResource},
+	{"PAYMENT.CAPTURE.REVERSED", paypal.ResourceTypeRefund, refundResource},
+	{"PAYMENT.CAPTURE.", paypal.ResourceTypeCapture, captureResource},
+	{"PAYMENT.AUTHORIZATION.", paypal.ResourceTypeAuthorization, authorizationResource},
+	{"CHECKOUT.ORDER.", paypal.ResourceTypeCheckoutOrder, orderResource},
+	{"BILLING.SUBSCRIPTION.", paypal.ResourceTypeSubscription, subscriptionResource},
+	{"CUSTOMER.DISPUTE.", paypal.ResourceTypeDispute, disputeResource},
+	{"PAYMENT.PAYOUTSBATCH.", paypal.ResourceTypePayouts, payoutsResource},
+	{"PAYMENT.PAYOUTS-ITEM.", paypal.ResourceTypePayoutsItem, payoutsItemResource},
+}
+
+// Statuses of the resources that differ from the last part of the event type
+var eventStatuses = map[string]string{
+	paypal.EventPaymentCaptureDenied:      "DECLINED",
+	paypal.EventPaymentCaptureRefunded:    "COMPLETED",
+	paypal.EventPaymentCaptureReversed:    "COMPLETED",
+	"BILLING.SUBSCRIPTION.CREATED":        "APPROVAL_PENDING",
+	"BILLING.SUBSCRIPTION.ACTIVATED":      "ACTIVE",
+	"BILLING.SUBSCRIPTION.UPDATED":        "ACTIVE",
+	"BILLING.SUBSCRIPTION.RE-ACTIVATED":   "ACTIVE",
+	"BILLING.SUBSCRIPTION.PAYMENT.FAILED": "ACTIVE",
+	"CUSTOMER.DISPUTE.CREATED":            "OPEN",
+	"CUSTOMER.DISPUTE.UPDATED":            "UNDER_REVIEW",
+	"PAYMENT.PAYOUTS-ITEM.SUCCEEDED":      "SUCCESS",
+	"PAYMENT.PAYOUTS-ITEM.HELD":           "ONHOLD",
+	"PAYMENT.PAYOUTS-ITEM.CANCELED":       "RETURNED",
+}
+
+// NewEventFixture returns an event of eventType with a realistic resource for
+// captures, refunds, authorizations, orders, subscriptions, disputes and
+// payouts, e.g. PAYMENT.CAPTURE.COMPLETED or BILLING.SUBSCRIPTION.CANCELLED.
+// Other event types get an empty resource.
+//
+//	body := paypaltest.NewEventFixture(paypal.EventPaymentCaptureCompleted).
+//		Set("amount.value", "99.00").
+//		JSON()
+func NewEventFixture(eventType string) *EventFixture {
+	e := &EventFixture{
+		ID:         "WH-" + randomID(),
+		EventType:  eventType,
+		Summary:    "Test " + eventType + " event",
+		CreateTime: time.Now().UTC().Truncate(time.Second),
+		Resource:   map[string]interface{}{},
+	}
+
+	status, ok := eventStatuses[eventType]
+	if !ok {
+		status = eventType[strings.LastIndex(eventType, ".")+1:]
+	}
+	for _, r := range eventResources {
+		if strings.HasPrefix(eventType, r.prefix) {
+			e.ResourceType = r.resourceType
+			e.Resource = r.resource(status)
+			break
+		}
+	}
+	return e
+}
+
+// Set sets a field of the resource, path separates the keys of nested objects
+// with dots, e.g. "amount.value" or "purchase_units" for a whole array
+func (e *EventFixture) Set(path string, value interface{}) *EventFixture {
+	keys := strings.Split(path, ".")
+	m := e.Resource
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := m[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			m[key] = child
+		}
+		m = child
+	}
+	m[keys[len(keys)-1]] = value
+	return e
+}
+
+// JSON returns the payload PayPal would post to the webhook
+func (e *EventFixture) JSON() []byte {
+	data, err := json.Marshal(map[string]interface{}{
+		"id":               e.ID,
+		"event_version":    "1.0",
+		"create_time":      e.CreateTime.Format(time.RFC3339),
+		"resource_type":    e.ResourceType,
+		"resource_version": "2.0",
+		"event_type":       e.EventType,
+		"summary":          e.Summary,
+		"resource":         e.Resource,
+		"links": []paypal.Link{
+			{Href: paypal.APIBaseSandBox + "/v1/notifications/webhooks-events/" + e.ID, Rel: "self", Method: http.MethodGet},
+			{Href: paypal.APIBaseSandBox + "/v1/notifications/webhooks-events/" + e.ID + "/resend", Rel: "resend", Method: http.MethodPost},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// AnyEvent returns the event decoded like a received notification
+func (e *EventFixture) AnyEvent() *paypal.AnyEvent {
+	event := &paypal.AnyEvent{}
+	if err := json.Unmarshal(e.JSON(), event); err != nil {
+		panic(err)
+	}
+	return event
+}
+
+func money(value string) map[string]interface{} {
+	return map[string]interface{}{"currency_code": "USD", "value": value}
+}
+
+func timestamp() string {
+	return time.Now().UTC().Truncate(time.Second).Format(time.RFC3339)
+}
+
+func apiLink(path, rel, method string) map[string]interface{} {
+	return map[string]interface{}{"href": paypal.APIBaseSandBox + path, "rel": rel, "method": method}
+}
+
+func captureResource(status string) map[string]interface{} {
+	id := randomID()
+	return map[string]interface{}{
+		"id":            id,
+		"status":        status,
+		"amount":        money("10.00"),
+		"final_capture": true,
+		"seller_protection": map[string]interface{}{
+			"status":             "ELIGIBLE",
+			"dispute_categories": []string{"ITEM_NOT_RECEIVED", "UNAUTHORIZED_TRANSACTION"},
+		},
+		"seller_receivable_breakdown": map[string]interface{}{
+			"gross_amount": money("10.00"),
+			"paypal_fee":   money("0.64"),
+			"net_amount":   money("9.36"),
+		},
+		"invoice_id":  "INV-" + id,
+		"create_time": timestamp(),
+		"update_time": timestamp(),
+		"links": []interface{}{
+			apiLink("/v2/payments/captures/"+id, "self", http.MethodGet),
+			apiLink("/v2/payments/captures/"+id+"/refund", "refund", http.MethodPost),
+		},
+	}
+}
+
+func refundResource(status string) map[string]interface{} {
+	id := randomID()
+	return map[string]interface{}{
+		"id":     id,
+		"status": status,
+		"amount": money("10.00"),
+		"seller_payable_breakdown": map[string]interface{}{
+			"gross_amount":          money("10.00"),
+			"paypal_fee":            money("0.34"),
+			"net_amount":            money("9.66"),
+			"total_refunded_amount": money("10.00"),
+		},
+		"create_time": timestamp(),
+		"update_time": timestamp(),
+		"links": []interface{}{
+			apiLink("/v2/payments/refunds/"+id, "self", http.MethodGet),
+			apiLink("/v2/payments/captures/"+randomID(), "up", http.MethodGet),
+		},
+	}
+}
+
+func authorizationResource(status string) map[string]interface{} {
+	id := randomID()
+	return map[string]interface{}{
+		"id":              id,
+		"status":          status,
+		"amount":          money("10.00"),
+		"expiration_time": time.Now().UTC().Add(29 * 24 * time.Hour).Truncate(time.Second).Format(time.RFC3339),
+		"create_time":     timestamp(),
+		"update_time":     timestamp(),
+		"links": []interface{}{
+			apiLink("/v2/payments/authorizations/"+id, "self", http.MethodGet),
+			apiLink("/v2/payments/authorizations/"+id+"/capture", "capture", http.MethodPost),
+			apiLink("/v2/payments/authorizations/"+id+"/void", "void", http.MethodPost),
+		},
+	}
+}
+
+func orderResource(status string) map[string]interface{} {
+	id := randomID()
+	return map[string]interface{}{
+		"id":     id,
+		"intent": paypal.OrderIntentCapture,
+		"status": status,
+		"purchase_units": []interface{}{map[string]interface{}{
+			"reference_id": "default",
+			"amount":       money("10.00"),
+			"payee":        map[string]interface{}{"email_address": "merchant@example.com", "merchant_id": "MERCHANT-ID"},
+		}},
+		"payer": map[string]interface{}{
+			"name":          map[string]interface{}{"given_name": "John", "surname": "Doe"},
+			"email_address": "buyer@example.com",
+			"payer_id":      "PAYER-ID",
+		},
+		"create_time": timestamp(),
+		"links": []interface{}{
+			apiLink("/v2/checkout/orders/"+id, "self", http.MethodGet),
+			apiLink("/v2/checkout/orders/"+id+"/capture", "capture", http.MethodPost),
+		},
+	}
+}
+
+func subscriptionResource(status string) map[string]interface{} {
+	id := "I-" + randomID()
+	return map[string]interface{}{
+		"id":         id,
+		"plan_id":    "P-" + randomID(),
+		"status":     status,
+		"quantity":   "1",
+		"start_time": timestamp(),
+		"subscriber": map[string]interface{}{
+			"name":          map[string]interface{}{"given_name": "John", "surname": "Doe"},
+			"email_address": "buyer@example.com",
+			"payer_id":      "PAYER-ID",
+		},
+		"billing_info": map[string]interface{}{
+			"outstanding_balance":   money("0.00"),
+			"failed_payments_count": 0,
+			"next_billing_time":     time.Now().UTC().Add(30 * 24 * time.Hour).Truncate(time.Second).Format(time.RFC3339),
+		},
+		"create_time": timestamp(),
+		"update_time": timestamp(),
+		"links": []interface{}{
+			apiLink("/v1/billing/subscriptions/"+id, "self", http.MethodGet),
+		},
+	}
+}
+
+func disputeResource(status string) map[string]interface{} {
+	id := "PP-D-" + randomID()
+	return map[string]interface{}{
+		"dispute_id":               id,
+		"reason":                   "MERCHANDISE_OR_SERVICE_NOT_RECEIVED",
+		"status":                   status,
+		"dispute_amount":           money("10.00"),
+		"dispute_life_cycle_stage": "INQUIRY",
+		"dispute_channel":          "INTERNAL",
+		"disputed_transactions": []interface{}{map[string]interface{}{
+			"seller_transaction_id": randomID(),
+			"buyer_transaction_id":  randomID(),
+			"transaction_status":    "COMPLETED",
+			"gross_amount":          money("10.00"),
+		}},
+		"seller_response_due_date": time.Now().UTC().Add(10 * 24 * time.Hour).Truncate(time.Second).Format(time.RFC3339),
+		"create_time":              timestamp(),
+		"update_time":              timestamp(),
+		"links": []interface{}{
+			apiLink("/v1/customer/disputes/"+id, "self", http.MethodGet),
+		},
+	}
+}
+
+func payoutsResource(status string) map[string]interface{} {
+	id := randomID()
+	return map[string]interface{}{
+		"batch_header": map[string]interface{}{
+			"payout_batch_id": id,
+			"batch_status":    status,
+			"time_created":    timestamp(),
+			"sender_batch_header": map[string]interface{}{
+				"sender_batch_id": "BATCH-" + id,
+			},
+			"amount": money("10.00"),
+			"fees":   money("0.25"),
+		},
+		"links": []interface{}{
+			apiLink("/v1/payments/payouts/"+id, "self", http.MethodGet),
+		},
+	}
+}
+
+func payoutsItemResource(status string) map[string]interface{} {
+	id := randomID()
+	return map[string]interface{}{
+		"payout_item_id":     id,
+		"transaction_id":     randomID(),
+		"transaction_status": status,
+		"payout_batch_id":    randomID(),
+		"payout_item_fee":    money("0.25"),
+		"payout_item": map[string]interface{}{
+			"recipient_type": paypal.EmailRecipientType,
+			"amount":         money("10.00"),
+			"receiver":       "receiver@example.com",
+		},
+		"time_processed": timestamp(),
+		"links": []interface{}{
+			apiLink("/v1/payments/payouts-item/"+id, "self", http.MethodGet),
+		},
+	}
+}
+
+// randomID returns an uppercase ID like the ones of PayPal resources
+func randomID() string {
+	n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 80))
+	if err != nil {
+		panic(err)
+	}
+	return strings.ToUpper(fmt.Sprintf("%017s", n.Text(36)))
+}
+
+// WebhookSigner signs webhook events like PayPal, with a signing certificate
+// issued by its own root and served by a local TLS server, so handlers can
+// verify them locally with the WebhookVerifier returned by Verifier.
+type WebhookSigner struct {
+	WebhookID string
+
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	roots  *x509.CertPool
+}
+
+// NewWebhookSigner returns a WebhookSigner for the webhook ID. Call Close when done.
+func NewWebhookSigner(webhookID string) *WebhookSigner {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "paypaltest root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		panic(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		panic(err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "messageverificationcerts.sandbox.paypal.com"},
+		DNSNames:     []string{"messageverificationcerts.sandbox.paypal.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, ca, &key.PublicKey, caKey)
+	if err != nil {
+		panic(err)
+	}
+
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+	chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})...)
+
+	s := &WebhookSigner{WebhookID: webhookID, key: key, roots: x509.NewCertPool()}
+	s.roots.AddCert(ca)
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(chain)
+	}))
+	return s
+}
+
+// Close stops the server of the signing certificate
+func (s *WebhookSigner) Close() {
+	s.server.Close()
+}
+
+// Sign returns the PAYPAL-* headers PayPal sends with the body
+func (s *WebhookSigner) Sign(body []byte) http.Header {
+	transmissionID := randomID()
+	transmissionTime := time.Now().UTC().Format(time.RFC3339)
+	message := transmissionID + "|" + transmissionTime + "|" + s.WebhookID + "|" + strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10)
+	digest := sha256.Sum256([]byte(message))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		panic(err)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("PAYPAL-AUTH-ALGO", "SHA256withRSA")
+	header.Set("PAYPAL-CERT-URL", s.server.URL+"/v1/notifications/certs/CERT-paypaltest")
+	header.Set("PAYPAL-TRANSMISSION-ID", transmissionID)
+	header.Set("PAYPAL-TRANSMISSION-SIG", base64.StdEncoding.EncodeToString(signature))
+	header.Set("PAYPAL-TRANSMISSION-TIME", transmissionTime)
+	return header
+}
+
+// NewRequest returns a signed webhook notification of the event posted to target,
+// to pass to the ServeHTTP method of a handler
+func (s *WebhookSigner) NewRequest(target string, event *EventFixture) *http.Request {
+	body := event.JSON()
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	req.Header = s.Sign(body)
+	return req
+}
+
+// Verifier returns a WebhookVerifier accepting the signatures of the signer,
+// e.g. for the VerifyLocally method of a WebhookHandler or EventRouter
+func (s *WebhookSigner) Verifier() *paypal.WebhookVerifier {
+	u, _ := url.Parse(s.server.URL)
+	return paypal.NewWebhookVerifier(s.WebhookID,
+		paypal.WithVerifierHTTPClient(s.server.Client()),
+		paypal.WithVerifierRoots(s.roots),
+		paypal.WithVerifierCertHosts(u.Hostname()),
+	)
+}
@@ -0,0 +1,113 @@
+package paypaltest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/optiopay/paypal/v4"
+)
+
+func TestEventFixtureResources(t *testing.T) {
+	tests := []struct {
+		eventType string
+		check     func(resource interface{}) bool
+	}{
+		{paypal.EventPaymentCaptureCompleted, func(r interface{}) bool {
+			c, ok := r.(*paypal.CaptureDetailsResponse)
+			return ok && c.ID != "" && c.Status == "COMPLETED" && c.Amount.Value == "10.00"
+		}},
+		{paypal.EventPaymentCaptureDenied, func(r interface{}) bool {
+			c, ok := r.(*paypal.CaptureDetailsResponse)
+			return ok && c.Status == "DECLINED"
+		}},
+		{paypal.EventPaymentCaptureRefunded, func(r interface{}) bool {
+			refund, ok := r.(*paypal.RefundResponse)
+			return ok && refund.ID != "" && refund.Status == "COMPLETED"
+		}},
+		{"PAYMENT.AUTHORIZATION.CREATED", func(r interface{}) bool {
+			a, ok := r.(*paypal.Authorization)
+			return ok && a.ID != "" && a.Status == "CREATED"
+		}},
+		{"CHECKOUT.ORDER.APPROVED", func(r interface{}) bool {
+			o, ok := r.(*paypal.Order)
+			return ok && o.ID != "" && o.Status == "APPROVED" && len(o.PurchaseUnits) == 1
+		}},
+		{"BILLING.SUBSCRIPTION.CANCELLED", func(r interface{}) bool {
+			s, ok := r.(*paypal.Subscription)
+			return ok && s.ID != "" && s.SubscriptionStatus == "CANCELLED"
+		}},
+		{"CUSTOMER.DISPUTE.CREATED", func(r interface{}) bool {
+			d, ok := r.(*paypal.Dispute)
+			return ok && d.ID != "" && d.Status == "OPEN"
+		}},
+		{"PAYMENT.PAYOUTSBATCH.SUCCESS", func(r interface{}) bool {
+			p, ok := r.(*paypal.PayoutResponse)
+			return ok && paypal.PayoutBatchDone(p)
+		}},
+		{"PAYMENT.PAYOUTS-ITEM.SUCCEEDED", func(r interface{}) bool {
+			p, ok := r.(*paypal.PayoutItemResponse)
+			return ok && p.PayoutItemID != "" && p.TransactionStatus == "SUCCESS"
+		}},
+	}
+
+	for _, tt := range tests {
+		resource, err := NewEventFixture(tt.eventType).AnyEvent().DecodeResource()
+		if err != nil {
+			t.Errorf("%s: %v", tt.eventType, err)
+			continue
+		}
+		if !tt.check(resource) {
+			t.Errorf("%s: unexpected resource %+v", tt.eventType, resource)
+		}
+	}
+}
+
+func TestEventFixtureSet(t *testing.T) {
+	event := NewEventFixture(paypal.EventPaymentCaptureCompleted).
+		Set("id", "CAPTURE-1").
+		Set("amount.value", "99.00").
+		Set("custom_id", "order-42").
+		AnyEvent()
+
+	resource, err := event.DecodeResource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	capture := resource.(*paypal.CaptureDetailsResponse)
+	if capture.ID != "CAPTURE-1" || capture.Amount.Value != "99.00" || capture.Amount.Currency != "USD" || capture.CustomID != "order-42" {
+		t.Errorf("unexpected capture %+v", capture)
+	}
+}
+
+func TestWebhookSigner(t *testing.T) {
+	signer := NewWebhookSigner("WH-ID")
+	defer signer.Close()
+
+	var received *paypal.WebhookEvent
+	router := paypal.NewEventRouter(nil, signer.WebhookID)
+	router.VerifyLocally(signer.Verifier())
+	router.On(paypal.EventPaymentCaptureCompleted, func(ctx context.Context, event *paypal.WebhookEvent) error {
+		received = event
+		return nil
+	})
+
+	fixture := NewEventFixture(paypal.EventPaymentCaptureCompleted)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, signer.NewRequest("/webhook", fixture))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if received == nil || received.ID != fixture.ID {
+		t.Errorf("expected event %s, got %+v", fixture.ID, received)
+	}
+
+	req := signer.NewRequest("/webhook", fixture)
+	req.Header.Set("PAYPAL-TRANSMISSION-ID", "tampered")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a tampered signature, got %d", rec.Code)
+	}
+}
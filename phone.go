@@ -0,0 +1,171 @@
+package paypal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Phone is a phone number split the way PayPal expects it. It decodes both
+// the object form and the plain string form some APIs use.
+// Payer phones of orders are a PhoneWithType, converted with its Phone method
+// and NewPhoneWithType. Phone fields that were strings before Phone existed,
+// such as PayerInfo.Phone and UserInfo.Phone, are kept as strings so that code
+// using them still compiles, parse them with ParsePhone.
+// Doc: https://developer.paypal.com/docs/api/invoicing/v2/#definition-phone_detail
+type Phone struct {
+	CountryCode     string `json:"country_code,omitempty"`
+	NationalNumber  string `json:"national_number"`
+	ExtensionNumber string `json:"extension_number,omitempty"`
+	PhoneType       string `json:"phone_type,omitempty"`
+}
+
+// ParsePhone parses a phone number such as "+1 (408) 555-0123" or "408 555 0123".
+// Numbers without a leading + or 00 get the defaultCountryCode, which may be empty.
+func ParsePhone(number, defaultCountryCode string) (*Phone, error) {
+	number = strings.TrimSpace(number)
+	international := strings.HasPrefix(number, "+") || strings.HasPrefix(number, "00")
+	if strings.HasPrefix(number, "00") {
+		number = number[2:]
+	}
+
+	var extension string
+	for _, sep := range []string{" ext. ", " ext ", " x", ";ext="} {
+		if i := strings.Index(strings.ToLower(number), sep); i >= 0 {
+			extension = digits(number[i+len(sep):])
+			number = number[:i]
+			break
+		}
+	}
+
+	national := digits(number)
+	if national == "" {
+		return nil, fmt.Errorf("paypal: invalid phone number %q", number)
+	}
+
+	phone := &Phone{ExtensionNumber: extension}
+	if international {
+		code, ok := splitCountryCode(national)
+		if !ok {
+			return nil, fmt.Errorf("paypal: unknown country calling code in %q", number)
+		}
+		phone.CountryCode, national = code, national[len(code):]
+	} else {
+		phone.CountryCode = digits(defaultCountryCode)
+		// Trunk prefix of national formats, e.g. 030 in Germany
+		if phone.CountryCode != "1" {
+			national = strings.TrimPrefix(national, "0")
+		}
+	}
+
+	phone.NationalNumber = national
+	return phone, phone.Validate()
+}
+
+// Validate checks the lengths PayPal accepts, E.164 allows at most 15 digits
+func (p Phone) Validate() error {
+	if len(p.CountryCode) > 3 {
+		return fmt.Errorf("paypal: phone country_code must be at most 3 digits, got %q", p.CountryCode)
+	}
+	if p.NationalNumber == "" || len(p.NationalNumber)+len(p.CountryCode) > 15 {
+		return fmt.Errorf("paypal: phone national_number must have 1 to %d digits, got %q", 15-len(p.CountryCode), p.NationalNumber)
+	}
+	if digits(p.NationalNumber) != p.NationalNumber || digits(p.CountryCode) != p.CountryCode {
+		return errors.New("paypal: phone numbers must only contain digits")
+	}
+	return nil
+}
+
+// E164 returns the number in E.164 format, e.g. +14085550123.
+// Without country code only the national number is returned.
+func (p Phone) E164() string {
+	if p.CountryCode == "" {
+		return p.NationalNumber
+	}
+	return "+" + p.CountryCode + p.NationalNumber
+}
+
+// String returns the number in E.164 format with its extension
+func (p Phone) String() string {
+	if p.ExtensionNumber != "" {
+		return p.E164() + " ext. " + p.ExtensionNumber
+	}
+	return p.E164()
+}
+
+// UnmarshalJSON decodes the object form and the string form of phone numbers
+func (p *Phone) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		parsed, err := ParsePhone(s, "")
+		if err != nil {
+			// Keep what PayPal sent rather than failing the whole response
+			*p = Phone{NationalNumber: digits(s)}
+			return nil
+		}
+		*p = *parsed
+		return nil
+	}
+
+	type phone Phone
+	return json.Unmarshal(b, (*phone)(p))
+}
+
+// Phone returns the number as a Phone with the given country calling code
+func (n *PhoneWithTypeNumber) Phone(countryCode string) Phone {
+	return Phone{CountryCode: digits(countryCode), NationalNumber: digits(n.NationalNumber)}
+}
+
+// Phone returns the payer phone as a Phone with the given country calling
+// code, PayPal doesn't return the country code of payer phones
+func (p *PhoneWithType) Phone(countryCode string) Phone {
+	var phone Phone
+	if p.PhoneNumber != nil {
+		phone = p.PhoneNumber.Phone(countryCode)
+	}
+	phone.PhoneType = p.PhoneType
+	return phone
+}
+
+// NewPhoneWithType returns the payer phone of an order for a Phone, e.g. one
+// returned by ParsePhone. PayPal takes the national number without country code.
+func NewPhoneWithType(phone Phone) *PhoneWithType {
+	return &PhoneWithType{
+		PhoneType:   phone.PhoneType,
+		PhoneNumber: &PhoneWithTypeNumber{NationalNumber: phone.NationalNumber},
+	}
+}
+
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// countryCallingCodes are the 1 and 2 digit calling codes, all others have 3 digits
+var countryCallingCodes = map[string]bool{
+	"1": true, "7": true,
+	"20": true, "27": true, "30": true, "31": true, "32": true, "33": true, "34": true, "36": true,
+	"39": true, "40": true, "41": true, "43": true, "44": true, "45": true, "46": true, "47": true,
+	"48": true, "49": true, "51": true, "52": true, "53": true, "54": true, "55": true, "56": true,
+	"57": true, "58": true, "60": true, "61": true, "62": true, "63": true, "64": true, "65": true,
+	"66": true, "81": true, "82": true, "84": true, "86": true, "90": true, "91": true, "92": true,
+	"93": true, "94": true, "95": true, "98": true,
+}
+
+// splitCountryCode returns the country calling code at the start of an international number
+func splitCountryCode(number string) (string, bool) {
+	for n := 1; n <= 2 && n < len(number); n++ {
+		if countryCallingCodes[number[:n]] {
+			return number[:n], true
+		}
+	}
+	if len(number) > 3 {
+		return number[:3], true
+	}
+	return "", false
+}
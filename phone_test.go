@@ -0,0 +1,73 @@
+package paypal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		number, defaultCountry string
+		expected               Phone
+	}{
+		{"+1 (408) 555-0123", "", Phone{CountryCode: "1", NationalNumber: "4085550123"}},
+		{"408 555 0123", "1", Phone{CountryCode: "1", NationalNumber: "4085550123"}},
+		{"0049 30 1234567", "", Phone{CountryCode: "49", NationalNumber: "301234567"}},
+		{"030 1234567", "49", Phone{CountryCode: "49", NationalNumber: "301234567"}},
+		{"+353 1 234 5678 ext. 42", "", Phone{CountryCode: "353", NationalNumber: "12345678", ExtensionNumber: "42"}},
+	}
+	for _, tt := range tests {
+		p, err := ParsePhone(tt.number, tt.defaultCountry)
+		if err != nil {
+			t.Errorf("%s: %v", tt.number, err)
+			continue
+		}
+		if *p != tt.expected {
+			t.Errorf("%s: expecting %+v, got %+v", tt.number, tt.expected, *p)
+		}
+	}
+
+	if p, _ := ParsePhone("+1 408 555 0123", ""); p.E164() != "+14085550123" {
+		t.Errorf("expecting E.164 +14085550123, got %s", p.E164())
+	}
+	if _, err := ParsePhone("call me", "1"); err == nil {
+		t.Errorf("expecting an error for a number without digits")
+	}
+	if _, err := ParsePhone("+1 4085550123456789", ""); err == nil {
+		t.Errorf("expecting an error for a number longer than E.164 allows")
+	}
+}
+
+func TestPhoneUnmarshalJSON(t *testing.T) {
+	var phones []Phone
+	data := []byte(`[{"country_code":"1","national_number":"4085550123","phone_type":"MOBILE"},"+44 20 7946 0958"]`)
+	if err := json.Unmarshal(data, &phones); err != nil {
+		t.Fatal(err)
+	}
+	if phones[0].E164() != "+14085550123" || phones[0].PhoneType != "MOBILE" {
+		t.Errorf("unexpected object phone %+v", phones[0])
+	}
+	if phones[1].CountryCode != "44" || phones[1].NationalNumber != "2079460958" {
+		t.Errorf("unexpected string phone %+v", phones[1])
+	}
+}
+
+func TestPhoneWithType(t *testing.T) {
+	phone, err := ParsePhone("+1 (408) 555-0123", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	phone.PhoneType = "MOBILE"
+
+	payer := CreateOrderPayer{Phone: NewPhoneWithType(*phone)}
+	data, _ := json.Marshal(payer.Phone)
+	if string(data) != `{"phone_type":"MOBILE","phone_number":{"national_number":"4085550123"}}` {
+		t.Errorf("unexpected payer phone %s", data)
+	}
+	if got := payer.Phone.Phone("1"); got != *phone {
+		t.Errorf("expecting %+v, got %+v", *phone, got)
+	}
+	if got := (&PhoneWithType{PhoneType: "HOME"}).Phone("1"); got.PhoneType != "HOME" || got.NationalNumber != "" {
+		t.Errorf("unexpected phone without number %+v", got)
+	}
+}
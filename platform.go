@@ -0,0 +1,144 @@
+package paypal
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// authAssertionKey is the context key of the auth assertion set with
+// WithSellerMerchantID or WithSellerEmail
+type authAssertionKey struct{}
+
+// sellerIdentity identifies the seller a platform acts on behalf of,
+// claim is either payer_id or email
+type sellerIdentity struct {
+	claim string
+	value string
+}
+
+// AuthAssertion returns the PayPal-Auth-Assertion header value a platform sends
+// to act on behalf of the seller with the given merchant ID. It is an unsigned
+// JWT with the client ID as issuer.
+// Doc: https://developer.paypal.com/docs/api/reference/api-requests/#paypal-auth-assertion
+func (c *Client) AuthAssertion(sellerMerchantID string) string {
+	return c.authAssertion(sellerIdentity{claim: "payer_id", value: sellerMerchantID})
+}
+
+// AuthAssertionForEmail returns the PayPal-Auth-Assertion header value a platform
+// sends to act on behalf of the seller with the given PayPal account email
+func (c *Client) AuthAssertionForEmail(sellerEmail string) string {
+	return c.authAssertion(sellerIdentity{claim: "email", value: sellerEmail})
+}
+
+func (c *Client) authAssertion(seller sellerIdentity) string {
+	header, _ := json.Marshal(map[string]string{"alg": "none"})
+	payload, _ := json.Marshal(map[string]string{
+		"iss":        c.ClientID,
+		seller.claim: seller.value,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+}
+
+// SetAuthAssertion makes every API request act on behalf of the seller with the
+// given merchant ID by sending the PayPal-Auth-Assertion header.
+// Pass an empty ID to stop sending it.
+func (c *Client) SetAuthAssertion(sellerMerchantID string) {
+	c.setSeller(sellerIdentity{claim: "payer_id", value: sellerMerchantID})
+}
+
+// SetAuthAssertionEmail is like SetAuthAssertion, identifying the seller by
+// its PayPal account email
+func (c *Client) SetAuthAssertionEmail(sellerEmail string) {
+	c.setSeller(sellerIdentity{claim: "email", value: sellerEmail})
+}
+
+func (c *Client) setSeller(seller sellerIdentity) {
+	if seller.value == "" {
+		c.seller = nil
+		return
+	}
+	c.seller = &seller
+}
+
+// WithAuthAssertion makes the Client act on behalf of a seller, see SetAuthAssertion
+func WithAuthAssertion(sellerMerchantID string) ClientOption {
+	return func(c *Client) {
+		c.SetAuthAssertion(sellerMerchantID)
+	}
+}
+
+// WithAuthAssertionEmail makes the Client act on behalf of a seller, see SetAuthAssertionEmail
+func WithAuthAssertionEmail(sellerEmail string) ClientOption {
+	return func(c *Client) {
+		c.SetAuthAssertionEmail(sellerEmail)
+	}
+}
+
+// WithSellerMerchantID returns a context making the requests made with it act on
+// behalf of the seller with the given merchant ID, overriding SetAuthAssertion
+func WithSellerMerchantID(ctx context.Context, sellerMerchantID string) context.Context {
+	return context.WithValue(ctx, authAssertionKey{}, sellerIdentity{claim: "payer_id", value: sellerMerchantID})
+}
+
+// WithSellerEmail is like WithSellerMerchantID, identifying the seller by
+// its PayPal account email
+func WithSellerEmail(ctx context.Context, sellerEmail string) context.Context {
+	return context.WithValue(ctx, authAssertionKey{}, sellerIdentity{claim: "email", value: sellerEmail})
+}
+
+// setAuthAssertion sets the PayPal-Auth-Assertion header of the seller from the
+// request context or the client, unless the header is already set
+func (c *Client) setAuthAssertion(req *http.Request) {
+	if assertion := c.requestAuthAssertion(req); assertion != "" {
+		req.Header.Set("PayPal-Auth-Assertion", assertion)
+	}
+}
+
+// requestAuthAssertion returns the PayPal-Auth-Assertion the request is sent
+// with: its header, else the seller from the request context or the client
+func (c *Client) requestAuthAssertion(req *http.Request) string {
+	if assertion := req.Header.Get("PayPal-Auth-Assertion"); assertion != "" {
+		return assertion
+	}
+
+	if seller, ok := req.Context().Value(authAssertionKey{}).(sellerIdentity); ok {
+		if seller.value != "" {
+			return c.authAssertion(seller)
+		}
+		return ""
+	}
+	if c.seller != nil {
+		return c.authAssertion(*c.seller)
+	}
+	return ""
+}
+
+// CaptureOrderAsPlatform captures an order on behalf of the seller identified
+// by sellerMerchantID, setting the PayPal-Auth-Assertion header for it.
+// Doc: https://developer.paypal.com/docs/api/orders/v2/#orders_capture
+// Endpoint: POST /v2/checkout/orders/ID/capture
+func (c *Client) CaptureOrderAsPlatform(ctx context.Context, orderID, sellerMerchantID string, captureOrderRequest CaptureOrderRequest) (*CaptureOrderResponse, error) {
+	capture := &CaptureOrderResponse{}
+
+	if sellerMerchantID == "" {
+		return capture, fmt.Errorf("paypal: seller merchant ID is required to capture as a platform")
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/capture"), captureOrderRequest)
+	if err != nil {
+		return capture, err
+	}
+
+	req.Header.Set("Prefer", "return=representation")
+	req.Header.Set("PayPal-Auth-Assertion", c.AuthAssertion(sellerMerchantID))
+
+	if err = c.SendWithAuth(req, capture); err != nil {
+		return capture, err
+	}
+
+	return capture, nil
+}
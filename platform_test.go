@@ -0,0 +1,98 @@
+package paypal
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCaptureOrderAsPlatform(t *testing.T) {
+	var assertion string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		assertion = r.Header.Get("PayPal-Auth-Assertion")
+		w.Write([]byte(`{"id":"O1","status":"COMPLETED"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("platform-client", "bar", ts.URL)
+	if _, err := c.CaptureOrderAsPlatform(context.Background(), "O1", "SELLER123", CaptureOrderRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 || parts[2] != "" {
+		t.Fatalf("expecting an unsigned JWT, got %q", assertion)
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims map[string]string
+	json.Unmarshal(payload, &claims)
+	if claims["iss"] != "platform-client" || claims["payer_id"] != "SELLER123" {
+		t.Errorf("unexpected assertion claims %v", claims)
+	}
+
+	if _, err := c.CaptureOrderAsPlatform(context.Background(), "O1", "", CaptureOrderRequest{}); err == nil {
+		t.Errorf("expecting an error without a seller merchant ID")
+	}
+}
+
+func TestAuthAssertionOptions(t *testing.T) {
+	var assertions []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			if r.Header.Get("PayPal-Auth-Assertion") != "" {
+				t.Errorf("token requests must not act on behalf of a seller")
+			}
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		assertions = append(assertions, r.Header.Get("PayPal-Auth-Assertion"))
+		w.Write([]byte(`{"id":"O1","status":"CREATED"}`))
+	}))
+	defer ts.Close()
+
+	claims := func(assertion string) map[string]string {
+		parts := strings.Split(assertion, ".")
+		if len(parts) != 3 {
+			return nil
+		}
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var claims map[string]string
+		json.Unmarshal(payload, &claims)
+		return claims
+	}
+
+	c, _ := NewClient("platform-client", "bar", ts.URL, WithAuthAssertion("SELLER123"))
+	ctx := context.Background()
+
+	c.GetOrder(ctx, "O1")
+	c.GetOrder(WithSellerEmail(ctx, "seller@example.com"), "O1")
+	c.GetOrder(WithSellerMerchantID(ctx, ""), "O1")
+	c.SetAuthAssertion("")
+	c.GetOrder(ctx, "O1")
+
+	if len(assertions) != 4 {
+		t.Fatalf("expecting 4 requests, got %d", len(assertions))
+	}
+	if got := claims(assertions[0]); got["payer_id"] != "SELLER123" || got["iss"] != "platform-client" {
+		t.Errorf("expecting the client seller, got %v", got)
+	}
+	if got := claims(assertions[1]); got["email"] != "seller@example.com" || got["payer_id"] != "" {
+		t.Errorf("expecting the context seller, got %v", got)
+	}
+	if assertions[2] != "" || assertions[3] != "" {
+		t.Errorf("expecting no assertion, got %q and %q", assertions[2], assertions[3])
+	}
+}
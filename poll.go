@@ -0,0 +1,97 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// defaultPollPolicy is used by Poll when no policy is given
+var defaultPollPolicy = RetryPolicy{BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+// ErrPollAttemptsExceeded is returned by Poll when the resource did not reach a
+// terminal state within the MaxAttempts of the policy
+var ErrPollAttemptsExceeded = errors.New("paypal: resource did not reach a terminal state")
+
+// Accepted reports whether PayPal accepted the request for asynchronous
+// processing with 202 Accepted, the resource then has to be polled, see Poll
+func (m ResponseMetadata) Accepted() bool {
+	return m.StatusCode == http.StatusAccepted
+}
+
+// Poll gets the resource of link, usually the "self" link of a 202 Accepted
+// response, into v until done returns true, the context expires or the
+// MaxAttempts of the policy are exhausted, 0 meaning no limit.
+// The delay between requests starts at the BaseDelay of the policy, doubles
+// up to its MaxDelay and follows the Retry-After header when PayPal sends
+// one. A nil policy polls every second, backing off up to 30 seconds.
+//
+//	batch, _ := c.CreatePayout(ctx, payout)
+//	err := c.Poll(ctx, *paypal.FindLink(batch.Links, "self"), batch, func() bool {
+//		return paypal.PayoutBatchDone(batch)
+//	}, nil)
+func (c *Client) Poll(ctx context.Context, link Link, v interface{}, done func() bool, policy *RetryPolicy) error {
+	p := defaultPollPolicy
+	if policy != nil {
+		p = *policy
+		if p.BaseDelay <= 0 {
+			p.BaseDelay = defaultPollPolicy.BaseDelay
+		}
+	}
+	link.Method = http.MethodGet
+
+	for attempt := 0; ; attempt++ {
+		var meta ResponseMetadata
+		if err := c.FollowLink(WithResponseMetadata(ctx, &meta), link, nil, v); err != nil {
+			return err
+		}
+		if done() {
+			return nil
+		}
+		if p.MaxAttempts > 0 && attempt+1 >= p.MaxAttempts {
+			return ErrPollAttemptsExceeded
+		}
+
+		delay, ok := retryAfter(&http.Response{Header: meta.Header})
+		if !ok {
+			delay = backoffDelay(p.BaseDelay, p.MaxDelay, attempt)
+		}
+		if delay <= 0 {
+			// Never poll in a busy loop, e.g. with Retry-After: 0
+			delay = p.BaseDelay
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// PayoutBatchDone reports whether the payout batch reached a terminal state,
+// i.e. it is neither PENDING nor PROCESSING
+func PayoutBatchDone(p *PayoutResponse) bool {
+	if p == nil || p.BatchHeader == nil {
+		return false
+	}
+	switch p.BatchHeader.BatchStatus {
+	case "", BatchStatusPending, BatchStatusProcessing:
+		return false
+	}
+	return true
+}
+
+// WaitForPayout polls the payout batch until it reached a terminal state, see
+// Poll and PayoutBatchDone
+// Endpoint: GET /v1/payments/payouts/ID
+func (c *Client) WaitForPayout(ctx context.Context, payoutBatchID string, policy *RetryPolicy) (*PayoutResponse, error) {
+	response := &PayoutResponse{}
+	link := Link{Href: c.APIBase + "/v1/payments/payouts/" + payoutBatchID}
+
+	err := c.Poll(ctx, link, response, func() bool { return PayoutBatchDone(response) }, policy)
+	return response, err
+}
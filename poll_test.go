@@ -0,0 +1,91 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestPollPayout(t *testing.T) {
+	var polls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.RequestURI == "/v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case r.Method == http.MethodPost && r.RequestURI == "/v1/payments/payouts":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"batch_header":{"payout_batch_id":"BATCH-1","batch_status":"PENDING"},"links":[{"href":"/v1/payments/payouts/BATCH-1","rel":"self","method":"GET"}]}`))
+		case r.Method == http.MethodGet && r.RequestURI == "/v1/payments/payouts/BATCH-1":
+			polls++
+			status := "PROCESSING"
+			if polls == 3 {
+				status = "SUCCESS"
+			}
+			w.Header().Set("Retry-After", "0")
+			w.Write([]byte(`{"batch_header":{"payout_batch_id":"BATCH-1","batch_status":"` + status + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	var meta ResponseMetadata
+	batch, err := c.CreatePayout(WithResponseMetadata(ctx, &meta), Payout{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !meta.Accepted() || PayoutBatchDone(batch) {
+		t.Fatalf("expecting a pending 202 response, got %d %+v", meta.StatusCode, batch.BatchHeader)
+	}
+
+	err = c.Poll(ctx, *FindLink(batch.Links, "self"), batch, func() bool { return PayoutBatchDone(batch) }, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batch.BatchHeader.BatchStatus != BatchStatusSuccess || polls != 3 {
+		t.Errorf("expecting SUCCESS after 3 polls, got %s after %d", batch.BatchHeader.BatchStatus, polls)
+	}
+
+	polls = 0
+	_, err = c.WaitForPayout(ctx, "BATCH-1", &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond})
+	if !errors.Is(err, ErrPollAttemptsExceeded) {
+		t.Errorf("expecting ErrPollAttemptsExceeded, got %v", err)
+	}
+
+	polls = 0
+	response, err := c.WaitForPayout(ctx, "BATCH-1", &RetryPolicy{BaseDelay: time.Millisecond})
+	if err != nil || response.BatchHeader.BatchStatus != BatchStatusSuccess {
+		t.Errorf("expecting SUCCESS, got %+v %v", response.BatchHeader, err)
+	}
+}
+
+func TestPollContextDeadline(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Write([]byte(`{"batch_header":{"batch_status":"PENDING"}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.WaitForPayout(ctx, "BATCH-1", &RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 20 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expecting the context deadline, got %v", err)
+	}
+}
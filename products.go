@@ -2,8 +2,10 @@ package paypal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type (
@@ -71,16 +73,31 @@ func (c *Client) CreateProduct(ctx context.Context, product Product) (*CreatePro
 	return response, err
 }
 
-// UpdateProduct. updates a product information
+// UpdateProduct. updates a product information, nothing is sent when the
+// product has no field to update
 // Doc: https://developer.paypal.com/docs/api/catalog-products/v1/#products_patch
 // Endpoint: PATCH /v1/catalogs/products/:product_id
 func (c *Client) UpdateProduct(ctx context.Context, product Product) error {
-	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/catalogs/products/", product.ID), product.GetUpdatePatch())
+	patches := product.GetUpdatePatch()
+	if len(patches) == 0 {
+		return nil
+	}
+	return c.UpdateProductWithPatches(ctx, product.ID, patches)
+}
+
+// UpdateProductWithPatches applies the patches to a product, e.g. to add or remove its image_url
+// Doc: https://developer.paypal.com/docs/api/catalog-products/v1/#products_patch
+// Endpoint: PATCH /v1/catalogs/products/:product_id
+func (c *Client) UpdateProductWithPatches(ctx context.Context, productId string, patches []Patch) error {
+	if len(patches) == 0 {
+		return errors.New("paypal: a product update needs at least one patch")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/catalogs/products/", productId), patches)
 	if err != nil {
 		return err
 	}
-	err = c.SendWithAuth(req, nil)
-	return err
+	return c.SendWithAuth(req, nil)
 }
 
 // Get product details
@@ -107,13 +124,19 @@ func (c *Client) ListProducts(ctx context.Context, params *ProductListParameters
 	}
 
 	if params != nil {
-		q := req.URL.Query()
-		q.Add("page", params.Page)
-		q.Add("page_size", params.PageSize)
-		q.Add("total_required", params.TotalRequired)
-		req.URL.RawQuery = q.Encode()
+		req.URL.RawQuery = params.ListParams.query().Encode()
 	}
 
 	err = c.SendWithAuth(req, response)
 	return response, err
 }
+
+// NewProductPaginator returns a Paginator over all pages of products,
+// decode each page into a ListProductsResponse
+func (c *Client) NewProductPaginator(params *ProductListParameters) *Paginator {
+	q := url.Values{}
+	if params != nil {
+		q = params.ListParams.query()
+	}
+	return c.NewPaginator("/v1/catalogs/products", q)
+}
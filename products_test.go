@@ -0,0 +1,61 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestProductUpdateAndList(t *testing.T) {
+	var requests int
+	var query string
+	var patches []Patch
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "GET /v1/catalogs/products":
+			requests++
+			query = r.URL.RawQuery
+			w.Write([]byte(`{"products":[{"id":"PROD-1","name":"Video streaming","type":"SERVICE"}],"total_items":1}`))
+		case "PATCH /v1/catalogs/products/PROD-1":
+			requests++
+			json.NewDecoder(r.Body).Decode(&patches)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	if err := c.UpdateProductWithPatches(ctx, "PROD-1", nil); err == nil {
+		t.Error("expecting an error for an update without patches")
+	}
+	if err := c.UpdateProductWithPatches(ctx, "PROD-1", []Patch{{Operation: "add", Path: "/image_url", Value: "https://example.com/p.png"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 || patches[0].Path != "/image_url" {
+		t.Errorf("unexpected patches %+v", patches)
+	}
+
+	products, err := c.ListProducts(ctx, &ProductListParameters{ListParams{Page: "2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "page=2" {
+		t.Errorf("expecting only the set parameters, got %q", query)
+	}
+	if len(products.Products) != 1 || products.Products[0].Type != ProductTypeService {
+		t.Errorf("unexpected products %+v", products)
+	}
+	if requests != 2 {
+		t.Errorf("expecting 2 requests, got %d", requests)
+	}
+}
@@ -0,0 +1,129 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter limits the rate and the concurrency of requests. It is a token
+// bucket refilled at rate tokens per second, which pauses all requests when
+// PayPal responds with 429 and a Retry-After header.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	slots       chan struct{}
+}
+
+// SetRateLimit limits requests to rate per second on average, allowing bursts
+// of up to burst requests. Requests wait for their turn until their context is
+// done. When PayPal responds with 429 Too Many Requests and a Retry-After
+// header, all requests wait for the requested delay.
+// A rate of 0 removes the limit.
+func (c *Client) SetRateLimit(rate float64, burst int) {
+	l := c.rateLimiter()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if burst < 1 {
+		burst = 1
+	}
+	l.rate = rate
+	l.burst = float64(burst)
+	l.tokens = l.burst
+	l.last = time.Now()
+}
+
+// SetMaxConcurrentRequests limits the number of requests in flight at the same time.
+// A max of 0 removes the limit.
+func (c *Client) SetMaxConcurrentRequests(max int) {
+	l := c.rateLimiter()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.slots = nil
+	if max > 0 {
+		l.slots = make(chan struct{}, max)
+	}
+}
+
+func (c *Client) rateLimiter() *rateLimiter {
+	if c.limiter == nil {
+		c.limiter = &rateLimiter{}
+	}
+	return c.limiter
+}
+
+// acquire waits until the request may be sent. The returned function must be
+// called when the request is done.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	l.mu.Lock()
+	now := time.Now()
+	var delay time.Duration
+	if l.rate > 0 {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+	if paused := l.pausedUntil.Sub(now); paused > delay {
+		delay = paused
+	}
+	slots := l.slots
+	l.mu.Unlock()
+
+	if delay > 0 && !sleepContext(ctx, delay) {
+		l.cancel()
+		return nil, ctx.Err()
+	}
+
+	if slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// cancel returns the token of a request which was not sent
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate > 0 {
+		l.tokens++
+	}
+}
+
+// record pauses all requests for the delay requested by a 429 response
+func (l *rateLimiter) record(resp *http.Response) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	delay, ok := retryAfter(resp)
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(delay); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
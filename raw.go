@@ -0,0 +1,50 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+)
+
+// rawBodyKey is the context key of the body set with WithRawResponseBody
+type rawBodyKey struct{}
+
+// WithRawResponseBody returns a context that stores the unmodified response
+// body of the API call made with it into body, for error responses too.
+// Use it to read fields the response structs don't have yet:
+//
+//	var raw []byte
+//	order, err := c.GetOrder(paypal.WithRawResponseBody(ctx, &raw), orderID)
+func WithRawResponseBody(ctx context.Context, body *[]byte) context.Context {
+	return context.WithValue(ctx, rawBodyKey{}, body)
+}
+
+// SendRaw makes an authenticated request to the API and returns the response
+// with its unmodified body, e.g. for endpoints this package doesn't cover.
+// API errors are returned as *ErrorResponse along with the error body.
+func (c *Client) SendRaw(req *http.Request) (*http.Response, []byte, error) {
+	var body []byte
+	resp, err := c.Do(req.WithContext(WithRawResponseBody(req.Context(), &body)), nil)
+	return resp, body, err
+}
+
+// captureRawBody reads the response body into the destination of the request
+// context, if any, and replaces it so it can still be decoded afterwards
+func captureRawBody(req *http.Request, resp *http.Response) {
+	if _, ok := req.Context().Value(rawBodyKey{}).(*[]byte); !ok {
+		return
+	}
+
+	data, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	storeRawBody(req.Context(), data)
+}
+
+// storeRawBody stores a response body into the destination of ctx, if any
+func storeRawBody(ctx context.Context, data []byte) {
+	if dst, ok := ctx.Value(rawBodyKey{}).(*[]byte); ok && dst != nil {
+		*dst = data
+	}
+}
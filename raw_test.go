@@ -0,0 +1,50 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestRawResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.URL.Path == "/v2/checkout/orders/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"name":"RESOURCE_NOT_FOUND"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"O1","unknown":{"nested":1}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	var raw []byte
+	order, err := c.GetOrder(WithRawResponseBody(context.Background(), &raw), "O1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != "O1" || string(raw) != `{"id":"O1","unknown":{"nested":1}}` {
+		t.Errorf("unexpected order %+v and raw body %s", order, raw)
+	}
+
+	req, _ := c.NewRequest(context.Background(), "GET", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/missing"), nil)
+	resp, body, err := c.SendRaw(req)
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Fatalf("expecting an *ErrorResponse, got %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || string(body) != `{"name":"RESOURCE_NOT_FOUND"}` {
+		t.Errorf("unexpected raw response %d %s", resp.StatusCode, body)
+	}
+}
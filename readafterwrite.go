@@ -0,0 +1,117 @@
+package paypal
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// readAfterWriteWindow is how long after a create GET 404s are considered
+// caused by eventual consistency
+const readAfterWriteWindow = time.Minute
+
+// readAfterWriteRetry retries GET requests answered with 404 shortly after
+// the client created the resource, until PayPal has propagated it
+type readAfterWriteRetry struct {
+	sync.Mutex
+	attempts int
+	delay    time.Duration
+	created  map[string]time.Time
+}
+
+// SetReadAfterWriteRetry retries a GET answered with 404 up to attempts times,
+// waiting delay in between, when the client created the resource with a POST
+// within the last minute. Resources such as subscriptions and products can
+// briefly 404 right after they are created. Created resources are identified
+// by the Location header, the self link or the id of the POST response.
+// An attempts of 0 disables the retry.
+func (c *Client) SetReadAfterWriteRetry(attempts int, delay time.Duration) {
+	if attempts <= 0 {
+		c.readAfterWrite = nil
+		return
+	}
+	c.readAfterWrite = &readAfterWriteRetry{
+		attempts: attempts,
+		delay:    delay,
+		created:  make(map[string]time.Time),
+	}
+}
+
+// record registers the resources created by successful POST requests, v is
+// the decoded response
+func (r *readAfterWriteRetry) record(req *http.Request, resp *http.Response, v interface{}, err error) {
+	if req.Method != http.MethodPost || err != nil || resp == nil {
+		return
+	}
+	paths := createdPaths(req, resp, v)
+	if len(paths) == 0 {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+	for path, created := range r.created {
+		if now.Sub(created) >= readAfterWriteWindow {
+			delete(r.created, path)
+		}
+	}
+	for _, path := range paths {
+		r.created[path] = now
+	}
+}
+
+// shouldRetry reports whether the request should be sent again after the given retries
+func (r *readAfterWriteRetry) shouldRetry(req *http.Request, resp *http.Response, retries int) bool {
+	if req.Method != http.MethodGet || resp == nil || resp.StatusCode != http.StatusNotFound || retries >= r.attempts {
+		return false
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	created, ok := r.created[strings.TrimSuffix(req.URL.Path, "/")]
+	return ok && time.Since(created) < readAfterWriteWindow
+}
+
+// createdPaths returns the paths of the resource created by a POST: its
+// Location, its self link and the request path followed by its id
+func createdPaths(req *http.Request, resp *http.Response, v interface{}) []string {
+	var paths []string
+	if location, err := resp.Location(); err == nil {
+		paths = append(paths, location.Path)
+	}
+
+	if _, ok := v.(io.Writer); v == nil || ok {
+		return paths
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return paths
+	}
+	var created struct {
+		ID    string `json:"id"`
+		Links Links  `json:"links"`
+	}
+	if json.Unmarshal(data, &created) != nil {
+		return paths
+	}
+	if self := created.Links.Href("self"); self != "" {
+		if u, err := url.Parse(self); err == nil {
+			paths = append(paths, u.Path)
+		}
+	}
+	if created.ID != "" {
+		paths = append(paths, strings.TrimSuffix(req.URL.Path, "/")+"/"+created.ID)
+	}
+
+	for i := range paths {
+		paths[i] = strings.TrimSuffix(paths[i], "/")
+	}
+	return paths
+}
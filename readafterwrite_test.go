@@ -0,0 +1,93 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestReadAfterWriteRetry(t *testing.T) {
+	gets := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"PROD-1"}`))
+			return
+		}
+		if r.URL.Path != "/v1/catalogs/products/PROD-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gets++
+		if gets < 3 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"name":"RESOURCE_NOT_FOUND"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"PROD-1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetReadAfterWriteRetry(3, time.Millisecond)
+
+	var retries int
+	c.SetMetricsHook(func(m RequestMetrics) {
+		retries = m.Retries
+	})
+
+	// Without a recent create the 404 is returned right away
+	if _, err := c.GetProduct(context.Background(), "PROD-1"); err == nil || gets != 1 {
+		t.Fatalf("expecting a single failed GET, got %d requests and %v", gets, err)
+	}
+
+	if _, err := c.CreateProduct(context.Background(), Product{Name: "Product"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var m RequestMetrics
+	product, err := c.GetProduct(WithRequestMetrics(context.Background(), &m), "PROD-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if product.ID != "PROD-1" || gets != 3 || retries != 1 {
+		t.Errorf("expecting the GET to succeed after 1 retry, got %d requests and %d retries", gets, retries)
+	}
+	if m.Retries != 1 || m.StatusCode != http.StatusOK {
+		t.Errorf("expecting the call metrics in the context, got %+v", m)
+	}
+
+	// Resources the client did not create are not retried
+	if _, err := c.GetProduct(context.Background(), "PROD-2"); err == nil || retries != 0 {
+		t.Errorf("expecting the GET of another resource not to be retried, got %d retries and %v", retries, err)
+	}
+}
+
+func TestCreatedPaths(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://api-m.paypal.com/v1/billing/subscriptions/", nil)
+	resp := &http.Response{Header: http.Header{"Location": {"https://api-m.paypal.com/v1/billing/subscriptions/I-LOCATION"}}, Request: req}
+	subscription := &SubscriptionDetailResp{}
+	subscription.ID = "I-1"
+	subscription.Links = Links{{Rel: "self", Href: "https://api-m.paypal.com/v1/billing/subscriptions/I-SELF"}}
+
+	paths := createdPaths(req, resp, subscription)
+	expected := []string{"/v1/billing/subscriptions/I-LOCATION", "/v1/billing/subscriptions/I-SELF", "/v1/billing/subscriptions/I-1"}
+	if len(paths) != len(expected) {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expecting %s, got %s", expected[i], paths[i])
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestRecorderAndReplayer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "secret-token", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Write([]byte(`{"id":"5O190127TN364715T","status":"CREATED","payment_source":{"card":{"number":"4111111111111111"}}}`))
+	}))
+
+	buf := &bytes.Buffer{}
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetRecorder(NewJSONRecordSink(buf))
+
+	if _, err := c.GetOrder(context.Background(), "5O190127TN364715T"); err != nil {
+		t.Fatal(err)
+	}
+	ts.Close()
+
+	if strings.Contains(buf.String(), "secret-token") || strings.Contains(buf.String(), "4111111111111111") {
+		t.Fatalf("expecting secrets to be redacted, got %s", buf.String())
+	}
+
+	records, err := ReadRecords(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].StatusCode != http.StatusOK {
+		t.Fatalf("unexpected records %+v", records)
+	}
+
+	// Replay against any API base without network access
+	c, _ = NewClient("foo", "bar", APIBaseSandBox)
+	c.SetHTTPClient(&http.Client{Transport: NewReplayer(records)})
+
+	order, err := c.GetOrder(context.Background(), "5O190127TN364715T")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != "5O190127TN364715T" || order.Status != OrderStatusCreated {
+		t.Errorf("replayed order is incorrect, Given: %+v", order)
+	}
+
+	if _, err = c.GetOrder(context.Background(), "5O190127TN364715T"); err == nil {
+		t.Errorf("expecting an error when the records are exhausted")
+	}
+}
+
+func TestCassette(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "secret-token", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Write([]byte(`{"id":"2GG279541U471931P","status":"COMPLETED","amount":{"currency_code":"USD","value":"10.99"}}`))
+	}))
+
+	dir, err := ioutil.TempDir("", "paypal-cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "refund.jsonl")
+
+	cassette, err := NewCassette(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cassette.Recording() {
+		t.Fatal("expecting a new cassette to record")
+	}
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetHTTPClient(&http.Client{Transport: cassette})
+	if _, err := c.RefundCapture(context.Background(), "2GG279541U471931P", RefundCaptureRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cassette.Save(); err != nil {
+		t.Fatal(err)
+	}
+	ts.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-token") || strings.Count(string(data), "\n") != 1 {
+		t.Fatalf("expecting one redacted record, got %s", data)
+	}
+
+	cassette, err = NewCassette(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cassette.Recording() {
+		t.Fatal("expecting an existing cassette to replay")
+	}
+
+	c, _ = NewClient("foo", "bar", ts.URL)
+	c.SetHTTPClient(&http.Client{Transport: cassette})
+	refund, err := c.RefundCapture(context.Background(), "2GG279541U471931P", RefundCaptureRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.Status != "COMPLETED" || refund.Amount.Value != "10.99" {
+		t.Errorf("replayed refund is incorrect, Given: %+v", refund)
+	}
+}
@@ -0,0 +1,144 @@
+package paypal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces secret values in logged and recorded data
+const redacted = "REDACTED"
+
+// redactedJSONKeys are JSON keys whose values are always secrets
+var redactedJSONKeys = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+	"security_code": true,
+	"cvv2":          true,
+	"cryptogram":    true,
+	"emv_data":      true,
+	"pin":           true,
+	"email":         true,
+	"email_address": true,
+	"payer_email":   true,
+}
+
+// redactedFormKeys are form values that are always secrets
+var redactedFormKeys = []string{"code", "code_verifier", "refresh_token", "client_secret", "token"}
+
+// redactHeader returns a copy of the header with credentials removed
+func redactHeader(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	h = h.Clone()
+	for _, key := range []string{"Authorization", "Paypal-Auth-Assertion"} {
+		if h.Get(key) != "" {
+			h.Set(key, redacted)
+		}
+	}
+	return h
+}
+
+// redactedRequest returns the header and body of the request with credentials,
+// card numbers and emails removed, the body is nil when it cannot be read again
+func redactedRequest(req *http.Request) (http.Header, []byte) {
+	header := redactHeader(req.Header)
+	if req.GetBody == nil {
+		return header, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return header, nil
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil || len(data) == 0 {
+		return header, nil
+	}
+	return header, redactBody(req.Header.Get("Content-Type"), data)
+}
+
+// redactBody returns the body with tokens, credentials and card numbers removed.
+// Bodies that are neither JSON nor form encoded are returned unchanged.
+func redactBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		for _, key := range redactedFormKeys {
+			if values.Get(key) != "" {
+				values.Set(key, redacted)
+			}
+		}
+		return []byte(values.Encode())
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return body
+	}
+	redactJSON(data, "")
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// redactJSON removes secrets from decoded JSON in place. Card numbers are only
+// redacted inside card objects, other "number" fields (e.g. invoices) are kept.
+func redactJSON(data interface{}, parent string) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := value.(string); ok {
+				if redactedJSONKeys[key] || (key == "number" && isCardKey(parent)) {
+					v[key] = redacted
+				}
+				continue
+			}
+			redactJSON(value, key)
+		}
+	case []interface{}:
+		for _, value := range v {
+			redactJSON(value, parent)
+		}
+	}
+}
+
+func isCardKey(key string) bool {
+	return key == "card" || key == "credit_card" || key == "tokenized_card"
+}
+
+// dumpRedactedResponse dumps the response like httputil.DumpResponse with
+// credentials removed from the header and the body
+func dumpRedactedResponse(resp *http.Response) []byte {
+	var body []byte
+	if resp.Body != nil {
+		body, _ = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	redactedResp := *resp
+	redactedResp.Header = redactHeader(resp.Header)
+	dump, err := httputil.DumpResponse(&redactedResp, false)
+	if err != nil {
+		return nil
+	}
+
+	return append(dump, redactBody(resp.Header.Get("Content-Type"), body)...)
+}
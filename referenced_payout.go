@@ -0,0 +1,170 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type (
+	// ReferencedPayoutItemRequest references a transaction whose funds are disbursed to the payee
+	// Doc: https://developer.paypal.com/docs/api/referenced-payouts/v1/#definition-referenced_payouts_item
+	ReferencedPayoutItemRequest struct {
+		ReferenceID   string `json:"reference_id"`
+		ReferenceType string `json:"reference_type"`
+	}
+
+	// ReferencedPayoutRequest struct
+	ReferencedPayoutRequest struct {
+		ReferencedPayouts []ReferencedPayoutItemRequest `json:"referenced_payouts"`
+	}
+
+	// ReferencedPayoutBatchHeader struct
+	ReferencedPayoutBatchHeader struct {
+		PayoutsBatchID string `json:"payouts_batch_id,omitempty"`
+		BatchStatus    string `json:"batch_status,omitempty"`
+		TotalItems     int    `json:"total_items,omitempty"`
+	}
+
+	// ReferencedPayoutResponse struct
+	ReferencedPayoutResponse struct {
+		BatchHeader       *ReferencedPayoutBatchHeader `json:"batch_header,omitempty"`
+		ReferencedPayouts []ReferencedPayoutItem       `json:"referenced_payouts,omitempty"`
+		Links             Links                        `json:"links,omitempty"`
+	}
+
+	// ReferencedPayoutItem is the disbursement of a referenced transaction
+	// Doc: https://developer.paypal.com/docs/api/referenced-payouts/v1/#definition-referenced_payouts_item
+	ReferencedPayoutItem struct {
+		ItemID                    string                 `json:"item_id,omitempty"`
+		ProcessingState           *ReferencedPayoutState `json:"processing_state,omitempty"`
+		ReferenceID               string                 `json:"reference_id,omitempty"`
+		ReferenceType             string                 `json:"reference_type,omitempty"`
+		PayoutTransactionID       string                 `json:"payout_transaction_id,omitempty"`
+		DisbursementTransactionID string                 `json:"disbursement_transaction_id,omitempty"`
+		ExternalMerchantID        string                 `json:"external_merchant_id,omitempty"`
+		ExternalReferenceID       string                 `json:"external_reference_id,omitempty"`
+		PayeeEmail                string                 `json:"payee_email,omitempty"`
+		PayoutAmount              *Money                 `json:"payout_amount,omitempty"`
+		PayoutDestination         string                 `json:"payout_destination,omitempty"`
+		InvoiceID                 string                 `json:"invoice_id,omitempty"`
+		Custom                    string                 `json:"custom,omitempty"`
+		Links                     Links                  `json:"links,omitempty"`
+	}
+
+	// ReferencedPayoutState struct
+	ReferencedPayoutState struct {
+		Status string `json:"status,omitempty"`
+		Reason string `json:"reason,omitempty"`
+	}
+)
+
+// Possible values for `reference_type` in ReferencedPayoutItemRequest
+const (
+	ReferenceTypeTransactionID string = "TRANSACTION_ID"
+)
+
+// Possible values for `status` in ReferencedPayoutState
+//
+// https://developer.paypal.com/docs/api/referenced-payouts/v1/#definition-processing_state
+const (
+	ReferencedPayoutStatusPending string = "PENDING"
+	ReferencedPayoutStatusSuccess string = "SUCCESS"
+	ReferencedPayoutStatusFailed  string = "FAILED"
+)
+
+// IsPending reports whether the disbursement of the item is still processing
+func (i *ReferencedPayoutItem) IsPending() bool {
+	return i.ProcessingState == nil || i.ProcessingState.Status == ReferencedPayoutStatusPending
+}
+
+// CreateReferencedPayout disburses the funds of delayed disbursement transactions
+// in a batch, which PayPal processes asynchronously. Poll the batch with
+// GetReferencedPayout or its items with GetReferencedPayoutItem.
+// Doc: https://developer.paypal.com/docs/api/referenced-payouts/v1/#referenced-payouts_create_batch
+// Endpoint: POST /v1/payments/referenced-payouts
+func (c *Client) CreateReferencedPayout(ctx context.Context, r ReferencedPayoutRequest) (*ReferencedPayoutResponse, error) {
+	response := &ReferencedPayoutResponse{}
+	if len(r.ReferencedPayouts) == 0 {
+		return response, errors.New("paypal: a referenced payout needs at least one item")
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/referenced-payouts"), r)
+	if err != nil {
+		return response, err
+	}
+	req.Header.Set("Prefer", "respond-async")
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// CreateReferencedPayoutForCaptures disburses the funds of the given captures
+// of orders created with the DELAY_FUNDS_DISBURSEMENT disbursement mode
+func (c *Client) CreateReferencedPayoutForCaptures(ctx context.Context, captureIDs ...string) (*ReferencedPayoutResponse, error) {
+	r := ReferencedPayoutRequest{}
+	for _, id := range captureIDs {
+		r.ReferencedPayouts = append(r.ReferencedPayouts, ReferencedPayoutItemRequest{
+			ReferenceID:   id,
+			ReferenceType: ReferenceTypeTransactionID,
+		})
+	}
+	return c.CreateReferencedPayout(ctx, r)
+}
+
+// GetReferencedPayout shows the status of a referenced payouts batch and its items
+// Doc: https://developer.paypal.com/docs/api/referenced-payouts/v1/#referenced-payouts_get_batch_details
+// Endpoint: GET /v1/payments/referenced-payouts/ID
+func (c *Client) GetReferencedPayout(ctx context.Context, payoutsBatchID string) (*ReferencedPayoutResponse, error) {
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/referenced-payouts/"+payoutsBatchID), nil)
+	response := &ReferencedPayoutResponse{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// CreateReferencedPayoutItem disburses the funds of a single transaction synchronously
+// Doc: https://developer.paypal.com/docs/api/referenced-payouts/v1/#referenced-payouts-items_create
+// Endpoint: POST /v1/payments/referenced-payouts-items
+func (c *Client) CreateReferencedPayoutItem(ctx context.Context, item ReferencedPayoutItemRequest) (*ReferencedPayoutItem, error) {
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/referenced-payouts-items"), item)
+	response := &ReferencedPayoutItem{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetReferencedPayoutItem shows the processing state of a referenced payout item
+// Doc: https://developer.paypal.com/docs/api/referenced-payouts/v1/#referenced-payouts-items_get
+// Endpoint: GET /v1/payments/referenced-payouts-items/ID
+func (c *Client) GetReferencedPayoutItem(ctx context.Context, payoutsItemID string) (*ReferencedPayoutItem, error) {
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/referenced-payouts-items/"+payoutsItemID), nil)
+	response := &ReferencedPayoutItem{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
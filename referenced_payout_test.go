@@ -0,0 +1,66 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestReferencedPayouts(t *testing.T) {
+	var prefer string
+	var body ReferencedPayoutRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "POST /v1/payments/referenced-payouts":
+			prefer = r.Header.Get("Prefer")
+			json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"links":[{"href":"https://api.paypal.com/v1/payments/referenced-payouts/BATCH-1","rel":"self","method":"GET"}]}`))
+		case "GET /v1/payments/referenced-payouts-items/ITEM-1":
+			w.Write([]byte(`{"item_id":"ITEM-1","processing_state":{"status":"SUCCESS"},"reference_id":"CAPTURE-1",` +
+				`"reference_type":"TRANSACTION_ID","payout_amount":{"currency_code":"USD","value":"9.00"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	c.SetReturnRepresentation()
+	ctx := context.Background()
+
+	if _, err := c.CreateReferencedPayoutForCaptures(ctx); err == nil {
+		t.Error("expecting an error for a referenced payout without items")
+	}
+
+	resp, err := c.CreateReferencedPayoutForCaptures(ctx, "CAPTURE-1", "CAPTURE-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefer != "respond-async" {
+		t.Errorf("expecting Prefer: respond-async, got %q", prefer)
+	}
+	if len(body.ReferencedPayouts) != 2 || body.ReferencedPayouts[1].ReferenceID != "CAPTURE-2" ||
+		body.ReferencedPayouts[1].ReferenceType != ReferenceTypeTransactionID {
+		t.Errorf("unexpected request %+v", body)
+	}
+	if FindLink(resp.Links, "self") == nil {
+		t.Errorf("expecting a self link, got %+v", resp.Links)
+	}
+
+	item, err := c.GetReferencedPayoutItem(ctx, "ITEM-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.IsPending() || item.PayoutAmount.Value != "9.00" {
+		t.Errorf("unexpected item %+v", item)
+	}
+}
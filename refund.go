@@ -0,0 +1,91 @@
+package paypal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrRefundExceedsCapture is returned when a refund would exceed the amount left on a capture
+var ErrRefundExceedsCapture = errors.New("paypal: refund exceeds the refundable amount of the capture")
+
+// RefundableAmount returns how much of the captured amount can still be refunded
+// after the given prior refunds
+func RefundableAmount(captured *Money, refunded []Money) (*Money, error) {
+	if captured == nil {
+		return nil, errors.New("paypal: captured amount is required")
+	}
+
+	remaining, err := parseAmount(captured.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, refund := range refunded {
+		if refund.Currency != captured.Currency {
+			return nil, fmt.Errorf("paypal: refund currency %s does not match capture currency %s", refund.Currency, captured.Currency)
+		}
+		value, err := parseAmount(refund.Value)
+		if err != nil {
+			return nil, err
+		}
+		remaining.Sub(remaining, value)
+	}
+
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+
+	return &Money{Currency: captured.Currency, Value: remaining.FloatString(CurrencyDecimals(captured.Currency))}, nil
+}
+
+// ValidateRefundAmount checks a refund against the captured amount and the prior
+// refunds, so over-refunding fails without a round-trip to PayPal.
+// A nil amount refunds the remaining amount and is valid as long as something is left.
+// Errors for over-refunds wrap ErrRefundExceedsCapture.
+func ValidateRefundAmount(captured *Money, refunded []Money, amount *Money) error {
+	remaining, err := RefundableAmount(captured, refunded)
+	if err != nil {
+		return err
+	}
+
+	max, err := parseAmount(remaining.Value)
+	if err != nil {
+		return err
+	}
+
+	if amount == nil {
+		if max.Sign() == 0 {
+			return fmt.Errorf("%w: nothing left to refund", ErrRefundExceedsCapture)
+		}
+		return nil
+	}
+
+	if amount.Currency != captured.Currency {
+		return fmt.Errorf("paypal: refund currency %s does not match capture currency %s", amount.Currency, captured.Currency)
+	}
+
+	value, err := parseAmount(amount.Value)
+	if err != nil {
+		return err
+	}
+	if value.Sign() <= 0 {
+		return fmt.Errorf("paypal: refund amount must be positive, got %s", amount.Value)
+	}
+	if value.Cmp(max) > 0 {
+		return fmt.Errorf("%w: requested %s %s, at most %s %s", ErrRefundExceedsCapture,
+			amount.Value, amount.Currency, remaining.Value, remaining.Currency)
+	}
+
+	return nil
+}
+
+// parseAmount parses a decimal amount string exactly, without float rounding
+func parseAmount(value string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || strings.ContainsAny(value, "eE/") {
+		return nil, fmt.Errorf("paypal: invalid amount %q", value)
+	}
+	return r, nil
+}
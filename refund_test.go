@@ -0,0 +1,144 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestValidateRefundAmount(t *testing.T) {
+	captured := &Money{Currency: "USD", Value: "100.00"}
+	refunded := []Money{{Currency: "USD", Value: "30.00"}, {Currency: "USD", Value: "20.50"}}
+
+	remaining, err := RefundableAmount(captured, refunded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining.Value != "49.50" || remaining.Currency != "USD" {
+		t.Errorf("expecting 49.50 USD refundable, got %s %s", remaining.Value, remaining.Currency)
+	}
+
+	tests := []struct {
+		amount *Money
+		valid  bool
+		over   bool
+	}{
+		{&Money{Currency: "USD", Value: "49.50"}, true, false},
+		{&Money{Currency: "USD", Value: "49.51"}, false, true},
+		{&Money{Currency: "EUR", Value: "10.00"}, false, false},
+		{&Money{Currency: "USD", Value: "0"}, false, false},
+		{&Money{Currency: "USD", Value: "abc"}, false, false},
+		{nil, true, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateRefundAmount(captured, refunded, tt.amount)
+		if (err == nil) != tt.valid {
+			t.Errorf("amount %+v: expecting valid=%v, got %v", tt.amount, tt.valid, err)
+		}
+		if errors.Is(err, ErrRefundExceedsCapture) != tt.over {
+			t.Errorf("amount %+v: expecting over-refund=%v, got %v", tt.amount, tt.over, err)
+		}
+	}
+
+	fullyRefunded := append(refunded, Money{Currency: "USD", Value: "49.50"})
+	if err := ValidateRefundAmount(captured, fullyRefunded, nil); !errors.Is(err, ErrRefundExceedsCapture) {
+		t.Errorf("expecting an over-refund error for a fully refunded capture, got %v", err)
+	}
+}
+
+func TestRefundCaptureWithPlatformFees(t *testing.T) {
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{
+			"id": "1JU08902781691411",
+			"status": "COMPLETED",
+			"amount": {"currency_code": "USD", "value": "10.00"},
+			"note_to_payer": "Defective product",
+			"seller_payable_breakdown": {
+				"gross_amount": {"currency_code": "USD", "value": "10.00"},
+				"paypal_fee": {"currency_code": "USD", "value": "0"},
+				"net_amount": {"currency_code": "USD", "value": "9.00"},
+				"platform_fees": [{"amount": {"currency_code": "USD", "value": "1.00"}}],
+				"total_refunded_amount": {"currency_code": "USD", "value": "10.00"}
+			},
+			"create_time": "2026-10-14T10:00:00Z"
+		}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	refund, err := c.RefundCapture(context.Background(), "2GG279541U471931P", RefundCaptureRequest{
+		Amount:      &Money{Currency: "USD", Value: "10.00"},
+		NoteToPayer: "Defective product",
+		PaymentInstruction: &RefundPaymentInstruction{
+			PlatformFees: []PlatformFee{{Amount: &Money{Currency: "USD", Value: "1.00"}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"amount":{"currency_code":"USD","value":"10.00"},"note_to_payer":"Defective product","payment_instruction":{"platform_fees":[{"amount":{"currency_code":"USD","value":"1.00"}}]}}`
+	if body != expected {
+		t.Errorf("unexpected refund request %s", body)
+	}
+
+	b := refund.SellerPayableBreakdown
+	if b == nil || b.NetAmount.Value != "9.00" || len(b.PlatformFees) != 1 || b.TotalRefundedAmount.Value != "10.00" {
+		t.Errorf("unexpected seller payable breakdown %+v", b)
+	}
+	if refund.NoteToPayer != "Defective product" || refund.CreateTime == nil {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
+
+func TestGetRefundDetails(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		path = r.Method + " " + r.URL.Path
+		w.Write([]byte(`{"id":"1JU08902781691411","status":"COMPLETED",` +
+			`"amount":{"currency_code":"USD","value":"10.99"},` +
+			`"seller_payable_breakdown":{"net_amount":{"currency_code":"USD","value":"10.67"}},` +
+			`"links":[{"href":"https://api.paypal.com/v2/payments/refunds/1JU08902781691411","rel":"self","method":"GET"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	refund, err := c.GetRefundDetails(context.Background(), "1JU08902781691411")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "GET /v2/payments/refunds/1JU08902781691411" {
+		t.Errorf("unexpected request %s", path)
+	}
+	if refund.Status != RefundStatusCompleted || refund.Amount.Value != "10.99" {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+	if refund.SellerPayableBreakdown == nil || refund.SellerPayableBreakdown.NetAmount.Value != "10.67" {
+		t.Errorf("unexpected seller payable breakdown %+v", refund.SellerPayableBreakdown)
+	}
+	if len(refund.Links) != 1 || refund.Links[0].Rel != "self" {
+		t.Errorf("unexpected links %+v", refund.Links)
+	}
+}
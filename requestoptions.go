@@ -0,0 +1,65 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestOption modifies a single API request, e.g. to add a header PayPal
+// supports for one endpoint only
+type RequestOption func(req *http.Request)
+
+// requestOptionsKey is the context key of the options set with WithRequestOptions
+type requestOptionsKey struct{}
+
+// WithHeader sets a request header, overriding the header the client would send
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// WithQuery sets a query parameter of the request URL
+func WithQuery(key, value string) RequestOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		q.Set(key, value)
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+// WithIdempotencyKey sets the PayPal-Request-Id header of the request, see WithRequestID
+func WithIdempotencyKey(requestID string) RequestOption {
+	return WithHeader("PayPal-Request-Id", requestID)
+}
+
+// WithClientMetadataID sets the PayPal-Client-Metadata-Id header of the request,
+// the tracking ID of the fraudnet or magnes script on the page of the buyer.
+// PayPal requires it for reference transactions with the buyer present and uses
+// it to evaluate the risk of the payment.
+func WithClientMetadataID(id string) RequestOption {
+	return WithHeader("PayPal-Client-Metadata-Id", id)
+}
+
+// WithRequestOptions returns a context applying opts to every request made with
+// it, after the options of ctx. It works with every endpoint method:
+//
+//	ctx = paypal.WithRequestOptions(ctx, paypal.WithHeader("Prefer", "return=minimal"))
+//	order, err := c.GetOrder(ctx, orderID)
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	prev, _ := ctx.Value(requestOptionsKey{}).([]RequestOption)
+	all := make([]RequestOption, 0, len(prev)+len(opts))
+	all = append(append(all, prev...), opts...)
+	return context.WithValue(ctx, requestOptionsKey{}, all)
+}
+
+// applyRequestOptions applies the options of the request context to req
+func applyRequestOptions(req *http.Request) {
+	opts, _ := req.Context().Value(requestOptionsKey{}).([]RequestOption)
+	for _, opt := range opts {
+		opt(req)
+	}
+}
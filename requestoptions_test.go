@@ -0,0 +1,80 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestRequestOptions(t *testing.T) {
+	var got *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		got = r
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	ctx := WithRequestOptions(context.Background(), WithHeader("Accept-Language", "de_DE"))
+	ctx = WithRequestOptions(ctx, WithQuery("fields", "payment_source"), WithIdempotencyKey("key-1"))
+	if _, err := c.GetOrder(ctx, "O1"); err != nil {
+		t.Fatal(err)
+	}
+	if got.Header.Get("Accept-Language") != "de_DE" {
+		t.Errorf("expecting the header option to override the default, got %q", got.Header.Get("Accept-Language"))
+	}
+	if got.URL.Query().Get("fields") != "payment_source" {
+		t.Errorf("expecting the query option, got %q", got.URL.RawQuery)
+	}
+	if got.Header.Get("PayPal-Request-Id") != "key-1" {
+		t.Errorf("expecting the idempotency key, got %q", got.Header.Get("PayPal-Request-Id"))
+	}
+
+	req, err := c.NewRequest(context.Background(), "GET", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/O1"), nil,
+		WithHeader("Prefer", "return=minimal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SendWithAuth(req, &Order{}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Header.Get("Prefer") != "return=minimal" || got.Header.Get("Accept-Language") != "en_US" {
+		t.Errorf("expecting only the NewRequest options, got %v", got.Header)
+	}
+}
+
+func TestClientMetadataID(t *testing.T) {
+	var ids []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ids = append(ids, r.Header.Get("PayPal-Client-Metadata-Id"))
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+	ctx := context.Background()
+
+	c.GetOrder(ctx, "O1")
+	c.GetOrder(WithRequestOptions(ctx, WithClientMetadataID("fraudnet-1")), "O1")
+	c.SetClientMetadataID("session-1")
+	c.GetOrder(ctx, "O1")
+	c.GetOrder(WithRequestOptions(ctx, WithClientMetadataID("fraudnet-2")), "O1")
+
+	if fmt.Sprint(ids) != "[ fraudnet-1 session-1 fraudnet-2]" {
+		t.Errorf("unexpected PayPal-Client-Metadata-Id headers %q", ids)
+	}
+}
@@ -0,0 +1,185 @@
+package paypal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCacheSize bounds the number of cached responses
+const responseCacheSize = 1000
+
+type (
+	// responseCache keeps GET responses of selected endpoints for a TTL, keyed by cacheKey
+	responseCache struct {
+		sync.Mutex
+		ttl       time.Duration
+		endpoints map[string]bool
+		entries   map[string]responseCacheEntry
+	}
+
+	responseCacheEntry struct {
+		expires time.Time
+		path    string
+		header  http.Header
+		body    []byte
+	}
+)
+
+// SetResponseCache caches successful GET responses of the given endpoints for ttl.
+// Endpoints are path templates with {id} for resource IDs, the same as reported
+// in RequestMetrics, e.g. "/v1/catalogs/products/{id}" or "/v1/billing/plans/{id}".
+// Only enable it for resources that rarely change; a successful non-GET request
+// to a cached resource, e.g. a PATCH, removes it from the cache.
+// Responses are cached for less than ttl when their Cache-Control max-age is
+// shorter, and not at all with no-store or no-cache.
+// Responses are cached per client, seller acted on behalf of and Accept-Language.
+// Cache hits are served without a request and are not reported to the metrics hook.
+// A ttl of 0 or no endpoints disables the cache.
+func (c *Client) SetResponseCache(ttl time.Duration, endpoints ...string) {
+	if ttl <= 0 || len(endpoints) == 0 {
+		c.responseCache = nil
+		return
+	}
+
+	cache := &responseCache{
+		ttl:       ttl,
+		endpoints: make(map[string]bool, len(endpoints)),
+		entries:   make(map[string]responseCacheEntry),
+	}
+	for _, endpoint := range endpoints {
+		cache.endpoints[endpoint] = true
+	}
+	c.responseCache = cache
+}
+
+// cacheable reports whether the response of the request may be cached
+func (rc *responseCache) cacheable(req *http.Request) bool {
+	return req.Method == http.MethodGet && rc.endpoints[endpointTemplate(req.URL.Path)]
+}
+
+// cacheKey identifies the cached responses of a request: its URL and the headers
+// the response depends on besides, so that responses are never shared between
+// clients, sellers acted on behalf of or languages
+func (c *Client) cacheKey(req *http.Request) string {
+	language := req.Header.Get("Accept-Language")
+	if language == "" {
+		language = "en_US"
+	}
+	return strings.Join([]string{req.URL.String(), c.ClientID, c.requestAuthAssertion(req), language}, "\n")
+}
+
+// serve decodes the response cached under key for the request into v
+func (rc *responseCache) serve(key string, req *http.Request, v interface{}) (*http.Response, bool, error) {
+	if !rc.cacheable(req) {
+		return nil, false, nil
+	}
+
+	rc.Lock()
+	entry, ok := rc.entries[key]
+	rc.Unlock()
+
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false, nil
+	}
+
+	resp := &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     entry.header.Clone(),
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}
+
+	storeRawBody(req.Context(), entry.body)
+
+	var err error
+	switch w := v.(type) {
+	case nil:
+	case io.Writer:
+		_, err = w.Write(entry.body)
+	default:
+		err = json.Unmarshal(entry.body, v)
+	}
+
+	return resp, true, err
+}
+
+// put caches the body of a successful response under key for the TTL of the
+// cache, or less when the Cache-Control header of the response says so
+func (rc *responseCache) put(key string, req *http.Request, resp *http.Response, body []byte) {
+	ttl := cacheControlTTL(resp.Header, rc.ttl)
+	if ttl <= 0 {
+		return
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	if len(rc.entries) >= responseCacheSize {
+		rc.evictExpired()
+	}
+	if len(rc.entries) >= responseCacheSize {
+		for key := range rc.entries {
+			delete(rc.entries, key)
+			break
+		}
+	}
+
+	rc.entries[key] = responseCacheEntry{
+		expires: time.Now().Add(ttl),
+		path:    req.URL.Path,
+		header:  resp.Header.Clone(),
+		body:    body,
+	}
+}
+
+// invalidate removes the cached responses of the resource at path and of its parents and children
+func (rc *responseCache) invalidate(path string) {
+	rc.Lock()
+	defer rc.Unlock()
+
+	for key, entry := range rc.entries {
+		if strings.HasPrefix(path, entry.path) || strings.HasPrefix(entry.path, path) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+func (rc *responseCache) evictExpired() {
+	now := time.Now()
+	for key, entry := range rc.entries {
+		if now.After(entry.expires) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// cacheControlTTL returns how long a response may be cached, at most ttl, following
+// the max-age of its Cache-Control header. It is 0 for no-store and no-cache responses.
+func cacheControlTTL(header http.Header, ttl time.Duration) time.Duration {
+	for _, directive := range strings.Split(strings.Join(header["Cache-Control"], ","), ",") {
+		name, value := directive, ""
+		if i := strings.IndexByte(directive, '='); i >= 0 {
+			name, value = directive[:i], strings.Trim(strings.TrimSpace(directive[i+1:]), `"`)
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "no-store", "no-cache":
+			return 0
+		case "max-age":
+			if seconds, err := strconv.Atoi(value); err == nil && time.Duration(seconds)*time.Second < ttl {
+				ttl = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return ttl
+}
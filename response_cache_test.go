@@ -0,0 +1,126 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestResponseCache(t *testing.T) {
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests[r.Method+" "+r.URL.Path]++
+		if r.Method == http.MethodPatch {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.URL.Path == "/v1/catalogs/products/PROD-2" {
+			w.Header().Set("Cache-Control", "private, no-store")
+		}
+		w.Write([]byte(`{"id":"PROD-1","name":"Product"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetResponseCache(time.Minute, "/v1/catalogs/products/{id}")
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		product, err := c.GetProduct(ctx, "PROD-1")
+		if err != nil || product.Name != "Product" {
+			t.Fatalf("unexpected product %+v %v", product, err)
+		}
+	}
+	if n := requests["GET /v1/catalogs/products/PROD-1"]; n != 1 {
+		t.Errorf("expecting cached products to be fetched once, got %d requests", n)
+	}
+
+	// Endpoints that were not opted in are never cached
+	c.GetOrder(ctx, "O1")
+	c.GetOrder(ctx, "O1")
+	if n := requests["GET /v2/checkout/orders/O1"]; n != 2 {
+		t.Errorf("expecting orders not to be cached, got %d requests", n)
+	}
+
+	// Responses the server forbids to store are never cached
+	c.GetProduct(ctx, "PROD-2")
+	c.GetProduct(ctx, "PROD-2")
+	if n := requests["GET /v1/catalogs/products/PROD-2"]; n != 2 {
+		t.Errorf("expecting no-store responses not to be cached, got %d requests", n)
+	}
+
+	if err := c.UpdateProduct(ctx, Product{ID: "PROD-1", Name: "Renamed"}); err != nil {
+		t.Fatal(err)
+	}
+	c.GetProduct(ctx, "PROD-1")
+	if n := requests["GET /v1/catalogs/products/PROD-1"]; n != 2 {
+		t.Errorf("expecting the update to invalidate the cache, got %d requests", n)
+	}
+}
+
+func TestResponseCacheSeller(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"PROD-1","name":"` + r.Header.Get("PayPal-Auth-Assertion") + `"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+	c.SetResponseCache(time.Minute, "/v1/catalogs/products/{id}")
+
+	ctx := context.Background()
+	a, err := c.GetProduct(WithSellerMerchantID(ctx, "SELLER_A"), "PROD-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := c.GetProduct(WithSellerMerchantID(ctx, "SELLER_B"), "PROD-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 || a.Name != c.AuthAssertion("SELLER_A") || b.Name != c.AuthAssertion("SELLER_B") {
+		t.Errorf("expecting responses not to be shared between sellers, got %d requests", requests)
+	}
+
+	c.GetProduct(WithSellerMerchantID(ctx, "SELLER_A"), "PROD-1")
+	c.GetProduct(ctx, "PROD-1")
+	if requests != 3 {
+		t.Errorf("expecting only the response of the same seller to be served, got %d requests", requests)
+	}
+}
+
+func TestCacheControlTTL(t *testing.T) {
+	tests := []struct {
+		cacheControl string
+		expected     time.Duration
+	}{
+		{"", time.Minute},
+		{"max-age=30", 30 * time.Second},
+		{"public, max-age=\"3600\"", time.Minute},
+		{"max-age=0", 0},
+		{"no-cache", 0},
+		{"private, No-Store", 0},
+		{"max-age=invalid", time.Minute},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.cacheControl != "" {
+			header.Set("Cache-Control", tt.cacheControl)
+		}
+		if ttl := cacheControlTTL(header, time.Minute); ttl != tt.expected {
+			t.Errorf("%q: expecting %s, got %s", tt.cacheControl, tt.expected, ttl)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package paypal
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPredicate decides whether a failed request is retried. For API errors
+// err is an *ErrorResponse, so the parsed error name and details can be inspected.
+type RetryPredicate func(resp *http.Response, err error) bool
+
+// RetryPolicy configures how failed requests are retried, see SetRetryPolicy
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, including the first attempt
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled for every further retry
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts, including delays requested with Retry-After
+	MaxDelay time.Duration
+	// Jitter randomly shortens delays by up to this fraction, between 0 and 1,
+	// so clients failing at the same time do not retry at the same time.
+	// Values above 1 are treated as 1.
+	Jitter float64
+}
+
+// retryBaseDelay is the delay before the first retry, doubled for every further retry
+var retryBaseDelay = 500 * time.Millisecond
+
+// maxBackoffShift bounds how many times backoff delays are doubled
+const maxBackoffShift = 30
+
+// backoffDelay returns base doubled attempt times, at most maxDelay when it is
+// positive. Delays that would overflow are the longest duration instead.
+func backoffDelay(base, maxDelay time.Duration, attempt int) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+	if attempt > maxBackoffShift {
+		attempt = maxBackoffShift
+	}
+	delay := base << uint(attempt)
+	if delay>>uint(attempt) != base {
+		delay = time.Duration(math.MaxInt64)
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// DefaultRetryPredicate retries 429 and 5xx responses
+func DefaultRetryPredicate(resp *http.Response, err error) bool {
+	if resp == nil {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// SetMaxRetries retries failed requests up to maxRetries times with exponential
+// backoff. Only idempotent requests are retried: GET, HEAD, PUT, DELETE and
+// requests with a PayPal-Request-Id header. Which failures are retried is
+// decided by the retry predicate, DefaultRetryPredicate unless set with
+// SetRetryPredicate. A Retry-After header in the response overrides the backoff
+// delay. A maxRetries of 0, the default, disables retries.
+func (c *Client) SetMaxRetries(maxRetries int) {
+	c.maxRetries = maxRetries
+}
+
+// SetRetryPolicy retries failed requests as configured by the policy. The same
+// requests are retried as with SetMaxRetries. When PayPal responds with a
+// Retry-After header, the request is retried after the requested delay instead
+// of the backoff delay.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.maxRetries = policy.MaxAttempts - 1
+	c.retryPolicy = &policy
+}
+
+// SetRetryPredicate sets the function deciding which failures are retried,
+// e.g. to retry INTERNAL_SERVICE_ERROR but no other 5xx.
+// Pass nil to restore DefaultRetryPredicate.
+func (c *Client) SetRetryPredicate(predicate RetryPredicate) {
+	c.retryPredicate = predicate
+}
+
+// nextRetry reports whether the request should be retried after the given
+// number of retries, and how long to wait before it
+func (c *Client) nextRetry(req *http.Request, resp *http.Response, err error, retries int) (time.Duration, bool) {
+	if raw := c.readAfterWrite; raw != nil && raw.shouldRetry(req, resp, retries) {
+		return raw.delay, true
+	}
+
+	if err == nil || retries >= c.maxRetries || !isIdempotent(req) {
+		return 0, false
+	}
+	if !canRewindBody(req) {
+		return 0, false
+	}
+
+	predicate := c.retryPredicate
+	if predicate == nil {
+		predicate = DefaultRetryPredicate
+	}
+	if !predicate(resp, err) {
+		return 0, false
+	}
+
+	return c.retryDelay(resp, retries), true
+}
+
+// retryDelay returns how long to wait before the next retry
+func (c *Client) retryDelay(resp *http.Response, retries int) time.Duration {
+	policy := RetryPolicy{BaseDelay: retryBaseDelay}
+	if c.retryPolicy != nil {
+		policy = *c.retryPolicy
+		if policy.BaseDelay <= 0 {
+			policy.BaseDelay = retryBaseDelay
+		}
+	}
+
+	delay, ok := retryAfter(resp)
+	if !ok {
+		delay = backoffDelay(policy.BaseDelay, policy.MaxDelay, retries)
+		if jitter := math.Min(policy.Jitter, 1); jitter > 0 {
+			delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+		}
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+
+	return delay
+}
+
+// retryAfter returns the delay requested by the Retry-After header of the response,
+// given either in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// isIdempotent reports whether sending the request twice has the same effect as once
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return req.Header.Get("PayPal-Request-Id") != ""
+}
+
+// canRewindBody reports whether the request can be sent again, i.e. it has no
+// body or one rewindBody can replay
+func canRewindBody(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewindBody resets the request body so the request can be sent again
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
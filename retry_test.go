@@ -0,0 +1,189 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestRetryPredicate(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests[r.Method+" "+r.URL.Path]++
+		switch {
+		case r.URL.Path == "/v2/checkout/orders/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"name":"SERVICE_UNAVAILABLE"}`))
+		case requests[r.Method+" "+r.URL.Path] < 3:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"name":"INTERNAL_SERVICE_ERROR"}`))
+		default:
+			w.Write([]byte(`{"id":"O1"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetMaxRetries(3)
+	c.SetRetryPredicate(func(resp *http.Response, err error) bool {
+		var errResp *ErrorResponse
+		return errors.As(err, &errResp) && errResp.Name == "INTERNAL_SERVICE_ERROR"
+	})
+
+	ctx := context.Background()
+	if _, err := c.GetOrder(ctx, "O1"); err != nil {
+		t.Errorf("expecting the GET to succeed after retries, got %v", err)
+	}
+	if n := requests["GET /v2/checkout/orders/O1"]; n != 3 {
+		t.Errorf("expecting 3 GET requests, got %d", n)
+	}
+
+	if _, err := c.GetOrder(ctx, "unavailable"); err == nil || requests["GET /v2/checkout/orders/unavailable"] != 1 {
+		t.Errorf("expecting SERVICE_UNAVAILABLE not to be retried, got %d requests", requests["GET /v2/checkout/orders/unavailable"])
+	}
+
+	// POSTs are only retried with a PayPal-Request-Id
+	c.CaptureOrder(ctx, "O2", CaptureOrderRequest{})
+	if n := requests["POST /v2/checkout/orders/O2/capture"]; n != 1 {
+		t.Errorf("expecting 1 POST without PayPal-Request-Id, got %d", n)
+	}
+	if _, err := c.CaptureOrderWithPaypalRequestId(ctx, "O3", CaptureOrderRequest{}, "capture-O3"); err != nil {
+		t.Errorf("expecting the POST with PayPal-Request-Id to succeed after retries, got %v", err)
+	}
+	if n := requests["POST /v2/checkout/orders/O3/capture"]; n != 3 {
+		t.Errorf("expecting 3 POST requests with PayPal-Request-Id, got %d", n)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests++
+		if requests < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"name":"RATE_LIMIT_REACHED"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour})
+
+	// Retry-After: 0 overrides the hour long backoff
+	if _, err := c.GetOrder(context.Background(), "O1"); err != nil {
+		t.Errorf("expecting the GET to succeed after retries, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expecting 3 attempts, got %d", requests)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	c := &Client{}
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second, Jitter: 0.5})
+
+	for retries, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		delay := c.retryDelay(nil, retries)
+		if delay > max || delay < max/2 {
+			t.Errorf("retry %d: expecting delay between %s and %s, got %s", retries, max/2, max, delay)
+		}
+	}
+
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 100, BaseDelay: time.Second, Jitter: 5})
+	for _, retries := range []int{0, 10, 64, 99} {
+		if delay := c.retryDelay(nil, retries); delay < 0 {
+			t.Errorf("retry %d: expecting a positive delay, got %s", retries, delay)
+		}
+	}
+
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second, Jitter: 0.5})
+	resp := &http.Response{Header: http.Header{"Retry-After": {"3"}}}
+	if delay := c.retryDelay(resp, 0); delay != 3*time.Second {
+		t.Errorf("expecting the Retry-After delay, got %s", delay)
+	}
+	resp.Header.Set("Retry-After", "60")
+	if delay := c.retryDelay(resp, 0); delay != 5*time.Second {
+		t.Errorf("expecting Retry-After to be capped by MaxDelay, got %s", delay)
+	}
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if delay := c.retryDelay(resp, 0); delay != 0 {
+		t.Errorf("expecting no delay for a past Retry-After date, got %s", delay)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		base, max time.Duration
+		attempt   int
+		expected  time.Duration
+	}{
+		{time.Second, 0, 0, time.Second},
+		{time.Second, 0, 3, 8 * time.Second},
+		{time.Second, 5 * time.Second, 3, 5 * time.Second},
+		{time.Second, 5 * time.Second, 200, 5 * time.Second},
+		{time.Second, 0, 200, time.Second << maxBackoffShift},
+		{time.Hour, 0, 40, time.Duration(math.MaxInt64)},
+	}
+	for _, tt := range tests {
+		if delay := backoffDelay(tt.base, tt.max, tt.attempt); delay != tt.expected {
+			t.Errorf("%s max %s attempt %d: expecting %s, got %s", tt.base, tt.max, tt.attempt, tt.expected, delay)
+		}
+	}
+}
+
+func TestRetryErrorResponse(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"name":"SERVICE_UNAVAILABLE","unknown_field":"kept"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetMaxRetries(2)
+
+	_, err := c.GetOrder(context.Background(), "O1")
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("expecting an *ErrorResponse, got %v", err)
+	}
+	if errResp.Attempts != 3 {
+		t.Errorf("expecting 3 attempts, got %d", errResp.Attempts)
+	}
+	if errResp.Method != http.MethodGet || errResp.URL != ts.URL+"/v2/checkout/orders/O1" {
+		t.Errorf("unexpected request %s %s", errResp.Method, errResp.URL)
+	}
+	if errResp.StatusCode() != http.StatusServiceUnavailable || string(errResp.Body) != `{"name":"SERVICE_UNAVAILABLE","unknown_field":"kept"}` {
+		t.Errorf("unexpected response %d %s", errResp.StatusCode(), errResp.Body)
+	}
+}
@@ -48,6 +48,9 @@ func (c *Client) RefundSale(ctx context.Context, saleID string, a *Amount) (*Ref
 
 // GetRefund by ID
 // Use it to look up details of a specific refund on direct and captured payments.
+//
+// Deprecated: the path does not exist in the v2 API and Refund has the v1 fields,
+// use GetRefundDetails for refunds of captures.
 // Endpoint: GET /v2/payments/refund/ID
 func (c *Client) GetRefund(ctx context.Context, refundID string) (*Refund, error) {
 	refund := &Refund{}
@@ -63,3 +66,22 @@ func (c *Client) GetRefund(ctx context.Context, refundID string) (*Refund, error
 
 	return refund, nil
 }
+
+// GetRefundDetails returns a refund of a capture by ID, e.g. to poll a PENDING
+// refund until it is COMPLETED or FAILED
+// Doc: https://developer.paypal.com/docs/api/payments/v2/#refunds_get
+// Endpoint: GET /v2/payments/refunds/ID
+func (c *Client) GetRefundDetails(ctx context.Context, refundID string) (*RefundResponse, error) {
+	refund := &RefundResponse{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/refunds/"+refundID), nil)
+	if err != nil {
+		return refund, err
+	}
+
+	if err = c.SendWithAuth(req, refund); err != nil {
+		return refund, err
+	}
+
+	return refund, nil
+}
@@ -0,0 +1,108 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// PayPal has no public API to create sandbox test accounts, they are created in
+// the developer dashboard (https://developer.paypal.com/dashboard/accounts).
+// SandboxAccount loads their credentials from the environment so CI can use
+// dedicated accounts without hardcoding them, and WithMockResponse or SetMockResponse trigger
+// sandbox negative testing.
+
+type (
+	// SandboxAccount holds the credentials of a sandbox test account
+	SandboxAccount struct {
+		ClientID   string
+		Secret     string
+		Email      string
+		MerchantID string
+	}
+
+	mockResponseKey struct{}
+)
+
+// SandboxAccountFromEnv loads a sandbox account from the environment variables
+// PAYPAL_SANDBOX_<NAME>_CLIENT_ID, _SECRET, _EMAIL and _MERCHANT_ID, e.g. for the
+// name "seller" from PAYPAL_SANDBOX_SELLER_CLIENT_ID. Client ID and secret are required.
+func SandboxAccountFromEnv(name string) (*SandboxAccount, error) {
+	prefix := "PAYPAL_SANDBOX_" + strings.ToUpper(name) + "_"
+
+	account := &SandboxAccount{
+		ClientID:   os.Getenv(prefix + "CLIENT_ID"),
+		Secret:     os.Getenv(prefix + "SECRET"),
+		Email:      os.Getenv(prefix + "EMAIL"),
+		MerchantID: os.Getenv(prefix + "MERCHANT_ID"),
+	}
+	if account.ClientID == "" || account.Secret == "" {
+		return nil, fmt.Errorf("paypal: %sCLIENT_ID and %sSECRET are required", prefix, prefix)
+	}
+
+	return account, nil
+}
+
+// NewClient returns a Client for the account on the sandbox API
+func (a *SandboxAccount) NewClient() (*Client, error) {
+	return NewClient(a.ClientID, a.Secret, APIBaseSandBox)
+}
+
+// Negative testing codes accepted by WithMockResponse and SetMockResponse
+//
+// https://developer.paypal.com/tools/sandbox/negative-testing/request-headers/
+const (
+	MockInstrumentDeclined         string = ErrorIssueInstrumentDeclined
+	MockTransactionRefused         string = ErrorIssueTransactionRefused
+	MockPayerActionRequired        string = ErrorIssuePayerActionRequired
+	MockDuplicateInvoiceID         string = ErrorIssueDuplicateInvoiceID
+	MockOrderNotApproved           string = ErrorIssueOrderNotApproved
+	MockOrderAlreadyCaptured       string = ErrorIssueOrderAlreadyCaptured
+	MockMaxNumberOfRefundsExceeded string = ErrorIssueMaxNumberOfRefundsExceeded
+	MockAuthorizationExpired       string = "AUTHORIZATION_EXPIRED"
+	MockAuthorizationVoided        string = "AUTHORIZATION_VOIDED"
+	MockCardExpired                string = "CARD_EXPIRED"
+	MockRefundAmountExceeded       string = "REFUND_AMOUNT_EXCEEDED"
+	MockCaptureFullyRefunded       string = "CAPTURE_FULLY_REFUNDED"
+	MockInternalServerError        string = "INTERNAL_SERVER_ERROR"
+	MockPermissionDenied           string = ErrorNamePermissionDenied
+	MockRateLimitReached           string = "RATE_LIMIT_REACHED"
+)
+
+// SetMockResponse makes every sandbox request fail with the given error code,
+// see WithMockResponse, which takes precedence. Pass an empty code to stop mocking.
+func (c *Client) SetMockResponse(code string) {
+	c.mockResponse = code
+}
+
+// WithSandboxMockResponse makes every sandbox request of the Client fail, see SetMockResponse
+func WithSandboxMockResponse(code string) ClientOption {
+	return func(c *Client) {
+		c.SetMockResponse(code)
+	}
+}
+
+// WithMockResponse returns a context making sandbox requests fail with the
+// given error code, e.g. "INSTRUMENT_DECLINED", using PayPal negative testing.
+// The mock is never sent to the live API.
+// Doc: https://developer.paypal.com/tools/sandbox/negative-testing/request-headers/
+func WithMockResponse(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, mockResponseKey{}, code)
+}
+
+// setMockResponse sets the PayPal-Mock-Response header from the request context or the client
+func (c *Client) setMockResponse(req *http.Request) {
+	code, ok := req.Context().Value(mockResponseKey{}).(string)
+	if !ok {
+		code = c.mockResponse
+	}
+	if code == "" || c.APIBase == APIBaseLive {
+		return
+	}
+
+	mock, _ := json.Marshal(map[string]string{"mock_application_codes": code})
+	req.Header.Set("PayPal-Mock-Response", string(mock))
+}
@@ -0,0 +1,70 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestSandboxAccountFromEnv(t *testing.T) {
+	os.Setenv("PAYPAL_SANDBOX_SELLER_CLIENT_ID", "client")
+	os.Setenv("PAYPAL_SANDBOX_SELLER_SECRET", "secret")
+	defer os.Unsetenv("PAYPAL_SANDBOX_SELLER_CLIENT_ID")
+	defer os.Unsetenv("PAYPAL_SANDBOX_SELLER_SECRET")
+
+	account, err := SandboxAccountFromEnv("seller")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := account.NewClient()
+	if err != nil || c.ClientID != "client" || c.APIBase != APIBaseSandBox {
+		t.Errorf("expecting a sandbox client, got %+v %v", c, err)
+	}
+
+	if _, err := SandboxAccountFromEnv("buyer"); err == nil {
+		t.Errorf("expecting an error for a missing account")
+	}
+}
+
+func TestWithMockResponse(t *testing.T) {
+	ctx := WithMockResponse(context.Background(), "INSTRUMENT_DECLINED")
+
+	c, _ := NewClient("foo", "bar", APIBaseSandBox)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, APIBaseSandBox+"/v2/checkout/orders/O1/capture", nil)
+	c.setMockResponse(req)
+	if h := req.Header.Get("PayPal-Mock-Response"); h != `{"mock_application_codes":"INSTRUMENT_DECLINED"}` {
+		t.Errorf("unexpected mock header %q", h)
+	}
+
+	live, _ := NewClient("foo", "bar", APIBaseLive)
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, APIBaseLive+"/v2/checkout/orders/O1/capture", nil)
+	live.setMockResponse(req)
+	if h := req.Header.Get("PayPal-Mock-Response"); h != "" {
+		t.Errorf("expecting no mock header on live, got %q", h)
+	}
+}
+
+func TestSetMockResponse(t *testing.T) {
+	c, _ := NewClient("foo", "bar", APIBaseSandBox, WithSandboxMockResponse(MockInternalServerError))
+
+	req, _ := http.NewRequest(http.MethodPost, APIBaseSandBox+"/v2/checkout/orders/O1/capture", nil)
+	c.setMockResponse(req)
+	if h := req.Header.Get("PayPal-Mock-Response"); h != `{"mock_application_codes":"INTERNAL_SERVER_ERROR"}` {
+		t.Errorf("unexpected client mock header %q", h)
+	}
+
+	ctx := WithMockResponse(context.Background(), MockCardExpired)
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, APIBaseSandBox+"/v2/checkout/orders/O1/capture", nil)
+	c.setMockResponse(req)
+	if h := req.Header.Get("PayPal-Mock-Response"); h != `{"mock_application_codes":"CARD_EXPIRED"}` {
+		t.Errorf("expecting the context mock to take precedence, got %q", h)
+	}
+
+	ctx = WithMockResponse(context.Background(), "")
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, APIBaseSandBox+"/v2/checkout/orders/O1/capture", nil)
+	c.setMockResponse(req)
+	if h := req.Header.Get("PayPal-Mock-Response"); h != "" {
+		t.Errorf("expecting an empty context mock to disable the client mock, got %q", h)
+	}
+}
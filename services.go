@@ -0,0 +1,244 @@
+package paypal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// The interfaces below group the endpoint methods of Client by API, so code
+// using the client can depend on the part it needs and tests can replace it
+// with a mock or a fake, e.g.
+//
+//	type Shop struct {
+//		Orders paypal.OrdersService // *paypal.Client in production
+//	}
+//
+// Left out are the client configuration (the Set* and Use methods), the
+// generic request methods (Do, Send*, NewRequest, SendRaw, FollowLink,
+// FollowRel, Poll, DownloadRange and Batch), the access token methods of the
+// client itself and the paginators, they are not endpoints or are built on
+// them. The deprecated v1 APIs are left out too, so new code doesn't depend on
+// them: billing plans and agreements of billing.go, sales, stored credit cards,
+// web profiles and the *Paypal* variants of the billing agreement methods.
+type (
+	// OrdersService are the endpoints of the Orders API
+	OrdersService interface {
+		CreateOrder(ctx context.Context, intent string, purchaseUnits []PurchaseUnitRequest, payer *CreateOrderPayer, appContext *ApplicationContext) (*Order, error)
+		CreateOrderWithPaypalRequestID(ctx context.Context, intent string, purchaseUnits []PurchaseUnitRequest, payer *CreateOrderPayer, appContext *ApplicationContext, requestID string) (*Order, error)
+		CreateOrderWithRequest(ctx context.Context, createOrderRequest CreateOrderRequest, requestID string) (*Order, error)
+		GetOrder(ctx context.Context, orderID string) (*Order, error)
+		GetOrderPayments(ctx context.Context, orderID string) (*OrderPayments, error)
+		UpdateOrder(ctx context.Context, orderID string, purchaseUnits []PurchaseUnitRequest) (*Order, error)
+		UpdateOrderWithPatches(ctx context.Context, orderID string, patches []Patch) error
+		UpdateOrderShippingOption(ctx context.Context, orderID, referenceID, optionID string) (*Order, error)
+		ConfirmPaymentSource(ctx context.Context, orderID string, confirmOrderRequest ConfirmOrderRequest) (*Order, error)
+		ConfirmOrderPaymentSource(ctx context.Context, orderID string, paymentSource *PaymentSource) (*Order, error)
+		AuthorizeOrder(ctx context.Context, orderID string, authorizeOrderRequest AuthorizeOrderRequest) (*Authorization, error)
+		CaptureOrder(ctx context.Context, orderID string, captureOrderRequest CaptureOrderRequest) (*CaptureOrderResponse, error)
+		CaptureOrderWithPaypalRequestId(ctx context.Context, orderID string, captureOrderRequest CaptureOrderRequest, requestID string) (*CaptureOrderResponse, error)
+		CapturePurchaseUnit(ctx context.Context, orderID string, referenceID string, paymentCaptureRequest *PaymentCaptureRequest, requestID string) (*PaymentCaptureResponse, error)
+		AddOrderTracker(ctx context.Context, orderID string, tracker AddOrderTrackerRequest) (*Order, error)
+		UpdateOrderTracker(ctx context.Context, orderID, trackerID string, patches []Patch) error
+		UpdateOrderTrackerStatus(ctx context.Context, orderID, trackerID string, status ShipmentStatus) error
+		CaptureOrderAsPlatform(ctx context.Context, orderID, sellerMerchantID string, captureOrderRequest CaptureOrderRequest) (*CaptureOrderResponse, error)
+		StartCheckout(ctx context.Context, order CreateOrderRequest) (*Checkout, error)
+		ResumeCheckout(ctx context.Context, orderID string) (*Checkout, error)
+	}
+
+	// PaymentsService are the endpoints of the Payments API: authorizations,
+	// captures and refunds
+	PaymentsService interface {
+		GetAuthorization(ctx context.Context, authID string) (*Authorization, error)
+		CaptureAuthorization(ctx context.Context, authID string, paymentCaptureRequest *PaymentCaptureRequest) (*PaymentCaptureResponse, error)
+		CaptureAuthorizationWithPaypalRequestId(ctx context.Context, authID string, paymentCaptureRequest *PaymentCaptureRequest, requestID string) (*PaymentCaptureResponse, error)
+		VoidAuthorization(ctx context.Context, authID string) (*Authorization, error)
+		ReauthorizeAuthorization(ctx context.Context, authID string, a *Amount) (*Authorization, error)
+		ReauthorizeAuthorizationAmount(ctx context.Context, authID string, amount *Money) (*Authorization, error)
+		GetCapturedPaymentDetails(ctx context.Context, id string) (*Capture, error)
+		CapturedDetail(ctx context.Context, captureID string) (*CaptureDetailsResponse, error)
+		RefundCapture(ctx context.Context, captureID string, refundCaptureRequest RefundCaptureRequest) (*RefundResponse, error)
+		RefundCaptureWithPaypalRequestId(ctx context.Context, captureID string, refundCaptureRequest RefundCaptureRequest, requestID string) (*RefundResponse, error)
+		GetRefundDetails(ctx context.Context, refundID string) (*RefundResponse, error)
+	}
+
+	// PayoutsService are the endpoints of the Payouts and Referenced Payouts APIs
+	PayoutsService interface {
+		CreatePayout(ctx context.Context, p Payout) (*PayoutResponse, error)
+		CreatePayoutSync(ctx context.Context, p Payout) (*PayoutResponse, error)
+		CreatePayoutOnce(ctx context.Context, key string, p Payout) (*PayoutResponse, error)
+		CreateSinglePayout(ctx context.Context, p Payout) (*PayoutResponse, error)
+		GetPayout(ctx context.Context, payoutBatchID string) (*PayoutResponse, error)
+		GetPayoutPage(ctx context.Context, payoutBatchID string, params *PayoutListParams) (*PayoutResponse, error)
+		WaitForPayout(ctx context.Context, payoutBatchID string, policy *RetryPolicy) (*PayoutResponse, error)
+		GetPayoutItem(ctx context.Context, payoutItemID string) (*PayoutItemResponse, error)
+		CancelPayoutItem(ctx context.Context, payoutItemID string) (*PayoutItemResponse, error)
+		CreateReferencedPayout(ctx context.Context, r ReferencedPayoutRequest) (*ReferencedPayoutResponse, error)
+		CreateReferencedPayoutForCaptures(ctx context.Context, captureIDs ...string) (*ReferencedPayoutResponse, error)
+		GetReferencedPayout(ctx context.Context, payoutsBatchID string) (*ReferencedPayoutResponse, error)
+		CreateReferencedPayoutItem(ctx context.Context, item ReferencedPayoutItemRequest) (*ReferencedPayoutItem, error)
+		GetReferencedPayoutItem(ctx context.Context, payoutsItemID string) (*ReferencedPayoutItem, error)
+	}
+
+	// SubscriptionsService are the endpoints of the Subscriptions API: plans,
+	// subscriptions and the catalog products they are for
+	SubscriptionsService interface {
+		CreateProduct(ctx context.Context, product Product) (*CreateProductResponse, error)
+		GetProduct(ctx context.Context, productId string) (*Product, error)
+		ListProducts(ctx context.Context, params *ProductListParameters) (*ListProductsResponse, error)
+		UpdateProduct(ctx context.Context, product Product) error
+		UpdateProductWithPatches(ctx context.Context, productId string, patches []Patch) error
+
+		CreateSubscriptionPlan(ctx context.Context, newPlan SubscriptionPlan) (*CreateSubscriptionPlanResponse, error)
+		GetSubscriptionPlan(ctx context.Context, planId string) (*SubscriptionPlan, error)
+		ListSubscriptionPlans(ctx context.Context, params *SubscriptionPlanListParameters) (*ListSubscriptionPlansResponse, error)
+		UpdateSubscriptionPlan(ctx context.Context, updatedPlan SubscriptionPlan) error
+		UpdateSubscriptionPlanWithPatches(ctx context.Context, planId string, patches []Patch) error
+		UpdateSubscriptionPlanPricing(ctx context.Context, planId string, pricingSchemes []PricingSchemeUpdate) error
+		ActivateSubscriptionPlan(ctx context.Context, planId string) error
+		DeactivateSubscriptionPlans(ctx context.Context, planId string) error
+
+		CreateSubscription(ctx context.Context, newSubscription SubscriptionBase) (*SubscriptionDetailResp, error)
+		GetSubscriptionDetails(ctx context.Context, subscriptionID string) (*SubscriptionDetailResp, error)
+		UpdateSubscription(ctx context.Context, updatedSubscription Subscription) error
+		UpdateSubscriptionWithPatches(ctx context.Context, subscriptionID string, patches []Patch) error
+		ReviseSubscription(ctx context.Context, subscriptionId string, reviseSubscription SubscriptionBase) (*SubscriptionDetailResp, error)
+		ReviseSubscriptionPlan(ctx context.Context, subscriptionId string, revision ReviseSubscriptionRequest) (*ReviseSubscriptionResponse, error)
+		ActivateSubscription(ctx context.Context, subscriptionId, activateReason string) error
+		SuspendSubscription(ctx context.Context, subscriptionId, reason string) error
+		CancelSubscription(ctx context.Context, subscriptionId, cancelReason string) error
+		CaptureSubscription(ctx context.Context, subscriptionId string, request CaptureReqeust) (*SubscriptionCaptureResponse, error)
+		CaptureOutstandingBalance(ctx context.Context, subscriptionId string, amount Money, note string) (*SubscriptionCaptureResponse, error)
+		GetSubscriptionTransactions(ctx context.Context, requestParams SubscriptionTransactionsParams) (*SubscriptionTransactionsResponse, error)
+		ListSubscriptionTransactions(ctx context.Context, subscriptionID string, start, end time.Time) ([]SubscriptionCaptureResponse, error)
+	}
+
+	// InvoicingService are the endpoints of the Invoicing API
+	InvoicingService interface {
+		CreateDraftInvoice(ctx context.Context, invoice Invoice) (*Invoice, error)
+		GenerateNextInvoiceNumber(ctx context.Context) (*InvoiceNumber, error)
+		GetInvoice(ctx context.Context, invoiceID string) (*Invoice, error)
+		ListInvoices(ctx context.Context, params *ListParams) (*ListInvoicesResponse, error)
+		SearchInvoices(ctx context.Context, search InvoiceSearch, params *ListParams) (*ListInvoicesResponse, error)
+		DeleteInvoice(ctx context.Context, invoiceID string) error
+		SendInvoice(ctx context.Context, invoiceID string, sendInvoiceRequest SendInvoiceRequest) (*Link, error)
+		SendInvoiceReminder(ctx context.Context, invoiceID string, notification InvoiceNotificationRequest) error
+		CancelSentInvoice(ctx context.Context, invoiceID string, notification InvoiceNotificationRequest) error
+		RecordInvoicePayment(ctx context.Context, invoiceID string, payment InvoicePaymentDetail) (*InvoicePaymentReference, error)
+		RecordInvoiceRefund(ctx context.Context, invoiceID string, refund InvoiceRefundDetail) (*InvoiceRefundReference, error)
+		GenerateInvoiceQRCode(ctx context.Context, invoiceID string, qrCodeRequest InvoiceQRCodeRequest) (*InvoiceQRCode, error)
+		CreateInvoiceTemplate(ctx context.Context, template InvoiceTemplate) (*InvoiceTemplate, error)
+		GetInvoiceTemplate(ctx context.Context, templateID string) (*InvoiceTemplate, error)
+		ListInvoiceTemplates(ctx context.Context, params *ListParams) (*ListInvoiceTemplatesResponse, error)
+		UpdateInvoiceTemplate(ctx context.Context, template InvoiceTemplate) (*InvoiceTemplate, error)
+		DeleteInvoiceTemplate(ctx context.Context, templateID string) error
+	}
+
+	// DisputesService are the endpoints of the Disputes API
+	DisputesService interface {
+		GetDispute(ctx context.Context, disputeID string) (*Dispute, error)
+		ListDisputes(ctx context.Context, params *DisputeListParams) (*ListDisputesResponse, error)
+		WaitForDisputeOutcome(ctx context.Context, disputeID string, interval time.Duration) (*Dispute, error)
+		AcceptDisputeClaim(ctx context.Context, disputeID string, accept AcceptDisputeClaimRequest) (*DisputeActionResponse, error)
+		ProvideDisputeEvidence(ctx context.Context, disputeID string, evidences []DisputeEvidence, files ...DisputeFile) (*DisputeActionResponse, error)
+		AppealDispute(ctx context.Context, disputeID string, evidences []DisputeEvidence, files ...DisputeFile) (*DisputeActionResponse, error)
+		AcknowledgeReturnItem(ctx context.Context, disputeID string, acknowledge AcknowledgeReturnItemRequest) (*DisputeActionResponse, error)
+		SendDisputeMessage(ctx context.Context, disputeID string, message string) (*DisputeActionResponse, error)
+		MakeDisputeOffer(ctx context.Context, disputeID string, offer MakeDisputeOfferRequest) (*DisputeActionResponse, error)
+		AcceptDisputeOffer(ctx context.Context, disputeID string, note string) (*DisputeActionResponse, error)
+		DenyDisputeOffer(ctx context.Context, disputeID string, note string) (*DisputeActionResponse, error)
+		EscalateDispute(ctx context.Context, disputeID string, note string) (*DisputeActionResponse, error)
+		DownloadDisputeDocument(ctx context.Context, document DisputeDocument, w io.Writer) (int64, error)
+	}
+
+	// WebhooksService are the endpoints of the Webhooks Management API
+	WebhooksService interface {
+		CreateWebhook(ctx context.Context, createWebhookRequest *CreateWebhookRequest) (*Webhook, error)
+		GetWebhook(ctx context.Context, webhookID string) (*Webhook, error)
+		ListWebhooks(ctx context.Context, anchorType string) (*ListWebhookResponse, error)
+		UpdateWebhook(ctx context.Context, webhookID string, fields []WebhookField) (*Webhook, error)
+		DeleteWebhook(ctx context.Context, webhookID string) error
+		GetWebhookEventTypes(ctx context.Context) (*WebhookEventTypesResponse, error)
+		ListWebhookSubscribedEventTypes(ctx context.Context, webhookID string) (*WebhookEventTypesResponse, error)
+		GetWebhookEvent(ctx context.Context, eventID string) (*WebhookEvent, error)
+		ListWebhookEvents(ctx context.Context, params *ListWebhookEventsParams) (*ListWebhookEventsResponse, error)
+		ResendWebhookEvent(ctx context.Context, eventID string, webhookIDs ...string) (*WebhookEvent, error)
+		SimulateWebhookEvent(ctx context.Context, simulation SimulateWebhookEventRequest) (*WebhookEvent, error)
+		VerifyWebhookSignature(ctx context.Context, httpReq *http.Request, webhookID string) (*VerifyWebhookResponse, error)
+		VerifyWebhookSignatureRaw(ctx context.Context, webhookID string, header http.Header, body []byte) (*VerifyWebhookResponse, error)
+		VerifyAndParse(ctx context.Context, webhookID string, header http.Header, body []byte) (*WebhookEvent, error)
+	}
+
+	// BillingAgreementsService are the endpoints of the billing agreements of
+	// the v1 Payments API used for reference transactions
+	BillingAgreementsService interface {
+		CreateBillingAgreementToken(ctx context.Context, description *string, shippingAddress *ShippingAddress, payer *Payer, plan *BillingPlan) (*BillingAgreementToken, error)
+		CreateBillingAgreementFromToken(ctx context.Context, tokenID string) (*BillingAgreementFromToken, error)
+		GetBillingAgreement(ctx context.Context, billingAgreementID string) (*BillingAgreementFromToken, error)
+		CancelBillingAgreement(ctx context.Context, billingAgreementID string) error
+		ChargeBillingAgreement(ctx context.Context, billingAgreementID string, purchaseUnits []PurchaseUnitRequest, clientMetadataID string, idempotencyKey string) (*CaptureOrderResponse, error)
+	}
+
+	// TransactionsService are the endpoints of the Transaction Search API
+	TransactionsService interface {
+		ListTransactions(ctx context.Context, req *TransactionSearchRequest) (*TransactionSearchResponse, error)
+		StreamTransactions(ctx context.Context, req *TransactionSearchRequest, fn func(SearchTransactionDetails) error) error
+	}
+
+	// TrackingService are the endpoints of the Tracking API
+	TrackingService interface {
+		AddTrackers(ctx context.Context, trackers []Tracker) (*TrackersBatchResponse, error)
+		GetTracker(ctx context.Context, transactionID, trackingNumber string) (*Tracker, error)
+		UpdateTracker(ctx context.Context, tracker Tracker) error
+	}
+
+	// IdentityService are the endpoints of the Identity API and of the tokens
+	// issued to buyers and sellers
+	IdentityService interface {
+		GrantNewAccessTokenFromAuthCode(ctx context.Context, code, redirectURI string) (*TokenResponse, error)
+		GrantNewAccessTokenFromRefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error)
+		ExchangeSellerAuthCode(ctx context.Context, authCode, sharedID, nonce string) (*TokenResponse, error)
+		RevokeToken(ctx context.Context, token, tokenTypeHint string) error
+		GenerateClientToken(ctx context.Context, customerID string) (*ClientToken, error)
+		GetUserInfo(ctx context.Context, schema string) (*UserInfo, error)
+		GetPaypalUserInfo(ctx context.Context, accessToken string) (*PaypalUserInfo, error)
+	}
+
+	// PartnerService are the endpoints of the Partner Referrals API and the
+	// merchant integrations of partners
+	PartnerService interface {
+		CreatePartnerReferral(ctx context.Context, referral ReferralRequest) (*ReferralResponse, error)
+		GetPartnerReferral(ctx context.Context, referralID string) (*PartnerReferral, error)
+		GetMerchantIntegration(ctx context.Context, partnerID, merchantID string) (*MerchantIntegration, error)
+		FindMerchantIntegration(ctx context.Context, partnerID, trackingID string) (*MerchantIntegrationReference, error)
+	}
+
+	// VaultService are the endpoints of the Payment Method Tokens API
+	VaultService interface {
+		CreateSetupToken(ctx context.Context, customerID string, paymentSource SetupTokenSource) (*SetupToken, error)
+		GetSetupToken(ctx context.Context, id string) (*SetupToken, error)
+		CreatePaymentToken(ctx context.Context, customerID string, paymentSource PaymentTokenSource) (*PaymentToken, error)
+		GetPaymentToken(ctx context.Context, id string) (*PaymentToken, error)
+		ListPaymentTokens(ctx context.Context, customerID string) (*PaymentTokens, error)
+		ListPaymentTokensPage(ctx context.Context, customerID string, params *ListParams) (*PaymentTokens, error)
+		DeletePaymentToken(ctx context.Context, id string) error
+	}
+)
+
+// Client implements every service
+var (
+	_ OrdersService            = (*Client)(nil)
+	_ PaymentsService          = (*Client)(nil)
+	_ PayoutsService           = (*Client)(nil)
+	_ SubscriptionsService     = (*Client)(nil)
+	_ InvoicingService         = (*Client)(nil)
+	_ DisputesService          = (*Client)(nil)
+	_ WebhooksService          = (*Client)(nil)
+	_ VaultService             = (*Client)(nil)
+	_ BillingAgreementsService = (*Client)(nil)
+	_ TransactionsService      = (*Client)(nil)
+	_ TrackingService          = (*Client)(nil)
+	_ IdentityService          = (*Client)(nil)
+	_ PartnerService           = (*Client)(nil)
+)
@@ -0,0 +1,40 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeOrders replaces the Orders API in tests, the embedded interface panics
+// for the methods it does not implement
+type fakeOrders struct {
+	OrdersService
+	orders map[string]*Order
+}
+
+func (f *fakeOrders) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	order, ok := f.orders[orderID]
+	if !ok {
+		return nil, &ErrorResponse{Name: "RESOURCE_NOT_FOUND"}
+	}
+	return order, nil
+}
+
+func TestOrdersServiceFake(t *testing.T) {
+	approved := func(orders OrdersService, orderID string) (bool, error) {
+		order, err := orders.GetOrder(context.Background(), orderID)
+		if err != nil {
+			return false, err
+		}
+		return order.Status == OrderStatusApproved, nil
+	}
+
+	orders := &fakeOrders{orders: map[string]*Order{"O1": {ID: "O1", Status: OrderStatusApproved}}}
+	if ok, err := approved(orders, "O1"); !ok || err != nil {
+		t.Errorf("expecting O1 to be approved, got %v, %v", ok, err)
+	}
+	if _, err := approved(orders, "O2"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expecting a not found error, got %v", err)
+	}
+}
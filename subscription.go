@@ -2,8 +2,11 @@ package paypal
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -18,6 +21,30 @@ type (
 		AutoRenewal        bool                `json:"auto_renewal,omitempty"`
 		ApplicationContext *ApplicationContext `json:"application_context,omitempty"`
 		CustomID           string              `json:"custom_id,omitempty"`
+		Plan               *PlanOverride       `json:"plan,omitempty"`
+	}
+
+	// PlanOverride customizes the plan for this subscription only
+	// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-plan_override
+	PlanOverride struct {
+		BillingCycles      []BillingCycleOverride      `json:"billing_cycles,omitempty"`
+		PaymentPreferences *PaymentPreferencesOverride `json:"payment_preferences,omitempty"`
+		Taxes              *Taxes                      `json:"taxes,omitempty"`
+	}
+
+	// BillingCycleOverride replaces the pricing or number of cycles of the plan billing cycle with the same sequence
+	BillingCycleOverride struct {
+		PricingScheme *PricingScheme `json:"pricing_scheme,omitempty"`
+		Sequence      int            `json:"sequence"`
+		TotalCycles   *int           `json:"total_cycles,omitempty"`
+	}
+
+	// PaymentPreferencesOverride struct
+	PaymentPreferencesOverride struct {
+		AutoBillOutstanding     *bool                 `json:"auto_bill_outstanding,omitempty"`
+		SetupFee                *Money                `json:"setup_fee,omitempty"`
+		SetupFeeFailureAction   SetupFeeFailureAction `json:"setup_fee_failure_action,omitempty"`
+		PaymentFailureThreshold *int                  `json:"payment_failure_threshold,omitempty"`
 	}
 
 	SubscriptionDetails struct {
@@ -68,6 +95,29 @@ type (
 		SharedListResponse
 	}
 
+	// ReviseSubscriptionRequest changes the plan, quantity or shipping of a subscription
+	// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_revise
+	ReviseSubscriptionRequest struct {
+		PlanID             string              `json:"plan_id,omitempty"`
+		Quantity           string              `json:"quantity,omitempty"`
+		EffectiveTime      *JSONTime           `json:"effective_time,omitempty"`
+		ShippingAmount     *Money              `json:"shipping_amount,omitempty"`
+		ShippingAddress    *ShippingDetail     `json:"shipping_address,omitempty"`
+		ApplicationContext *ApplicationContext `json:"application_context,omitempty"`
+		Plan               *PlanOverride       `json:"plan,omitempty"`
+	}
+
+	// ReviseSubscriptionResponse struct
+	ReviseSubscriptionResponse struct {
+		PlanID          string          `json:"plan_id,omitempty"`
+		Quantity        string          `json:"quantity,omitempty"`
+		EffectiveTime   *time.Time      `json:"effective_time,omitempty"`
+		ShippingAmount  *Money          `json:"shipping_amount,omitempty"`
+		ShippingAddress *ShippingDetail `json:"shipping_address,omitempty"`
+		PlanOverridden  bool            `json:"plan_overridden,omitempty"`
+		Links           Links           `json:"links,omitempty"`
+	}
+
 	CaptureReqeust struct {
 		Note        string      `json:"note"`
 		CaptureType CaptureType `json:"capture_type"`
@@ -75,6 +125,15 @@ type (
 	}
 )
 
+// ApprovalURL returns the link the buyer must be redirected to to approve the revision,
+// it is empty when the revision takes effect without approval
+func (r *ReviseSubscriptionResponse) ApprovalURL() string {
+	if link := FindLink(r.Links, "approve"); link != nil {
+		return link.Href
+	}
+	return ""
+}
+
 func (self *Subscription) GetUpdatePatch() []Patch {
 	result := []Patch{
 		{
@@ -91,11 +150,11 @@ func (self *Subscription) GetUpdatePatch() []Patch {
 // Endpoint: POST /v1/billing/subscriptions
 func (c *Client) CreateSubscription(ctx context.Context, newSubscription SubscriptionBase) (*SubscriptionDetailResp, error) {
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v1/billing/subscriptions"), newSubscription)
-	req.Header.Add("Prefer", "return=representation")
 	response := &SubscriptionDetailResp{}
 	if err != nil {
 		return response, err
 	}
+	req.Header.Set("Prefer", "return=representation")
 	err = c.SendWithAuth(req, response)
 	return response, err
 }
@@ -112,10 +171,26 @@ func (c *Client) UpdateSubscription(ctx context.Context, updatedSubscription Sub
 	return err
 }
 
+// UpdateSubscriptionWithPatches applies the patches to a subscription, e.g. to replace
+// its custom_id, shipping_amount or the plan/billing_cycles/@sequence==1/pricing_scheme/fixed_price
+// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_patch
+// Endpoint: PATCH /v1/billing/subscriptions/:subscription_id
+func (c *Client) UpdateSubscriptionWithPatches(ctx context.Context, subscriptionID string, patches []Patch) error {
+	if len(patches) == 0 {
+		return errors.New("paypal: a subscription update needs at least one patch")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/v1/billing/subscriptions/%s", c.APIBase, subscriptionID), patches)
+	if err != nil {
+		return err
+	}
+	return c.SendWithAuth(req, nil)
+}
+
 // GetSubscriptionDetails shows details for a subscription, by ID.
 // Endpoint: GET /v1/billing/subscriptions/
 func (c *Client) GetSubscriptionDetails(ctx context.Context, subscriptionID string) (*SubscriptionDetailResp, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/billing/subscriptions/%s", c.APIBase, subscriptionID), nil)
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/billing/subscriptions/%s", c.APIBase, subscriptionID), nil)
 	response := &SubscriptionDetailResp{}
 	if err != nil {
 		return response, err
@@ -149,6 +224,8 @@ func (c *Client) CancelSubscription(ctx context.Context, subscriptionId, cancelR
 }
 
 // Captures an authorized payment from the subscriber on the subscription.
+// PayPal may accept the capture without a transaction in the response, the
+// transaction then shows up in GetSubscriptionTransactions once processed.
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_capture
 // Endpoint: POST /v1/billing/subscriptions/{id}/capture
 func (c *Client) CaptureSubscription(ctx context.Context, subscriptionId string, request CaptureReqeust) (*SubscriptionCaptureResponse, error) {
@@ -157,10 +234,21 @@ func (c *Client) CaptureSubscription(ctx context.Context, subscriptionId string,
 	if err != nil {
 		return response, err
 	}
-	err = c.SendWithAuth(req, response)
+	if err = c.SendWithAuth(req, response); errors.Is(err, io.EOF) {
+		err = nil
+	}
 	return response, err
 }
 
+// CaptureOutstandingBalance captures the outstanding balance of a subscription
+func (c *Client) CaptureOutstandingBalance(ctx context.Context, subscriptionId string, amount Money, note string) (*SubscriptionCaptureResponse, error) {
+	return c.CaptureSubscription(ctx, subscriptionId, CaptureReqeust{
+		Note:        note,
+		CaptureType: CaptureTypeOutstandingBalance,
+		Amount:      amount,
+	})
+}
+
 // Suspends the subscription.
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_suspend
 // Endpoint: POST /v1/billing/subscriptions/{id}/suspend
@@ -177,9 +265,10 @@ func (c *Client) SuspendSubscription(ctx context.Context, subscriptionId, reason
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_transactions
 // Endpoint: GET /v1/billing/subscriptions/{id}/transactions
 func (c *Client) GetSubscriptionTransactions(ctx context.Context, requestParams SubscriptionTransactionsParams) (*SubscriptionTransactionsResponse, error) {
-	startTime := requestParams.StartTime.Format("2006-01-02T15:04:05Z")
-	endTime := requestParams.EndTime.Format("2006-01-02T15:04:05Z")
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/billing/subscriptions/%s/transactions?start_time=%s&end_time=%s", c.APIBase, requestParams.SubscriptionId, startTime, endTime), nil)
+	q := url.Values{}
+	q.Set("start_time", requestParams.StartTime.UTC().Format("2006-01-02T15:04:05Z"))
+	q.Set("end_time", requestParams.EndTime.UTC().Format("2006-01-02T15:04:05Z"))
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/billing/subscriptions/%s/transactions?%s", c.APIBase, requestParams.SubscriptionId, q.Encode()), nil)
 	response := &SubscriptionTransactionsResponse{}
 	if err != nil {
 		return response, err
@@ -189,7 +278,27 @@ func (c *Client) GetSubscriptionTransactions(ctx context.Context, requestParams
 	return response, err
 }
 
-// Revise plan or quantity of subscription
+// ListSubscriptionTransactions lists the transactions of a subscription between start and end,
+// the times are sent in UTC whatever their location
+// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_transactions
+// Endpoint: GET /v1/billing/subscriptions/{id}/transactions
+func (c *Client) ListSubscriptionTransactions(ctx context.Context, subscriptionID string, start, end time.Time) ([]SubscriptionCaptureResponse, error) {
+	if !start.Before(end) {
+		return nil, errors.New("paypal: subscription transactions start time must be before the end time")
+	}
+
+	response, err := c.GetSubscriptionTransactions(ctx, SubscriptionTransactionsParams{
+		SubscriptionId: subscriptionID,
+		StartTime:      start,
+		EndTime:        end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Transactions, nil
+}
+
+// Revise plan or quantity of subscription, see ReviseSubscriptionPlan for a typed revision
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_revise
 // Endpoint: POST /v1/billing/subscriptions/{id}/revise
 func (c *Client) ReviseSubscription(ctx context.Context, subscriptionId string, reviseSubscription SubscriptionBase) (*SubscriptionDetailResp, error) {
@@ -199,8 +308,23 @@ func (c *Client) ReviseSubscription(ctx context.Context, subscriptionId string,
 		return response, err
 	}
 
-	req.Header.Add("Content-Type", "application/json")
 	err = c.SendWithAuth(req, response)
 
 	return response, err
 }
+
+// ReviseSubscriptionPlan updates the plan, quantity or shipping of a subscription.
+// Changes that raise the price need the buyer's approval, redirect the buyer to
+// the ApprovalURL of the response when it is not empty.
+// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_revise
+// Endpoint: POST /v1/billing/subscriptions/{id}/revise
+func (c *Client) ReviseSubscriptionPlan(ctx context.Context, subscriptionId string, revision ReviseSubscriptionRequest) (*ReviseSubscriptionResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/subscriptions/%s/revise", c.APIBase, subscriptionId), revision)
+	response := &ReviseSubscriptionResponse{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
@@ -2,8 +2,13 @@ package paypal
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -39,10 +44,21 @@ type (
 	}
 
 	PricingScheme struct {
-		Version    int       `json:"version"`
-		FixedPrice Money     `json:"fixed_price"`
-		CreateTime time.Time `json:"create_time"`
-		UpdateTime time.Time `json:"update_time"`
+		Version      int           `json:"version"`
+		FixedPrice   Money         `json:"fixed_price"`
+		PricingModel PricingModel  `json:"pricing_model,omitempty"`
+		Tiers        []PricingTier `json:"tiers,omitempty"`
+		CreateTime   time.Time     `json:"create_time"`
+		UpdateTime   time.Time     `json:"update_time"`
+	}
+
+	// PricingTier is the price of a range of quantities of a VOLUME or TIERED pricing scheme,
+	// leave EndingQuantity empty for the last tier
+	// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-pricing_tier
+	PricingTier struct {
+		StartingQuantity string `json:"starting_quantity"`
+		EndingQuantity   string `json:"ending_quantity,omitempty"`
+		Amount           Money  `json:"amount"`
 	}
 
 	PricingSchemeUpdateRequest struct {
@@ -82,6 +98,124 @@ type (
 	}
 )
 
+// MarshalJSON omits the fields PayPal sets itself and the fixed price of
+// tiered schemes, which PayPal rejects when they are sent empty
+func (p PricingScheme) MarshalJSON() ([]byte, error) {
+	scheme := struct {
+		Version      int           `json:"version,omitempty"`
+		FixedPrice   *Money        `json:"fixed_price,omitempty"`
+		PricingModel PricingModel  `json:"pricing_model,omitempty"`
+		Tiers        []PricingTier `json:"tiers,omitempty"`
+		CreateTime   *time.Time    `json:"create_time,omitempty"`
+		UpdateTime   *time.Time    `json:"update_time,omitempty"`
+	}{
+		Version:      p.Version,
+		PricingModel: p.PricingModel,
+		Tiers:        p.Tiers,
+	}
+	if p.FixedPrice != (Money{}) {
+		scheme.FixedPrice = &p.FixedPrice
+	}
+	if !p.CreateTime.IsZero() {
+		scheme.CreateTime = &p.CreateTime
+	}
+	if !p.UpdateTime.IsZero() {
+		scheme.UpdateTime = &p.UpdateTime
+	}
+	return json.Marshal(scheme)
+}
+
+// Price returns the price of a billing cycle for quantity units, the quantity
+// of a subscription to a plan with quantity_supported. Without tiers it is the
+// fixed price times quantity. With the VOLUME model every unit costs the amount
+// of the tier the quantity falls in, with the TIERED model the units of each
+// tier cost the amount of their tier.
+func (p PricingScheme) Price(quantity int) (Money, error) {
+	if quantity < 1 {
+		return Money{}, fmt.Errorf("paypal: invalid quantity %d", quantity)
+	}
+	if len(p.Tiers) == 0 {
+		return multiplyMoney(p.FixedPrice, quantity, new(big.Rat))
+	}
+
+	ranges, err := tierRanges(p.Tiers)
+	if err != nil {
+		return Money{}, fmt.Errorf("paypal: %v", err)
+	}
+
+	total := new(big.Rat)
+	for i, r := range ranges {
+		if quantity < r.start {
+			break
+		}
+		last := quantity
+		if r.end != 0 && r.end < quantity {
+			last = r.end
+		}
+		switch p.PricingModel {
+		case PricingModelVolume:
+			if last == quantity {
+				return multiplyMoney(p.Tiers[i].Amount, quantity, total)
+			}
+		case PricingModelTiered:
+			if _, err := multiplyMoney(p.Tiers[i].Amount, last-r.start+1, total); err != nil {
+				return Money{}, err
+			}
+			if last == quantity {
+				return Money{Currency: p.Tiers[i].Amount.Currency, Value: total.FloatString(CurrencyDecimals(p.Tiers[i].Amount.Currency))}, nil
+			}
+		default:
+			return Money{}, fmt.Errorf("paypal: unknown pricing_model %q", p.PricingModel)
+		}
+	}
+	return Money{}, fmt.Errorf("paypal: no pricing tier for quantity %d", quantity)
+}
+
+// multiplyMoney adds amount times quantity to total and returns total as Money
+// in the currency of amount
+func multiplyMoney(amount Money, quantity int, total *big.Rat) (Money, error) {
+	value, err := parseAmount(amount.Value)
+	if err != nil {
+		return Money{}, err
+	}
+	total.Add(total, value.Mul(value, new(big.Rat).SetInt64(int64(quantity))))
+	return Money{Currency: amount.Currency, Value: total.FloatString(CurrencyDecimals(amount.Currency))}, nil
+}
+
+// quantityRange is the range of quantities of a pricing tier, end is 0 for
+// the open-ended last tier
+type quantityRange struct {
+	start, end int
+}
+
+// tierRanges parses the quantities of the tiers, which must be consecutive
+func tierRanges(tiers []PricingTier) ([]quantityRange, error) {
+	ranges := make([]quantityRange, len(tiers))
+	for i, tier := range tiers {
+		start, err := strconv.Atoi(tier.StartingQuantity)
+		if err != nil || start < 1 {
+			return nil, fmt.Errorf("tier %d: invalid starting_quantity %q", i, tier.StartingQuantity)
+		}
+		if i > 0 && start != ranges[i-1].end+1 {
+			return nil, fmt.Errorf("tier %d: starting_quantity %d does not follow ending_quantity %d of the previous tier", i, start, ranges[i-1].end)
+		}
+		ranges[i].start = start
+
+		if tier.EndingQuantity == "" {
+			if i != len(tiers)-1 {
+				return nil, fmt.Errorf("tier %d: only the last tier may have no ending_quantity", i)
+			}
+			continue
+		}
+		end, err := strconv.Atoi(tier.EndingQuantity)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("tier %d: invalid ending_quantity %q", i, tier.EndingQuantity)
+		}
+		ranges[i].end = end
+	}
+	return ranges, nil
+}
+
 func (self *SubscriptionPlan) GetUpdatePatch() []Patch {
 	result := []Patch{
 		{
@@ -178,19 +312,51 @@ func (c *Client) ListSubscriptionPlans(ctx context.Context, params *Subscription
 	}
 
 	if params != nil {
-		q := req.URL.Query()
-		q.Add("page", params.Page)
-		q.Add("page_size", params.PageSize)
-		q.Add("total_required", params.TotalRequired)
-		q.Add("product_id", params.ProductId)
-		q.Add("plan_ids", params.PlanIds)
-		req.URL.RawQuery = q.Encode()
+		req.URL.RawQuery = params.query().Encode()
 	}
 
 	err = c.SendWithAuth(req, response)
 	return response, err
 }
 
+// NewSubscriptionPlanPaginator returns a Paginator over all pages of plans,
+// decode each page into a ListSubscriptionPlansResponse
+func (c *Client) NewSubscriptionPlanPaginator(params *SubscriptionPlanListParameters) *Paginator {
+	q := url.Values{}
+	if params != nil {
+		q = params.query()
+	}
+	return c.NewPaginator("/v1/billing/plans", q)
+}
+
+// UpdateSubscriptionPlanWithPatches applies the patches to a plan, for changes
+// GetUpdatePatch doesn't cover such as /name or /payment_preferences/setup_fee
+// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_patch
+// Endpoint: PATCH /v1/billing/plans/:plan_id
+func (c *Client) UpdateSubscriptionPlanWithPatches(ctx context.Context, planId string, patches []Patch) error {
+	if len(patches) == 0 {
+		return errors.New("paypal: a plan update needs at least one patch")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/billing/plans/", planId), patches)
+	if err != nil {
+		return err
+	}
+	return c.SendWithAuth(req, nil)
+}
+
+// query returns the plan list parameters as query, omitting empty ones
+func (p *SubscriptionPlanListParameters) query() url.Values {
+	q := p.ListParams.query()
+	if p.ProductId != "" {
+		q.Set("product_id", p.ProductId)
+	}
+	if p.PlanIds != "" {
+		q.Set("plan_ids", p.PlanIds)
+	}
+	return q
+}
+
 // Activates a plan
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_activate
 // Endpoint: POST /v1/billing/plans/{id}/activate
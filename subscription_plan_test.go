@@ -0,0 +1,131 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestPricingSchemeMarshalJSON(t *testing.T) {
+	tiered, err := json.Marshal(PricingScheme{
+		PricingModel: PricingModelTiered,
+		Tiers: []PricingTier{
+			{StartingQuantity: "1", EndingQuantity: "10", Amount: Money{Currency: "USD", Value: "10"}},
+			{StartingQuantity: "11", Amount: Money{Currency: "USD", Value: "8"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"pricing_model":"TIERED","tiers":[{"starting_quantity":"1","ending_quantity":"10","amount":{"currency_code":"USD","value":"10.00"}},` +
+		`{"starting_quantity":"11","amount":{"currency_code":"USD","value":"8.00"}}]}`
+	if string(tiered) != expected {
+		t.Errorf("expecting %s, got %s", expected, tiered)
+	}
+
+	fixed, err := json.Marshal(PricingScheme{FixedPrice: Money{Currency: "EUR", Value: "5"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(fixed) != `{"fixed_price":{"currency_code":"EUR","value":"5.00"}}` {
+		t.Errorf("unexpected fixed pricing scheme %s", fixed)
+	}
+
+	var decoded PricingScheme
+	if err := json.Unmarshal([]byte(`{"version":2,"fixed_price":{"currency_code":"EUR","value":"5.00"},"create_time":"2024-01-01T00:00:00Z"}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Version != 2 || decoded.FixedPrice.Value != "5.00" || decoded.CreateTime.IsZero() {
+		t.Errorf("unexpected decoded pricing scheme %+v", decoded)
+	}
+}
+
+func TestPricingSchemePrice(t *testing.T) {
+	tiers := []PricingTier{
+		{StartingQuantity: "1", EndingQuantity: "10", Amount: Money{Currency: "USD", Value: "10"}},
+		{StartingQuantity: "11", EndingQuantity: "100", Amount: Money{Currency: "USD", Value: "8"}},
+		{StartingQuantity: "101", Amount: Money{Currency: "USD", Value: "5.50"}},
+	}
+	tests := []struct {
+		scheme   PricingScheme
+		quantity int
+		price    Money
+	}{
+		{PricingScheme{FixedPrice: Money{Currency: "EUR", Value: "4.99"}}, 3, Money{Currency: "EUR", Value: "14.97"}},
+		{PricingScheme{PricingModel: PricingModelVolume, Tiers: tiers}, 10, Money{Currency: "USD", Value: "100.00"}},
+		{PricingScheme{PricingModel: PricingModelVolume, Tiers: tiers}, 11, Money{Currency: "USD", Value: "88.00"}},
+		{PricingScheme{PricingModel: PricingModelVolume, Tiers: tiers}, 200, Money{Currency: "USD", Value: "1100.00"}},
+		{PricingScheme{PricingModel: PricingModelTiered, Tiers: tiers}, 5, Money{Currency: "USD", Value: "50.00"}},
+		{PricingScheme{PricingModel: PricingModelTiered, Tiers: tiers}, 11, Money{Currency: "USD", Value: "108.00"}},
+		{PricingScheme{PricingModel: PricingModelTiered, Tiers: tiers}, 102, Money{Currency: "USD", Value: "831.00"}},
+	}
+	for _, tt := range tests {
+		price, err := tt.scheme.Price(tt.quantity)
+		if err != nil {
+			t.Errorf("%s %d: %v", tt.scheme.PricingModel, tt.quantity, err)
+			continue
+		}
+		if price != tt.price {
+			t.Errorf("%s %d: expecting %+v, got %+v", tt.scheme.PricingModel, tt.quantity, tt.price, price)
+		}
+	}
+
+	bounded := PricingScheme{PricingModel: PricingModelVolume, Tiers: tiers[:2]}
+	if _, err := bounded.Price(101); err == nil {
+		t.Error("expecting an error for a quantity above the last tier")
+	}
+	if _, err := bounded.Price(0); err == nil {
+		t.Error("expecting an error for a quantity of 0")
+	}
+}
+
+func TestListSubscriptionPlansQuery(t *testing.T) {
+	var query string
+	var patches []Patch
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "GET /v1/billing/plans":
+			query = r.URL.RawQuery
+			w.Write([]byte(`{"plans":[{"id":"P-1","product_id":"PROD-1","name":"Basic","status":"ACTIVE"}],"total_items":1,"total_pages":1}`))
+		case "PATCH /v1/billing/plans/P-1":
+			json.NewDecoder(r.Body).Decode(&patches)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	plans, err := c.ListSubscriptionPlans(ctx, &SubscriptionPlanListParameters{
+		ProductId:  "PROD-1",
+		ListParams: ListParams{PageSize: "20"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "page_size=20&product_id=PROD-1" {
+		t.Errorf("expecting only the set parameters, got %q", query)
+	}
+	if len(plans.Plans) != 1 || plans.TotalItems != 1 {
+		t.Errorf("unexpected plans %+v", plans)
+	}
+
+	if err := c.UpdateSubscriptionPlanWithPatches(ctx, "P-1", nil); err == nil {
+		t.Error("expecting an error for an update without patches")
+	}
+	if err := c.UpdateSubscriptionPlanWithPatches(ctx, "P-1", []Patch{{Operation: "replace", Path: "/name", Value: "Premium"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 || patches[0].Value != "Premium" {
+		t.Errorf("unexpected patches %+v", patches)
+	}
+}
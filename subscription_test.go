@@ -0,0 +1,199 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestSubscriptionLifecycle(t *testing.T) {
+	var requests []string
+	var created map[string]interface{}
+	var patches []Patch
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			if r.URL.Path == "/v1/billing/subscriptions" {
+				json.NewDecoder(r.Body).Decode(&created)
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id":"I-1","plan_id":"P-1","status":"APPROVAL_PENDING",` +
+					`"links":[{"href":"https://www.paypal.com/webapps/billing/subscriptions?ba_token=BA-1","rel":"approve","method":"GET"}]}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPatch:
+			json.NewDecoder(r.Body).Decode(&patches)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			w.Write([]byte(`{"id":"I-1","plan_id":"P-1","status":"ACTIVE"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	cycles := 12
+	sub, err := c.CreateSubscription(ctx, SubscriptionBase{
+		PlanID:     "P-1",
+		Subscriber: &Subscriber{EmailAddress: "buyer@example.com"},
+		ApplicationContext: &ApplicationContext{
+			UserAction: UserActionSubscribeNow,
+			ReturnURL:  "https://example.com/return",
+		},
+		Plan: &PlanOverride{
+			BillingCycles: []BillingCycleOverride{{Sequence: 1, TotalCycles: &cycles}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.SubscriptionStatus != SubscriptionStatusApprovalPending || FindLink(sub.Links, "approve") == nil {
+		t.Errorf("unexpected subscription %+v", sub)
+	}
+	plan, _ := created["plan"].(map[string]interface{})
+	if plan == nil || created["application_context"] == nil {
+		t.Fatalf("unexpected request %v", created)
+	}
+	if cycle := plan["billing_cycles"].([]interface{})[0].(map[string]interface{}); cycle["total_cycles"] != float64(12) {
+		t.Errorf("unexpected billing cycle override %v", cycle)
+	}
+
+	if err := c.UpdateSubscriptionWithPatches(ctx, "I-1", nil); err == nil {
+		t.Error("expecting an error for an update without patches")
+	}
+	if err := c.UpdateSubscriptionWithPatches(ctx, "I-1", []Patch{{Operation: "replace", Path: "/custom_id", Value: "order-1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 || patches[0].Path != "/custom_id" {
+		t.Errorf("unexpected patches %+v", patches)
+	}
+
+	if err := c.SuspendSubscription(ctx, "I-1", "paused"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ActivateSubscription(ctx, "I-1", "resumed"); err != nil {
+		t.Fatal(err)
+	}
+	details, err := c.GetSubscriptionDetails(ctx, "I-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.SubscriptionStatus != SubscriptionStatusActive {
+		t.Errorf("expecting ACTIVE, got %s", details.SubscriptionStatus)
+	}
+	if err := c.CancelSubscription(ctx, "I-1", "done"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"POST /v1/billing/subscriptions",
+		"PATCH /v1/billing/subscriptions/I-1",
+		"POST /v1/billing/subscriptions/I-1/suspend",
+		"POST /v1/billing/subscriptions/I-1/activate",
+		"GET /v1/billing/subscriptions/I-1",
+		"POST /v1/billing/subscriptions/I-1/cancel",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expecting requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("request %d: expecting %s, got %s", i, expected[i], requests[i])
+		}
+	}
+}
+
+func TestReviseAndCaptureSubscription(t *testing.T) {
+	var revision map[string]interface{}
+	var capture CaptureReqeust
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "/v1/billing/subscriptions/I-1/revise":
+			json.NewDecoder(r.Body).Decode(&revision)
+			w.Write([]byte(`{"plan_id":"P-2","plan_overridden":false,"quantity":"3",` +
+				`"links":[{"href":"https://www.paypal.com/webapps/billing/subscriptions/update?ba_token=BA-1","rel":"approve","method":"GET"}]}`))
+		case "/v1/billing/subscriptions/I-1/capture":
+			json.NewDecoder(r.Body).Decode(&capture)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	revised, err := c.ReviseSubscriptionPlan(ctx, "I-1", ReviseSubscriptionRequest{PlanID: "P-2", Quantity: "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revision["plan_id"] != "P-2" || revision["quantity"] != "3" {
+		t.Errorf("unexpected revision %v", revision)
+	}
+	if revised.ApprovalURL() != "https://www.paypal.com/webapps/billing/subscriptions/update?ba_token=BA-1" {
+		t.Errorf("unexpected approval url %q", revised.ApprovalURL())
+	}
+	if (&ReviseSubscriptionResponse{}).ApprovalURL() != "" {
+		t.Error("expecting no approval url without an approve link")
+	}
+
+	if _, err := c.CaptureOutstandingBalance(ctx, "I-1", Money{Currency: "USD", Value: "10.00"}, "overdue"); err != nil {
+		t.Fatal(err)
+	}
+	if capture.CaptureType != CaptureTypeOutstandingBalance || capture.Amount.Value != "10.00" {
+		t.Errorf("unexpected capture %+v", capture)
+	}
+}
+
+func TestListSubscriptionTransactions(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		query = r.URL.Path + "?" + r.URL.RawQuery
+		w.Write([]byte(`{"transactions":[{"id":"TX-1","status":"COMPLETED","payer_email":"buyer@example.com",` +
+			`"amount_with_breakdown":{"gross_amount":{"currency_code":"USD","value":"10.00"},"net_amount":{"currency_code":"USD","value":"9.41"}},` +
+			`"time":"2024-01-10T09:00:00Z"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	berlin := time.FixedZone("CET", 3600)
+	start := time.Date(2024, 1, 1, 1, 0, 0, 0, berlin)
+	end := time.Date(2024, 2, 1, 1, 0, 0, 0, berlin)
+
+	if _, err := c.ListSubscriptionTransactions(ctx, "I-1", end, start); err == nil {
+		t.Error("expecting an error for an end time before the start time")
+	}
+
+	txs, err := c.ListSubscriptionTransactions(ctx, "I-1", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "/v1/billing/subscriptions/I-1/transactions?end_time=2024-02-01T00%3A00%3A00Z&start_time=2024-01-01T00%3A00%3A00Z"
+	if query != expected {
+		t.Errorf("expecting %s, got %s", expected, query)
+	}
+	if len(txs) != 1 || txs[0].Status != SubscriptionCaptureStatusCompleted || txs[0].AmountWithBreakdown.NetAmount.Value != "9.41" {
+		t.Errorf("unexpected transactions %+v", txs)
+	}
+}
@@ -0,0 +1,102 @@
+package paypal
+
+// Possible values for `liability_shift` in CardAuthenticationResult
+//
+// https://developer.paypal.com/docs/checkout/advanced/customize/3d-secure/response-parameters/
+const (
+	LiabilityShiftPossible string = "POSSIBLE"
+	LiabilityShiftYes      string = "YES"
+	LiabilityShiftNo       string = "NO"
+	LiabilityShiftUnknown  string = "UNKNOWN"
+)
+
+// Possible values for `enrollment_status` in ThreeDSecureResponse
+//
+// https://developer.paypal.com/docs/checkout/advanced/customize/3d-secure/response-parameters/
+const (
+	EnrollmentStatusReady       string = "Y"
+	EnrollmentStatusNotEnrolled string = "N"
+	EnrollmentStatusUnavailable string = "U"
+	EnrollmentStatusBypassed    string = "B"
+)
+
+// Possible values for `authentication_status` in ThreeDSecureResponse
+//
+// https://developer.paypal.com/docs/checkout/advanced/customize/3d-secure/response-parameters/
+const (
+	AuthenticationStatusSuccessful       string = "Y"
+	AuthenticationStatusFailed           string = "N"
+	AuthenticationStatusRejected         string = "R"
+	AuthenticationStatusAttempted        string = "A"
+	AuthenticationStatusUnableToComplete string = "U"
+	AuthenticationStatusChallenge        string = "C"
+	AuthenticationStatusInfoOnly         string = "I"
+	AuthenticationStatusDecoupled        string = "D"
+)
+
+// Possible values for `method` in CardVerification
+//
+// https://developer.paypal.com/docs/checkout/advanced/customize/3d-secure/api/
+const (
+	// SCAMethodAlways triggers 3D Secure for every transaction
+	SCAMethodAlways string = "SCA_ALWAYS"
+	// SCAMethodWhenRequired triggers 3D Secure when it is mandated, e.g. by PSD2
+	SCAMethodWhenRequired string = "SCA_WHEN_REQUIRED"
+)
+
+// Possible values for `payment_initiator` in CardStoredCredential
+const (
+	PaymentInitiatorCustomer string = "CUSTOMER"
+	PaymentInitiatorMerchant string = "MERCHANT"
+)
+
+// Possible values for `payment_type` in CardStoredCredential
+const (
+	StoredPaymentTypeOneTime     string = "ONE_TIME"
+	StoredPaymentTypeRecurring   string = "RECURRING"
+	StoredPaymentTypeUnscheduled string = "UNSCHEDULED"
+)
+
+// Possible values for `usage` in CardStoredCredential
+const (
+	StoredCredentialUsageFirst      string = "FIRST"
+	StoredCredentialUsageSubsequent string = "SUBSEQUENT"
+	StoredCredentialUsageDerived    string = "DERIVED"
+)
+
+// WithSCA returns a copy of the card that requests a 3D Secure verification
+// with method, SCAMethodAlways or SCAMethodWhenRequired
+func (c PaymentSourceCard) WithSCA(method string) *PaymentSourceCard {
+	attributes := CardAttributes{}
+	if c.Attributes != nil {
+		attributes = *c.Attributes
+	}
+	attributes.Verification = &CardVerification{Method: method}
+	c.Attributes = &attributes
+	return &c
+}
+
+// LiabilityShifted reports whether the liability for fraudulent chargebacks
+// shifted to the card issuer
+func (r *CardAuthenticationResult) LiabilityShifted() bool {
+	return r != nil && (r.LiabilityShift == LiabilityShiftPossible || r.LiabilityShift == LiabilityShiftYes)
+}
+
+// ShouldProceed reports whether the payment can be authorized or captured
+// following the 3D Secure recommendations of PayPal: either the liability
+// shifted, or the card is not enrolled in 3D Secure and the merchant keeps
+// the liability. Failed, rejected and unknown authentications must not proceed.
+func (r *CardAuthenticationResult) ShouldProceed() bool {
+	if r.LiabilityShifted() {
+		return true
+	}
+	if r == nil || r.LiabilityShift != LiabilityShiftNo || r.ThreeDSecure == nil {
+		return false
+	}
+
+	switch r.ThreeDSecure.EnrollmentStatus {
+	case EnrollmentStatusNotEnrolled, EnrollmentStatusUnavailable, EnrollmentStatusBypassed:
+		return r.ThreeDSecure.AuthenticationStatus == ""
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package paypal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCardAuthenticationResult(t *testing.T) {
+	data := []byte(`{
+		"id": "ORDER",
+		"status": "APPROVED",
+		"payment_source": {
+			"card": {
+				"last_digits": "7704",
+				"authentication_result": {
+					"liability_shift": "POSSIBLE",
+					"three_d_secure": {"enrollment_status": "Y", "authentication_status": "Y"}
+				}
+			}
+		}
+	}`)
+
+	order := &Order{}
+	if err := json.Unmarshal(data, order); err != nil {
+		t.Fatal(err)
+	}
+	result := order.PaymentSource.Card.AuthenticationResult
+	if !result.LiabilityShifted() || !result.ShouldProceed() || result.ThreeDSecure.AuthenticationStatus != AuthenticationStatusSuccessful {
+		t.Errorf("unexpected authentication result %+v", result)
+	}
+
+	tests := []struct {
+		result  *CardAuthenticationResult
+		proceed bool
+	}{
+		{&CardAuthenticationResult{LiabilityShift: "NO", ThreeDSecure: &ThreeDSecureResponse{EnrollmentStatus: "Y", AuthenticationStatus: "N"}}, false},
+		{&CardAuthenticationResult{LiabilityShift: "NO", ThreeDSecure: &ThreeDSecureResponse{EnrollmentStatus: "Y", AuthenticationStatus: "R"}}, false},
+		{&CardAuthenticationResult{LiabilityShift: "UNKNOWN", ThreeDSecure: &ThreeDSecureResponse{EnrollmentStatus: "Y", AuthenticationStatus: "U"}}, false},
+		{&CardAuthenticationResult{LiabilityShift: "NO", ThreeDSecure: &ThreeDSecureResponse{EnrollmentStatus: "N"}}, true},
+		{&CardAuthenticationResult{LiabilityShift: "NO", ThreeDSecure: &ThreeDSecureResponse{EnrollmentStatus: "B"}}, true},
+		{&CardAuthenticationResult{LiabilityShift: "UNKNOWN"}, false},
+		{nil, false},
+	}
+	for i, tt := range tests {
+		if proceed := tt.result.ShouldProceed(); proceed != tt.proceed {
+			t.Errorf("%d: expecting ShouldProceed %v got %v", i, tt.proceed, proceed)
+		}
+	}
+}
+
+func TestCardSCARequest(t *testing.T) {
+	card := (&PaymentSourceCard{Number: "4111111111111111", Expiry: "2030-01"}).WithSCA(SCAMethodWhenRequired)
+	card.StoredCredential = &CardStoredCredential{
+		PaymentInitiator: PaymentInitiatorMerchant,
+		PaymentType:      StoredPaymentTypeRecurring,
+		Usage:            StoredCredentialUsageSubsequent,
+		PreviousNetworkTransactionReference: &NetworkTransactionReference{
+			ID:      "156GHJ654SFD543SDF",
+			Network: "VISA",
+		},
+	}
+
+	b, err := json.Marshal(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"attributes":{"verification":{"method":"SCA_WHEN_REQUIRED"}}`,
+		`"stored_credential":{"payment_initiator":"MERCHANT","payment_type":"RECURRING","usage":"SUBSEQUENT","previous_network_transaction_reference":{"id":"156GHJ654SFD543SDF","network":"VISA"}}`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected %s in %s", want, b)
+		}
+	}
+
+	data := []byte(`{"card":{"last_digits":"7704","brand":"VISA","type":"CREDIT","authentication_result":{"liability_shift":"NO","three_d_secure":{"enrollment_status":"N"}}}}`)
+	source := &PaymentSource{}
+	if err := json.Unmarshal(data, source); err != nil {
+		t.Fatal(err)
+	}
+	if source.Card.Brand != "VISA" || source.Card.Type != "CREDIT" || !source.Card.AuthenticationResult.ShouldProceed() {
+		t.Errorf("unexpected card %+v", source.Card)
+	}
+}
@@ -0,0 +1,44 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// requestTimeoutKey is the context key of the timeout set with WithRequestTimeout
+type requestTimeoutKey struct{}
+
+// SetRequestTimeout sets the default time limit of an API call, retries
+// included, applied on top of the deadline of the caller's context.
+// Unlike WithTimeout it can be overridden per call with WithRequestTimeout.
+// The access token request is bounded by SetTokenRefreshTimeout instead.
+// 0 disables the default timeout.
+func (c *Client) SetRequestTimeout(d time.Duration) {
+	c.requestTimeout = d
+}
+
+// WithRequestTimeout returns a context overriding the default timeout set with
+// SetRequestTimeout for the calls made with it, e.g. longer for a large payout
+// batch. A timeout of 0 disables the default timeout for these calls.
+//
+//	ctx = paypal.WithRequestTimeout(ctx, 2*time.Minute)
+//	batch, err := c.CreatePayout(ctx, payout)
+func WithRequestTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, d)
+}
+
+// applyRequestTimeout returns req with the deadline of the call timeout, the
+// returned cancel func has to be called once the call completed
+func (c *Client) applyRequestTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	timeout := c.requestTimeout
+	if d, ok := req.Context().Value(requestTimeoutKey{}).(time.Duration); ok {
+		timeout = d
+	}
+	if timeout <= 0 {
+		return req, func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	return req.WithContext(ctx), cancel
+}
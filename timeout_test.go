@@ -0,0 +1,43 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		select {
+		case <-time.After(50 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`{"id":"ID"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+	ctx := context.Background()
+
+	if _, err := c.GetOrder(ctx, "ID"); err != nil {
+		t.Fatalf("expecting no timeout by default, got %v", err)
+	}
+
+	c.SetRequestTimeout(10 * time.Millisecond)
+	if _, err := c.GetOrder(ctx, "ID"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expecting the default timeout, got %v", err)
+	}
+
+	if _, err := c.GetOrder(WithRequestTimeout(ctx, time.Second), "ID"); err != nil {
+		t.Errorf("expecting the longer timeout of the call, got %v", err)
+	}
+	if _, err := c.GetOrder(WithRequestTimeout(ctx, 0), "ID"); err != nil {
+		t.Errorf("expecting no timeout for the call, got %v", err)
+	}
+}
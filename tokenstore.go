@@ -0,0 +1,303 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// tokenExpiryMargin is how long before its expiry a stored token is replaced
+	tokenExpiryMargin = time.Minute
+
+	// tokenFlightTimeout bounds token requests without a token refresh timeout,
+	// they are not canceled with the context of the request
+	tokenFlightTimeout = time.Minute
+)
+
+type (
+	// TokenStore stores access tokens so they can be shared, e.g. between the
+	// instances of a service. GetToken returns nil without an error when no
+	// token is stored for the key, expired tokens are replaced by the client and
+	// tokens without an expiry never expire. PutToken is called with a nil
+	// token to remove a token revoked with RevokeAccessToken.
+	// Implementations must be safe for concurrent use.
+	TokenStore interface {
+		GetToken(ctx context.Context, key string) (*oauth2.Token, error)
+		PutToken(ctx context.Context, key string, token *oauth2.Token) error
+	}
+
+	// MemoryTokenStore is a TokenStore keeping tokens in memory, e.g. to share
+	// them between the clients of a process
+	MemoryTokenStore struct {
+		mu     sync.Mutex
+		tokens map[string]*oauth2.Token
+	}
+)
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]*oauth2.Token)}
+}
+
+// GetToken returns the token stored for the key
+func (s *MemoryTokenStore) GetToken(ctx context.Context, key string) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokens[key], nil
+}
+
+// PutToken stores the token for the key
+func (s *MemoryTokenStore) PutToken(ctx context.Context, key string, token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = token
+	return nil
+}
+
+// SetTokenStore makes the client take access tokens from the store and put
+// the tokens it requests there. Tokens are stored with the key
+// "paypal:<APIBase>:<ClientID>". When the store fails, the client requests
+// a new token so requests keep working.
+func (c *Client) SetTokenStore(store TokenStore) {
+	c.tokenStore = store
+}
+
+// forceTokenRefreshKey is the context key making requestToken skip the token store
+type forceTokenRefreshKey struct{}
+
+// GetAccessToken returns the access token of the client with its expiry and
+// scopes, requesting a new one when there is none or it is about to expire,
+// e.g. to pass it to another process. ExpiresIn is the number of seconds left.
+func (c *Client) GetAccessToken(ctx context.Context) (*TokenResponse, error) {
+	if _, err := c.accessToken(ctx); err != nil {
+		return nil, err
+	}
+	return c.tokenResponse(), nil
+}
+
+// RefreshAccessToken is like GetAccessToken, requesting a new token from
+// PayPal even when the current or stored token is still valid
+func (c *Client) RefreshAccessToken(ctx context.Context) (*TokenResponse, error) {
+	c.Lock()
+	c.Token = nil
+	c.tokenExpiresAt = time.Time{}
+	c.Unlock()
+
+	return c.GetAccessToken(context.WithValue(ctx, forceTokenRefreshKey{}, true))
+}
+
+// RevokeAccessToken revokes the access token of the client at PayPal, e.g. on
+// logout or when rotating the credentials, instead of letting it linger until
+// it expires. The token is removed from the client and from the token store
+// first, so the next request gets a new token even when revoking fails.
+// It does nothing when the client has no token.
+// Endpoint: POST /v1/oauth2/token/terminate
+func (c *Client) RevokeAccessToken(ctx context.Context) error {
+	c.Lock()
+	var token string
+	if c.Token != nil {
+		token = c.Token.Token
+	}
+	c.Token = nil
+	c.tokenExpiresAt = time.Time{}
+	c.tokenInjected = false
+	c.Unlock()
+
+	if token == "" {
+		return nil
+	}
+
+	if c.tokenStore != nil {
+		// Keep a newer token another instance stored since
+		key := c.tokenStoreKey()
+		if stored, err := c.tokenStore.GetToken(ctx, key); err == nil && stored != nil && stored.AccessToken == token {
+			c.tokenStore.PutToken(ctx, key, nil)
+		}
+	}
+
+	return c.RevokeToken(ctx, token, TokenTypeHintAccessToken)
+}
+
+// Scopes returns the space separated scopes of the token
+func (t *TokenResponse) Scopes() []string {
+	return strings.Fields(t.Scope)
+}
+
+// tokenResponse returns a copy of the token of the client
+func (c *Client) tokenResponse() *TokenResponse {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.Token == nil {
+		return &TokenResponse{}
+	}
+	resp := *c.Token
+	resp.ExpiresAt = c.tokenExpiresAt
+	if !c.tokenExpiresAt.IsZero() {
+		resp.ExpiresIn = expirationTime(time.Until(c.tokenExpiresAt) / time.Second)
+	}
+	return &resp
+}
+
+// tokenFlight is a token request shared by concurrent callers
+type tokenFlight struct {
+	done  chan struct{}
+	token *oauth2.Token
+	err   error
+}
+
+// accessToken returns a valid access token of the client. When the token
+// expired, a single request gets a new one and concurrent callers wait for it.
+// The request is not canceled with the context of any caller, a caller giving
+// up only stops waiting.
+func (c *Client) accessToken(ctx context.Context) (*oauth2.Token, error) {
+	c.Lock()
+	if token := c.currentToken(); token != nil {
+		c.Unlock()
+		return token, nil
+	}
+
+	flight := c.tokenFlight
+	if flight == nil {
+		flight = &tokenFlight{done: make(chan struct{})}
+		c.tokenFlight = flight
+		go c.runTokenFlight(c.tokenContext(ctx), flight)
+	}
+	c.Unlock()
+
+	select {
+	case <-flight.done:
+		return flight.token, flight.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runTokenFlight requests the token of the flight and sets it on the client
+func (c *Client) runTokenFlight(ctx context.Context, flight *tokenFlight) {
+	ctx, cancel := c.tokenTimeout(ctx)
+	defer cancel()
+
+	flight.token, flight.err = c.requestToken(ctx)
+
+	c.Lock()
+	if flight.err == nil {
+		c.setToken(flight.token)
+	}
+	c.tokenFlight = nil
+	c.Unlock()
+	close(flight.done)
+}
+
+// tokenContext returns the context of a token request for a caller with ctx:
+// it is never canceled and only keeps the http.Client to use and whether the
+// token store must be skipped
+func (c *Client) tokenContext(ctx context.Context) context.Context {
+	tokenCtx := context.Background()
+	if c.httpClient != nil {
+		tokenCtx = context.WithValue(tokenCtx, oauth2.HTTPClient, c.httpClient)
+	} else if client, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		tokenCtx = context.WithValue(tokenCtx, oauth2.HTTPClient, client)
+	}
+	if force, _ := ctx.Value(forceTokenRefreshKey{}).(bool); force {
+		tokenCtx = context.WithValue(tokenCtx, forceTokenRefreshKey{}, true)
+	}
+	return tokenCtx
+}
+
+// tokenTimeout bounds a token request with the token refresh timeout, or
+// tokenFlightTimeout when there is none
+func (c *Client) tokenTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.tokenRefreshTimeout
+	if timeout <= 0 {
+		timeout = tokenFlightTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// currentToken returns the token of the client unless it is about to expire.
+// Tokens set with SetAccessToken only expire when PayPal rejects them.
+func (c *Client) currentToken() *oauth2.Token {
+	if c.Token == nil || c.Token.Token == "" {
+		return nil
+	}
+	if !c.tokenExpiresAt.IsZero() && time.Until(c.tokenExpiresAt) < tokenExpiryMargin {
+		return nil
+	}
+
+	return &oauth2.Token{
+		AccessToken:  c.Token.Token,
+		TokenType:    c.Token.Type,
+		RefreshToken: c.Token.RefreshToken,
+		Expiry:       c.tokenExpiresAt,
+	}
+}
+
+// setToken replaces the token of the client
+func (c *Client) setToken(token *oauth2.Token) {
+	resp := &TokenResponse{
+		Token:        token.AccessToken,
+		Type:         token.TokenType,
+		RefreshToken: token.RefreshToken,
+	}
+	if !token.Expiry.IsZero() {
+		resp.ExpiresIn = expirationTime(time.Until(token.Expiry) / time.Second)
+	}
+	resp.Scope, _ = token.Extra("scope").(string)
+	resp.AppID, _ = token.Extra("app_id").(string)
+
+	c.Token = resp
+	c.tokenExpiresAt = token.Expiry
+	c.tokenInjected = false
+}
+
+// dropInjectedToken removes a token set with SetAccessToken, so the next
+// request gets a token with the client credentials. It reports whether there
+// was such a token.
+func (c *Client) dropInjectedToken() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.tokenInjected {
+		return false
+	}
+	c.Token = nil
+	c.tokenExpiresAt = time.Time{}
+	c.tokenInjected = false
+	return true
+}
+
+// requestToken returns a valid access token from the token store or PayPal
+func (c *Client) requestToken(ctx context.Context) (*oauth2.Token, error) {
+	key := c.tokenStoreKey()
+
+	if force, _ := ctx.Value(forceTokenRefreshKey{}).(bool); c.tokenStore != nil && !force {
+		token, err := c.tokenStore.GetToken(ctx, key)
+		if err == nil && token != nil && (token.Expiry.IsZero() || time.Until(token.Expiry) > tokenExpiryMargin) {
+			return token, nil
+		}
+	}
+
+	token, err := c.ccCfg.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.tokenStore != nil {
+		c.tokenStore.PutToken(ctx, key, token)
+	}
+
+	return token, nil
+}
+
+// tokenStoreKey returns the key of the token of the client in the token store
+func (c *Client) tokenStoreKey() string {
+	return "paypal:" + c.APIBase + ":" + c.ClientID
+}
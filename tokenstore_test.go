@@ -0,0 +1,365 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestTokenStore(t *testing.T) {
+	tokenRequests := 0
+	var authorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			tokenRequests++
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "fetched", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	store := NewMemoryTokenStore()
+	ctx := context.Background()
+
+	// Clients of the same app share the stored token
+	for i := 0; i < 2; i++ {
+		c, _ := NewClient("foo", "bar", ts.URL)
+		c.SetTokenStore(store)
+		if _, err := c.GetOrder(ctx, "O1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tokenRequests != 1 || authorization != "Bearer fetched" {
+		t.Errorf("expecting a single token request, got %d", tokenRequests)
+	}
+
+	// Tokens about to expire are replaced
+	key := "paypal:" + ts.URL + ":foo"
+	store.PutToken(ctx, key, &oauth2.Token{AccessToken: "expiring", TokenType: "Bearer", Expiry: time.Now().Add(time.Second)})
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetTokenStore(store)
+	c.GetOrder(ctx, "O1")
+	if token, _ := store.GetToken(ctx, key); tokenRequests != 2 || token.AccessToken != "fetched" {
+		t.Errorf("expecting the expiring token to be replaced, got %d token requests", tokenRequests)
+	}
+
+	store.PutToken(ctx, key, &oauth2.Token{AccessToken: "stored", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)})
+	c, _ = NewClient("foo", "bar", ts.URL)
+	c.SetTokenStore(store)
+	c.GetOrder(ctx, "O1")
+	if authorization != "Bearer stored" {
+		t.Errorf("expecting the stored token to be used, got %q", authorization)
+	}
+}
+
+func TestAccessTokenSingleFlight(t *testing.T) {
+	var mu sync.Mutex
+	tokenRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			mu.Lock()
+			tokenRequests++
+			mu.Unlock()
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte(`{"access_token":"123","token_type":"Bearer","expires_in":32400}`))
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := c.NewRequest(context.Background(), http.MethodGet, ts.URL+"/v2/checkout/orders/O1", nil)
+			errs <- c.SendWithAuth(req, &Order{})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("expecting concurrent requests to share a token request, got %d", tokenRequests)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if c.Token == nil || c.Token.Token != "123" || c.Token.ExpiresIn < 32000 || time.Until(c.tokenExpiresAt) < 8*time.Hour {
+		t.Errorf("expecting the client token to be updated, got %+v", c.Token)
+	}
+}
+
+func TestAccessTokenCanceledCaller(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			<-release
+			w.Write([]byte(`{"access_token":"123","token_type":"Bearer","expires_in":32400}`))
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	// The first caller starts the token request and gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := c.GetOrder(ctx, "O1")
+		canceled <- err
+	}()
+	for {
+		c.Lock()
+		started := c.tokenFlight != nil
+		c.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	waiting := make(chan error)
+	go func() {
+		_, err := c.GetOrder(context.Background(), "O1")
+		waiting <- err
+	}()
+
+	cancel()
+	if err := <-canceled; err == nil {
+		t.Error("expecting the canceled caller to fail")
+	}
+	close(release)
+	if err := <-waiting; err != nil {
+		t.Errorf("expecting the waiting caller to get the token, got %v", err)
+	}
+}
+
+func TestGetAccessToken(t *testing.T) {
+	tokenRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		tokenRequests++
+		w.Write([]byte(`{"scope":"openid https://uri.paypal.com/services/payments/payment","access_token":"token",` +
+			`"token_type":"Bearer","app_id":"APP-1","expires_in":32400}`))
+	}))
+	defer ts.Close()
+
+	store := NewMemoryTokenStore()
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetTokenStore(store)
+	ctx := context.Background()
+
+	token, err := c.GetAccessToken(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != "token" || token.AppID != "APP-1" || len(token.Scopes()) != 2 || token.Scopes()[0] != "openid" {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if d := time.Until(token.ExpiresAt); d < 8*time.Hour || d > 9*time.Hour || token.ExpiresIn < 32000 {
+		t.Errorf("expecting the token to expire in 9 hours, got %s and %d seconds", d, token.ExpiresIn)
+	}
+
+	if _, err = c.GetAccessToken(ctx); err != nil || tokenRequests != 1 {
+		t.Errorf("expecting the current token to be reused, got %d token requests %v", tokenRequests, err)
+	}
+	if _, err = c.RefreshAccessToken(ctx); err != nil || tokenRequests != 2 {
+		t.Errorf("expecting a forced refresh to skip the store, got %d token requests %v", tokenRequests, err)
+	}
+
+	c.SetAccessToken("injected")
+	if token, err = c.GetAccessToken(ctx); err != nil || token.Token != "injected" || !token.ExpiresAt.IsZero() {
+		t.Errorf("expecting the injected token without expiry, got %+v %v", token, err)
+	}
+}
+
+func TestGetAccessTokenHTTPClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":32400}`))
+	}))
+	defer ts.Close()
+
+	transport := &countingTransport{}
+	c, _ := NewClient("foo", "bar", ts.URL, WithHTTPClient(&http.Client{Transport: transport}))
+	ctx := context.Background()
+
+	if _, err := c.GetAccessToken(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RefreshAccessToken(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if transport.requests != 2 {
+		t.Errorf("expecting the token requests to use the client transport, got %d requests", transport.requests)
+	}
+}
+
+func TestInjectedTokenFallback(t *testing.T) {
+	tokenRequests := 0
+	var authorizations []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			tokenRequests++
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "fetched", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if body, _ := ioutil.ReadAll(r.Body); r.Method == http.MethodPost && len(body) == 0 {
+			http.Error(w, "expecting the body to be sent again", http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer fetched" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_token","error_description":"Token signature verification failed"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	// An expired token is replaced before the request
+	c.SetAccessTokenWithExpiry("expired", time.Now().Add(-time.Minute))
+	if _, err := c.GetOrder(ctx, "O1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A token without expiry is replaced once PayPal rejects it
+	c.SetAccessToken("revoked")
+	if _, err := c.CaptureOrder(ctx, "O1", CaptureOrderRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetOrder(ctx, "O1"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"Bearer fetched", "Bearer revoked", "Bearer fetched", "Bearer fetched"}
+	if len(authorizations) != len(expected) {
+		t.Fatalf("expecting requests with %v, got %v", expected, authorizations)
+	}
+	for i := range expected {
+		if authorizations[i] != expected[i] {
+			t.Errorf("request %d: expecting %s, got %s", i, expected[i], authorizations[i])
+		}
+	}
+	if tokenRequests != 2 {
+		t.Errorf("expecting 2 token requests, got %d", tokenRequests)
+	}
+}
+
+func TestInjectedTokenFallbackBody(t *testing.T) {
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "fetched", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer fetched" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_token","error_description":"Token signature verification failed"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("revoked")
+
+	// The body of the request can't be read twice, it must not be sent again empty
+	body := struct{ io.Reader }{strings.NewReader(`{"intent":"CAPTURE"}`)}
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v2/checkout/orders", body)
+	if _, err := c.Do(req, nil); err == nil {
+		t.Error("expecting the rejected token to fail the request")
+	}
+	if len(bodies) != 1 || bodies[0] != `{"intent":"CAPTURE"}` {
+		t.Errorf("expecting the request to be sent once, got bodies %q", bodies)
+	}
+}
+
+func TestRevokeAccessToken(t *testing.T) {
+	var revoked []string
+	tokens := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			tokens++
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "token-" + strconv.Itoa(tokens), Expiry: time.Now().Add(time.Hour)})
+		case "/v1/oauth2/token/terminate":
+			if user, _, _ := r.BasicAuth(); user != "foo" {
+				http.Error(w, "expecting basic auth", http.StatusUnauthorized)
+				return
+			}
+			r.ParseForm()
+			revoked = append(revoked, r.PostForm.Get("token")+" "+r.PostForm.Get("token_type_hint"))
+		default:
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	store := NewMemoryTokenStore()
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetTokenStore(store)
+	ctx := context.Background()
+
+	if err := c.RevokeAccessToken(ctx); err != nil || len(revoked) != 0 {
+		t.Fatalf("expecting nothing to revoke, got %v %v", err, revoked)
+	}
+
+	if _, err := c.GetAccessToken(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RevokeAccessToken(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(revoked) != 1 || revoked[0] != "token-1 ACCESS_TOKEN" {
+		t.Errorf("unexpected revocations %v", revoked)
+	}
+	if stored, _ := store.GetToken(ctx, "paypal:"+ts.URL+":foo"); stored != nil {
+		t.Errorf("expecting the revoked token to be removed from the store, got %+v", stored)
+	}
+
+	token, err := c.GetAccessToken(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != "token-2" {
+		t.Errorf("expecting a new token after revoking, got %s", token.Token)
+	}
+}
@@ -0,0 +1,77 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// Tracer starts a span for every API call, including its retries. It is
+	// implemented on top of a tracing library, e.g. OpenTelemetry, see the README.
+	Tracer interface {
+		// Start starts a span as a child of the span in ctx and returns a context
+		// holding the new span, which is passed on to the http.Client
+		Start(ctx context.Context, name string) (context.Context, Span)
+	}
+
+	// Span is a span started by a Tracer
+	Span interface {
+		// SetAttribute sets an attribute, values are strings or ints
+		SetAttribute(key string, value interface{})
+		// End ends the span, err is the error of the API call or nil
+		End(err error)
+	}
+)
+
+// Attributes set on the spans of API calls
+const (
+	SpanAttributeMethod     = "http.request.method"
+	SpanAttributeStatusCode = "http.response.status_code"
+	SpanAttributeEndpoint   = "paypal.endpoint"
+	SpanAttributeDebugID    = "paypal.debug_id"
+	SpanAttributeRetries    = "paypal.retries"
+)
+
+// SetTracer sets the Tracer starting a span for every API call. The span is
+// named "paypal <method> <endpoint>", e.g. "paypal POST /v2/checkout/orders/{id}/capture".
+// Pass nil to remove it.
+func (c *Client) SetTracer(tracer Tracer) {
+	c.tracer = tracer
+}
+
+// WithTracer sets the Tracer of the client, see SetTracer
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) {
+		c.SetTracer(tracer)
+	}
+}
+
+// startSpan starts the span of an API call and returns the request to send with its context
+func (c *Client) startSpan(req *http.Request) (*http.Request, Span) {
+	if c.tracer == nil {
+		return req, nil
+	}
+
+	endpoint := endpointTemplate(req.URL.Path)
+	ctx, span := c.tracer.Start(req.Context(), "paypal "+req.Method+" "+endpoint)
+	span.SetAttribute(SpanAttributeMethod, req.Method)
+	span.SetAttribute(SpanAttributeEndpoint, endpoint)
+
+	return req.WithContext(ctx), span
+}
+
+// endSpan ends the span of an API call
+func endSpan(span Span, resp *http.Response, err error, retries int) {
+	if span == nil {
+		return
+	}
+
+	if resp != nil {
+		span.SetAttribute(SpanAttributeStatusCode, resp.StatusCode)
+		if debugID := resp.Header.Get("Paypal-Debug-Id"); debugID != "" {
+			span.SetAttribute(SpanAttributeDebugID, debugID)
+		}
+	}
+	span.SetAttribute(SpanAttributeRetries, retries)
+	span.End(err)
+}
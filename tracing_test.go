@@ -0,0 +1,78 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type spanKey struct{}
+
+type testSpan struct {
+	name  string
+	attrs map[string]interface{}
+	ended bool
+	err   error
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *testSpan) End(err error)                              { s.ended, s.err = true, err }
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &testSpan{name: name, attrs: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func TestTracer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Header().Set("Paypal-Debug-Id", "f05063556a338")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY"}`))
+	}))
+	defer ts.Close()
+
+	tracer := &testTracer{}
+	c, _ := NewClient("foo", "bar", ts.URL, WithTracer(tracer))
+
+	var propagated bool
+	c.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			propagated = req.Context().Value(spanKey{}) != nil
+			return next.RoundTrip(req)
+		})
+	})
+
+	_, err := c.CaptureOrder(context.Background(), "5O190127TN364715T", CaptureOrderRequest{})
+	if err == nil {
+		t.Fatal("expecting an error")
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expecting 1 span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "paypal POST /v2/checkout/orders/{id}/capture" || !span.ended || span.err != err {
+		t.Errorf("unexpected span %+v", span)
+	}
+	if span.attrs[SpanAttributeStatusCode] != http.StatusUnprocessableEntity || span.attrs[SpanAttributeDebugID] != "f05063556a338" {
+		t.Errorf("unexpected span attributes %v", span.attrs)
+	}
+	if !propagated {
+		t.Errorf("expecting the span context to be passed to the http.Client")
+	}
+}
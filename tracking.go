@@ -0,0 +1,119 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type (
+	// Tracker is the tracking information of a shipment for a PayPal transaction
+	// Doc: https://developer.paypal.com/docs/api/tracking/v1/#definition-tracker
+	Tracker struct {
+		TransactionID    string         `json:"transaction_id"`
+		TrackingNumber   string         `json:"tracking_number,omitempty"`
+		Status           ShipmentStatus `json:"status"`
+		Carrier          Carrier        `json:"carrier,omitempty"`
+		CarrierNameOther string         `json:"carrier_name_other,omitempty"`
+		ShipmentDate     string         `json:"shipment_date,omitempty"`
+		NotifyBuyer      bool           `json:"notify_buyer,omitempty"`
+		LastUpdatedTime  *time.Time     `json:"last_updated_time,omitempty"`
+		Links            Links          `json:"links,omitempty"`
+	}
+
+	// TrackerIdentifier identifies a tracker added with AddTrackers
+	TrackerIdentifier struct {
+		TransactionID  string `json:"transaction_id"`
+		TrackingNumber string `json:"tracking_number,omitempty"`
+		Links          Links  `json:"links,omitempty"`
+	}
+
+	// TrackersBatchResponse lists the trackers added by AddTrackers and the
+	// errors of the trackers PayPal rejected
+	TrackersBatchResponse struct {
+		TrackerIdentifiers []TrackerIdentifier `json:"tracker_identifiers"`
+		Errors             []ErrorResponse     `json:"errors,omitempty"`
+		Links              Links               `json:"links,omitempty"`
+	}
+)
+
+// AddTrackers adds the tracking information of up to 20 shipments in one call.
+// Trackers PayPal rejects are listed in the Errors of the response, the others
+// are added.
+// Doc: https://developer.paypal.com/docs/api/tracking/v1/#trackers-batch_post
+// Endpoint: POST /v1/shipping/trackers-batch
+func (c *Client) AddTrackers(ctx context.Context, trackers []Tracker) (*TrackersBatchResponse, error) {
+	type addTrackersRequest struct {
+		Trackers []Tracker `json:"trackers"`
+	}
+
+	response := &TrackersBatchResponse{}
+	if len(trackers) == 0 {
+		return response, errors.New("paypal: at least one tracker is required")
+	}
+	for _, tracker := range trackers {
+		if err := tracker.validate(); err != nil {
+			return response, err
+		}
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v1/shipping/trackers-batch"), addTrackersRequest{Trackers: trackers})
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// GetTracker shows the tracking information of a shipment, by transaction ID
+// and tracking number
+// Doc: https://developer.paypal.com/docs/api/tracking/v1/#trackers_get
+// Endpoint: GET /v1/shipping/trackers/ID-TRACKING_NUMBER
+func (c *Client) GetTracker(ctx context.Context, transactionID, trackingNumber string) (*Tracker, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/shipping/trackers/%s", c.APIBase, trackerID(transactionID, trackingNumber)), nil)
+	response := &Tracker{}
+	if err != nil {
+		return response, err
+	}
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// UpdateTracker replaces the tracking information of a shipment, e.g. to set
+// its status to ShipmentStatusDelivered
+// Doc: https://developer.paypal.com/docs/api/tracking/v1/#trackers_put
+// Endpoint: PUT /v1/shipping/trackers/ID-TRACKING_NUMBER
+func (c *Client) UpdateTracker(ctx context.Context, tracker Tracker) error {
+	if err := tracker.validate(); err != nil {
+		return err
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPut, fmt.Sprintf("%s/v1/shipping/trackers/%s", c.APIBase, trackerID(tracker.TransactionID, tracker.TrackingNumber)), tracker)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+func (t Tracker) validate() error {
+	if t.TransactionID == "" {
+		return errors.New("paypal: transaction ID is required to track a shipment")
+	}
+	if t.Status == "" {
+		return fmt.Errorf("paypal: status is required to track the shipment of %s", t.TransactionID)
+	}
+	if t.Carrier == CarrierOther && t.CarrierNameOther == "" {
+		return fmt.Errorf("paypal: carrier name is required with carrier %s", CarrierOther)
+	}
+	return nil
+}
+
+// trackerID is the ID of the tracker of a shipment in the Tracking API
+func trackerID(transactionID, trackingNumber string) string {
+	return transactionID + "-" + trackingNumber
+}
@@ -0,0 +1,90 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestTrackers(t *testing.T) {
+	var requests []string
+	var batch map[string][]map[string]interface{}
+	var updated Tracker
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&batch)
+			w.Write([]byte(`{
+				"tracker_identifiers": [{"transaction_id": "TX-1", "tracking_number": "1Z999"}],
+				"errors": [{"name": "RESOURCE_NOT_FOUND", "message": "The specified resource does not exist.", "details": [{"field": "/trackers/1/transaction_id", "value": "TX-2", "issue": "INVALID_TRANSACTION_ID"}]}]
+			}`))
+		case http.MethodGet:
+			w.Write([]byte(`{"transaction_id":"TX-1","tracking_number":"1Z999","status":"SHIPPED","carrier":"UPS","last_updated_time":"2026-10-14T10:00:00Z"}`))
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&updated)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	resp, err := c.AddTrackers(ctx, []Tracker{
+		{TransactionID: "TX-1", TrackingNumber: "1Z999", Status: ShipmentStatusShipped, Carrier: CarrierUPS},
+		{TransactionID: "TX-2", TrackingNumber: "AB123", Status: ShipmentStatusShipped, Carrier: CarrierOther, CarrierNameOther: "Local Courier"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.TrackerIdentifiers) != 1 || len(resp.Errors) != 1 || resp.Errors[0].Details[0].Issue != "INVALID_TRANSACTION_ID" {
+		t.Errorf("unexpected batch response %+v", resp)
+	}
+	if len(batch["trackers"]) != 2 || batch["trackers"][1]["carrier_name_other"] != "Local Courier" {
+		t.Errorf("unexpected batch request %v", batch)
+	}
+
+	tracker, err := c.GetTracker(ctx, "TX-1", "1Z999")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tracker.Status != ShipmentStatusShipped || tracker.Carrier != CarrierUPS || tracker.LastUpdatedTime == nil {
+		t.Errorf("unexpected tracker %+v", tracker)
+	}
+
+	tracker.Status = ShipmentStatusDelivered
+	if err := c.UpdateTracker(ctx, *tracker); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != ShipmentStatusDelivered {
+		t.Errorf("unexpected update %+v", updated)
+	}
+
+	if _, err := c.AddTrackers(ctx, []Tracker{{TransactionID: "TX-3", Carrier: CarrierOther, Status: ShipmentStatusShipped}}); err == nil {
+		t.Error("expecting an error for carrier OTHER without name")
+	}
+	if _, err := c.AddTrackers(ctx, nil); err == nil {
+		t.Error("expecting an error without trackers")
+	}
+
+	expected := []string{
+		"POST /v1/shipping/trackers-batch",
+		"GET /v1/shipping/trackers/TX-1-1Z999",
+		"PUT /v1/shipping/trackers/TX-1-1Z999",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests\n%s", strings.Join(requests, "\n"))
+	}
+}
@@ -2,8 +2,11 @@ package paypal
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,6 +27,54 @@ type TransactionSearchRequest struct {
 	Page                        *int
 }
 
+// ReportField is a block of transaction details returned by ListTransactions
+type ReportField string
+
+// Possible values for `fields` in TransactionSearchRequest
+//
+// https://developer.paypal.com/docs/api/transaction-search/v1/#transactions_get
+const (
+	ReportFieldTransactionInfo ReportField = "transaction_info"
+	ReportFieldPayerInfo       ReportField = "payer_info"
+	ReportFieldShippingInfo    ReportField = "shipping_info"
+	ReportFieldAuctionInfo     ReportField = "auction_info"
+	ReportFieldCartInfo        ReportField = "cart_info"
+	ReportFieldIncentiveInfo   ReportField = "incentive_info"
+	ReportFieldStoreInfo       ReportField = "store_info"
+	ReportFieldAll             ReportField = "all"
+)
+
+// ReportFields returns the value of TransactionSearchRequest.Fields requesting
+// only the given blocks, e.g. ReportFields(ReportFieldTransactionInfo)
+func ReportFields(fields ...ReportField) *string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = string(f)
+	}
+	s := strings.Join(names, ",")
+	return &s
+}
+
+// Possible values for `transaction_status` in TransactionSearchRequest and SearchTransactionInfo
+//
+// https://developer.paypal.com/docs/api/transaction-search/v1/#transactions_get
+const (
+	TransactionStatusDenied   string = "D"
+	TransactionStatusPending  string = "P"
+	TransactionStatusSuccess  string = "S"
+	TransactionStatusReversed string = "V"
+)
+
+// MaxTransactionSearchRange is the longest date range of a transaction search
+const MaxTransactionSearchRange = 31 * 24 * time.Hour
+
+// MaxTransactionSearchPageSize is the largest page size of a transaction search
+const MaxTransactionSearchPageSize = 500
+
+// ErrorNameResultsetTooLarge is returned for transaction searches matching more
+// transactions than PayPal returns for one date range
+const ErrorNameResultsetTooLarge string = "RESULTSET_TOO_LARGE"
+
 type TransactionSearchResponse struct {
 	TransactionDetails  []SearchTransactionDetails `json:"transaction_details"`
 	AccountNumber       string                     `json:"account_number"`
@@ -34,20 +85,36 @@ type TransactionSearchResponse struct {
 	SharedListResponse
 }
 
-// ListTransactions - Use this to search PayPal transactions from the last 31 days.
+// ListTransactions - Use this to search PayPal transactions, the date range of
+// a search is at most 31 days long.
 // Endpoint: GET /v1/reporting/transactions
 func (c *Client) ListTransactions(ctx context.Context, req *TransactionSearchRequest) (*TransactionSearchResponse, error) {
 	response := &TransactionSearchResponse{}
 
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
 	r, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v1/reporting/transactions"), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	q := r.URL.Query()
+	r.URL.RawQuery = req.query().Encode()
 
-	q.Add("start_date", req.StartDate.Format(time.RFC3339))
-	q.Add("end_date", req.EndDate.Format(time.RFC3339))
+	if err = c.SendWithAuth(r, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// query returns the query parameters of the search
+func (req *TransactionSearchRequest) query() url.Values {
+	q := url.Values{}
+
+	q.Add("start_date", req.StartDate.UTC().Format(time.RFC3339))
+	q.Add("end_date", req.EndDate.UTC().Format(time.RFC3339))
 
 	if req.TransactionID != nil {
 		q.Add("transaction_id", *req.TransactionID)
@@ -86,11 +153,145 @@ func (c *Client) ListTransactions(ctx context.Context, req *TransactionSearchReq
 		q.Add("page", strconv.Itoa(*req.Page))
 	}
 
-	r.URL.RawQuery = q.Encode()
+	return q
+}
 
-	if err = c.SendWithAuth(r, response); err != nil {
-		return nil, err
+// validate checks the date range of the search before PayPal rejects it
+func (req *TransactionSearchRequest) validate() error {
+	if err := req.validateDates(); err != nil {
+		return err
+	}
+	if req.EndDate.Sub(req.StartDate) > MaxTransactionSearchRange {
+		return fmt.Errorf("paypal: transaction search range from %s to %s is longer than 31 days", req.StartDate.Format(time.RFC3339), req.EndDate.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validateDates checks the search has a start date before its end date
+func (req *TransactionSearchRequest) validateDates() error {
+	if req == nil || req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return errors.New("paypal: start and end date are required to search transactions")
 	}
+	if !req.StartDate.Before(req.EndDate) {
+		return fmt.Errorf("paypal: transaction search starts at %s, after its end at %s", req.StartDate.Format(time.RFC3339), req.EndDate.Format(time.RFC3339))
+	}
+	return nil
+}
 
-	return response, nil
+// NewTransactionPaginator returns a Paginator over all pages of a transaction search,
+// decode each page into a TransactionSearchResponse
+func (c *Client) NewTransactionPaginator(req *TransactionSearchRequest) *Paginator {
+	return c.NewPaginator("/v1/reporting/transactions", req.query())
+}
+
+// StreamTransactions searches the transactions of a date range of any length and
+// calls fn for each of them, oldest window first. The range is split into
+// windows of at most MaxTransactionSearchRange, a window matching too many
+// transactions is split again, and the pages of each window are fetched with
+// MaxTransactionSearchPageSize unless req sets PageSize. Page is ignored.
+// When a window is split after some of its pages were streamed, the
+// transactions already passed to fn are skipped in its halves, so fn is called
+// once per transaction.
+//
+// Streaming stops at the first error, an error returned by fn is returned as is.
+// Endpoint: GET /v1/reporting/transactions
+func (c *Client) StreamTransactions(ctx context.Context, req *TransactionSearchRequest, fn func(SearchTransactionDetails) error) error {
+	if err := req.validateDates(); err != nil {
+		return err
+	}
+
+	var windows []transactionSearchWindow
+	for _, dates := range transactionSearchWindows(req.StartDate, req.EndDate) {
+		windows = append(windows, transactionSearchWindow{start: dates[0], end: dates[1]})
+	}
+	for len(windows) > 0 {
+		w := windows[0]
+		windows = windows[1:]
+
+		window := *req
+		window.StartDate, window.EndDate = w.start, w.end
+		streamed, err := c.streamTransactionWindow(ctx, &window, w.skip, fn)
+		if isResultsetTooLarge(err) && w.end.Sub(w.start) > 2*time.Second {
+			// Search both halves instead, before the remaining windows
+			for id := range w.skip {
+				streamed[id] = true
+			}
+			var halves []transactionSearchWindow
+			for _, dates := range splitTransactionSearchWindow(w.start, w.end) {
+				halves = append(halves, transactionSearchWindow{start: dates[0], end: dates[1], skip: streamed})
+			}
+			windows = append(halves, windows...)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transactionSearchWindow is a date range searched by StreamTransactions and
+// the IDs of the transactions of the range that were streamed already
+type transactionSearchWindow struct {
+	start, end time.Time
+	skip       map[string]bool
+}
+
+// streamTransactionWindow calls fn for the transactions of every page of a
+// search within MaxTransactionSearchRange, except those in skip. It returns
+// the IDs of the transactions passed to fn.
+func (c *Client) streamTransactionWindow(ctx context.Context, req *TransactionSearchRequest, skip map[string]bool, fn func(SearchTransactionDetails) error) (map[string]bool, error) {
+	if req.PageSize == nil {
+		pageSize := MaxTransactionSearchPageSize
+		req.PageSize = &pageSize
+	}
+
+	streamed := map[string]bool{}
+	for page := 1; ; page++ {
+		req.Page = &page
+		response, err := c.ListTransactions(ctx, req)
+		if err != nil {
+			return streamed, err
+		}
+		for _, details := range response.TransactionDetails {
+			id := details.TransactionInfo.TransactionID
+			if id != "" && skip[id] {
+				continue
+			}
+			if err := fn(details); err != nil {
+				return streamed, err
+			}
+			if id != "" {
+				streamed[id] = true
+			}
+		}
+		if page >= response.TotalPages {
+			return streamed, nil
+		}
+	}
+}
+
+// transactionSearchWindows splits a date range into consecutive windows of at
+// most MaxTransactionSearchRange. PayPal includes the end date in a search and
+// dates have a precision of a second, a window ends a second before the next.
+func transactionSearchWindows(start, end time.Time) [][2]time.Time {
+	var windows [][2]time.Time
+	for end.Sub(start) > MaxTransactionSearchRange {
+		next := start.Add(MaxTransactionSearchRange)
+		windows = append(windows, [2]time.Time{start, next.Add(-time.Second)})
+		start = next
+	}
+	return append(windows, [2]time.Time{start, end})
+}
+
+// splitTransactionSearchWindow splits a window in two halves, on a second
+func splitTransactionSearchWindow(start, end time.Time) [][2]time.Time {
+	middle := start.Add(end.Sub(start) / 2).Truncate(time.Second)
+	return [][2]time.Time{{start, middle}, {middle.Add(time.Second), end}}
+}
+
+// isResultsetTooLarge reports whether a search failed for matching too many transactions
+func isResultsetTooLarge(err error) bool {
+	errResp, ok := asErrorResponse(err)
+	return ok && (errResp.Name == ErrorNameResultsetTooLarge || errResp.HasIssue(ErrorNameResultsetTooLarge))
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
@@ -45,6 +44,14 @@ const (
 	LandingPageTypeLogin   string = "Login"
 )
 
+// Possible values for `user_action` in FlowConfig
+//
+// https://developer.paypal.com/docs/api/payment-experience/#definition-flow_config
+const (
+	FlowUserActionCommit   string = "commit"
+	FlowUserActionContinue string = "continue"
+)
+
 // Possible value for `allowed_payment_method` in PaymentOptions
 //
 // https://developer.paypal.com/docs/api/payments/#definition-payment_options
@@ -73,6 +80,22 @@ const (
 	OrderStatusCompleted string = "COMPLETED"
 )
 
+// Possible value for `processing_instruction` in CreateOrderRequest
+//
+// https://developer.paypal.com/docs/api/orders/v2/#orders_create
+const (
+	ProcessingInstructionOrderCompleteOnPaymentApproval string = "ORDER_COMPLETE_ON_PAYMENT_APPROVAL"
+	ProcessingInstructionNoInstruction                  string = "NO_INSTRUCTION"
+)
+
+// Possible values for `payment_method_preference` in ExperienceContext
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-paypal_wallet_experience_context
+const (
+	PaymentMethodPreferenceUnrestricted             string = "UNRESTRICTED"
+	PaymentMethodPreferenceImmediatePaymentRequired string = "IMMEDIATE_PAYMENT_REQUIRED"
+)
+
 // Possible values for `category` in Item
 //
 // https://developer.paypal.com/docs/api/orders/v2/#definition-item
@@ -81,6 +104,44 @@ const (
 	ItemCategoryPhysicalGood string = "PHYSICAL_GOODS"
 )
 
+// Possible values for `status` in RefundResponse
+//
+// https://developer.paypal.com/docs/api/payments/v2/#refunds_get
+const (
+	RefundStatusCancelled string = "CANCELLED"
+	RefundStatusFailed    string = "FAILED"
+	RefundStatusPending   string = "PENDING"
+	RefundStatusCompleted string = "COMPLETED"
+)
+
+// Possible values for `type` in OrderPayment
+const (
+	OrderPaymentTypeAuthorization string = "AUTHORIZATION"
+	OrderPaymentTypeCapture       string = "CAPTURE"
+	OrderPaymentTypeRefund        string = "REFUND"
+)
+
+// Possible values for `status` in AuthorizationAmount
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-authorization_status
+const (
+	AuthorizationStatusCreated           string = "CREATED"
+	AuthorizationStatusCaptured          string = "CAPTURED"
+	AuthorizationStatusDenied            string = "DENIED"
+	AuthorizationStatusPartiallyCaptured string = "PARTIALLY_CAPTURED"
+	AuthorizationStatusVoided            string = "VOIDED"
+	AuthorizationStatusPending           string = "PENDING"
+)
+
+// Possible values for `disbursement_mode` in PaymentInstruction, DELAYED holds
+// the funds until they are released with CreateReferencedPayout
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-payment_instruction
+const (
+	DisbursementModeInstant string = "INSTANT"
+	DisbursementModeDelayed string = "DELAYED"
+)
+
 // Possible values for `shipping_preference` in ApplicationContext
 //
 // https://developer.paypal.com/docs/api/orders/v2/#definition-application_context
@@ -109,8 +170,12 @@ const (
 const (
 	OperationAPIIntegration   string = "API_INTEGRATION"
 	ProductExpressCheckout    string = "EXPRESS_CHECKOUT"
+	ProductPPCP               string = "PPCP"
+	ProductPaymentMethods     string = "PAYMENT_METHODS"
+	ProductAdvancedVaulting   string = "ADVANCED_VAULTING"
 	IntegrationMethodPayPal   string = "PAYPAL"
 	IntegrationTypeThirdParty string = "THIRD_PARTY"
+	IntegrationTypeFirstParty string = "FIRST_PARTY"
 	ConsentShareData          string = "SHARE_DATA_CONSENT"
 )
 
@@ -125,6 +190,9 @@ const (
 	FeatureUpdateSellerDispute   string = "UPDATE_SELLER_DISPUTE"
 	FeatureDisputeReadBuyer      string = "DISPUTE_READ_BUYER"
 	FeatureUpdateCustomerDispute string = "UPDATE_CUSTOMER_DISPUTES"
+	FeatureAccessMerchantInfo    string = "ACCESS_MERCHANT_INFORMATION"
+	FeatureVault                 string = "VAULT"
+	FeatureBillingAgreement      string = "BILLING_AGREEMENT"
 )
 
 // https://developer.paypal.com/docs/api/payments.payouts-batch/v1/?mark=recipient_type#definition-recipient_type
@@ -135,6 +203,21 @@ const (
 	// Note: The PayPal sandbox doesn't support type PHONE
 )
 
+// Possible values for `transaction_status` in PayoutItemResponse
+//
+// https://developer.paypal.com/docs/api/payments.payouts-batch/v1/#definition-transaction_status
+const (
+	PayoutItemStatusSuccess   string = "SUCCESS"
+	PayoutItemStatusFailed    string = "FAILED"
+	PayoutItemStatusPending   string = "PENDING"
+	PayoutItemStatusUnclaimed string = "UNCLAIMED"
+	PayoutItemStatusReturned  string = "RETURNED"
+	PayoutItemStatusOnHold    string = "ONHOLD"
+	PayoutItemStatusBlocked   string = "BLOCKED"
+	PayoutItemStatusRefunded  string = "REFUNDED"
+	PayoutItemStatusReversed  string = "REVERSED"
+)
+
 // https://developer.paypal.com/docs/api/payments.payouts-batch/v1/?mark=recipient_wallet#definition-recipient_wallet
 const (
 	PaypalRecipientWallet string = "PAYPAL"
@@ -152,6 +235,12 @@ const (
 	BatchStatusCanceled   string = "CANCELED"
 )
 
+// Possible values for `status` in WebhookEventType
+const (
+	WebhookEventTypeStatusEnabled    string = "ENABLED"
+	WebhookEventTypeStatusDeprecated string = "DEPRECATED"
+)
+
 const (
 	LinkRelSelf      string = "self"
 	LinkRelActionURL string = "action_url"
@@ -166,6 +255,11 @@ type (
 	// JSONTime overrides MarshalJson method to format in ISO8601
 	JSONTime time.Time
 
+	// JSONDate is a date without time, e.g. an invoice or due date, sent as
+	// YYYY-MM-DD. It also decodes card expiry months (YYYY-MM) and full
+	// timestamps.
+	JSONDate time.Time
+
 	// Address struct
 	Address struct {
 		Line1       string `json:"line1,omitempty"`
@@ -209,9 +303,17 @@ type (
 		Locale             string             `json:"locale,omitempty"`
 		ShippingPreference ShippingPreference `json:"shipping_preference,omitempty"`
 		UserAction         UserAction         `json:"user_action,omitempty"`
-		//LandingPage        string `json:"landing_page,omitempty"` // not found in documentation
-		ReturnURL string `json:"return_url,omitempty"`
-		CancelURL string `json:"cancel_url,omitempty"`
+		LandingPage        LandingPage        `json:"landing_page,omitempty"`
+		ReturnURL          string             `json:"return_url,omitempty"`
+		CancelURL          string             `json:"cancel_url,omitempty"`
+		PaymentMethod      *PaymentMethod     `json:"payment_method,omitempty"`
+	}
+
+	// PaymentMethod sets the payment method preferences of the payee and payer
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-payment_method
+	PaymentMethod struct {
+		PayerSelected  string         `json:"payer_selected,omitempty"`
+		PayeePreferred PayeePreferred `json:"payee_preferred,omitempty"`
 	}
 
 	// Authorization struct
@@ -226,7 +328,7 @@ type (
 		CreateTime       *time.Time            `json:"create_time,omitempty"`
 		UpdateTime       *time.Time            `json:"update_time,omitempty"`
 		ExpirationTime   *time.Time            `json:"expiration_time,omitempty"`
-		Links            []Link                `json:"links,omitempty"`
+		Links            Links                 `json:"links,omitempty"`
 	}
 
 	// AuthorizeOrderResponse .
@@ -254,8 +356,10 @@ type (
 
 	// https://developer.paypal.com/docs/api/payments/v2/#definition-payment_instruction
 	PaymentInstruction struct {
-		PlatformFees     []PlatformFee `json:"platform_fees,omitempty"`
-		DisbursementMode string        `json:"disbursement_mode,omitempty"`
+		PlatformFees            []PlatformFee `json:"platform_fees,omitempty"`
+		DisbursementMode        string        `json:"disbursement_mode,omitempty"`
+		PayeePricingTierID      string        `json:"payee_pricing_tier_id,omitempty"`
+		PayeeReceivableFxRateID string        `json:"payee_receivable_fx_rate_id,omitempty"`
 	}
 
 	// https://developer.paypal.com/docs/api/payments/v2/#authorizations_capture
@@ -265,6 +369,8 @@ type (
 		SoftDescriptor string `json:"soft_descriptor,omitempty"`
 		Amount         *Money `json:"amount,omitempty"`
 		FinalCapture   bool   `json:"final_capture,omitempty"`
+
+		PaymentInstruction *PaymentInstruction `json:"payment_instruction,omitempty"`
 	}
 
 	SellerProtection struct {
@@ -283,9 +389,15 @@ type (
 		ID               string                `json:"id,omitempty"`
 		Amount           *Money                `json:"amount,omitempty"`
 		InvoiceID        string                `json:"invoice_id,omitempty"`
+		CustomID         string                `json:"custom_id,omitempty"`
 		FinalCapture     bool                  `json:"final_capture,omitempty"`
 		DisbursementMode string                `json:"disbursement_mode,omitempty"`
-		Links            []Link                `json:"links,omitempty"`
+		Links            Links                 `json:"links,omitempty"`
+
+		SellerProtection          *SellerProtection          `json:"seller_protection,omitempty"`
+		SellerReceivableBreakdown *SellerReceivableBreakdown `json:"seller_receivable_breakdown,omitempty"`
+		CreateTime                *time.Time                 `json:"create_time,omitempty"`
+		UpdateTime                *time.Time                 `json:"update_time,omitempty"`
 	}
 
 	//https://developer.paypal.com/docs/api/payments/v2/#captures_get
@@ -300,7 +412,7 @@ type (
 		FinalCapture              bool                       `json:"final_capture,omitempty"`
 		SellerReceivableBreakdown *SellerReceivableBreakdown `json:"seller_receivable_breakdown,omitempty"`
 		DisbursementMode          string                     `json:"disbursement_mode,omitempty"`
-		Links                     []Link                     `json:"links,omitempty"`
+		Links                     Links                      `json:"links,omitempty"`
 		UpdateTime                *time.Time                 `json:"update_time,omitempty"`
 		CreateTime                *time.Time                 `json:"create_time,omitempty"`
 	}
@@ -314,7 +426,16 @@ type (
 	RefundCaptureRequest struct {
 		Amount      *Money `json:"amount,omitempty"`
 		InvoiceID   string `json:"invoice_id,omitempty"`
+		CustomID    string `json:"custom_id,omitempty"`
 		NoteToPayer string `json:"note_to_payer,omitempty"`
+		// PaymentInstruction refunds platform fees, only for platforms acting on behalf of a seller
+		PaymentInstruction *RefundPaymentInstruction `json:"payment_instruction,omitempty"`
+	}
+
+	// RefundPaymentInstruction has the platform fees to refund with a capture refund
+	// https://developer.paypal.com/docs/api/payments/v2/#definition-payment_instruction
+	RefundPaymentInstruction struct {
+		PlatformFees []PlatformFee `json:"platform_fees,omitempty"`
 	}
 
 	// BatchHeader struct
@@ -325,6 +446,8 @@ type (
 		BatchStatus       string             `json:"batch_status,omitempty"`
 		TimeCreated       *time.Time         `json:"time_created,omitempty"`
 		TimeCompleted     *time.Time         `json:"time_completed,omitempty"`
+		TimeClosed        *time.Time         `json:"time_closed,omitempty"`
+		FundingSource     string             `json:"funding_source,omitempty"`
 		SenderBatchHeader *SenderBatchHeader `json:"sender_batch_header,omitempty"`
 	}
 
@@ -341,16 +464,20 @@ type (
 
 	// BillingAgreementFromToken struct
 	BillingAgreementFromToken struct {
-		ID          string      `json:"id,omitempty"`
-		Description string      `json:"description,omitempty"`
-		Payer       *Payer      `json:"payer,omitempty"`
-		Plan        BillingPlan `json:"plan,omitempty"`
-		Links       []Link      `json:"links,omitempty"`
+		ID              string           `json:"id,omitempty"`
+		State           string           `json:"state,omitempty"`
+		Description     string           `json:"description,omitempty"`
+		Payer           *Payer           `json:"payer,omitempty"`
+		Plan            BillingPlan      `json:"plan,omitempty"`
+		ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+		CreateTime      *time.Time       `json:"create_time,omitempty"`
+		UpdateTime      *time.Time       `json:"update_time,omitempty"`
+		Links           Links            `json:"links,omitempty"`
 	}
 
 	// BillingAgreementToken response struct
 	BillingAgreementToken struct {
-		Links   []Link `json:"links,omitempty"`
+		Links   Links  `json:"links,omitempty"`
 		TokenID string `json:"token_id,omitempty"`
 	}
 
@@ -393,7 +520,7 @@ type (
 		IsFinalCapture bool       `json:"is_final_capture"`
 		CreateTime     *time.Time `json:"create_time,omitempty"`
 		UpdateTime     *time.Time `json:"update_time,omitempty"`
-		Links          []Link     `json:"links,omitempty"`
+		Links          Links      `json:"links,omitempty"`
 	}
 
 	// ChargeModel struct
@@ -411,8 +538,36 @@ type (
 		Log                  io.Writer // If user set log file name all requests will be logged there
 		Token                *TokenResponse
 		tokenExpiresAt       time.Time
+		tokenInjected        bool
 		returnRepresentation bool
+		partnerAttributionID string
+		clientMetadataID     string
+		strictDecoding       bool
+		mockResponse         string
+		logUnredacted        bool
+		logger               Logger
+		curlLog              io.Writer
+		tracer               Tracer
+		seller               *sellerIdentity
 		ccCfg                *clientcredentials.Config
+		breaker              *circuitBreaker
+		etags                *etagCache
+		httpClient           *http.Client
+		recorder             RecordSink
+		autoIdempotency      bool
+		metricsHook          MetricsHook
+		readAfterWrite       *readAfterWriteRetry
+		maxRetries           int
+		retryPredicate       RetryPredicate
+		retryPolicy          *RetryPolicy
+		tokenRefreshTimeout  time.Duration
+		requestTimeout       time.Duration
+		tokenStore           TokenStore
+		tokenFlight          *tokenFlight
+		responseCache        *responseCache
+		middlewares          []Middleware
+		limiter              *rateLimiter
+		validateRequests     bool
 	}
 
 	// CreditCard struct
@@ -494,19 +649,38 @@ type (
 
 	// ErrorResponseDetail struct
 	ErrorResponseDetail struct {
-		Field string `json:"field"`
-		Issue string `json:"issue"`
-		Links []Link `json:"link"`
+		Field       string `json:"field"`
+		Issue       string `json:"issue"`
+		Description string `json:"description,omitempty"`
+		Value       string `json:"value,omitempty"`
+		Location    string `json:"location,omitempty"`
+		Links       Links  `json:"link"`
 	}
 
 	// ErrorResponse https://developer.paypal.com/docs/api/errors/
+	//
+	// DebugID is taken from the Paypal-Debug-Id header when the body has none,
+	// include it when contacting PayPal support.
 	ErrorResponse struct {
-		Response        *http.Response        `json:"-"`
+		Response *http.Response `json:"-"`
+		// Body is the raw response body, the body of Response is already closed
+		Body []byte `json:"-"`
+		// Method and URL of the failed request
+		Method string `json:"-"`
+		URL    string `json:"-"`
+		// Attempts is the number of times the request was sent, retries included
+		Attempts int `json:"-"`
+		// RequestHeader and RequestBody are a copy of the failed request with
+		// credentials, card numbers and emails redacted
+		RequestHeader http.Header `json:"-"`
+		RequestBody   []byte      `json:"-"`
+
 		Name            string                `json:"name"`
 		DebugID         string                `json:"debug_id"`
 		Message         string                `json:"message"`
 		InformationLink string                `json:"information_link"`
 		Details         []ErrorResponseDetail `json:"details"`
+		Links           Links                 `json:"links,omitempty"`
 	}
 
 	// ExecuteAgreementResponse struct
@@ -519,13 +693,13 @@ type (
 		StartDate        time.Time        `json:"start_date"`
 		ShippingAddress  ShippingAddress  `json:"shipping_address"`
 		AgreementDetails AgreementDetails `json:"agreement_details"`
-		Links            []Link           `json:"links"`
+		Links            Links            `json:"links"`
 	}
 
 	// ExecuteResponse struct
 	ExecuteResponse struct {
 		ID           string        `json:"id"`
-		Links        []Link        `json:"links"`
+		Links        Links         `json:"links"`
 		State        string        `json:"state"`
 		Payer        PaymentPayer  `json:"payer"`
 		Transactions []Transaction `json:"transactions,omitempty"`
@@ -563,6 +737,9 @@ type (
 		Enctype     string `json:"enctype,omitempty"`
 	}
 
+	// Links are the HATEOAS links of a response, see Find and FollowRel
+	Links []Link
+
 	// PurchaseUnitAmount struct
 	PurchaseUnitAmount struct {
 		Currency  string                       `json:"currency_code"`
@@ -601,6 +778,7 @@ type (
 		InvoiceID          string              `json:"invoice_id,omitempty"`
 		ID                 string              `json:"id,omitempty"`
 		SoftDescriptor     string              `json:"soft_descriptor,omitempty"`
+		Items              []Item              `json:"items,omitempty"`
 		Shipping           *ShippingDetail     `json:"shipping,omitempty"`
 	}
 
@@ -627,6 +805,18 @@ type (
 		Surname   string `json:"surname,omitempty"`
 	}
 
+	// CreateOrderRequest - https://developer.paypal.com/docs/api/orders/v2/#orders_create
+	CreateOrderRequest struct {
+		Intent             string                `json:"intent"`
+		Payer              *CreateOrderPayer     `json:"payer,omitempty"`
+		PurchaseUnits      []PurchaseUnitRequest `json:"purchase_units"`
+		PaymentSource      *PaymentSource        `json:"payment_source,omitempty"`
+		ApplicationContext *ApplicationContext   `json:"application_context,omitempty"`
+		// ProcessingInstruction ORDER_COMPLETE_ON_PAYMENT_APPROVAL captures the order
+		// when the buyer approves it, PayPal requires a PayPal-Request-Id with it
+		ProcessingInstruction string `json:"processing_instruction,omitempty"`
+	}
+
 	// CreateOrderPayer used with create order requests
 	CreateOrderPayer struct {
 		Name         *CreateOrderPayerName          `json:"name,omitempty"`
@@ -660,18 +850,25 @@ type (
 		AutoBillAmount          string        `json:"auto_bill_amount,omitempty"`
 		InitialFailAmountAction string        `json:"initial_fail_amount_action,omitempty"`
 		MaxFailAttempts         string        `json:"max_fail_attempts,omitempty"`
+		// AcceptedPaymentType, SkipShippingAddress and ImmutableShippingAddress
+		// apply to reference transaction agreement tokens
+		AcceptedPaymentType      string `json:"accepted_pymt_type,omitempty"`
+		SkipShippingAddress      bool   `json:"skip_shipping_address,omitempty"`
+		ImmutableShippingAddress bool   `json:"immutable_shipping_address,omitempty"`
 	}
 
 	// Order struct
 	Order struct {
-		ID            string                 `json:"id,omitempty"`
-		Status        string                 `json:"status,omitempty"`
-		Intent        string                 `json:"intent,omitempty"`
-		Payer         *PayerWithNameAndPhone `json:"payer,omitempty"`
-		PurchaseUnits []PurchaseUnit         `json:"purchase_units,omitempty"`
-		Links         []Link                 `json:"links,omitempty"`
-		CreateTime    *time.Time             `json:"create_time,omitempty"`
-		UpdateTime    *time.Time             `json:"update_time,omitempty"`
+		ID                    string                 `json:"id,omitempty"`
+		Status                string                 `json:"status,omitempty"`
+		Intent                string                 `json:"intent,omitempty"`
+		ProcessingInstruction string                 `json:"processing_instruction,omitempty"`
+		Payer                 *PayerWithNameAndPhone `json:"payer,omitempty"`
+		PurchaseUnits         []PurchaseUnit         `json:"purchase_units,omitempty"`
+		PaymentSource         *PaymentSource         `json:"payment_source,omitempty"`
+		Links                 Links                  `json:"links,omitempty"`
+		CreateTime            *time.Time             `json:"create_time,omitempty"`
+		UpdateTime            *time.Time             `json:"update_time,omitempty"`
 	}
 
 	// ExchangeRate struct
@@ -698,14 +895,69 @@ type (
 	CaptureAmount struct {
 		ID                        string                     `json:"id,omitempty"`
 		CustomID                  string                     `json:"custom_id,omitempty"`
+		InvoiceID                 string                     `json:"invoice_id,omitempty"`
 		Amount                    *PurchaseUnitAmount        `json:"amount,omitempty"`
 		SellerProtection          *SellerProtection          `json:"seller_protection,omitempty"`
 		SellerReceivableBreakdown *SellerReceivableBreakdown `json:"seller_receivable_breakdown,omitempty"`
+		Status                    string                     `json:"status,omitempty"`
+		StatusDetails             *CaptureStatusDetails      `json:"status_details,omitempty"`
+		FinalCapture              bool                       `json:"final_capture,omitempty"`
+		DisbursementMode          string                     `json:"disbursement_mode,omitempty"`
+		CreateTime                *time.Time                 `json:"create_time,omitempty"`
+		UpdateTime                *time.Time                 `json:"update_time,omitempty"`
+		Links                     Links                      `json:"links,omitempty"`
+	}
+
+	// AuthorizationAmount is an authorization of an order
+	AuthorizationAmount struct {
+		ID               string              `json:"id,omitempty"`
+		Status           string              `json:"status,omitempty"`
+		Amount           *PurchaseUnitAmount `json:"amount,omitempty"`
+		CustomID         string              `json:"custom_id,omitempty"`
+		InvoiceID        string              `json:"invoice_id,omitempty"`
+		SellerProtection *SellerProtection   `json:"seller_protection,omitempty"`
+		ExpirationTime   *time.Time          `json:"expiration_time,omitempty"`
+		CreateTime       *time.Time          `json:"create_time,omitempty"`
+		UpdateTime       *time.Time          `json:"update_time,omitempty"`
+		Links            Links               `json:"links,omitempty"`
+	}
+
+	// RefundAmount is a refund of an order capture
+	RefundAmount struct {
+		ID          string              `json:"id,omitempty"`
+		Status      string              `json:"status,omitempty"`
+		Amount      *PurchaseUnitAmount `json:"amount,omitempty"`
+		InvoiceID   string              `json:"invoice_id,omitempty"`
+		NoteToPayer string              `json:"note_to_payer,omitempty"`
+		CreateTime  *time.Time          `json:"create_time,omitempty"`
+		UpdateTime  *time.Time          `json:"update_time,omitempty"`
+		Links       Links               `json:"links,omitempty"`
+	}
+
+	// CapturedPayments has the authorizations, captures and refunds of an order
+	//
+	// https://developer.paypal.com/docs/api/orders/v2/#definition-payment_collection
+	CapturedPayments struct {
+		Authorizations []AuthorizationAmount `json:"authorizations,omitempty"`
+		Captures       []CaptureAmount       `json:"captures,omitempty"`
+		Refunds        []RefundAmount        `json:"refunds,omitempty"`
 	}
 
-	// CapturedPayments has the amounts for a captured order
-	CapturedPayments struct {
-		Captures []CaptureAmount `json:"captures,omitempty"`
+	// OrderPayment is an authorization, capture or refund of an order
+	OrderPayment struct {
+		Type        string              `json:"type"`
+		ID          string              `json:"id"`
+		Status      string              `json:"status,omitempty"`
+		ReferenceID string              `json:"reference_id,omitempty"`
+		Amount      *PurchaseUnitAmount `json:"amount,omitempty"`
+		CreateTime  *time.Time          `json:"create_time,omitempty"`
+		UpdateTime  *time.Time          `json:"update_time,omitempty"`
+	}
+
+	// OrderPayments is a flattened view of all payments of an order, oldest first
+	OrderPayments struct {
+		OrderID  string         `json:"order_id"`
+		Payments []OrderPayment `json:"payments"`
 	}
 
 	// CapturedPurchaseItem are items for a captured order
@@ -720,6 +972,8 @@ type (
 	CapturedPurchaseUnit struct {
 		Items       []CapturedPurchaseItem       `json:"items,omitempty"`
 		ReferenceID string                       `json:"reference_id"`
+		CustomID    string                       `json:"custom_id,omitempty"`
+		InvoiceID   string                       `json:"invoice_id,omitempty"`
 		Shipping    CapturedPurchaseUnitShipping `json:"shipping,omitempty"`
 		Payments    *CapturedPayments            `json:"payments,omitempty"`
 	}
@@ -746,6 +1000,8 @@ type (
 		Payer         *PayerWithNameAndPhone `json:"payer,omitempty"`
 		Address       *Address               `json:"address,omitempty"`
 		PurchaseUnits []CapturedPurchaseUnit `json:"purchase_units,omitempty"`
+		PaymentSource *PaymentSource         `json:"payment_source,omitempty"`
+		Links         Links                  `json:"links,omitempty"`
 	}
 
 	// Payer struct
@@ -807,13 +1063,155 @@ type (
 		Intent       string        `json:"intent"`
 		Payer        Payer         `json:"payer"`
 		Transactions []Transaction `json:"transactions"`
-		Links        []Link        `json:"links"`
+		Links        Links         `json:"links"`
 	}
 
 	// PaymentSource structure
+	// Exactly one payment source must be set in requests.
 	PaymentSource struct {
-		Card  *PaymentSourceCard  `json:"card,omitempty"`
-		Token *PaymentSourceToken `json:"token,omitempty"`
+		Card       *PaymentSourceCard      `json:"card,omitempty"`
+		Token      *PaymentSourceToken     `json:"token,omitempty"`
+		Paypal     *PaymentSourcePaypal    `json:"paypal,omitempty"`
+		Bancontact *PaymentSourceAPM       `json:"bancontact,omitempty"`
+		Blik       *PaymentSourceAPM       `json:"blik,omitempty"`
+		EPS        *PaymentSourceAPM       `json:"eps,omitempty"`
+		Giropay    *PaymentSourceAPM       `json:"giropay,omitempty"`
+		Ideal      *PaymentSourceAPM       `json:"ideal,omitempty"`
+		MyBank     *PaymentSourceAPM       `json:"mybank,omitempty"`
+		P24        *PaymentSourceAPM       `json:"p24,omitempty"`
+		Sofort     *PaymentSourceAPM       `json:"sofort,omitempty"`
+		ApplePay   *PaymentSourceApplePay  `json:"apple_pay,omitempty"`
+		GooglePay  *PaymentSourceGooglePay `json:"google_pay,omitempty"`
+	}
+
+	// PaymentSourceAPM is an alternative payment method like iDEAL or Bancontact.
+	// BIC is used by iDEAL and Sofort, Email by BLIK and Przelewy24.
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-ideal_request
+	PaymentSourceAPM struct {
+		Name              string             `json:"name,omitempty"`
+		CountryCode       string             `json:"country_code,omitempty"`
+		BIC               string             `json:"bic,omitempty"`
+		Email             string             `json:"email,omitempty"`
+		IBANLastChars     string             `json:"iban_last_chars,omitempty"`
+		ExperienceContext *ExperienceContext `json:"experience_context,omitempty"`
+	}
+
+	// ConfirmOrderRequest is the request of ConfirmPaymentSource
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#orders_confirm
+	ConfirmOrderRequest struct {
+		PaymentSource         *PaymentSource      `json:"payment_source"`
+		ProcessingInstruction string              `json:"processing_instruction,omitempty"`
+		ApplicationContext    *ApplicationContext `json:"application_context,omitempty"`
+	}
+
+	// PaymentSourcePaypal is the PayPal wallet paying an order, in requests it
+	// customizes the checkout experience of the buyer
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-paypal_wallet
+	PaymentSourcePaypal struct {
+		VaultID           string                         `json:"vault_id,omitempty"`
+		EmailAddress      string                         `json:"email_address,omitempty"`
+		AccountID         string                         `json:"account_id,omitempty"`
+		Name              *CreateOrderPayerName          `json:"name,omitempty"`
+		Phone             *PhoneWithType                 `json:"phone,omitempty"`
+		BirthDate         string                         `json:"birth_date,omitempty"`
+		TaxInfo           *TaxInfo                       `json:"tax_info,omitempty"`
+		Address           *ShippingDetailAddressPortable `json:"address,omitempty"`
+		ExperienceContext *ExperienceContext             `json:"experience_context,omitempty"`
+		Attributes        *PaypalWalletAttributes        `json:"attributes,omitempty"`
+	}
+
+	// PaymentSourceApplePay is an Apple Pay payment, either with a token the
+	// merchant decrypted or with the vault ID of a saved Apple Pay card
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-apple_pay_request
+	PaymentSourceApplePay struct {
+		ID               string                  `json:"id,omitempty"`
+		Name             string                  `json:"name,omitempty"`
+		EmailAddress     string                  `json:"email_address,omitempty"`
+		PhoneNumber      *Phone                  `json:"phone_number,omitempty"`
+		DecryptedToken   *ApplePayDecryptedToken `json:"decrypted_token,omitempty"`
+		StoredCredential *CardStoredCredential   `json:"stored_credential,omitempty"`
+		VaultID          string                  `json:"vault_id,omitempty"`
+		Attributes       *CardAttributes         `json:"attributes,omitempty"`
+		// Card is only returned in responses
+		Card *WalletCard `json:"card,omitempty"`
+	}
+
+	// ApplePayDecryptedToken is the payment token of Apple Pay after decryption
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-apple_pay_decrypted_token_data
+	ApplePayDecryptedToken struct {
+		TransactionAmount    *Money               `json:"transaction_amount,omitempty"`
+		TokenizedCard        *WalletCard          `json:"tokenized_card"`
+		DeviceManufacturerID string               `json:"device_manufacturer_id,omitempty"`
+		PaymentDataType      string               `json:"payment_data_type,omitempty"`
+		PaymentData          *ApplePayPaymentData `json:"payment_data,omitempty"`
+	}
+
+	// ApplePayPaymentData holds the 3D Secure cryptogram or the EMV data of the
+	// decrypted token, depending on its payment data type
+	ApplePayPaymentData struct {
+		Cryptogram   string `json:"cryptogram,omitempty"`
+		ECIIndicator string `json:"eci_indicator,omitempty"`
+		EMVData      string `json:"emv_data,omitempty"`
+		Pin          string `json:"pin,omitempty"`
+	}
+
+	// PaymentSourceGooglePay is a Google Pay payment, with either the card of the
+	// Google Pay JS response or a token the merchant decrypted
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-google_pay_request
+	PaymentSourceGooglePay struct {
+		Name             string                     `json:"name,omitempty"`
+		EmailAddress     string                     `json:"email_address,omitempty"`
+		PhoneNumber      *Phone                     `json:"phone_number,omitempty"`
+		Card             *WalletCard                `json:"card,omitempty"`
+		DecryptedToken   *GooglePayDecryptedToken   `json:"decrypted_token,omitempty"`
+		AssuranceDetails *GooglePayAssuranceDetails `json:"assurance_details,omitempty"`
+		Attributes       *CardAttributes            `json:"attributes,omitempty"`
+	}
+
+	// GooglePayDecryptedToken is the payment token of Google Pay after decryption
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-google_pay_decrypted_token_data
+	GooglePayDecryptedToken struct {
+		MessageID            string      `json:"message_id,omitempty"`
+		MessageExpiration    string      `json:"message_expiration,omitempty"`
+		PaymentMethod        string      `json:"payment_method"`
+		Card                 *WalletCard `json:"card"`
+		AuthenticationMethod string      `json:"authentication_method"`
+		Cryptogram           string      `json:"cryptogram,omitempty"`
+		ECIIndicator         string      `json:"eci_indicator,omitempty"`
+	}
+
+	// GooglePayAssuranceDetails tells how Google verified the card and its holder
+	GooglePayAssuranceDetails struct {
+		AccountVerified         bool `json:"account_verified"`
+		CardHolderAuthenticated bool `json:"card_holder_authenticated"`
+	}
+
+	// WalletCard is the card of an Apple Pay or Google Pay payment. Number and
+	// Expiry are only sent with decrypted tokens, LastDigits and the
+	// authentication result are only returned in responses.
+	WalletCard struct {
+		Name                 string                    `json:"name,omitempty"`
+		Number               string                    `json:"number,omitempty"`
+		Expiry               string                    `json:"expiry,omitempty"`
+		LastDigits           string                    `json:"last_digits,omitempty"`
+		Type                 string                    `json:"type,omitempty"`
+		Brand                string                    `json:"brand,omitempty"`
+		BillingAddress       *CardBillingAddress       `json:"billing_address,omitempty"`
+		AuthenticationResult *CardAuthenticationResult `json:"authentication_result,omitempty"`
+	}
+
+	// ExperienceContext customizes the payer experience of a PayPal wallet payment,
+	// it replaces the deprecated application_context of orders
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-paypal_wallet_experience_context
+	ExperienceContext struct {
+		BrandName               string             `json:"brand_name,omitempty"`
+		Locale                  string             `json:"locale,omitempty"`
+		ShippingPreference      ShippingPreference `json:"shipping_preference,omitempty"`
+		UserAction              UserAction         `json:"user_action,omitempty"`
+		LandingPage             LandingPage        `json:"landing_page,omitempty"`
+		PaymentMethodPreference string             `json:"payment_method_preference,omitempty"`
+		ReturnURL               string             `json:"return_url,omitempty"`
+		CancelURL               string             `json:"cancel_url,omitempty"`
 	}
 
 	// PaymentSourceCard structure
@@ -826,6 +1224,182 @@ type (
 		LastDigits     string              `json:"last_digits"`
 		CardType       string              `json:"card_type"`
 		BillingAddress *CardBillingAddress `json:"billing_address"`
+
+		Attributes       *CardAttributes       `json:"attributes,omitempty"`
+		StoredCredential *CardStoredCredential `json:"stored_credential,omitempty"`
+
+		// Brand and Type are only returned in responses
+		Brand                string                    `json:"brand,omitempty"`
+		Type                 string                    `json:"type,omitempty"`
+		AuthenticationResult *CardAuthenticationResult `json:"authentication_result,omitempty"`
+	}
+
+	// CardAttributes holds the additional instructions of a card payment source,
+	// e.g. to request a 3D Secure verification
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-card_attributes
+	CardAttributes struct {
+		Customer     *VaultCustomer      `json:"customer,omitempty"`
+		Vault        *PaymentSourceVault `json:"vault,omitempty"`
+		Verification *CardVerification   `json:"verification,omitempty"`
+	}
+
+	// PaypalWalletAttributes holds the additional instructions of a PayPal
+	// wallet payment source
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-paypal_wallet_attributes
+	PaypalWalletAttributes struct {
+		Customer *VaultCustomer      `json:"customer,omitempty"`
+		Vault    *PaymentSourceVault `json:"vault,omitempty"`
+	}
+
+	// PaymentSourceVault asks PayPal to save the payment source when the
+	// payment succeeds. Responses carry the vault ID and status, the ID is
+	// the payment token to pay with later on.
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-vault_instruction_base
+	PaymentSourceVault struct {
+		StoreInVault                string `json:"store_in_vault,omitempty"`
+		UsageType                   string `json:"usage_type,omitempty"`
+		CustomerType                string `json:"customer_type,omitempty"`
+		Description                 string `json:"description,omitempty"`
+		UsagePattern                string `json:"usage_pattern,omitempty"`
+		PermitMultiplePaymentTokens bool   `json:"permit_multiple_payment_tokens,omitempty"`
+
+		// ID, Status, Customer and Links are only returned in responses
+		ID       string         `json:"id,omitempty"`
+		Status   string         `json:"status,omitempty"`
+		Customer *VaultCustomer `json:"customer,omitempty"`
+		Links    Links          `json:"links,omitempty"`
+	}
+
+	// CardVerification sets when PayPal runs the 3D Secure contingency, see the
+	// SCAMethod constants
+	CardVerification struct {
+		Method string `json:"method,omitempty"`
+	}
+
+	// CardStoredCredential describes a payment with a card stored on file, for
+	// the card network rules on credential on file transactions
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-card_stored_credential
+	CardStoredCredential struct {
+		PaymentInitiator                    string                       `json:"payment_initiator"`
+		PaymentType                         string                       `json:"payment_type"`
+		Usage                               string                       `json:"usage,omitempty"`
+		PreviousNetworkTransactionReference *NetworkTransactionReference `json:"previous_network_transaction_reference,omitempty"`
+	}
+
+	// NetworkTransactionReference identifies a previous transaction of the card
+	// network, e.g. the first payment of a recurring series
+	NetworkTransactionReference struct {
+		ID      string `json:"id"`
+		Date    string `json:"date,omitempty"`
+		Network string `json:"network,omitempty"`
+	}
+
+	// CardAuthenticationResult is the result of the 3D Secure authentication of a card
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-authentication_response
+	CardAuthenticationResult struct {
+		LiabilityShift string                `json:"liability_shift,omitempty"`
+		ThreeDSecure   *ThreeDSecureResponse `json:"three_d_secure,omitempty"`
+	}
+
+	// ThreeDSecureResponse holds the enrollment and authentication status of a 3D Secure authentication
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-three_d_secure_authentication_response
+	ThreeDSecureResponse struct {
+		EnrollmentStatus     string `json:"enrollment_status,omitempty"`
+		AuthenticationStatus string `json:"authentication_status,omitempty"`
+	}
+
+	// VaultCustomer is the customer a payment token belongs to
+	VaultCustomer struct {
+		ID                 string `json:"id,omitempty"`
+		MerchantCustomerID string `json:"merchant_customer_id,omitempty"`
+	}
+
+	// VaultSetupToken references a setup token approved by the buyer
+	VaultSetupToken struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+
+	// PaymentTokenSource is the payment source to vault
+	PaymentTokenSource struct {
+		Card  *PaymentSourceCard `json:"card,omitempty"`
+		Token *VaultSetupToken   `json:"token,omitempty"`
+	}
+
+	// VaultedCard is a card saved in the vault
+	VaultedCard struct {
+		Name           string              `json:"name,omitempty"`
+		Brand          string              `json:"brand,omitempty"`
+		Type           string              `json:"type,omitempty"`
+		LastDigits     string              `json:"last_digits,omitempty"`
+		Expiry         string              `json:"expiry,omitempty"`
+		BillingAddress *CardBillingAddress `json:"billing_address,omitempty"`
+	}
+
+	// VaultedPaypalWallet is a PayPal wallet saved in the vault
+	VaultedPaypalWallet struct {
+		EmailAddress string `json:"email_address,omitempty"`
+		PayerID      string `json:"payer_id,omitempty"`
+	}
+
+	// VaultedPaymentSource is the saved payment source of a payment token
+	VaultedPaymentSource struct {
+		Card   *VaultedCard         `json:"card,omitempty"`
+		Paypal *VaultedPaypalWallet `json:"paypal,omitempty"`
+	}
+
+	// PaymentToken struct
+	// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#payment-tokens_create
+	PaymentToken struct {
+		ID            string                `json:"id"`
+		Customer      *VaultCustomer        `json:"customer,omitempty"`
+		PaymentSource *VaultedPaymentSource `json:"payment_source,omitempty"`
+		Links         Links                 `json:"links,omitempty"`
+	}
+
+	// VaultCard is a card to save in the vault with a setup token
+	// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#definition-card_request
+	VaultCard struct {
+		Name           string              `json:"name,omitempty"`
+		Number         string              `json:"number"`
+		Expiry         string              `json:"expiry"`
+		SecurityCode   string              `json:"security_code,omitempty"`
+		BillingAddress *CardBillingAddress `json:"billing_address,omitempty"`
+	}
+
+	// VaultPaypalWallet is a PayPal wallet to save in the vault with a setup
+	// token, the buyer approves it at the approve link of the setup token
+	// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#definition-paypal_wallet_request
+	VaultPaypalWallet struct {
+		Description                 string             `json:"description,omitempty"`
+		UsagePattern                string             `json:"usage_pattern,omitempty"`
+		UsageType                   string             `json:"usage_type"`
+		CustomerType                string             `json:"customer_type,omitempty"`
+		PermitMultiplePaymentTokens bool               `json:"permit_multiple_payment_tokens,omitempty"`
+		ExperienceContext           *ExperienceContext `json:"experience_context,omitempty"`
+	}
+
+	// SetupTokenSource is the payment source of a setup token
+	SetupTokenSource struct {
+		Card   *VaultCard         `json:"card,omitempty"`
+		Paypal *VaultPaypalWallet `json:"paypal,omitempty"`
+	}
+
+	// SetupToken is a payment source waiting to be approved and saved as a payment token
+	// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#setup-tokens_create
+	SetupToken struct {
+		ID            string                `json:"id"`
+		Customer      *VaultCustomer        `json:"customer,omitempty"`
+		Status        string                `json:"status,omitempty"`
+		PaymentSource *VaultedPaymentSource `json:"payment_source,omitempty"`
+		Links         Links                 `json:"links,omitempty"`
+	}
+
+	// PaymentTokens is a page of the payment tokens of a customer
+	PaymentTokens struct {
+		Customer      *VaultCustomer `json:"customer,omitempty"`
+		PaymentTokens []PaymentToken `json:"payment_tokens"`
+		SharedListResponse
 	}
 
 	// CardBillingAddress structure
@@ -869,7 +1443,7 @@ type (
 		PayoutItemFee     *AmountPayout `json:"payout_item_fee,omitempty"`
 		PayoutItem        *PayoutItem   `json:"payout_item"`
 		TimeProcessed     *time.Time    `json:"time_processed,omitempty"`
-		Links             []Link        `json:"links"`
+		Links             Links         `json:"links"`
 		Error             ErrorResponse `json:"errors,omitempty"`
 	}
 
@@ -877,7 +1451,16 @@ type (
 	PayoutResponse struct {
 		BatchHeader *BatchHeader         `json:"batch_header"`
 		Items       []PayoutItemResponse `json:"items"`
-		Links       []Link               `json:"links"`
+		Links       Links                `json:"links"`
+		Page        int                  `json:"page,omitempty"`
+		TotalItems  int                  `json:"total_items,omitempty"`
+		TotalPages  int                  `json:"total_pages,omitempty"`
+	}
+
+	// PayoutListParams selects a page of the items of a payout batch
+	PayoutListParams struct {
+		ListParams
+		Fields string `json:"fields,omitempty"`
 	}
 
 	// RedirectURLs struct
@@ -899,10 +1482,31 @@ type (
 
 	// RefundResponse .
 	RefundResponse struct {
-		ID     string              `json:"id,omitempty"`
-		Amount *PurchaseUnitAmount `json:"amount,omitempty"`
-		Status string              `json:"status,omitempty"`
-		Links  []Link              `json:"links,omitempty"`
+		ID            string                `json:"id,omitempty"`
+		Amount        *PurchaseUnitAmount   `json:"amount,omitempty"`
+		InvoiceID     string                `json:"invoice_id,omitempty"`
+		CustomID      string                `json:"custom_id,omitempty"`
+		Status        string                `json:"status,omitempty"`
+		StatusDetails *CaptureStatusDetails `json:"status_details,omitempty"`
+		NoteToPayer   string                `json:"note_to_payer,omitempty"`
+		Links         Links                 `json:"links,omitempty"`
+
+		SellerPayableBreakdown *SellerPayableBreakdown `json:"seller_payable_breakdown,omitempty"`
+		CreateTime             *time.Time              `json:"create_time,omitempty"`
+		UpdateTime             *time.Time              `json:"update_time,omitempty"`
+	}
+
+	// SellerPayableBreakdown has the detailed breakdown of a refund, the amounts
+	// are what the seller pays back
+	// https://developer.paypal.com/docs/api/payments/v2/#definition-seller_payable_breakdown
+	SellerPayableBreakdown struct {
+		GrossAmount                   *Money        `json:"gross_amount,omitempty"`
+		PaypalFee                     *Money        `json:"paypal_fee,omitempty"`
+		PaypalFeeInReceivableCurrency *Money        `json:"paypal_fee_in_receivable_currency,omitempty"`
+		NetAmount                     *Money        `json:"net_amount,omitempty"`
+		NetAmountInReceivableCurrency *Money        `json:"net_amount_in_receivable_currency,omitempty"`
+		PlatformFees                  []PlatformFee `json:"platform_fees,omitempty"`
+		TotalRefundedAmount           *Money        `json:"total_refunded_amount,omitempty"`
 	}
 
 	// Related struct
@@ -930,7 +1534,7 @@ type (
 		ClearingTime              string     `json:"clearing_time,omitempty"`
 		ProtectionEligibility     string     `json:"protection_eligibility,omitempty"`
 		ProtectionEligibilityType string     `json:"protection_eligibility_type,omitempty"`
-		Links                     []Link     `json:"links,omitempty"`
+		Links                     Links      `json:"links,omitempty"`
 	}
 
 	// SenderBatchHeader struct
@@ -938,6 +1542,8 @@ type (
 		EmailSubject  string `json:"email_subject"`
 		EmailMessage  string `json:"email_message"`
 		SenderBatchID string `json:"sender_batch_id,omitempty"`
+		RecipientType string `json:"recipient_type,omitempty"`
+		Note          string `json:"note,omitempty"`
 	}
 
 	//ShippingAmount struct
@@ -982,7 +1588,55 @@ type (
 	// ShippingDetail struct
 	ShippingDetail struct {
 		Name    *Name                          `json:"name,omitempty"`
+		Type    string                         `json:"type,omitempty"`
 		Address *ShippingDetailAddressPortable `json:"address,omitempty"`
+		// Options the buyer chooses from at approval, see SelectShippingOption
+		Options []ShippingOption `json:"options,omitempty"`
+		// Trackers are only set in responses, see AddOrderTracker
+		Trackers []OrderTracker `json:"trackers,omitempty"`
+	}
+
+	// ShippingOption is a shipping method offered to the buyer, the amount of
+	// the selected option is the shipping of the amount breakdown
+	// Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-shipping_option
+	ShippingOption struct {
+		ID       string `json:"id"`
+		Label    string `json:"label"`
+		Type     string `json:"type,omitempty"`
+		Amount   *Money `json:"amount,omitempty"`
+		Selected bool   `json:"selected"`
+	}
+
+	// OrderTrackerItem is an item of a tracked shipment
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-tracker_item
+	OrderTrackerItem struct {
+		Name     string `json:"name,omitempty"`
+		Quantity string `json:"quantity,omitempty"`
+		SKU      string `json:"sku,omitempty"`
+		URL      string `json:"url,omitempty"`
+		ImageURL string `json:"image_url,omitempty"`
+	}
+
+	// AddOrderTrackerRequest adds the tracking information of a shipment to an order
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#orders_track_create
+	AddOrderTrackerRequest struct {
+		CaptureID        string             `json:"capture_id"`
+		TrackingNumber   string             `json:"tracking_number,omitempty"`
+		Carrier          Carrier            `json:"carrier,omitempty"`
+		CarrierNameOther string             `json:"carrier_name_other,omitempty"`
+		NotifyPayer      bool               `json:"notify_payer,omitempty"`
+		Items            []OrderTrackerItem `json:"items,omitempty"`
+	}
+
+	// OrderTracker is the tracking information of a shipment of an order
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-order_tracker_response
+	OrderTracker struct {
+		ID         string             `json:"id,omitempty"`
+		Status     ShipmentStatus     `json:"status,omitempty"`
+		Items      []OrderTrackerItem `json:"items,omitempty"`
+		Links      Links              `json:"links,omitempty"`
+		CreateTime *time.Time         `json:"create_time,omitempty"`
+		UpdateTime *time.Time         `json:"update_time,omitempty"`
 	}
 
 	// Subscriber struct
@@ -990,6 +1644,7 @@ type (
 		ShippingAddress ShippingDetail       `json:"shipping_address,omitempty"`
 		Name            CreateOrderPayerName `json:"name,omitempty"`
 		EmailAddress    string               `json:"email_address,omitempty"`
+		PayerID         string               `json:"payer_id,omitempty"`
 	}
 
 	expirationTime int64
@@ -1000,6 +1655,18 @@ type (
 		Token        string         `json:"access_token"`
 		Type         string         `json:"token_type"`
 		ExpiresIn    expirationTime `json:"expires_in"`
+		Scope        string         `json:"scope,omitempty"`
+		IDToken      string         `json:"id_token,omitempty"`
+		AppID        string         `json:"app_id,omitempty"`
+		// ExpiresAt is the expiry of the token, zero when it is unknown, e.g. for
+		// tokens set with SetAccessToken
+		ExpiresAt time.Time `json:"-"`
+	}
+
+	// ClientToken authorizes the JavaScript SDK, pass it in the data-client-token attribute
+	ClientToken struct {
+		ClientToken string         `json:"client_token"`
+		ExpiresIn   expirationTime `json:"expires_in"`
 	}
 
 	// Transaction struct
@@ -1048,6 +1715,35 @@ type (
 		PayerID         string   `json:"payer_id,omitempty"`
 	}
 
+	// PaypalUserInfo is the profile of a PayPal user in the paypalv1.1 schema
+	// Doc: https://developer.paypal.com/docs/api/identity/v1/#userinfo_get
+	PaypalUserInfo struct {
+		UserID          string           `json:"user_id"`
+		PayerID         string           `json:"payer_id,omitempty"`
+		Name            string           `json:"name,omitempty"`
+		GivenName       string           `json:"given_name,omitempty"`
+		FamilyName      string           `json:"family_name,omitempty"`
+		Emails          []UserInfoEmail  `json:"emails,omitempty"`
+		VerifiedAccount bool             `json:"verified_account,omitempty"`
+		Address         *UserInfoAddress `json:"address,omitempty"`
+	}
+
+	// UserInfoEmail is an email address of a PayPal user
+	UserInfoEmail struct {
+		Value     string `json:"value"`
+		Primary   bool   `json:"primary,omitempty"`
+		Confirmed bool   `json:"confirmed,omitempty"`
+	}
+
+	// UserInfoAddress is the address of a PayPal user, in the OpenID Connect format
+	UserInfoAddress struct {
+		StreetAddress string `json:"street_address,omitempty"`
+		Locality      string `json:"locality,omitempty"`
+		Region        string `json:"region,omitempty"`
+		PostalCode    string `json:"postal_code,omitempty"`
+		Country       string `json:"country,omitempty"`
+	}
+
 	// WebProfile represents the configuration of the payment web payment experience
 	//
 	// https://developer.paypal.com/docs/api/payment-experience/
@@ -1057,6 +1753,8 @@ type (
 		Presentation Presentation `json:"presentation,omitempty"`
 		InputFields  InputFields  `json:"input_fields,omitempty"`
 		FlowConfig   FlowConfig   `json:"flow_config,omitempty"`
+		// Temporary profiles are deleted by PayPal after three hours
+		Temporary bool `json:"temporary,omitempty"`
 	}
 
 	// Presentation represents the branding and locale that a customer sees on
@@ -1064,9 +1762,11 @@ type (
 	//
 	// https://developer.paypal.com/docs/api/payment-experience/#definition-presentation
 	Presentation struct {
-		BrandName  string `json:"brand_name,omitempty"`
-		LogoImage  string `json:"logo_image,omitempty"`
-		LocaleCode string `json:"locale_code,omitempty"`
+		BrandName         string `json:"brand_name,omitempty"`
+		LogoImage         string `json:"logo_image,omitempty"`
+		LocaleCode        string `json:"locale_code,omitempty"`
+		ReturnURLLabel    string `json:"return_url_label,omitempty"`
+		NoteToSellerLabel string `json:"note_to_seller_label,omitempty"`
 	}
 
 	// InputFields represents the fields that are displayed to a customer on
@@ -1083,9 +1783,10 @@ type (
 	//
 	// https://developer.paypal.com/docs/api/payment-experience/#definition-flow_config
 	FlowConfig struct {
-		LandingPageType   string `json:"landing_page_type,omitempty"`
-		BankTXNPendingURL string `json:"bank_txn_pending_url,omitempty"`
-		UserAction        string `json:"user_action,omitempty"`
+		LandingPageType     string `json:"landing_page_type,omitempty"`
+		BankTXNPendingURL   string `json:"bank_txn_pending_url,omitempty"`
+		UserAction          string `json:"user_action,omitempty"`
+		ReturnURIHTTPMethod string `json:"return_uri_http_method,omitempty"`
 	}
 
 	// VerifyWebhookResponse struct
@@ -1102,7 +1803,7 @@ type (
 		ID         string             `json:"id"`
 		URL        string             `json:"url"`
 		EventTypes []WebhookEventType `json:"event_types"`
-		Links      []Link             `json:"links"`
+		Links      Links              `json:"links"`
 	}
 
 	// Event struct.
@@ -1115,7 +1816,7 @@ type (
 		ResourceType    string    `json:"resource_type"`
 		EventType       string    `json:"event_type"`
 		Summary         string    `json:"summary,omitempty"`
-		Links           []Link    `json:"links"`
+		Links           Links     `json:"links"`
 		EventVersion    string    `json:"event_version,omitempty"`
 		ResourceVersion string    `json:"resource_version,omitempty"`
 	}
@@ -1126,10 +1827,15 @@ type (
 	}
 
 	// WebhookEventType struct
+	//
+	// ResourceVersions pins the versions of the resource payload delivered for
+	// the event when subscribing; PayPal lists the supported versions in
+	// GetWebhookEventTypes.
 	WebhookEventType struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
-		Status      string `json:"status,omitempty"`
+		Name             string   `json:"name"`
+		Description      string   `json:"description"`
+		Status           string   `json:"status,omitempty"`
+		ResourceVersions []string `json:"resource_versions,omitempty"`
 	}
 
 	// CreateWebhookRequest struct
@@ -1142,6 +1848,36 @@ type (
 		Webhooks []Webhook `json:"webhooks"`
 	}
 
+	// ListWebhookEventsParams filters the webhook events listed by NewWebhookEventPaginator
+	ListWebhookEventsParams struct {
+		PageSize      string
+		StartTime     *time.Time
+		EndTime       *time.Time
+		TransactionID string
+		EventType     string
+	}
+
+	// ListWebhookEventsResponse is a page of webhook events
+	ListWebhookEventsResponse struct {
+		Events []WebhookEvent `json:"events"`
+		Count  int            `json:"count"`
+		Links  Links          `json:"links,omitempty"`
+	}
+
+	// ResendWebhookEventRequest is the body of ResendWebhookEvent
+	ResendWebhookEventRequest struct {
+		WebhookIDs []string `json:"webhook_ids,omitempty"`
+	}
+
+	// SimulateWebhookEventRequest is the body of SimulateWebhookEvent, set
+	// either WebhookID or URL. ResourceVersion defaults to the latest version.
+	SimulateWebhookEventRequest struct {
+		WebhookID       string `json:"webhook_id,omitempty"`
+		URL             string `json:"url,omitempty"`
+		EventType       string `json:"event_type"`
+		ResourceVersion string `json:"resource_version,omitempty"`
+	}
+
 	WebhookField struct {
 		Operation string      `json:"op"`
 		Path      string      `json:"path"`
@@ -1171,13 +1907,14 @@ type (
 		SellerReceivableBreakdown *SellerReceivableBreakdown `json:"seller_receivable_breakdown,omitempty"`
 		NoteToPayer               string                     `json:"note_to_payer,omitempty"`
 		CustomID                  string                     `json:"custom_id,omitempty"`
+		InvoiceID                 string                     `json:"invoice_id,omitempty"`
 		PartnerClientID           string                     `json:"partner_client_id,omitempty"`
 		MerchantID                string                     `json:"merchant_id,omitempty"`
 		Intent                    string                     `json:"intent,omitempty"`
 		BillingAgreementID        *string                    `json:"billing_agreement_id,omitempty"`
 		PurchaseUnits             []*PurchaseUnitRequest     `json:"purchase_units,omitempty"`
 		Payer                     *PayerWithNameAndPhone     `json:"payer,omitempty"`
-		Links                     []Link                     `json:"links,omitempty"`
+		Links                     Links                      `json:"links,omitempty"`
 	}
 
 	CaptureSellerBreakdown struct {
@@ -1187,16 +1924,29 @@ type (
 		TotalRefundedAmount *PurchaseUnitAmount `json:"total_refunded_amount,omitempty"`
 	}
 
+	// ReferralRequest is the body of CreatePartnerReferral
+	// Doc: https://developer.paypal.com/docs/api/partner-referrals/v2/#partner-referrals_create
 	ReferralRequest struct {
+		Email                 string                 `json:"email,omitempty"`
+		PreferredLanguageCode string                 `json:"preferred_language_code,omitempty"`
 		TrackingID            string                 `json:"tracking_id"`
 		PartnerConfigOverride *PartnerConfigOverride `json:"partner_config_override,omitempty"`
 		Operations            []Operation            `json:"operations,omitempty"`
 		Products              []string               `json:"products,omitempty"`
+		Capabilities          []string               `json:"capabilities,omitempty"`
 		LegalConsents         []Consent              `json:"legal_consents,omitempty"`
 	}
 
 	ReferralResponse struct {
-		Links []Link `json:"links,omitempty"`
+		Links Links `json:"links,omitempty"`
+	}
+
+	// PartnerReferral is a referral created with CreatePartnerReferral
+	PartnerReferral struct {
+		PartnerReferralID string          `json:"partner_referral_id"`
+		SubmitterPayerID  string          `json:"submitter_payer_id,omitempty"`
+		ReferralData      ReferralRequest `json:"referral_data"`
+		Links             Links           `json:"links,omitempty"`
 	}
 
 	PartnerConfigOverride struct {
@@ -1217,15 +1967,23 @@ type (
 	}
 
 	RestAPIIntegration struct {
-		IntegrationMethod string            `json:"integration_method"`
-		IntegrationType   string            `json:"integration_type"`
-		ThirdPartyDetails ThirdPartyDetails `json:"third_party_details"`
+		IntegrationMethod string             `json:"integration_method"`
+		IntegrationType   string             `json:"integration_type"`
+		ThirdPartyDetails ThirdPartyDetails  `json:"third_party_details"`
+		FirstPartyDetails *FirstPartyDetails `json:"first_party_details,omitempty"`
 	}
 
 	ThirdPartyDetails struct {
 		Features []string `json:"features"`
 	}
 
+	// FirstPartyDetails are the features a seller integrating with its own
+	// credentials grants, the seller nonce is the code verifier of ExchangeSellerAuthCode
+	FirstPartyDetails struct {
+		Features    []string `json:"features"`
+		SellerNonce string   `json:"seller_nonce"`
+	}
+
 	Consent struct {
 		Type    string `json:"type"`
 		Granted bool   `json:"granted"`
@@ -1329,12 +2087,42 @@ type (
 		PayerInfo       *SearchPayerInfo      `json:"payer_info"`
 		ShippingInfo    *SearchShippingInfo   `json:"shipping_info"`
 		CartInfo        *SearchCartInfo       `json:"cart_info"`
+		StoreInfo       *SearchStoreInfo      `json:"store_info,omitempty"`
+		AuctionInfo     *SearchAuctionInfo    `json:"auction_info,omitempty"`
+		IncentiveInfo   *SearchIncentiveInfo  `json:"incentive_info,omitempty"`
+	}
+
+	// SearchStoreInfo is the store and terminal of an in-store transaction
+	SearchStoreInfo struct {
+		StoreID    string `json:"store_id,omitempty"`
+		TerminalID string `json:"terminal_id,omitempty"`
+	}
+
+	// SearchAuctionInfo is the auction a transaction paid for
+	SearchAuctionInfo struct {
+		AuctionSite        string `json:"auction_site,omitempty"`
+		AuctionItemSite    string `json:"auction_item_site,omitempty"`
+		AuctionBuyerID     string `json:"auction_buyer_id,omitempty"`
+		AuctionClosingDate string `json:"auction_closing_date,omitempty"`
+	}
+
+	// SearchIncentiveInfo lists the incentives, such as coupons, applied to a transaction
+	SearchIncentiveInfo struct {
+		IncentiveDetails []SearchIncentiveDetail `json:"incentive_details,omitempty"`
+	}
+
+	// SearchIncentiveDetail is an incentive applied to a transaction
+	SearchIncentiveDetail struct {
+		IncentiveType        string `json:"incentive_type,omitempty"`
+		IncentiveCode        string `json:"incentive_code,omitempty"`
+		IncentiveAmount      *Money `json:"incentive_amount,omitempty"`
+		IncentiveProgramCode string `json:"incentive_program_code,omitempty"`
 	}
 
 	SharedResponse struct {
 		CreateTime string `json:"create_time"`
 		UpdateTime string `json:"update_time"`
-		Links      []Link `json:"links"`
+		Links      Links  `json:"links"`
 	}
 
 	ListParams struct {
@@ -1344,15 +2132,26 @@ type (
 	}
 
 	SharedListResponse struct {
-		TotalItems int    `json:"total_items,omitempty"`
-		TotalPages int    `json:"total_pages,omitempty"`
-		Links      []Link `json:"links,omitempty"`
+		TotalItems int   `json:"total_items,omitempty"`
+		TotalPages int   `json:"total_pages,omitempty"`
+		Links      Links `json:"links,omitempty"`
 	}
 )
 
 // Error method implementation for ErrorResponse struct
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %s, %+v", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message, r.Details)
+	var msg string
+	if r.Response != nil && r.Response.Request != nil {
+		msg = fmt.Sprintf("%v %v: %d %s, %+v", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message, r.Details)
+	} else if r.Method != "" {
+		msg = fmt.Sprintf("%v %v: %d %s, %+v", r.Method, r.URL, r.StatusCode(), r.Message, r.Details)
+	} else {
+		msg = fmt.Sprintf("paypal: %d %s %s, %+v", r.StatusCode(), r.Name, r.Message, r.Details)
+	}
+	if r.DebugID != "" {
+		msg += " (debug_id " + r.DebugID + ")"
+	}
+	return msg
 }
 
 // MarshalJSON for JSONTime
@@ -1361,14 +2160,64 @@ func (t JSONTime) MarshalJSON() ([]byte, error) {
 	return []byte(stamp), nil
 }
 
-// UnmarshalJSON for JSONTime, timezone offset is missing a colon ':"
+// UnmarshalJSON for JSONTime, PayPal sends RFC 3339 timestamps with or without
+// a colon in the timezone offset, e.g. "2023-03-12T12:00:00+0000"
 func (t *JSONTime) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), `"`)
-	nt, err := time.Parse("2006-01-02T15:04:05Z0700", s)
+	nt, err := parseJSONTime(b, timestampLayouts)
 	*t = JSONTime(nt)
 	return err
 }
 
+// String returns the time formatted with RFC 3339
+func (t JSONTime) String() string {
+	return time.Time(t).Format(time.RFC3339)
+}
+
+// MarshalJSON for JSONDate
+func (d JSONDate) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON for JSONDate
+func (d *JSONDate) UnmarshalJSON(b []byte) error {
+	nt, err := parseJSONTime(b, append([]string{"2006-01-02", "2006-01"}, timestampLayouts...))
+	*d = JSONDate(nt)
+	return err
+}
+
+// String returns the date formatted as YYYY-MM-DD
+func (d JSONDate) String() string {
+	return time.Time(d).Format("2006-01-02")
+}
+
+// timestampLayouts are the timestamp formats found in PayPal responses
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05",
+}
+
+// parseJSONTime parses a JSON string with the first matching layout, null and
+// empty strings are the zero time
+func parseJSONTime(b []byte, layouts []string) (time.Time, error) {
+	if string(b) == "null" {
+		return time.Time{}, nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return time.Time{}, fmt.Errorf("paypal: invalid time %s", b)
+	}
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("paypal: unsupported time format %q", s)
+}
+
 func (e *expirationTime) UnmarshalJSON(b []byte) error {
 	var n json.Number
 	err := json.Unmarshal(b, &n)
@@ -1,12 +1,19 @@
 package paypal
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,11 +36,129 @@ func TestNewClient(t *testing.T) {
 	}
 
 	c, err = NewClient("1", "2", "3")
+	if err == nil {
+		t.Errorf("Expected error for NewClient(1, 2, 3), 3 is not an API base URL")
+	}
+
+	c, err = NewClient("1", "2", APIBaseSandBox)
 	if err != nil {
-		t.Errorf("Not expected error for NewClient(1, 2, 3), got %v", err)
+		t.Errorf("Not expected error for NewClient(1, 2, APIBaseSandBox), got %v", err)
 	}
 	if c == nil {
-		t.Errorf("Expected non-nil Client for NewClient(1, 2, 3)")
+		t.Errorf("Expected non-nil Client for NewClient(1, 2, APIBaseSandBox)")
+	}
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.URL.Path == "/v2/checkout/orders/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	transport := &countingTransport{}
+	httpClient := &http.Client{}
+	c, err := NewClient("foo", "bar", ts.URL, WithHTTPClient(httpClient), WithTransport(transport), WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if httpClient.Transport != nil || httpClient.Timeout != 0 {
+		t.Errorf("expecting options not to modify the given http.Client")
+	}
+
+	ctx := context.Background()
+	if _, err = c.GetOrder(ctx, "O1"); err != nil {
+		t.Fatal(err)
+	}
+	if transport.requests != 2 {
+		t.Errorf("expecting token and order requests to use the transport, got %d requests", transport.requests)
+	}
+
+	if _, err = c.GetOrder(ctx, "slow"); err == nil {
+		t.Errorf("expecting the request to time out")
+	}
+}
+
+func TestTLSAndProxyOptions(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	})
+	ts := httptest.NewUnstartedServer(handler)
+	ts.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	ts.StartTLS()
+	defer ts.Close()
+
+	ctx := context.Background()
+	c, _ := NewClient("foo", "bar", ts.URL)
+	if _, err := c.GetOrder(ctx, "O1"); err == nil {
+		t.Fatal("expecting the certificate of the test server to be rejected")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+	c, _ = NewClient("foo", "bar", ts.URL, WithTLSConfig(&tls.Config{RootCAs: pool}))
+	if _, err := c.GetOrder(ctx, "O1"); err != nil {
+		t.Fatalf("expecting the CA of the TLS config to be trusted, got %v", err)
+	}
+
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.Host+r.URL.Path)
+		handler(w, r)
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	c, _ = NewClient("foo", "bar", "http://api.paypal.test", WithProxy(proxyURL))
+	if _, err := c.GetOrder(ctx, "O1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(proxied) != 2 || proxied[0] != "api.paypal.test/v1/oauth2/token" || proxied[1] != "api.paypal.test/v2/checkout/orders/O1" {
+		t.Errorf("expecting token and order requests through the proxy, got %v", proxied)
+	}
+}
+
+func TestPartnerAttributionID(t *testing.T) {
+	var bnCodes []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		bnCodes = append(bnCodes, r.Header.Get("PayPal-Partner-Attribution-Id"))
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL, WithPartnerAttributionID("EXAMPLE_BN_CODE"))
+	c.GetOrder(context.Background(), "O1")
+	c.SetPartnerAttributionID("")
+	c.GetOrder(context.Background(), "O1")
+
+	if len(bnCodes) != 2 || bnCodes[0] != "EXAMPLE_BN_CODE" || bnCodes[1] != "" {
+		t.Errorf("unexpected PayPal-Partner-Attribution-Id headers %q", bnCodes)
 	}
 }
 
@@ -61,6 +186,55 @@ func TestTypeUserInfo(t *testing.T) {
 	}
 }
 
+func TestTypeOrderPaypalPaymentSource(t *testing.T) {
+	response := `{
+    "id": "5O190127TN364715T",
+    "status": "PAYER_ACTION_REQUIRED",
+    "intent": "CAPTURE",
+    "payment_source": {
+        "paypal": {
+            "email_address": "buyer@example.com",
+            "account_id": "QYR5Z8XDVJNXQ",
+            "name": {"given_name": "John", "surname": "Doe"}
+        }
+    },
+    "purchase_units": [{
+        "reference_id": "default",
+        "items": [{"name": "T-Shirt", "quantity": "2", "unit_amount": {"currency_code": "USD", "value": "10.00"}}]
+    }]
+}`
+
+	o := &Order{}
+	if err := json.Unmarshal([]byte(response), o); err != nil {
+		t.Fatal(err)
+	}
+	if o.PaymentSource == nil || o.PaymentSource.Paypal == nil ||
+		o.PaymentSource.Paypal.AccountID != "QYR5Z8XDVJNXQ" ||
+		o.PaymentSource.Paypal.Name.GivenName != "John" {
+		t.Errorf("Order payment source decoded result is incorrect, Given: %+v", o.PaymentSource)
+	}
+	if len(o.PurchaseUnits) != 1 || len(o.PurchaseUnits[0].Items) != 1 || o.PurchaseUnits[0].Items[0].Quantity != "2" {
+		t.Errorf("Order items decoded result is incorrect, Given: %+v", o.PurchaseUnits)
+	}
+
+	req := CreateOrderRequest{
+		Intent: OrderIntentCapture,
+		PaymentSource: &PaymentSource{Paypal: &PaymentSourcePaypal{
+			ExperienceContext: &ExperienceContext{
+				BrandName:               "EXAMPLE INC",
+				UserAction:              UserActionPayNow,
+				PaymentMethodPreference: PaymentMethodPreferenceImmediatePaymentRequired,
+				ReturnURL:               "https://example.com/return",
+			},
+		}},
+	}
+	data, _ := json.Marshal(req)
+	expected := `"payment_source":{"paypal":{"experience_context":{"brand_name":"EXAMPLE INC","user_action":"PAY_NOW","payment_method_preference":"IMMEDIATE_PAYMENT_REQUIRED","return_url":"https://example.com/return"}}}`
+	if !strings.Contains(string(data), expected) {
+		t.Errorf("CreateOrderRequest encoded result is incorrect, Given: %s", data)
+	}
+}
+
 func TestTypeItem(t *testing.T) {
 	response := `{
     "name":"Item",
@@ -484,6 +658,9 @@ func (ts *webprofileTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request
 		if r.Method == "PUT" {
 			ts.updatevalid(w, r)
 		}
+		if r.Method == "PATCH" {
+			ts.patchvalid(w, r)
+		}
 		if r.Method == "DELETE" {
 			ts.deletevalid(w, r)
 		}
@@ -495,6 +672,9 @@ func (ts *webprofileTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request
 		if r.Method == "PUT" {
 			ts.updateinvalid(w, r)
 		}
+		if r.Method == "PATCH" {
+			ts.updateinvalid(w, r)
+		}
 		if r.Method == "DELETE" {
 			ts.deleteinvalid(w, r)
 		}
@@ -509,6 +689,12 @@ func (ts *webprofileTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request
 			ts.createWithoutName(w, r)
 		}
 	}
+	if r.RequestURI == fmt.Sprintf("/v1/billing-agreements/agreements/%s", testBillingAgreementID) {
+		if r.Method == "GET" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"` + testBillingAgreementID + `","state":"ACTIVE","description":"Monthly top-up","plan":{"type":"MERCHANT_INITIATED_BILLING"},"create_time":"2023-04-01T10:00:00Z"}`))
+		}
+	}
 	if r.RequestURI == fmt.Sprintf("/v1/billing-agreements/agreements/%s/cancel", testBillingAgreementID) {
 		if r.Method == "POST" {
 			ts.deletevalid(w, r)
@@ -623,6 +809,21 @@ func (ts *webprofileTestServer) updatevalid(w http.ResponseWriter, r *http.Reque
 
 }
 
+func (ts *webprofileTestServer) patchvalid(w http.ResponseWriter, r *http.Request) {
+	var patches []Patch
+	if err := json.NewDecoder(r.Body).Decode(&patches); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if len(patches) != 1 || patches[0].Operation != "replace" || patches[0].Path != "/presentation/logo_image" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (ts *webprofileTestServer) updateinvalid(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusNotFound)
@@ -862,6 +1063,69 @@ func TestSetWebProfile_invalid(t *testing.T) {
 	}
 }
 
+func TestPatchWebProfile(t *testing.T) {
+	ts := httptest.NewServer(&webprofileTestServer{t: t})
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	patches := []Patch{{
+		Operation: "replace",
+		Path:      "/presentation/logo_image",
+		Value:     "https://example.com/logo.png",
+	}}
+
+	if err := c.PatchWebProfile(context.Background(), "XP-CP6S-W9DY-96H8-MVN2", patches); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.PatchWebProfile(context.Background(), "foobar", patches); err == nil {
+		t.Fatal("expecting an error got nil")
+	}
+
+	if err := c.PatchWebProfile(context.Background(), "XP-CP6S-W9DY-96H8-MVN2", nil); err == nil {
+		t.Fatal("expecting an error for empty patches got nil")
+	}
+}
+
+func TestWebProfileMarshal(t *testing.T) {
+	wp := WebProfile{
+		Name:      "Shop",
+		Temporary: true,
+		Presentation: Presentation{
+			BrandName:      "Shop",
+			ReturnURLLabel: "Back to shop",
+		},
+		InputFields: InputFields{
+			NoShipping:      NoShippingHide,
+			AddressOverride: AddrOverrideFromCall,
+		},
+		FlowConfig: FlowConfig{
+			LandingPageType:     LandingPageTypeBilling,
+			UserAction:          FlowUserActionCommit,
+			ReturnURIHTTPMethod: "POST",
+		},
+	}
+
+	b, err := json.Marshal(wp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`"temporary":true`,
+		`"return_url_label":"Back to shop"`,
+		`"no_shipping":1`,
+		`"address_override":1`,
+		`"landing_page_type":"Billing"`,
+		`"return_uri_http_method":"POST"`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected %s in %s", want, b)
+		}
+	}
+}
+
 func TestDeleteWebProfile_valid(t *testing.T) {
 	ts := httptest.NewServer(&webprofileTestServer{t: t})
 	defer ts.Close()
@@ -930,6 +1194,103 @@ func TestCreateBillingAgreementFromToken(t *testing.T) {
 	}
 }
 
+func TestGetBillingAgreement(t *testing.T) {
+	ts := httptest.NewServer(&webprofileTestServer{t: t})
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	agreement, err := c.GetBillingAgreement(context.Background(), testBillingAgreementID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if agreement.ID != testBillingAgreementID || agreement.State != BillingAgreementStateActive {
+		t.Errorf("unexpected agreement %+v", agreement)
+	}
+	if agreement.Plan.Type != BillingAgreementTypeMerchantInitiated {
+		t.Errorf("expected plan type %s, got %s", BillingAgreementTypeMerchantInitiated, agreement.Plan.Type)
+	}
+	if agreement.CreateTime == nil {
+		t.Error("expected create_time to be decoded")
+	}
+}
+
+func TestBillingAgreementTokenApprovalURL(t *testing.T) {
+	token := BillingAgreementToken{
+		TokenID: "BA-8A802366G0648845Y",
+		Links: []Link{
+			{Href: "https://api.sandbox.paypal.com/v1/billing-agreements/BA-8A802366G0648845Y/agreements", Rel: "self", Method: "POST"},
+			{Href: "https://www.sandbox.paypal.com/agreements/approve?ba_token=BA-8A802366G0648845Y", Rel: "approval_url", Method: "POST"},
+		},
+	}
+
+	if got := token.ApprovalURL(); got != "https://www.sandbox.paypal.com/agreements/approve?ba_token=BA-8A802366G0648845Y" {
+		t.Errorf("unexpected approval url %q", got)
+	}
+	if got := (&BillingAgreementToken{}).ApprovalURL(); got != "" {
+		t.Errorf("expected empty approval url, got %q", got)
+	}
+}
+
+func TestChargeBillingAgreement(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		calls = append(calls, r.Method+" "+r.RequestURI+" "+r.Header.Get("PayPal-Client-Metadata-Id")+" "+r.Header.Get("PayPal-Request-Id"))
+		switch r.RequestURI {
+		case "/v2/checkout/orders":
+			w.Write([]byte(`{"id":"ORDER-1","status":"CREATED"}`))
+		case "/v2/checkout/orders/ORDER-1/capture":
+			var body CaptureOrderRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.PaymentSource == nil || body.PaymentSource.Token == nil ||
+				body.PaymentSource.Token.ID != "B-5YM77066HW8097625" ||
+				body.PaymentSource.Token.Type != PaymentSourceTokenTypeBillingAgreement {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"id":"ORDER-1","status":"COMPLETED"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	units := []PurchaseUnitRequest{{Amount: &PurchaseUnitAmount{Currency: "USD", Value: "9.99"}}}
+
+	capture, err := c.ChargeBillingAgreement(context.Background(), "B-5YM77066HW8097625", units, "cmid-123", "INV-42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capture.Status != OrderStatusCompleted {
+		t.Errorf("expected COMPLETED, got %s", capture.Status)
+	}
+
+	expected := []string{
+		"POST /v2/checkout/orders cmid-123 INV-42-create",
+		"POST /v2/checkout/orders/ORDER-1/capture cmid-123 INV-42-capture",
+	}
+	if got := strings.Join(calls, "\n"); got != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests:\n%s", got)
+	}
+
+	if _, err := c.ChargeBillingAgreement(context.Background(), "", units, "", "INV-42"); err == nil {
+		t.Error("expected an error without billing agreement ID")
+	}
+	if _, err := c.ChargeBillingAgreement(context.Background(), "B-5YM77066HW8097625", nil, "", "INV-42"); err == nil {
+		t.Error("expected an error without purchase units")
+	}
+	if _, err := c.ChargeBillingAgreement(context.Background(), "B-5YM77066HW8097625", units, "", ""); err == nil {
+		t.Error("expected an error without idempotency key")
+	}
+}
+
 func TestCancelBillingAgreement(t *testing.T) {
 
 	ts := httptest.NewServer(&webprofileTestServer{t: t})
@@ -943,3 +1304,799 @@ func TestCancelBillingAgreement(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestExchangeSellerAuthCode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI != "/v1/oauth2/token" || r.Method != "POST" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.RequestURI)
+		}
+
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "shared-id" || pass != "" {
+			t.Errorf("expecting basic auth with shared id, got %q:%q", user, pass)
+		}
+
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "authorization_code" ||
+			r.Form.Get("code") != "auth-code" ||
+			r.Form.Get("code_verifier") != "nonce" {
+			t.Errorf("unexpected form values %v", r.Form)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"seller-token","token_type":"Bearer","expires_in":32400}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	token, err := c.ExchangeSellerAuthCode(context.Background(), "auth-code", "shared-id", "nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.Token != "seller-token" || token.ExpiresIn != 32400 {
+		t.Errorf("TokenResponse decoded result is incorrect, Given: %+v", token)
+	}
+}
+
+func TestDisputeAllowedActions(t *testing.T) {
+	response := `{
+		"dispute_id": "PP-D-4012",
+		"status": "WAITING_FOR_SELLER_RESPONSE",
+		"dispute_amount": {"currency_code": "USD", "value": "3.00"},
+		"links": [
+			{"href": "https://api.sandbox.paypal.com/v1/customer/disputes/PP-D-4012", "rel": "self", "method": "GET"},
+			{"href": "https://api.sandbox.paypal.com/v1/customer/disputes/PP-D-4012/send-message", "rel": "send_message", "method": "POST"}
+		]
+	}`
+
+	d := &Dispute{}
+	if err := json.Unmarshal([]byte(response), d); err != nil {
+		t.Fatalf("Dispute Unmarshal failed: %v", err)
+	}
+
+	if d.ID != "PP-D-4012" || d.DisputeAmount.Value != "3.00" {
+		t.Errorf("Dispute decoded result is incorrect, Given: %+v", d)
+	}
+	if d.CanProvideMoreEvidence() {
+		t.Errorf("expecting no more evidence to be allowed")
+	}
+	if !d.CanSendMessage() {
+		t.Errorf("expecting a message to be allowed")
+	}
+}
+
+func TestPaymentCaptureRequestValidate(t *testing.T) {
+	r := &PaymentCaptureRequest{SoftDescriptor: "YEOWZA TSHIRTS"}
+	if err := r.Validate(); err != nil {
+		t.Errorf("expecting no error got %v", err)
+	}
+
+	r.SoftDescriptor = "YEOWZA T-SHIRT SHOP ORDER 1234"
+	if err := r.Validate(); err == nil {
+		t.Errorf("expecting an error for a soft_descriptor of %d characters", len(r.SoftDescriptor))
+	}
+}
+
+func TestRefundCorrelationIDs(t *testing.T) {
+	request, _ := json.Marshal(RefundCaptureRequest{InvoiceID: "INV-1", CustomID: "ORDER-1"})
+	if string(request) != `{"invoice_id":"INV-1","custom_id":"ORDER-1"}` {
+		t.Errorf("RefundCaptureRequest marshalled result is incorrect, Given: %s", request)
+	}
+
+	response := `{
+		"id": "1JU08902781691411",
+		"status": "COMPLETED",
+		"invoice_id": "INV-1",
+		"custom_id": "ORDER-1",
+		"amount": {"currency_code": "USD", "value": "10.99"}
+	}`
+
+	refund := &RefundResponse{}
+	if err := json.Unmarshal([]byte(response), refund); err != nil {
+		t.Fatalf("RefundResponse Unmarshal failed: %v", err)
+	}
+
+	if refund.InvoiceID != "INV-1" || refund.CustomID != "ORDER-1" {
+		t.Errorf("RefundResponse decoded result is incorrect, Given: %+v", refund)
+	}
+}
+
+func TestOrderPayments(t *testing.T) {
+	data := []byte(`{
+		"id": "5O190127TN364715T",
+		"purchase_units": [{
+			"reference_id": "default",
+			"payments": {
+				"authorizations": [{"id": "A1", "status": "CAPTURED", "create_time": "2026-01-01T10:00:00Z"}],
+				"captures": [{"id": "C1", "status": "PARTIALLY_REFUNDED", "final_capture": true, "create_time": "2026-01-01T11:00:00Z"}],
+				"refunds": [{"id": "R1", "status": "COMPLETED", "create_time": "2026-01-02T10:00:00Z"}]
+			}
+		}, {
+			"reference_id": "second",
+			"payments": {
+				"captures": [{"id": "C2", "status": "COMPLETED", "create_time": "2026-01-01T10:30:00Z"}]
+			}
+		}]
+	}`)
+
+	order := &Order{}
+	if err := json.Unmarshal(data, order); err != nil {
+		t.Fatal(err)
+	}
+
+	payments := order.Payments()
+	var ids []string
+	for _, p := range payments.Payments {
+		ids = append(ids, p.ID)
+	}
+	if fmt.Sprint(ids) != "[A1 C2 C1 R1]" {
+		t.Errorf("expecting payments oldest first, got %v", ids)
+	}
+	if p := payments.Payments[1]; p.Type != OrderPaymentTypeCapture || p.ReferenceID != "second" {
+		t.Errorf("unexpected payment %+v", p)
+	}
+	if !order.PurchaseUnits[0].Payments.Captures[0].FinalCapture {
+		t.Errorf("expecting final_capture to be parsed")
+	}
+}
+
+func TestClientDo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Header().Set("Paypal-Debug-Id", "f3ad9d7f8a8d2")
+		if r.URL.Path == "/v2/checkout/orders/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"name":"RESOURCE_NOT_FOUND"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	req, _ := c.NewRequest(context.Background(), http.MethodGet, ts.URL+"/v2/checkout/orders/O1", nil)
+	order := &Order{}
+	resp, err := c.Do(req, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != "O1" || resp.Header.Get("Paypal-Debug-Id") != "f3ad9d7f8a8d2" {
+		t.Errorf("expecting decoded order and response headers, got %+v %v", order, resp.Header)
+	}
+
+	req, _ = c.NewRequest(context.Background(), http.MethodGet, ts.URL+"/v2/checkout/orders/missing", nil)
+	resp, err = c.Do(req, order)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expecting the response to be returned with the error, got %v %v", resp, err)
+	}
+}
+
+func TestSendInvoiceAdditionalRecipients(t *testing.T) {
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.RequestURI != "/v2/invoicing/invoices/INV2-1/send" {
+			t.Errorf("unexpected request %s", r.RequestURI)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"href":"https://www.paypal.com/invoice/p/#INV2-1","rel":"payer-view","method":"GET"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	sendToInvoicer := true
+	link, err := c.SendInvoice(context.Background(), "INV2-1", SendInvoiceRequest{
+		SendToInvoicer:       &sendToInvoicer,
+		AdditionalRecipients: []string{"ap@example.com", "buyer@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Rel != "payer-view" {
+		t.Errorf("expecting payer-view link, got %+v", link)
+	}
+	if fmt.Sprint(body["additional_recipients"]) != "[ap@example.com buyer@example.com]" || body["send_to_invoicer"] != true {
+		t.Errorf("unexpected request body %v", body)
+	}
+	if _, ok := body["send_to_recipient"]; ok {
+		t.Errorf("expecting send_to_recipient to be omitted, got %v", body)
+	}
+
+	invoice := Invoice{}
+	json.Unmarshal([]byte(`{"id":"INV2-1","additional_recipients":[{"email_address":"ap@example.com"}]}`), &invoice)
+	if len(invoice.AdditionalRecipients) != 1 || invoice.AdditionalRecipients[0].EmailAddress != "ap@example.com" {
+		t.Errorf("expecting additional recipients to be parsed, got %+v", invoice)
+	}
+}
+
+func TestCapturePlatformFees(t *testing.T) {
+	data := []byte(`{
+		"id": "2GG279541U471931P",
+		"status": "COMPLETED",
+		"seller_receivable_breakdown": {
+			"gross_amount": {"currency_code": "USD", "value": "100.00"},
+			"paypal_fee": {"currency_code": "USD", "value": "3.00"},
+			"platform_fees": [
+				{"amount": {"currency_code": "USD", "value": "2.00"}, "payee": {"merchant_id": "PLATFORM1"}},
+				{"amount": {"currency_code": "USD", "value": "0.50"}, "payee": {"email_address": "fees@example.com"}}
+			],
+			"net_amount": {"currency_code": "USD", "value": "94.50"}
+		}
+	}`)
+
+	capture := &PaymentCaptureResponse{}
+	if err := json.Unmarshal(data, capture); err != nil {
+		t.Fatal(err)
+	}
+
+	breakdown := capture.SellerReceivableBreakdown
+	if breakdown == nil || len(breakdown.PlatformFees) != 2 || breakdown.PlatformFees[0].Payee.MerchantID != "PLATFORM1" {
+		t.Fatalf("expecting platform fees to be parsed, got %+v", breakdown)
+	}
+
+	total, err := breakdown.TotalPlatformFees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.Value != "2.50" || total.Currency != "USD" {
+		t.Errorf("expecting 2.50 USD platform fees, got %+v", total)
+	}
+
+	if total, err := (&SellerReceivableBreakdown{}).TotalPlatformFees(); total != nil || err != nil {
+		t.Errorf("expecting no platform fees, got %v %v", total, err)
+	}
+}
+
+func TestCreateOrderWithRequestProcessingInstruction(t *testing.T) {
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.Header.Get("PayPal-Request-Id") != "order-1" {
+			t.Errorf("expecting PayPal-Request-Id to be set")
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"id":"O1","status":"PAYER_ACTION_REQUIRED"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	_, err := c.CreateOrderWithRequest(context.Background(), CreateOrderRequest{
+		Intent:                OrderIntentCapture,
+		PurchaseUnits:         []PurchaseUnitRequest{{Amount: &PurchaseUnitAmount{Currency: "USD", Value: "7.00"}}},
+		ProcessingInstruction: ProcessingInstructionOrderCompleteOnPaymentApproval,
+	}, "order-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body["processing_instruction"] != "ORDER_COMPLETE_ON_PAYMENT_APPROVAL" || body["intent"] != "CAPTURE" {
+		t.Errorf("unexpected request body %v", body)
+	}
+}
+
+func TestPaymentTokensForCustomer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.RequestURI == "/v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case r.Method == http.MethodPost:
+			var body map[string]map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["customer"]["id"] != "CUST-1" || body["payment_source"]["token"] == nil {
+				t.Errorf("unexpected request body %v", body)
+			}
+			w.Write([]byte(`{"id":"TOKEN-2","customer":{"id":"CUST-1"}}`))
+		default:
+			if r.URL.Query().Get("customer_id") != "CUST-1" {
+				t.Errorf("expecting tokens to be filtered by customer, got %s", r.RequestURI)
+			}
+			w.Write([]byte(`{"customer":{"id":"CUST-1"},"payment_tokens":[{"id":"TOKEN-1","payment_source":{"card":{"brand":"VISA","last_digits":"1111"}}}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	token, err := c.CreatePaymentToken(context.Background(), "CUST-1", PaymentTokenSource{
+		Token: &VaultSetupToken{ID: "SETUP-1", Type: "SETUP_TOKEN"},
+	})
+	if err != nil || token.Customer.ID != "CUST-1" {
+		t.Fatalf("expecting the token to be attached to the customer, got %+v %v", token, err)
+	}
+
+	tokens, err := c.ListPaymentTokens(context.Background(), "CUST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens.PaymentTokens) != 1 || tokens.PaymentTokens[0].PaymentSource.Card.LastDigits != "1111" {
+		t.Errorf("unexpected payment tokens %+v", tokens)
+	}
+}
+
+func TestWaitForDisputeOutcome(t *testing.T) {
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		polls++
+		if r.URL.Path == "/v1/customer/disputes/PP-D-1" && polls >= 3 {
+			w.Write([]byte(`{"dispute_id":"PP-D-1","status":"RESOLVED","dispute_outcome":{"outcome_code":"RESOLVED_SELLER_FAVOUR"}}`))
+			return
+		}
+		w.Write([]byte(`{"dispute_id":"PP-D-1","status":"UNDER_REVIEW"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	dispute, err := c.WaitForDisputeOutcome(context.Background(), "PP-D-1", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls != 3 || dispute.DisputeOutcome.OutcomeCode != "RESOLVED_SELLER_FAVOUR" {
+		t.Errorf("expecting the outcome after 3 polls, got %d polls and %+v", polls, dispute)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	dispute, err = c.WaitForDisputeOutcome(ctx, "PP-D-2", 5*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) || dispute.Status != DisputeStatusUnderReview {
+		t.Errorf("expecting the last dispute with a deadline error, got %+v %v", dispute, err)
+	}
+
+	polls = 0
+	if _, err = c.WaitForDisputeOutcome(context.Background(), "PP-D-2", 0); err == nil || polls != 0 {
+		t.Errorf("expecting an error without polling for a zero interval, got %d polls and %v", polls, err)
+	}
+}
+
+func TestTokenRefreshTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			time.Sleep(100 * time.Millisecond)
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Write([]byte(`{"id":"O1"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetTokenRefreshTimeout(10 * time.Millisecond)
+
+	start := time.Now()
+	if _, err := c.GetOrder(context.Background(), "O1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expecting the token refresh to time out, got %v", err)
+	}
+	if time.Since(start) > 90*time.Millisecond {
+		t.Errorf("expecting the slow token endpoint to fail fast, took %s", time.Since(start))
+	}
+
+	c.SetTokenRefreshTimeout(time.Second)
+	if order, err := c.GetOrder(context.Background(), "O1"); err != nil || order.ID != "O1" {
+		t.Errorf("expecting the order within the token refresh timeout, got %+v %v", order, err)
+	}
+}
+
+func TestListTransactionsReportFields(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if fields := r.URL.Query().Get("fields"); fields != "transaction_info,cart_info" {
+			t.Errorf("expecting only transaction and cart info to be requested, got %q", fields)
+		}
+		w.Write([]byte(`{"transaction_details":[]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	_, err := c.ListTransactions(context.Background(), &TransactionSearchRequest{
+		StartDate: time.Now().Add(-24 * time.Hour),
+		EndDate:   time.Now(),
+		Fields:    ReportFields(ReportFieldTransactionInfo, ReportFieldCartInfo),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamTransactions(t *testing.T) {
+	var windows []string
+	tooLarge := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("page_size") != "500" {
+			http.Error(w, "expecting the largest page size", http.StatusBadRequest)
+			return
+		}
+		start, end, page := q.Get("start_date"), q.Get("end_date"), q.Get("page")
+		if start == "2026-02-01T00:00:00Z" && tooLarge {
+			tooLarge = false
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"name":"RESULTSET_TOO_LARGE","message":"Result set size is greater than the maximum limit."}`))
+			return
+		}
+		windows = append(windows, start+" "+end+" "+page)
+		totalPages := 1
+		if start == "2026-01-01T00:00:00Z" {
+			totalPages = 2
+		}
+		fmt.Fprintf(w, `{"transaction_details":[{"transaction_info":{"transaction_id":"%s/%s"}}],"page":%s,"total_pages":%d}`, start, page, page, totalPages)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	req := &TransactionSearchRequest{
+		StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	var ids []string
+	err := c.StreamTransactions(ctx, req, func(details SearchTransactionDetails) error {
+		ids = append(ids, details.TransactionInfo.TransactionID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"2026-01-01T00:00:00Z 2026-01-31T23:59:59Z 1",
+		"2026-01-01T00:00:00Z 2026-01-31T23:59:59Z 2",
+		"2026-02-01T00:00:00Z 2026-02-15T00:00:00Z 1",
+		"2026-02-15T00:00:01Z 2026-03-01T00:00:00Z 1",
+	}
+	if strings.Join(windows, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected searches %q", windows)
+	}
+	if len(ids) != 4 || ids[1] != "2026-01-01T00:00:00Z/2" {
+		t.Errorf("unexpected transactions %v", ids)
+	}
+	if req.Page != nil || req.PageSize != nil {
+		t.Error("expecting the request of the caller to be left unchanged")
+	}
+
+	stop := errors.New("stop")
+	windows = nil
+	err = c.StreamTransactions(ctx, req, func(SearchTransactionDetails) error { return stop })
+	if err != stop || len(windows) != 1 {
+		t.Errorf("expecting streaming to stop after the first transaction, got %v after %q", err, windows)
+	}
+}
+
+func TestStreamTransactionsSplitAfterPage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		start, end, page := q.Get("start_date"), q.Get("end_date"), q.Get("page")
+		// The whole window fails on its second page, its halves hold T1 and T2
+		switch {
+		case start == "2026-01-01T00:00:00Z" && end == "2026-01-11T00:00:00Z" && page == "1":
+			w.Write([]byte(`{"transaction_details":[{"transaction_info":{"transaction_id":"T1"}}],"page":1,"total_pages":2}`))
+		case start == "2026-01-01T00:00:00Z" && end == "2026-01-11T00:00:00Z":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"name":"RESULTSET_TOO_LARGE"}`))
+		case start == "2026-01-01T00:00:00Z":
+			w.Write([]byte(`{"transaction_details":[{"transaction_info":{"transaction_id":"T1"}}],"page":1,"total_pages":1}`))
+		default:
+			w.Write([]byte(`{"transaction_details":[{"transaction_info":{"transaction_id":"T2"}}],"page":1,"total_pages":1}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+
+	req := &TransactionSearchRequest{
+		StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC),
+	}
+	var ids []string
+	err := c.StreamTransactions(context.Background(), req, func(details SearchTransactionDetails) error {
+		ids = append(ids, details.TransactionInfo.TransactionID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ids, ",") != "T1,T2" {
+		t.Errorf("expecting each transaction to be streamed once, got %v", ids)
+	}
+}
+
+func TestWebhookEventTypeResourceVersions(t *testing.T) {
+	field := ReplaceWebhookEventTypes(WebhookEventType{
+		Name:             "PAYMENT.CAPTURE.COMPLETED",
+		ResourceVersions: []string{"2.0"},
+	})
+
+	data, err := json.Marshal([]WebhookField{field})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"op":"replace","path":"/event_types","value":[{"name":"PAYMENT.CAPTURE.COMPLETED","description":"","resource_versions":["2.0"]}]}]`
+	if string(data) != expected {
+		t.Errorf("unexpected update %s", data)
+	}
+
+	data, _ = json.Marshal(WebhookEventType{Name: "PAYMENT.CAPTURE.COMPLETED"})
+	if string(data) != `{"name":"PAYMENT.CAPTURE.COMPLETED","description":""}` {
+		t.Errorf("expecting resource_versions to be omitted, got %s", data)
+	}
+}
+
+func TestCapturePurchaseUnit(t *testing.T) {
+	var captured string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "/v2/checkout/orders/ORDER":
+			w.Write([]byte(`{"id":"ORDER","purchase_units":[
+				{"reference_id":"SELLER-A","payments":{"authorizations":[{"id":"AUTH-A","status":"CAPTURED"}]}},
+				{"reference_id":"SELLER-B","payments":{"authorizations":[{"id":"AUTH-B","status":"CREATED"}]}}
+			]}`))
+		default:
+			captured = r.URL.Path
+			w.Write([]byte(`{"id":"CAPTURE","status":"COMPLETED"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	capture, err := c.CapturePurchaseUnit(ctx, "ORDER", "SELLER-B", &PaymentCaptureRequest{FinalCapture: true}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if captured != "/v2/payments/authorizations/AUTH-B/capture" || capture.ID != "CAPTURE" {
+		t.Errorf("unexpected capture %s %+v", captured, capture)
+	}
+
+	if _, err = c.CapturePurchaseUnit(ctx, "ORDER", "SELLER-A", nil, ""); err == nil {
+		t.Errorf("expecting an error for a captured purchase unit")
+	}
+	if _, err = c.CapturePurchaseUnit(ctx, "ORDER", "SELLER-C", nil, ""); err == nil {
+		t.Errorf("expecting an error for an unknown purchase unit")
+	}
+}
+
+func TestLogRedaction(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		w.Write([]byte(`{"id":"O1","payer":{"email_address":"buyer@example.com"}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	log := &bytes.Buffer{}
+	c.SetLog(log)
+
+	ctx := context.Background()
+	source := &PaymentSource{Card: &PaymentSourceCard{Number: "4111111111111111", SecurityCode: "123"}}
+	c.ConfirmOrderPaymentSource(ctx, "O1", source)
+
+	for _, secret := range []string{"4111111111111111", `"123"`, "buyer@example.com"} {
+		if bytes.Contains(log.Bytes(), []byte(secret)) {
+			t.Errorf("expecting %s to be redacted in %s", secret, log)
+		}
+	}
+	if !bytes.Contains(log.Bytes(), []byte("HTTP/1.1 200 OK")) || !bytes.Contains(log.Bytes(), []byte(`"id":"O1"`)) {
+		t.Errorf("expecting the response in the log, got %s", log)
+	}
+
+	log.Reset()
+	c.SetLogUnredacted(true)
+	c.ConfirmOrderPaymentSource(ctx, "O1", source)
+	if !bytes.Contains(log.Bytes(), []byte("4111111111111111")) || !bytes.Contains(log.Bytes(), []byte("buyer@example.com")) {
+		t.Errorf("expecting an unredacted log, got %s", log)
+	}
+}
+
+func TestWalletRedaction(t *testing.T) {
+	sources := map[string]*PaymentSource{
+		"apple_pay 3DS": {ApplePay: &PaymentSourceApplePay{DecryptedToken: &ApplePayDecryptedToken{
+			TokenizedCard:   &WalletCard{Number: "4111111111111111", Expiry: "2030-01"},
+			PaymentDataType: "3DSECURE",
+			PaymentData:     &ApplePayPaymentData{Cryptogram: "CRYPTOGRAM", ECIIndicator: "7"},
+		}}},
+		"apple_pay EMV": {ApplePay: &PaymentSourceApplePay{DecryptedToken: &ApplePayDecryptedToken{
+			TokenizedCard:   &WalletCard{Number: "4111111111111111", Expiry: "2030-01"},
+			PaymentDataType: "EMV",
+			PaymentData:     &ApplePayPaymentData{EMVData: "EMVDATA", Pin: "PIN"},
+		}}},
+		"google_pay": {GooglePay: &PaymentSourceGooglePay{DecryptedToken: &GooglePayDecryptedToken{
+			PaymentMethod:        "CARD",
+			Card:                 &WalletCard{Number: "4111111111111111", Expiry: "2030-01"},
+			AuthenticationMethod: "CRYPTOGRAM_3DS",
+			Cryptogram:           "CRYPTOGRAM",
+		}}},
+	}
+
+	for name, source := range sources {
+		body, _ := json.Marshal(ConfirmOrderRequest{PaymentSource: source})
+		redactedBody := redactBody("application/json", body)
+		for _, secret := range []string{"4111111111111111", "CRYPTOGRAM\"", "EMVDATA", `"PIN"`} {
+			if bytes.Contains(redactedBody, []byte(secret)) {
+				t.Errorf("%s: expecting %s to be redacted in %s", name, secret, redactedBody)
+			}
+		}
+		if !bytes.Contains(redactedBody, []byte("2030-01")) {
+			t.Errorf("%s: expecting the expiry to be kept in %s", name, redactedBody)
+		}
+	}
+}
+
+func TestConfirmPaymentSourceIdeal(t *testing.T) {
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"id":"O1","status":"PAYER_ACTION_REQUIRED","links":[{"href":"https://www.paypal.com/payment/ideal?token=O1","rel":"payer-action","method":"GET"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	order, err := c.ConfirmPaymentSource(context.Background(), "O1", ConfirmOrderRequest{
+		PaymentSource: &PaymentSource{Ideal: &PaymentSourceAPM{
+			Name:              "John Doe",
+			CountryCode:       "NL",
+			ExperienceContext: &ExperienceContext{ReturnURL: "https://example.com/return", CancelURL: "https://example.com/cancel"},
+		}},
+		ProcessingInstruction: ProcessingInstructionOrderCompleteOnPaymentApproval,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ideal, _ := body["payment_source"].(map[string]interface{})["ideal"].(map[string]interface{})
+	if ideal["country_code"] != "NL" || body["processing_instruction"] != ProcessingInstructionOrderCompleteOnPaymentApproval {
+		t.Errorf("unexpected request %v", body)
+	}
+	if link := FindLink(order.Links, "payer-action"); link == nil || link.Href != "https://www.paypal.com/payment/ideal?token=O1" {
+		t.Errorf("expecting a payer-action link, got %+v", order.Links)
+	}
+}
+
+func TestOrderPaymentInstruction(t *testing.T) {
+	var body map[string][]map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"ORDER-1","status":"COMPLETED","purchase_units":[{"reference_id":"default",
+			"payment_instruction":{"disbursement_mode":"DELAYED"},
+			"payments":{"captures":[{"id":"CAPTURE-1","status":"COMPLETED","disbursement_mode":"DELAYED"}]}}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	instruction := &PaymentInstruction{
+		DisbursementMode: DisbursementModeDelayed,
+		PlatformFees: []PlatformFee{
+			{Amount: &Money{Currency: "USD", Value: "1.50"}, Payee: &PayeeForOrders{MerchantID: "PLATFORM"}},
+			{Amount: &Money{Currency: "USD", Value: "0.25"}},
+		},
+	}
+	total, err := instruction.TotalPlatformFees()
+	if err != nil || total.Value != "1.75" {
+		t.Fatalf("expecting 1.75 USD platform fees, got %v %v", total, err)
+	}
+
+	order, err := c.CreateOrder(context.Background(), OrderIntentCapture, []PurchaseUnitRequest{{
+		Amount:             &PurchaseUnitAmount{Currency: "USD", Value: "20.00"},
+		Payee:              &PayeeForOrders{MerchantID: "SELLER"},
+		PaymentInstruction: instruction,
+	}}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := body["purchase_units"][0]["payment_instruction"].(map[string]interface{})
+	fees := sent["platform_fees"].([]interface{})
+	if sent["disbursement_mode"] != "DELAYED" || len(fees) != 2 || fees[0].(map[string]interface{})["payee"].(map[string]interface{})["merchant_id"] != "PLATFORM" {
+		t.Errorf("unexpected payment instruction %v", sent)
+	}
+
+	unit := order.PurchaseUnits[0]
+	if unit.PaymentInstruction.DisbursementMode != DisbursementModeDelayed || unit.Payments.Captures[0].DisbursementMode != DisbursementModeDelayed {
+		t.Errorf("unexpected purchase unit %+v", unit)
+	}
+}
+
+func TestListTransactionsFilters(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"transaction_details":[{
+			"transaction_info":{"transaction_id":"TX-1","transaction_status":"S","transaction_amount":{"currency_code":"USD","value":"9.99"}},
+			"payer_info":{"account_id":"PAYER-1","payer_name":{"given_name":"Jane"}},
+			"cart_info":{"item_details":[{"item_code":"SKU-1","item_quantity":"1"}]},
+			"store_info":{"store_id":"STORE-1"},
+			"incentive_info":{"incentive_details":[{"incentive_code":"WELCOME","incentive_amount":{"currency_code":"USD","value":"1.00"}}]}
+		}],"page":1,"total_pages":1}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	cest := time.FixedZone("CEST", 2*3600)
+	status := TransactionStatusSuccess
+	page, err := c.ListTransactions(ctx, &TransactionSearchRequest{
+		StartDate:         time.Date(2026, 9, 1, 2, 0, 0, 0, cest),
+		EndDate:           time.Date(2026, 10, 1, 2, 0, 0, 0, cest),
+		TransactionStatus: &status,
+		Fields:            ReportFields(ReportFieldAll),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "end_date=2026-10-01T00%3A00%3A00Z&fields=all&start_date=2026-09-01T00%3A00%3A00Z&transaction_status=S" {
+		t.Errorf("unexpected query %s", query)
+	}
+	details := page.TransactionDetails[0]
+	if details.TransactionInfo.TransactionStatus != TransactionStatusSuccess || details.PayerInfo.AccountID != "PAYER-1" ||
+		details.CartInfo.ItemDetails[0].ItemCode != "SKU-1" || details.StoreInfo.StoreID != "STORE-1" ||
+		details.IncentiveInfo.IncentiveDetails[0].IncentiveCode != "WELCOME" {
+		t.Errorf("unexpected transaction details %+v", details)
+	}
+
+	now := time.Now()
+	for _, req := range []*TransactionSearchRequest{
+		{StartDate: now},
+		{StartDate: now, EndDate: now.Add(-time.Hour)},
+		{StartDate: now.Add(-32 * 24 * time.Hour), EndDate: now},
+	} {
+		if _, err := c.ListTransactions(ctx, req); err == nil {
+			t.Errorf("expecting an error for the range %s - %s", req.StartDate, req.EndDate)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package paypal
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"unicode/utf8"
+)
+
+// Validator is implemented by request payloads that can be checked locally
+// before they are sent, see SetRequestValidation
+type Validator interface {
+	Validate() error
+}
+
+// maxPurchaseUnits is the number of purchase units PayPal accepts in an order
+const maxPurchaseUnits = 10
+
+// maxPayoutItems is the number of items PayPal accepts in a payout batch
+const maxPayoutItems = 15000
+
+// Validate checks the intent, the purchase units and the application context
+// of the order against the constraints documented by PayPal
+// Doc: https://developer.paypal.com/docs/api/orders/v2/#orders_create
+func (r CreateOrderRequest) Validate() error {
+	switch r.Intent {
+	case OrderIntentCapture, OrderIntentAuthorize:
+	default:
+		return fmt.Errorf("paypal: invalid intent %q", r.Intent)
+	}
+
+	if len(r.PurchaseUnits) == 0 || len(r.PurchaseUnits) > maxPurchaseUnits {
+		return fmt.Errorf("paypal: an order must have 1 to %d purchase units, got %d", maxPurchaseUnits, len(r.PurchaseUnits))
+	}
+
+	references := map[string]bool{}
+	for i, unit := range r.PurchaseUnits {
+		if len(r.PurchaseUnits) > 1 {
+			if unit.ReferenceID == "" {
+				return fmt.Errorf("paypal: purchase unit %d: reference_id is required with several purchase units", i)
+			}
+			if references[unit.ReferenceID] {
+				return fmt.Errorf("paypal: purchase unit %d: duplicate reference_id %q", i, unit.ReferenceID)
+			}
+			references[unit.ReferenceID] = true
+		}
+		if err := validatePurchaseUnit(unit); err != nil {
+			return fmt.Errorf("paypal: purchase unit %d: %v", i, err)
+		}
+		if err := unit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if r.PaymentSource != nil && r.PaymentSource.Paypal != nil && r.PaymentSource.Paypal.ExperienceContext != nil {
+		if err := r.PaymentSource.Paypal.ExperienceContext.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.ApplicationContext != nil {
+		return r.ApplicationContext.Validate()
+	}
+	return nil
+}
+
+func validatePurchaseUnit(unit PurchaseUnitRequest) error {
+	if unit.Amount == nil {
+		return errors.New("amount is required")
+	}
+	if err := validateValue(unit.Amount.Currency, unit.Amount.Value); err != nil {
+		return fmt.Errorf("amount: %v", err)
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"reference_id", unit.ReferenceID, 256},
+		{"description", unit.Description, 127},
+		{"custom_id", unit.CustomID, 127},
+		{"invoice_id", unit.InvoiceID, 127},
+		{"soft_descriptor", unit.SoftDescriptor, SoftDescriptorMaxLength},
+	}
+	for _, f := range fields {
+		if err := validateLength(f.name, f.value, 0, f.max); err != nil {
+			return err
+		}
+	}
+
+	for i, item := range unit.Items {
+		if err := validateLength(fmt.Sprintf("item %d name", i), item.Name, 1, 127); err != nil {
+			return err
+		}
+		if item.Quantity == "" {
+			return fmt.Errorf("item %d: quantity is required", i)
+		}
+		if item.UnitAmount == nil {
+			return fmt.Errorf("item %d: unit_amount is required", i)
+		}
+		if err := validateValue(item.UnitAmount.Currency, item.UnitAmount.Value); err != nil {
+			return fmt.Errorf("item %d unit_amount: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the batch header and the items of the payout against the
+// constraints documented by PayPal
+// Doc: https://developer.paypal.com/docs/api/payments.payouts-batch/v1/#payouts_post
+func (p Payout) Validate() error {
+	if p.SenderBatchHeader == nil {
+		return errors.New("paypal: sender_batch_header is required")
+	}
+	if err := validateLength("sender_batch_id", p.SenderBatchHeader.SenderBatchID, 0, 256); err != nil {
+		return fmt.Errorf("paypal: %v", err)
+	}
+	if err := validateLength("email_subject", p.SenderBatchHeader.EmailSubject, 0, 255); err != nil {
+		return fmt.Errorf("paypal: %v", err)
+	}
+	if err := validateRecipientType(p.SenderBatchHeader.RecipientType); err != nil {
+		return fmt.Errorf("paypal: %v", err)
+	}
+
+	if len(p.Items) == 0 || len(p.Items) > maxPayoutItems {
+		return fmt.Errorf("paypal: a payout must have 1 to %d items, got %d", maxPayoutItems, len(p.Items))
+	}
+
+	for i, item := range p.Items {
+		if err := validatePayoutItem(item); err != nil {
+			return fmt.Errorf("paypal: payout item %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func validatePayoutItem(item PayoutItem) error {
+	if err := validateRecipientType(item.RecipientType); err != nil {
+		return err
+	}
+	switch item.RecipientWallet {
+	case "", PaypalRecipientWallet, VenmoRecipientWallet:
+	default:
+		return fmt.Errorf("invalid recipient_wallet %q", item.RecipientWallet)
+	}
+
+	if item.Receiver == "" {
+		return errors.New("receiver is required")
+	}
+	if item.RecipientType == EmailRecipientType {
+		if _, err := mail.ParseAddress(item.Receiver); err != nil {
+			return fmt.Errorf("invalid receiver email %q", item.Receiver)
+		}
+	}
+
+	if item.Amount == nil {
+		return errors.New("amount is required")
+	}
+	if err := validateValue(item.Amount.Currency, item.Amount.Value); err != nil {
+		return fmt.Errorf("amount: %v", err)
+	}
+
+	if err := validateLength("note", item.Note, 0, 4000); err != nil {
+		return err
+	}
+	return validateLength("sender_item_id", item.SenderItemID, 0, 63)
+}
+
+func validateRecipientType(recipientType string) error {
+	switch recipientType {
+	case "", EmailRecipientType, PhoneRecipientType, PaypalIdRecipientType:
+		return nil
+	}
+	return fmt.Errorf("invalid recipient_type %q", recipientType)
+}
+
+// Validate checks the plan, quantity and amounts of the subscription against
+// the constraints documented by PayPal
+// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_create
+func (s SubscriptionBase) Validate() error {
+	if s.PlanID == "" {
+		return errors.New("paypal: plan_id is required")
+	}
+	if err := validateLength("plan_id", s.PlanID, 0, 50); err != nil {
+		return fmt.Errorf("paypal: %v", err)
+	}
+	if s.Quantity != "" && digits(s.Quantity) != s.Quantity {
+		return fmt.Errorf("paypal: invalid quantity %q", s.Quantity)
+	}
+	if err := validateLength("custom_id", s.CustomID, 0, 127); err != nil {
+		return fmt.Errorf("paypal: %v", err)
+	}
+	if s.ShippingAmount != nil {
+		if err := validateValue(s.ShippingAmount.Currency, s.ShippingAmount.Value); err != nil {
+			return fmt.Errorf("paypal: shipping_amount: %v", err)
+		}
+	}
+	if s.ApplicationContext != nil {
+		return s.ApplicationContext.Validate()
+	}
+	return nil
+}
+
+// Validate checks the product, name and billing cycles of the plan against
+// the constraints documented by PayPal
+// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_create
+func (p SubscriptionPlan) Validate() error {
+	if p.ProductId == "" {
+		return errors.New("paypal: product_id is required")
+	}
+	if err := validateLength("name", p.Name, 1, 127); err != nil {
+		return fmt.Errorf("paypal: %v", err)
+	}
+	if err := validateLength("description", p.Description, 0, 127); err != nil {
+		return fmt.Errorf("paypal: %v", err)
+	}
+
+	if len(p.BillingCycles) == 0 || len(p.BillingCycles) > 12 {
+		return fmt.Errorf("paypal: a plan must have 1 to 12 billing cycles, got %d", len(p.BillingCycles))
+	}
+	for i, cycle := range p.BillingCycles {
+		if err := validateBillingCycle(cycle); err != nil {
+			return fmt.Errorf("paypal: billing cycle %d: %v", i, err)
+		}
+	}
+
+	if p.PaymentPreferences != nil {
+		if t := p.PaymentPreferences.PaymentFailureThreshold; t < 0 || t > 999 {
+			return fmt.Errorf("paypal: payment_failure_threshold must be 0 to 999, got %d", t)
+		}
+		if fee := p.PaymentPreferences.SetupFee; fee != nil {
+			if err := validateValue(fee.Currency, fee.Value); err != nil {
+				return fmt.Errorf("paypal: setup_fee: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateBillingCycle(cycle BillingCycle) error {
+	switch cycle.TenureType {
+	case TenureTypeRegular, TenureTypeTrial:
+	default:
+		return fmt.Errorf("invalid tenure_type %q", cycle.TenureType)
+	}
+	switch cycle.Frequency.IntervalUnit {
+	case IntervalUnitDay, IntervalUnitWeek, IntervalUnitMonth, IntervalUnitYear:
+	default:
+		return fmt.Errorf("invalid interval_unit %q", cycle.Frequency.IntervalUnit)
+	}
+	if cycle.Sequence < 1 || cycle.Sequence > 99 {
+		return fmt.Errorf("sequence must be 1 to 99, got %d", cycle.Sequence)
+	}
+	if cycle.TotalCycles < 0 || cycle.TotalCycles > 999 {
+		return fmt.Errorf("total_cycles must be 0 to 999, got %d", cycle.TotalCycles)
+	}
+	// trials may be free, tiered pricing schemes have no fixed price
+	price := cycle.PricingScheme.FixedPrice
+	if len(cycle.PricingScheme.Tiers) == 0 && (cycle.TenureType == TenureTypeRegular || price.Value != "") {
+		if err := validateValue(price.Currency, price.Value); err != nil {
+			return fmt.Errorf("fixed_price: %v", err)
+		}
+	}
+	if err := validatePricingTiers(cycle.PricingScheme); err != nil {
+		return fmt.Errorf("pricing_scheme: %v", err)
+	}
+	return nil
+}
+
+// validatePricingTiers checks that VOLUME and TIERED schemes have tiers covering
+// consecutive quantities, only the last one without an ending quantity
+func validatePricingTiers(scheme PricingScheme) error {
+	if len(scheme.Tiers) == 0 {
+		if scheme.PricingModel != "" {
+			return fmt.Errorf("pricing_model %s requires tiers", scheme.PricingModel)
+		}
+		return nil
+	}
+	switch scheme.PricingModel {
+	case PricingModelVolume, PricingModelTiered:
+	default:
+		return fmt.Errorf("tiers require a pricing_model of VOLUME or TIERED, got %q", scheme.PricingModel)
+	}
+
+	if _, err := tierRanges(scheme.Tiers); err != nil {
+		return err
+	}
+	for i, tier := range scheme.Tiers {
+		if err := validateValue(tier.Amount.Currency, tier.Amount.Value); err != nil {
+			return fmt.Errorf("tier %d amount: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// validateValue checks that currency is supported and that value is an amount
+// with at most the decimals of the currency
+func validateValue(currency, value string) error {
+	if !IsValidCurrency(currency) {
+		return fmt.Errorf("unsupported currency %q", currency)
+	}
+	r, err := parseAmount(value)
+	if err != nil || r.Sign() < 0 {
+		return fmt.Errorf("invalid value %q", value)
+	}
+	if _, ok := formatRat(currency, r); !ok {
+		return fmt.Errorf("%s %s has more than %d decimals", value, currency, CurrencyDecimals(currency))
+	}
+	return nil
+}
+
+// validateLength checks that the number of characters of value is between min and max
+func validateLength(name, value string, min, max int) error {
+	if n := utf8.RuneCountInString(value); n < min || n > max {
+		if min > 0 {
+			return fmt.Errorf("%s must be %d to %d characters, got %d", name, min, max, n)
+		}
+		return fmt.Errorf("%s must be at most %d characters, got %d", name, max, n)
+	}
+	return nil
+}
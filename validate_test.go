@@ -0,0 +1,165 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateOrderRequestValidate(t *testing.T) {
+	valid := func() CreateOrderRequest {
+		return CreateOrderRequest{
+			Intent: OrderIntentCapture,
+			PurchaseUnits: []PurchaseUnitRequest{{
+				Amount: &PurchaseUnitAmount{Currency: "USD", Value: "10.00"},
+				Items:  []Item{{Name: "T-Shirt", Quantity: "1", UnitAmount: &Money{Currency: "USD", Value: "10.00"}}},
+			}},
+		}
+	}
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("expecting a valid order, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *CreateOrderRequest)
+		err    string
+	}{
+		{"intent", func(r *CreateOrderRequest) { r.Intent = "SALE" }, `invalid intent "SALE"`},
+		{"no units", func(r *CreateOrderRequest) { r.PurchaseUnits = nil }, "1 to 10 purchase units"},
+		{"reference ids", func(r *CreateOrderRequest) { r.PurchaseUnits = append(r.PurchaseUnits, r.PurchaseUnits[0]) }, "reference_id is required"},
+		{"amount", func(r *CreateOrderRequest) { r.PurchaseUnits[0].Amount = nil }, "amount is required"},
+		{"decimals", func(r *CreateOrderRequest) { r.PurchaseUnits[0].Amount.Value = "10.001" }, "more than 2 decimals"},
+		{"currency", func(r *CreateOrderRequest) { r.PurchaseUnits[0].Amount.Currency = "usd" }, `unsupported currency "usd"`},
+		{"soft descriptor", func(r *CreateOrderRequest) { r.PurchaseUnits[0].SoftDescriptor = strings.Repeat("x", 23) }, "soft_descriptor must be at most 22"},
+		{"item name", func(r *CreateOrderRequest) { r.PurchaseUnits[0].Items[0].Name = "" }, "item 0 name must be 1 to 127"},
+		{"app context", func(r *CreateOrderRequest) { r.ApplicationContext = &ApplicationContext{UserAction: "BUY"} }, `invalid user_action "BUY"`},
+		{"experience context", func(r *CreateOrderRequest) {
+			r.PaymentSource = &PaymentSource{Paypal: &PaymentSourcePaypal{ExperienceContext: &ExperienceContext{LandingPage: LandingPageBilling}}}
+		}, `invalid landing_page "BILLING"`},
+	}
+	for _, tt := range tests {
+		r := valid()
+		tt.modify(&r)
+		err := r.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expecting %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestPayoutValidate(t *testing.T) {
+	payout := Payout{
+		SenderBatchHeader: &SenderBatchHeader{EmailSubject: "Payment"},
+		Items: []PayoutItem{{
+			RecipientType: EmailRecipientType,
+			Receiver:      "buyer@example.com",
+			Amount:        &AmountPayout{Currency: "EUR", Value: "5.00"},
+		}},
+	}
+	if err := payout.Validate(); err != nil {
+		t.Fatalf("expecting a valid payout, got %v", err)
+	}
+
+	payout.Items[0].Receiver = "not an email"
+	if err := payout.Validate(); err == nil || !strings.Contains(err.Error(), "payout item 0: invalid receiver email") {
+		t.Errorf("expecting an email error, got %v", err)
+	}
+	payout.Items[0].Receiver = "buyer@example.com"
+	payout.Items[0].Amount.Value = "5.5.5"
+	if err := payout.Validate(); err == nil || !strings.Contains(err.Error(), "invalid value") {
+		t.Errorf("expecting an amount error, got %v", err)
+	}
+	if err := (Payout{Items: payout.Items}).Validate(); err == nil {
+		t.Error("expecting an error without sender_batch_header")
+	}
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	if err := (SubscriptionBase{}).Validate(); err == nil || !strings.Contains(err.Error(), "plan_id is required") {
+		t.Errorf("expecting a plan_id error, got %v", err)
+	}
+	if err := (SubscriptionBase{PlanID: "P-5ML4271244454362WXNWU5NQ", Quantity: "two"}).Validate(); err == nil {
+		t.Error("expecting a quantity error")
+	}
+
+	plan := SubscriptionPlan{
+		ProductId: "PROD-XXCD1234QWER65782",
+		Name:      "Video Streaming Service Plan",
+		BillingCycles: []BillingCycle{
+			{TenureType: TenureTypeTrial, Sequence: 1, TotalCycles: 1, Frequency: Frequency{IntervalUnit: IntervalUnitMonth, IntervalCount: 1}},
+			{TenureType: TenureTypeRegular, Sequence: 2, Frequency: Frequency{IntervalUnit: IntervalUnitMonth, IntervalCount: 1},
+				PricingScheme: PricingScheme{FixedPrice: Money{Currency: "USD", Value: "10.00"}}},
+		},
+	}
+	if err := plan.Validate(); err != nil {
+		t.Fatalf("expecting a valid plan with a free trial, got %v", err)
+	}
+	plan.BillingCycles[1].PricingScheme.FixedPrice = Money{}
+	if err := plan.Validate(); err == nil || !strings.Contains(err.Error(), "billing cycle 1: fixed_price") {
+		t.Errorf("expecting a fixed_price error, got %v", err)
+	}
+
+	plan.QuantitySupported = true
+	plan.BillingCycles[1].PricingScheme = PricingScheme{
+		PricingModel: PricingModelTiered,
+		Tiers: []PricingTier{
+			{StartingQuantity: "1", EndingQuantity: "10", Amount: Money{Currency: "USD", Value: "10"}},
+			{StartingQuantity: "11", Amount: Money{Currency: "USD", Value: "8"}},
+		},
+	}
+	if err := plan.Validate(); err != nil {
+		t.Fatalf("expecting a valid tiered plan, got %v", err)
+	}
+	plan.BillingCycles[1].PricingScheme.Tiers[1].StartingQuantity = "12"
+	if err := plan.Validate(); err == nil || !strings.Contains(err.Error(), "billing cycle 1: pricing_scheme: tier 1") {
+		t.Errorf("expecting a tier quantities error, got %v", err)
+	}
+	plan.BillingCycles[1].PricingScheme.Tiers[1].StartingQuantity = "11"
+	plan.BillingCycles[1].PricingScheme.PricingModel = ""
+	if err := plan.Validate(); err == nil || !strings.Contains(err.Error(), "pricing_model") {
+		t.Errorf("expecting a pricing_model error, got %v", err)
+	}
+}
+
+func TestSetRequestValidation(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	c.SetAccessToken("123")
+	c.SetRequestValidation(true)
+
+	_, err := c.CreatePayout(context.Background(), Payout{})
+	if err == nil || !strings.Contains(err.Error(), "sender_batch_header is required") {
+		t.Errorf("expecting a local validation error, got %v", err)
+	}
+	_, err = c.CreateOrderWithRequest(context.Background(), CreateOrderRequest{Intent: "SALE"}, "")
+	if err == nil || !strings.Contains(err.Error(), "invalid intent") {
+		t.Errorf("expecting a local validation error, got %v", err)
+	}
+	mismatch := CreateOrderRequest{Intent: OrderIntentCapture, PurchaseUnits: []PurchaseUnitRequest{{Amount: &PurchaseUnitAmount{
+		Currency: "USD", Value: "10.00", Breakdown: &PurchaseUnitAmountBreakdown{ItemTotal: &Money{Currency: "USD", Value: "9.00"}},
+	}}}}
+	_, err = c.CreateOrderWithRequest(context.Background(), mismatch, "")
+	if err == nil || !strings.Contains(err.Error(), "adds up to 9.00 USD") {
+		t.Errorf("expecting a local breakdown error, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expecting no request to be sent, got %d", requests)
+	}
+
+	c.SetRequestValidation(false)
+	if _, err := c.CreatePayout(context.Background(), Payout{}); err == nil || requests != 1 {
+		t.Errorf("expecting the request to be sent without validation, got %v", err)
+	}
+	if _, err := c.CreateOrderWithRequest(context.Background(), mismatch, ""); err == nil || requests != 2 {
+		t.Errorf("expecting the order to be sent without validation, got %v", err)
+	}
+}
@@ -3,8 +3,62 @@ package paypal
 import (
 	"context"
 	"fmt"
+	"net/url"
 )
 
+// Possible values for `status` in SetupToken
+//
+// https://developer.paypal.com/docs/api/payment-tokens/v3/#setup-tokens_get
+const (
+	SetupTokenStatusCreated             string = "CREATED"
+	SetupTokenStatusPayerActionRequired string = "PAYER_ACTION_REQUIRED"
+	SetupTokenStatusApproved            string = "APPROVED"
+	SetupTokenStatusVaulted             string = "VAULTED"
+	SetupTokenStatusTokenized           string = "TOKENIZED"
+)
+
+// Possible values for `usage_type` in VaultPaypalWallet
+const (
+	VaultUsageTypeMerchant string = "MERCHANT"
+	VaultUsageTypePlatform string = "PLATFORM"
+)
+
+// StoreInVaultOnSuccess is the `store_in_vault` value of PaymentSourceVault
+// that saves the payment source once the payment is authorized or captured
+const StoreInVaultOnSuccess string = "ON_SUCCESS"
+
+// Possible values for `status` in the PaymentSourceVault of order responses
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-vault_response
+const (
+	VaultStatusVaulted  string = "VAULTED"
+	VaultStatusCreated  string = "CREATED"
+	VaultStatusApproved string = "APPROVED"
+)
+
+// Possible values for `type` in VaultSetupToken
+const (
+	VaultTokenTypeSetupToken string = "SETUP_TOKEN"
+)
+
+// Vault returns the vault result of the card, PayPal wallet, Apple Pay or
+// Google Pay payment source, or nil when the source was not saved
+func (s *PaymentSource) Vault() *PaymentSourceVault {
+	switch {
+	case s == nil:
+		return nil
+	case s.Card != nil && s.Card.Attributes != nil && s.Card.Attributes.Vault != nil:
+		return s.Card.Attributes.Vault
+	case s.Paypal != nil && s.Paypal.Attributes != nil && s.Paypal.Attributes.Vault != nil:
+		return s.Paypal.Attributes.Vault
+	case s.ApplePay != nil && s.ApplePay.Attributes != nil && s.ApplePay.Attributes.Vault != nil:
+		return s.ApplePay.Attributes.Vault
+	case s.GooglePay != nil && s.GooglePay.Attributes != nil && s.GooglePay.Attributes.Vault != nil:
+		return s.GooglePay.Attributes.Vault
+	}
+	return nil
+}
+
 // StoreCreditCard func
 // Endpoint: POST /v1/vault/credit-cards
 func (c *Client) StoreCreditCard(ctx context.Context, cc CreditCard) (*CreditCard, error) {
@@ -96,3 +150,162 @@ func (c *Client) PatchCreditCard(ctx context.Context, id string, ccf []CreditCar
 
 	return response, nil
 }
+
+// CreatePaymentToken saves a payment source in the vault. Pass the ID of a
+// customer PayPal generated for an earlier token to attach it to a returning
+// buyer, or an empty customerID to create a new customer.
+// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#payment-tokens_create
+// Endpoint: POST /v3/vault/payment-tokens
+func (c *Client) CreatePaymentToken(ctx context.Context, customerID string, paymentSource PaymentTokenSource) (*PaymentToken, error) {
+	type createPaymentTokenRequest struct {
+		Customer      *VaultCustomer     `json:"customer,omitempty"`
+		PaymentSource PaymentTokenSource `json:"payment_source"`
+	}
+
+	request := createPaymentTokenRequest{PaymentSource: paymentSource}
+	if customerID != "" {
+		request.Customer = &VaultCustomer{ID: customerID}
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v3/vault/payment-tokens"), request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PaymentToken{}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ListPaymentTokens lists the first page of the payment tokens saved for a customer
+// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#customer_payment-tokens_get
+// Endpoint: GET /v3/vault/payment-tokens?customer_id=customer_id
+func (c *Client) ListPaymentTokens(ctx context.Context, customerID string) (*PaymentTokens, error) {
+	return c.ListPaymentTokensPage(ctx, customerID, nil)
+}
+
+// ListPaymentTokensPage lists a page of the payment tokens saved for a customer
+// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#customer_payment-tokens_get
+// Endpoint: GET /v3/vault/payment-tokens?customer_id=customer_id
+func (c *Client) ListPaymentTokensPage(ctx context.Context, customerID string, params *ListParams) (*PaymentTokens, error) {
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v3/vault/payment-tokens"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = paymentTokensQuery(customerID, params).Encode()
+
+	response := &PaymentTokens{}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// NewPaymentTokenPaginator returns a Paginator over all pages of the payment
+// tokens of a customer, decode each page into PaymentTokens
+// Endpoint: GET /v3/vault/payment-tokens?customer_id=customer_id
+func (c *Client) NewPaymentTokenPaginator(customerID string, params *ListParams) *Paginator {
+	return c.NewPaginator("/v3/vault/payment-tokens", paymentTokensQuery(customerID, params))
+}
+
+func paymentTokensQuery(customerID string, params *ListParams) url.Values {
+	q := url.Values{}
+	if params != nil {
+		q = params.query()
+	}
+	q.Set("customer_id", customerID)
+	return q
+}
+
+// DeletePaymentToken deletes a payment token from the vault
+// Endpoint: DELETE /v3/vault/payment-tokens/payment_token_id
+func (c *Client) DeletePaymentToken(ctx context.Context, id string) error {
+	req, err := c.NewRequest(ctx, "DELETE", fmt.Sprintf("%s/v3/vault/payment-tokens/%s", c.APIBase, id), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// GetPaymentToken shows a payment token saved in the vault
+// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#payment-tokens_get
+// Endpoint: GET /v3/vault/payment-tokens/payment_token_id
+func (c *Client) GetPaymentToken(ctx context.Context, id string) (*PaymentToken, error) {
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/v3/vault/payment-tokens/%s", c.APIBase, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PaymentToken{}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreateSetupToken creates a setup token for a card or a PayPal wallet. Cards
+// may be vaulted right away, PayPal wallets have to be approved by the buyer at
+// ApprovalURL first. Create the payment token from the approved setup token with
+// CreatePaymentToken and a VaultSetupToken source. Pass an empty customerID to
+// create a new customer.
+// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#setup-tokens_create
+// Endpoint: POST /v3/vault/setup-tokens
+func (c *Client) CreateSetupToken(ctx context.Context, customerID string, paymentSource SetupTokenSource) (*SetupToken, error) {
+	type createSetupTokenRequest struct {
+		Customer      *VaultCustomer   `json:"customer,omitempty"`
+		PaymentSource SetupTokenSource `json:"payment_source"`
+	}
+
+	request := createSetupTokenRequest{PaymentSource: paymentSource}
+	if customerID != "" {
+		request.Customer = &VaultCustomer{ID: customerID}
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v3/vault/setup-tokens"), request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetupToken{}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetSetupToken shows a setup token, e.g. to check whether the buyer approved it
+// Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/#setup-tokens_get
+// Endpoint: GET /v3/vault/setup-tokens/setup_token_id
+func (c *Client) GetSetupToken(ctx context.Context, id string) (*SetupToken, error) {
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/v3/vault/setup-tokens/%s", c.APIBase, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetupToken{}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ApprovalURL returns the link the buyer approves a PayPal wallet setup token at
+func (t *SetupToken) ApprovalURL() string {
+	if link := FindLink(t.Links, "approve"); link != nil {
+		return link.Href
+	}
+	return ""
+}
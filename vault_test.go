@@ -0,0 +1,207 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestSetupTokens(t *testing.T) {
+	var requests []string
+	var bodies []map[string]map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		switch {
+		case r.Method == http.MethodPost:
+			var body map[string]map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			bodies = append(bodies, body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"SETUP-1","customer":{"id":"CUST-1"},"status":"PAYER_ACTION_REQUIRED","links":[{"href":"https://www.sandbox.paypal.com/agreements/approve?approval_session_id=1","rel":"approve"}]}`))
+		case r.URL.Path == "/v3/vault/setup-tokens/SETUP-1":
+			w.Write([]byte(`{"id":"SETUP-1","status":"APPROVED","payment_source":{"paypal":{"email_address":"buyer@example.com"}}}`))
+		case r.URL.Path == "/v3/vault/payment-tokens/TOKEN-1":
+			w.Write([]byte(`{"id":"TOKEN-1","customer":{"id":"CUST-1"},"payment_source":{"card":{"brand":"VISA","last_digits":"1111","expiry":"2030-01"}}}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	setup, err := c.CreateSetupToken(ctx, "", SetupTokenSource{
+		Paypal: &VaultPaypalWallet{
+			UsageType: VaultUsageTypeMerchant,
+			ExperienceContext: &ExperienceContext{
+				ReturnURL: "https://example.com/return",
+				CancelURL: "https://example.com/cancel",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setup.Status != SetupTokenStatusPayerActionRequired || !strings.Contains(setup.ApprovalURL(), "approval_session_id=1") {
+		t.Errorf("unexpected setup token %+v", setup)
+	}
+
+	if _, err := c.CreateSetupToken(ctx, "CUST-1", SetupTokenSource{
+		Card: &VaultCard{Number: "4111111111111111", Expiry: "2030-01"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	setup, err = c.GetSetupToken(ctx, "SETUP-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setup.Status != SetupTokenStatusApproved || setup.PaymentSource.Paypal.EmailAddress != "buyer@example.com" {
+		t.Errorf("unexpected setup token %+v", setup)
+	}
+
+	token, err := c.GetPaymentToken(ctx, "TOKEN-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.PaymentSource.Card.LastDigits != "1111" || token.Customer.ID != "CUST-1" {
+		t.Errorf("unexpected payment token %+v", token)
+	}
+
+	if len(bodies) != 2 || bodies[0]["customer"] != nil || bodies[0]["payment_source"]["paypal"] == nil {
+		t.Fatalf("unexpected request bodies %v", bodies)
+	}
+	card, _ := bodies[1]["payment_source"]["card"].(map[string]interface{})
+	if bodies[1]["customer"]["id"] != "CUST-1" || card["number"] != "4111111111111111" || card["security_code"] != nil {
+		t.Errorf("unexpected card request body %v", bodies[1])
+	}
+
+	expected := []string{
+		"POST /v3/vault/setup-tokens",
+		"POST /v3/vault/setup-tokens",
+		"GET /v3/vault/setup-tokens/SETUP-1",
+		"GET /v3/vault/payment-tokens/TOKEN-1",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests\n%s", strings.Join(requests, "\n"))
+	}
+}
+
+func TestPaymentTokenPages(t *testing.T) {
+	var requests []string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"customer":{"id":"CUST-1"},"payment_tokens":[{"id":"TOKEN-2","payment_source":{"paypal":{"email_address":"buyer@example.com"}}}],"total_pages":2}`))
+			return
+		}
+		w.Write([]byte(`{"customer":{"id":"CUST-1"},"payment_tokens":[{"id":"TOKEN-1","payment_source":{"card":{"brand":"VISA","type":"CREDIT","last_digits":"1111","expiry":"2030-01"}}}],"total_pages":2,"links":[{"href":"` + ts.URL + `/v3/vault/payment-tokens?customer_id=CUST-1&page=2&page_size=1","rel":"next"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	ctx := context.Background()
+
+	page, err := c.ListPaymentTokensPage(ctx, "CUST-1", &ListParams{PageSize: "1", TotalRequired: "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	card := page.PaymentTokens[0].PaymentSource.Card
+	if page.TotalPages != 2 || card.Brand != "VISA" || card.Type != "CREDIT" || card.Expiry != "2030-01" {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	var ids []string
+	p := c.NewPaymentTokenPaginator("CUST-1", &ListParams{PageSize: "1"})
+	for p.HasNext() {
+		var tokens PaymentTokens
+		if _, err := p.Next(ctx, &tokens); err != nil {
+			t.Fatal(err)
+		}
+		for _, token := range tokens.PaymentTokens {
+			ids = append(ids, token.ID)
+		}
+	}
+	if strings.Join(ids, ",") != "TOKEN-1,TOKEN-2" {
+		t.Errorf("unexpected tokens %v", ids)
+	}
+
+	expected := []string{
+		"GET /v3/vault/payment-tokens?customer_id=CUST-1&page_size=1&total_required=true",
+		"GET /v3/vault/payment-tokens?customer_id=CUST-1&page_size=1",
+		"GET /v3/vault/payment-tokens?customer_id=CUST-1&page=2&page_size=1",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests\n%s", strings.Join(requests, "\n"))
+	}
+}
+
+func TestVaultDuringPurchase(t *testing.T) {
+	source := PaymentSource{
+		Paypal: &PaymentSourcePaypal{
+			Attributes: &PaypalWalletAttributes{
+				Vault: &PaymentSourceVault{
+					StoreInVault: StoreInVaultOnSuccess,
+					UsageType:    VaultUsageTypeMerchant,
+				},
+			},
+		},
+	}
+	b, err := json.Marshal(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"paypal":{"attributes":{"vault":{"store_in_vault":"ON_SUCCESS","usage_type":"MERCHANT"}}}}`; string(b) != expected {
+		t.Errorf("unexpected payload %s", b)
+	}
+
+	response := []byte(`{
+		"id": "ORDER-1",
+		"status": "COMPLETED",
+		"payment_source": {
+			"card": {
+				"last_digits": "1111",
+				"attributes": {
+					"vault": {
+						"id": "nkq2y9g",
+						"status": "VAULTED",
+						"customer": {"id": "ROaDTk5Wfm"},
+						"links": [{"href": "https://api-m.sandbox.paypal.com/v3/vault/payment-tokens/nkq2y9g", "rel": "self", "method": "GET"}]
+					}
+				}
+			}
+		}
+	}`)
+	capture := &CaptureOrderResponse{}
+	if err := json.Unmarshal(response, capture); err != nil {
+		t.Fatal(err)
+	}
+	vault := capture.PaymentSource.Vault()
+	if vault == nil || vault.ID != "nkq2y9g" || vault.Status != VaultStatusVaulted || vault.Customer.ID != "ROaDTk5Wfm" {
+		t.Errorf("unexpected vault %+v", vault)
+	}
+
+	if (&PaymentSource{Card: &PaymentSourceCard{}}).Vault() != nil {
+		t.Error("expected no vault for a card without attributes")
+	}
+	var nilSource *PaymentSource
+	if nilSource.Vault() != nil {
+		t.Error("expected no vault for a nil payment source")
+	}
+}
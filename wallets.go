@@ -0,0 +1,21 @@
+package paypal
+
+// Possible values for `payment_data_type` in ApplePayDecryptedToken
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-apple_pay_decrypted_token_data
+const (
+	ApplePayPaymentDataType3DSecure string = "3DSECURE"
+	ApplePayPaymentDataTypeEMV      string = "EMV"
+)
+
+// Possible values for `authentication_method` in GooglePayDecryptedToken
+//
+// https://developer.paypal.com/docs/api/orders/v2/#definition-google_pay_decrypted_token_data
+const (
+	GooglePayAuthenticationPANOnly       string = "PAN_ONLY"
+	GooglePayAuthenticationCryptogram3DS string = "CRYPTOGRAM_3DS"
+)
+
+// GooglePayPaymentMethodCard is the only `payment_method` of a
+// GooglePayDecryptedToken PayPal supports
+const GooglePayPaymentMethodCard string = "CARD"
@@ -0,0 +1,94 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestConfirmPaymentSourceApplePay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.RequestURI != "/v2/checkout/orders/ORDER-1/confirm-payment-source" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body struct {
+			PaymentSource map[string]map[string]json.RawMessage `json:"payment_source"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token := string(body.PaymentSource["apple_pay"]["decrypted_token"])
+		expected := `{"transaction_amount":{"currency_code":"USD","value":"10.00"},"tokenized_card":{"number":"4111111111111111","expiry":"2030-12","type":"CREDIT"},"payment_data_type":"3DSECURE","payment_data":{"cryptogram":"AAAAAA","eci_indicator":"5"}}`
+		if token != expected {
+			t.Errorf("unexpected decrypted token %s", token)
+		}
+
+		w.Write([]byte(`{"id":"ORDER-1","status":"APPROVED","payment_source":{"apple_pay":{"name":"John Doe","card":{"last_digits":"1111","brand":"VISA","type":"CREDIT"}}}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+
+	order, err := c.ConfirmOrderPaymentSource(context.Background(), "ORDER-1", &PaymentSource{
+		ApplePay: &PaymentSourceApplePay{
+			DecryptedToken: &ApplePayDecryptedToken{
+				TransactionAmount: &Money{Currency: "USD", Value: "10.00"},
+				TokenizedCard:     &WalletCard{Number: "4111111111111111", Expiry: "2030-12", Type: "CREDIT"},
+				PaymentDataType:   ApplePayPaymentDataType3DSecure,
+				PaymentData:       &ApplePayPaymentData{Cryptogram: "AAAAAA", ECIIndicator: "5"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.PaymentSource.ApplePay == nil || order.PaymentSource.ApplePay.Card.LastDigits != "1111" {
+		t.Errorf("unexpected payment source %+v", order.PaymentSource)
+	}
+}
+
+func TestGooglePayPaymentSource(t *testing.T) {
+	source := PaymentSource{
+		GooglePay: &PaymentSourceGooglePay{
+			Name: "John Doe",
+			DecryptedToken: &GooglePayDecryptedToken{
+				PaymentMethod:        GooglePayPaymentMethodCard,
+				Card:                 &WalletCard{Number: "4111111111111111", Expiry: "2030-12"},
+				AuthenticationMethod: GooglePayAuthenticationPANOnly,
+			},
+			AssuranceDetails: &GooglePayAssuranceDetails{AccountVerified: true},
+			Attributes:       &CardAttributes{Verification: &CardVerification{Method: SCAMethodWhenRequired}},
+		},
+	}
+
+	b, err := json.Marshal(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"google_pay":{"name":"John Doe","decrypted_token":{"payment_method":"CARD","card":{"number":"4111111111111111","expiry":"2030-12"},"authentication_method":"PAN_ONLY"},"assurance_details":{"account_verified":true,"card_holder_authenticated":false},"attributes":{"verification":{"method":"SCA_WHEN_REQUIRED"}}}}`
+	if string(b) != expected {
+		t.Errorf("unexpected payload\n%s\nexpected\n%s", b, expected)
+	}
+
+	response := []byte(`{"google_pay":{"name":"John Doe","card":{"last_digits":"1111","brand":"VISA","authentication_result":{"liability_shift":"POSSIBLE"}}}}`)
+	decoded := &PaymentSource{}
+	if err := json.Unmarshal(response, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.GooglePay.Card.AuthenticationResult.LiabilityShifted() {
+		t.Errorf("unexpected google pay response %+v", decoded.GooglePay.Card)
+	}
+}
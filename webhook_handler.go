@@ -0,0 +1,133 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type (
+	// CaptureEvent is a PAYMENT.CAPTURE.COMPLETED, DENIED or PENDING notification
+	CaptureEvent struct {
+		Event
+		Resource CaptureDetailsResponse `json:"resource"`
+	}
+
+	// RefundEvent is a PAYMENT.CAPTURE.REFUNDED or REVERSED notification, the
+	// resource is the refund of the capture
+	RefundEvent struct {
+		Event
+		Resource RefundResponse `json:"resource"`
+	}
+
+	// AuthorizationEvent is a PAYMENT.AUTHORIZATION.* notification
+	AuthorizationEvent struct {
+		Event
+		Resource Authorization `json:"resource"`
+	}
+
+	// OrderEvent is a CHECKOUT.ORDER.* notification
+	OrderEvent struct {
+		Event
+		Resource Order `json:"resource"`
+	}
+
+	// WebhookHandler is an EventRouter with callbacks receiving the decoded
+	// resource of the most common events.
+	//
+	// It implements http.Handler: invalid signatures are answered with 400,
+	// verification and callback errors with 500 so PayPal redelivers the event,
+	// and handled or unregistered events with 200.
+	WebhookHandler struct {
+		*EventRouter
+	}
+)
+
+// NewWebhookHandler returns a WebhookHandler verifying events against the given webhook ID
+func NewWebhookHandler(c *Client, webhookID string) *WebhookHandler {
+	return &WebhookHandler{EventRouter: NewEventRouter(c, webhookID)}
+}
+
+// OnCaptureCompleted registers the callback for PAYMENT.CAPTURE.COMPLETED
+func (h *WebhookHandler) OnCaptureCompleted(handler func(ctx context.Context, event *CaptureEvent) error) {
+	h.On(EventPaymentCaptureCompleted, captureEventHandler(handler))
+}
+
+// OnCaptureDenied registers the callback for PAYMENT.CAPTURE.DENIED
+func (h *WebhookHandler) OnCaptureDenied(handler func(ctx context.Context, event *CaptureEvent) error) {
+	h.On(EventPaymentCaptureDenied, captureEventHandler(handler))
+}
+
+// OnCapturePending registers the callback for PAYMENT.CAPTURE.PENDING
+func (h *WebhookHandler) OnCapturePending(handler func(ctx context.Context, event *CaptureEvent) error) {
+	h.On(EventPaymentCapturePending, captureEventHandler(handler))
+}
+
+// OnCaptureRefunded registers the callback for PAYMENT.CAPTURE.REFUNDED
+func (h *WebhookHandler) OnCaptureRefunded(handler func(ctx context.Context, event *RefundEvent) error) {
+	h.On(EventPaymentCaptureRefunded, refundEventHandler(handler))
+}
+
+// OnCaptureReversed registers the callback for PAYMENT.CAPTURE.REVERSED
+func (h *WebhookHandler) OnCaptureReversed(handler func(ctx context.Context, event *RefundEvent) error) {
+	h.On(EventPaymentCaptureReversed, refundEventHandler(handler))
+}
+
+// OnAuthorizationCreated registers the callback for PAYMENT.AUTHORIZATION.CREATED
+func (h *WebhookHandler) OnAuthorizationCreated(handler func(ctx context.Context, event *AuthorizationEvent) error) {
+	h.On(EventPaymentAuthorizationCreated, authorizationEventHandler(handler))
+}
+
+// OnAuthorizationVoided registers the callback for PAYMENT.AUTHORIZATION.VOIDED
+func (h *WebhookHandler) OnAuthorizationVoided(handler func(ctx context.Context, event *AuthorizationEvent) error) {
+	h.On(EventPaymentAuthorizationVoided, authorizationEventHandler(handler))
+}
+
+// OnOrderApproved registers the callback for CHECKOUT.ORDER.APPROVED
+func (h *WebhookHandler) OnOrderApproved(handler func(ctx context.Context, event *OrderEvent) error) {
+	h.On(EventCheckoutOrderApproved, orderEventHandler(handler))
+}
+
+// OnOrderCompleted registers the callback for CHECKOUT.ORDER.COMPLETED
+func (h *WebhookHandler) OnOrderCompleted(handler func(ctx context.Context, event *OrderEvent) error) {
+	h.On(EventOrderCompleted, orderEventHandler(handler))
+}
+
+func captureEventHandler(handler func(ctx context.Context, event *CaptureEvent) error) WebhookEventHandler {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		typed := &CaptureEvent{Event: event.Event}
+		if err := json.Unmarshal(event.Resource, &typed.Resource); err != nil {
+			return err
+		}
+		return handler(ctx, typed)
+	}
+}
+
+func refundEventHandler(handler func(ctx context.Context, event *RefundEvent) error) WebhookEventHandler {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		typed := &RefundEvent{Event: event.Event}
+		if err := json.Unmarshal(event.Resource, &typed.Resource); err != nil {
+			return err
+		}
+		return handler(ctx, typed)
+	}
+}
+
+func authorizationEventHandler(handler func(ctx context.Context, event *AuthorizationEvent) error) WebhookEventHandler {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		typed := &AuthorizationEvent{Event: event.Event}
+		if err := json.Unmarshal(event.Resource, &typed.Resource); err != nil {
+			return err
+		}
+		return handler(ctx, typed)
+	}
+}
+
+func orderEventHandler(handler func(ctx context.Context, event *OrderEvent) error) WebhookEventHandler {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		typed := &OrderEvent{Event: event.Event}
+		if err := json.Unmarshal(event.Resource, &typed.Resource); err != nil {
+			return err
+		}
+		return handler(ctx, typed)
+	}
+}
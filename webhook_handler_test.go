@@ -0,0 +1,98 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	ts := newWebhookVerificationServer(VerificationStatusSuccess)
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	h := NewWebhookHandler(c, "WH-ID")
+
+	var capture *CaptureEvent
+	h.OnCaptureCompleted(func(ctx context.Context, event *CaptureEvent) error {
+		capture = event
+		return nil
+	})
+	var refund *RefundEvent
+	h.OnCaptureRefunded(func(ctx context.Context, event *RefundEvent) error {
+		refund = event
+		return nil
+	})
+	h.OnCaptureDenied(func(ctx context.Context, event *CaptureEvent) error {
+		return errors.New("failed")
+	})
+
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{`{"id":"WH-1","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"CAPTURE","status":"COMPLETED","amount":{"currency_code":"USD","value":"5.00"}}}`, http.StatusOK},
+		{`{"id":"WH-2","event_type":"PAYMENT.CAPTURE.REFUNDED","resource":{"id":"REFUND","status":"COMPLETED"}}`, http.StatusOK},
+		{`{"id":"WH-3","event_type":"PAYMENT.CAPTURE.DENIED","resource":{"id":"CAPTURE"}}`, http.StatusInternalServerError},
+		{`{"id":"WH-4","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":42}}`, http.StatusInternalServerError},
+		{`{"id":"WH-5","event_type":"CHECKOUT.ORDER.APPROVED","resource":{}}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks", strings.NewReader(tt.body)))
+
+		if w.Code != tt.status {
+			t.Errorf("%s: expecting status %d got %d", tt.body, tt.status, w.Code)
+		}
+	}
+
+	if capture == nil || capture.ID != "WH-1" || capture.Resource.ID != "CAPTURE" || capture.Resource.Amount.Value != "5.00" {
+		t.Errorf("unexpected capture event %+v", capture)
+	}
+	if refund == nil || refund.EventType != EventPaymentCaptureRefunded || refund.Resource.ID != "REFUND" {
+		t.Errorf("unexpected refund event %+v", refund)
+	}
+}
+
+func TestWebhookHandler_verifyLocally(t *testing.T) {
+	signer := newWebhookSigner(t, "messageverificationcerts.paypal.com")
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signer.chain)
+	}))
+	defer ts.Close()
+
+	h := NewWebhookHandler(nil, "WH-ID")
+	h.VerifyLocally(NewWebhookVerifier("WH-ID",
+		WithVerifierHTTPClient(ts.Client()),
+		WithVerifierRoots(signer.roots),
+		WithVerifierCertHosts("127.0.0.1"),
+	))
+	var handled string
+	h.OnOrderCompleted(func(ctx context.Context, event *OrderEvent) error {
+		handled = event.Resource.ID
+		return nil
+	})
+
+	body := []byte(`{"id":"WH-1","event_type":"CHECKOUT.ORDER.COMPLETED","resource":{"id":"ORDER"}}`)
+	header := signer.header(t, ts.URL+"/cert", "WH-ID", body)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/webhooks", strings.NewReader(string(body)))
+	req.Header = header
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || handled != "ORDER" {
+		t.Errorf("expecting the order to be handled, got status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/webhooks", strings.NewReader(`{"id":"WH-2","event_type":"CHECKOUT.ORDER.COMPLETED","resource":{}}`))
+	req.Header = header
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expecting status 400 for a tampered body, got %d", w.Code)
+	}
+}
@@ -0,0 +1,99 @@
+package paypal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownEventResource is returned by DecodeResource for resources without a known type
+var ErrUnknownEventResource = errors.New("paypal: unknown webhook event resource")
+
+// Possible values for `resource_type` in webhook events
+//
+// https://developer.paypal.com/api/rest/webhooks/event-names/
+const (
+	ResourceTypeCapture       string = "capture"
+	ResourceTypeRefund        string = "refund"
+	ResourceTypeAuthorization string = "authorization"
+	ResourceTypeCheckoutOrder string = "checkout-order"
+	ResourceTypeSubscription  string = "subscription"
+	ResourceTypePlan          string = "plan"
+	ResourceTypeProduct       string = "product"
+	ResourceTypeDispute       string = "dispute"
+	ResourceTypePayouts       string = "payouts"
+	ResourceTypePayoutsItem   string = "payouts_item"
+)
+
+// Resource types of the events sent without `resource_type`, by event type prefix
+var eventResourceTypes = []struct {
+	prefix       string
+	resourceType string
+}{
+	{"PAYMENT.CAPTURE.REFUNDED", ResourceTypeRefund},
+	{"PAYMENT.CAPTURE.REVERSED", ResourceTypeRefund},
+	{"PAYMENT.CAPTURE.", ResourceTypeCapture},
+	{"PAYMENT.AUTHORIZATION.", ResourceTypeAuthorization},
+	{"CHECKOUT.ORDER.", ResourceTypeCheckoutOrder},
+	{"BILLING.SUBSCRIPTION.", ResourceTypeSubscription},
+	{"BILLING.PLAN.", ResourceTypePlan},
+	{"CATALOG.PRODUCT.", ResourceTypeProduct},
+	{"CUSTOMER.DISPUTE.", ResourceTypeDispute},
+	{"PAYMENT.PAYOUTSBATCH.", ResourceTypePayouts},
+	{"PAYMENT.PAYOUTS-ITEM.", ResourceTypePayoutsItem},
+}
+
+// DecodeResource decodes the resource of the event into the struct of its
+// resource type: *CaptureDetailsResponse, *RefundResponse, *Authorization,
+// *Order, *Subscription, *SubscriptionPlan, *Product, *Dispute, *PayoutResponse
+// or *PayoutItemResponse. The type is taken from `resource_type`, or from the
+// event type when PayPal leaves it out.
+//
+// It returns an error wrapping ErrUnknownEventResource for other resources,
+// which are left to decode from the Resource field.
+func (e *AnyEvent) DecodeResource() (interface{}, error) {
+	var resource interface{}
+	switch e.resourceType() {
+	case ResourceTypeCapture:
+		resource = &CaptureDetailsResponse{}
+	case ResourceTypeRefund:
+		resource = &RefundResponse{}
+	case ResourceTypeAuthorization:
+		resource = &Authorization{}
+	case ResourceTypeCheckoutOrder:
+		resource = &Order{}
+	case ResourceTypeSubscription:
+		resource = &Subscription{}
+	case ResourceTypePlan:
+		resource = &SubscriptionPlan{}
+	case ResourceTypeProduct:
+		resource = &Product{}
+	case ResourceTypeDispute:
+		resource = &Dispute{}
+	case ResourceTypePayouts:
+		resource = &PayoutResponse{}
+	case ResourceTypePayoutsItem:
+		resource = &PayoutItemResponse{}
+	default:
+		return nil, fmt.Errorf("%w: %s %s", ErrUnknownEventResource, e.EventType, e.ResourceType)
+	}
+
+	if err := json.Unmarshal(e.Resource, resource); err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (e *AnyEvent) resourceType() string {
+	if e.ResourceType != "" {
+		return e.ResourceType
+	}
+	for _, r := range eventResourceTypes {
+		if strings.HasPrefix(e.EventType, r.prefix) {
+			return r.resourceType
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package paypal
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAnyEventDecodeResource(t *testing.T) {
+	tests := []struct {
+		body string
+		id   func(resource interface{}) string
+	}{
+		{
+			`{"event_type":"PAYMENT.CAPTURE.COMPLETED","resource_type":"capture","resource":{"id":"CAPTURE"}}`,
+			func(r interface{}) string { return r.(*CaptureDetailsResponse).ID },
+		},
+		{
+			`{"event_type":"PAYMENT.CAPTURE.REFUNDED","resource_type":"refund","resource":{"id":"REFUND"}}`,
+			func(r interface{}) string { return r.(*RefundResponse).ID },
+		},
+		{
+			`{"event_type":"PAYMENT.CAPTURE.REVERSED","resource":{"id":"REVERSAL"}}`,
+			func(r interface{}) string { return r.(*RefundResponse).ID },
+		},
+		{
+			`{"event_type":"BILLING.SUBSCRIPTION.ACTIVATED","resource_type":"subscription","resource":{"id":"I-SUB"}}`,
+			func(r interface{}) string { return r.(*Subscription).ID },
+		},
+		{
+			`{"event_type":"CUSTOMER.DISPUTE.CREATED","resource":{"dispute_id":"PP-D-1"}}`,
+			func(r interface{}) string { return r.(*Dispute).ID },
+		},
+	}
+
+	for _, tt := range tests {
+		event := &AnyEvent{}
+		if err := json.Unmarshal([]byte(tt.body), event); err != nil {
+			t.Fatal(err)
+		}
+		resource, err := event.DecodeResource()
+		if err != nil {
+			t.Errorf("%s: %v", tt.body, err)
+			continue
+		}
+		if id := tt.id(resource); id == "" {
+			t.Errorf("%s: resource not decoded: %+v", tt.body, resource)
+		}
+	}
+
+	event := &AnyEvent{Event: Event{EventType: "VAULT.PAYMENT-TOKEN.CREATED"}, Resource: json.RawMessage(`{}`)}
+	if _, err := event.DecodeResource(); !errors.Is(err, ErrUnknownEventResource) {
+		t.Errorf("expecting ErrUnknownEventResource, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// Possible values for `verification_status` in VerifyWebhookResponse
+//
+// https://developer.paypal.com/docs/api/webhooks/v1/#verify-webhook-signature_post
+const (
+	VerificationStatusSuccess string = "SUCCESS"
+	VerificationStatusFailure string = "FAILURE"
+)
+
+// ErrInvalidWebhookSignature is returned by VerifyAndParse when PayPal rejects the signature
+var ErrInvalidWebhookSignature = errors.New("paypal: invalid webhook signature")
+
+type (
+	// WebhookEvent is a webhook notification with its resource left undecoded
+	WebhookEvent = AnyEvent
+
+	// WebhookEventHandler handles a verified webhook event
+	WebhookEventHandler func(ctx context.Context, event *WebhookEvent) error
+
+	// EventRouter verifies incoming webhook notifications and dispatches them
+	// to the handler registered for their event type.
+	//
+	// EventRouter implements http.Handler. It responds with a non-2xx status when
+	// the event could not be handled, so PayPal redelivers it later.
+	EventRouter struct {
+		client    *Client
+		webhookID string
+		verifier  *WebhookVerifier
+
+		mu       sync.RWMutex
+		handlers map[string]WebhookEventHandler
+		fallback WebhookEventHandler
+	}
+)
+
+// NewEventRouter returns an EventRouter verifying events against the given webhook ID.
+// Events without a registered handler are acknowledged and dropped unless a fallback is set.
+func NewEventRouter(c *Client, webhookID string) *EventRouter {
+	return &EventRouter{
+		client:    c,
+		webhookID: webhookID,
+		handlers:  make(map[string]WebhookEventHandler),
+	}
+}
+
+// On registers the handler for an event type, e.g. EventPaymentCaptureCompleted
+func (r *EventRouter) On(eventType string, handler WebhookEventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[eventType] = handler
+}
+
+// Fallback registers the handler for event types without a handler
+func (r *EventRouter) Fallback(handler WebhookEventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fallback = handler
+}
+
+// VerifyLocally makes the router check signatures with the verifier instead of
+// calling PayPal for every event
+func (r *EventRouter) VerifyLocally(v *WebhookVerifier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.verifier = v
+}
+
+// Dispatch calls the handler registered for the event type
+func (r *EventRouter) Dispatch(ctx context.Context, event *WebhookEvent) error {
+	r.mu.RLock()
+	handler, ok := r.handlers[event.EventType]
+	if !ok {
+		handler = r.fallback
+	}
+	r.mu.RUnlock()
+
+	if handler == nil {
+		return nil
+	}
+	return handler(ctx, event)
+}
+
+// ServeHTTP verifies the webhook signature, decodes the event and dispatches it
+func (r *EventRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "unable to read webhook event", http.StatusBadRequest)
+		return
+	}
+
+	r.mu.RLock()
+	verifier := r.verifier
+	r.mu.RUnlock()
+
+	var event *WebhookEvent
+	if verifier != nil {
+		event, err = verifier.VerifyAndParse(ctx, req.Header, body)
+	} else {
+		event, err = r.client.VerifyAndParse(ctx, r.webhookID, req.Header, body)
+	}
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, ErrInvalidWebhookSignature):
+		http.Error(w, "invalid webhook signature", http.StatusBadRequest)
+		return
+	case errors.As(err, &syntaxErr):
+		http.Error(w, "unable to decode webhook event", http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, "unable to verify webhook signature", http.StatusInternalServerError)
+		return
+	}
+
+	if err = r.Dispatch(ctx, event); err != nil {
+		http.Error(w, "unable to handle webhook event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
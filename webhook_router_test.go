@@ -0,0 +1,153 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func newWebhookVerificationServer(status string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "/v1/notifications/verify-webhook-signature":
+			json.NewEncoder(w).Encode(VerifyWebhookResponse{VerificationStatus: status})
+		}
+	}))
+}
+
+func TestEventRouter(t *testing.T) {
+	ts := newWebhookVerificationServer(VerificationStatusSuccess)
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	router := NewEventRouter(c, "WH-ID")
+
+	var handled string
+	router.On(EventPaymentCaptureCompleted, func(ctx context.Context, event *WebhookEvent) error {
+		handled = event.ID
+		return nil
+	})
+	router.On(EventPaymentCaptureDenied, func(ctx context.Context, event *WebhookEvent) error {
+		return errors.New("failed")
+	})
+
+	tests := []struct {
+		eventType string
+		status    int
+	}{
+		{EventPaymentCaptureCompleted, http.StatusOK},
+		{EventPaymentCaptureDenied, http.StatusInternalServerError},
+		{EventPaymentCaptureRefunded, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		body := `{"id":"WH-EVENT","event_type":"` + tt.eventType + `","resource":{}}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks", strings.NewReader(body)))
+
+		if w.Code != tt.status {
+			t.Errorf("%s: expecting status %d got %d", tt.eventType, tt.status, w.Code)
+		}
+	}
+
+	if handled != "WH-EVENT" {
+		t.Errorf("expecting the capture completed handler to be called")
+	}
+}
+
+func TestEventRouter_invalidSignature(t *testing.T) {
+	ts := newWebhookVerificationServer(VerificationStatusFailure)
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	router := NewEventRouter(c, "WH-ID")
+	router.Fallback(func(ctx context.Context, event *WebhookEvent) error {
+		t.Errorf("unverified event must not be dispatched")
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks", strings.NewReader(`{"id":"WH-EVENT"}`)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expecting status %d got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestVerifyAndParse(t *testing.T) {
+	ts := newWebhookVerificationServer(VerificationStatusSuccess)
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	header := http.Header{}
+	header.Set("PAYPAL-TRANSMISSION-ID", "TRANSMISSION")
+
+	event, err := c.VerifyAndParse(context.Background(), "WH-ID", header, []byte(`{"id":"WH-EVENT","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"CAPTURE"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != "WH-EVENT" || event.EventType != EventPaymentCaptureCompleted || string(event.Resource) != `{"id":"CAPTURE"}` {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
+
+func TestVerifyAndParse_invalidSignature(t *testing.T) {
+	ts := newWebhookVerificationServer(VerificationStatusFailure)
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	event, err := c.VerifyAndParse(context.Background(), "WH-ID", http.Header{}, []byte(`{"id":"WH-EVENT"}`))
+	if !errors.Is(err, ErrInvalidWebhookSignature) || event != nil {
+		t.Errorf("expecting ErrInvalidWebhookSignature, got %v %v", event, err)
+	}
+}
+
+func TestVerifyWebhookSignatureRaw(t *testing.T) {
+	var sent map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		json.NewEncoder(w).Encode(VerifyWebhookResponse{VerificationStatus: VerificationStatusSuccess})
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("foo", "bar", ts.URL)
+	header := http.Header{}
+	header.Set("PAYPAL-TRANSMISSION-ID", "TX-ID")
+	header.Set("PAYPAL-TRANSMISSION-SIG", "SIG")
+	header.Set("PAYPAL-CERT-URL", "https://api.paypal.com/v1/notifications/certs/CERT")
+
+	result, err := c.VerifyWebhookSignatureRaw(context.Background(), "WH-ID", header, []byte(`{"id":"WH-EVENT"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Verified() {
+		t.Errorf("expecting a verified signature, got %+v", result)
+	}
+	if sent["transmission_id"] != "TX-ID" || sent["webhook_id"] != "WH-ID" || sent["webhook_event"].(map[string]interface{})["id"] != "WH-EVENT" {
+		t.Errorf("unexpected verification request %v", sent)
+	}
+
+	sent = nil
+	result, err = c.VerifyWebhookSignatureRaw(context.Background(), "WH-ID", header, []byte(`not json`))
+	if err != nil || result.Verified() {
+		t.Errorf("expecting an unverified signature for a body that is not JSON, got %+v %v", result, err)
+	}
+	if sent != nil {
+		t.Error("expecting a body that is not JSON not to be sent to PayPal")
+	}
+}
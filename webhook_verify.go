@@ -0,0 +1,299 @@
+package paypal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Hosts PayPal serves its webhook signing certificates from
+var defaultWebhookCertHosts = []string{
+	"api.paypal.com",
+	"api-m.paypal.com",
+	"api.sandbox.paypal.com",
+	"api-m.sandbox.paypal.com",
+}
+
+// defaultWebhookMaxAge is how old a webhook notification may be by default
+const defaultWebhookMaxAge = 5 * time.Minute
+
+// maxWebhookCerts is the number of signing certificates a WebhookVerifier caches
+const maxWebhookCerts = 8
+
+// Names of PayPal's webhook signing certificates
+var webhookCertNames = map[string]bool{
+	"messageverificationcerts.paypal.com":         true,
+	"messageverificationcerts.sandbox.paypal.com": true,
+}
+
+type (
+	// WebhookVerifier verifies webhook signatures locally with PayPal's signing
+	// certificate instead of calling the verify-webhook-signature endpoint.
+	// Certificates are fetched from the PAYPAL-CERT-URL header, only from PayPal's
+	// hosts, verified against the system roots and cached until they expire,
+	// up to 8 certificates.
+	// A WebhookVerifier is safe for concurrent use.
+	WebhookVerifier struct {
+		webhookID  string
+		httpClient *http.Client
+		roots      *x509.CertPool
+		certHosts  []string
+		maxAge     time.Duration
+		now        func() time.Time
+
+		mu    sync.Mutex
+		certs map[string]*x509.Certificate
+	}
+
+	// WebhookVerifierOption configures a WebhookVerifier
+	WebhookVerifierOption func(*WebhookVerifier)
+)
+
+// WithVerifierHTTPClient sets the HTTP client fetching the signing certificates
+func WithVerifierHTTPClient(client *http.Client) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.httpClient = client
+	}
+}
+
+// WithVerifierRoots sets the roots the signing certificates are verified against,
+// the system roots are used by default
+func WithVerifierRoots(roots *x509.CertPool) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.roots = roots
+	}
+}
+
+// WithVerifierCertHosts replaces the hosts signing certificates may be fetched from
+func WithVerifierCertHosts(hosts ...string) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.certHosts = hosts
+	}
+}
+
+// WithVerifierMaxAge sets how far the signed PAYPAL-TRANSMISSION-TIME of a
+// notification may be from the current time, in the past or the future, so
+// that a captured notification cannot be replayed later. It is 5 minutes by
+// default, 0 disables the check.
+func WithVerifierMaxAge(maxAge time.Duration) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.maxAge = maxAge
+	}
+}
+
+// NewWebhookVerifier returns a WebhookVerifier for the events of the given webhook ID
+func NewWebhookVerifier(webhookID string, opts ...WebhookVerifierOption) *WebhookVerifier {
+	v := &WebhookVerifier{
+		webhookID:  webhookID,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		certHosts:  defaultWebhookCertHosts,
+		maxAge:     defaultWebhookMaxAge,
+		now:        time.Now,
+		certs:      make(map[string]*x509.Certificate),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Verify checks the signature of a webhook notification from its headers and
+// raw body. It returns an error wrapping ErrInvalidWebhookSignature when the
+// signature doesn't match or the notification is older than the max age, other
+// errors mean the certificate could not be fetched.
+func (v *WebhookVerifier) Verify(ctx context.Context, header http.Header, body []byte) error {
+	if algo := header.Get("PAYPAL-AUTH-ALGO"); algo != "SHA256withRSA" {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidWebhookSignature, algo)
+	}
+	if err := v.checkTransmissionTime(header.Get("PAYPAL-TRANSMISSION-TIME")); err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(header.Get("PAYPAL-TRANSMISSION-SIG"))
+	if err != nil || len(signature) == 0 {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidWebhookSignature)
+	}
+
+	cert, err := v.certificate(ctx, header.Get("PAYPAL-CERT-URL"))
+	if err != nil {
+		return err
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: certificate without RSA key", ErrInvalidWebhookSignature)
+	}
+
+	// PayPal signs transmission_id|transmission_time|webhook_id|crc32 of the body
+	message := header.Get("PAYPAL-TRANSMISSION-ID") + "|" + header.Get("PAYPAL-TRANSMISSION-TIME") + "|" +
+		v.webhookID + "|" + strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10)
+	digest := sha256.Sum256([]byte(message))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidWebhookSignature)
+	}
+
+	return nil
+}
+
+// VerifyAndParse verifies the signature of a webhook notification and decodes the event
+func (v *WebhookVerifier) VerifyAndParse(ctx context.Context, header http.Header, body []byte) (*WebhookEvent, error) {
+	if err := v.Verify(ctx, header, body); err != nil {
+		return nil, err
+	}
+
+	event := &WebhookEvent{}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// checkTransmissionTime checks that the notification was sent within the max age
+func (v *WebhookVerifier) checkTransmissionTime(transmissionTime string) error {
+	if v.maxAge <= 0 {
+		return nil
+	}
+	sent, err := time.Parse(time.RFC3339, transmissionTime)
+	if err != nil {
+		return fmt.Errorf("%w: malformed transmission time %q", ErrInvalidWebhookSignature, transmissionTime)
+	}
+	if age := v.now().Sub(sent); age > v.maxAge || age < -v.maxAge {
+		return fmt.Errorf("%w: transmission time %s is more than %s from now", ErrInvalidWebhookSignature, transmissionTime, v.maxAge)
+	}
+	return nil
+}
+
+// certificate returns the verified signing certificate at certURL, from the cache when possible
+func (v *WebhookVerifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	u, err := url.Parse(certURL)
+	if err != nil || u.Scheme != "https" || !v.allowedHost(u.Hostname()) {
+		return nil, fmt.Errorf("%w: certificate URL %q is not a PayPal URL", ErrInvalidWebhookSignature, certURL)
+	}
+
+	// The query string is ignored, so that certificate URLs differing only in
+	// it neither fetch the certificate again nor grow the cache
+	key := u.Host + u.Path
+	u = &url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}
+
+	now := v.now()
+	v.mu.Lock()
+	cert, ok := v.certs[key]
+	v.mu.Unlock()
+	if ok && now.Before(cert.NotAfter) {
+		return cert, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("paypal: fetching webhook certificate: %s", resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if cert, err = v.parseCertificate(data, now); err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.cacheCertificate(key, cert, now)
+	v.mu.Unlock()
+
+	return cert, nil
+}
+
+// cacheCertificate adds cert to the cache, evicting expired certificates and
+// then the one expiring first when the cache is full. v.mu must be held.
+func (v *WebhookVerifier) cacheCertificate(key string, cert *x509.Certificate, now time.Time) {
+	if _, ok := v.certs[key]; !ok && len(v.certs) >= maxWebhookCerts {
+		var first string
+		for k, c := range v.certs {
+			if !now.Before(c.NotAfter) {
+				delete(v.certs, k)
+			} else if first == "" || c.NotAfter.Before(v.certs[first].NotAfter) {
+				first = k
+			}
+		}
+		if len(v.certs) >= maxWebhookCerts {
+			delete(v.certs, first)
+		}
+	}
+	v.certs[key] = cert
+}
+
+// parseCertificate returns the first certificate of the PEM chain once verified
+func (v *WebhookVerifier) parseCertificate(data []byte, now time.Time) (*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookSignature, err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("%w: no certificate at the certificate URL", ErrInvalidWebhookSignature)
+	}
+
+	leaf := chain[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookSignature, err)
+	}
+
+	named := webhookCertNames[leaf.Subject.CommonName]
+	for _, name := range leaf.DNSNames {
+		named = named || webhookCertNames[name]
+	}
+	if !named {
+		return nil, fmt.Errorf("%w: certificate %q is not a PayPal signing certificate", ErrInvalidWebhookSignature, leaf.Subject.CommonName)
+	}
+
+	return leaf, nil
+}
+
+func (v *WebhookVerifier) allowedHost(host string) bool {
+	for _, allowed := range v.certHosts {
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
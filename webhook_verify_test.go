@@ -0,0 +1,255 @@
+package paypal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"hash/crc32"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type webhookSigner struct {
+	key   *rsa.PrivateKey
+	roots *x509.CertPool
+	chain []byte
+}
+
+func newWebhookSigner(t *testing.T, commonName string) *webhookSigner {
+	t.Helper()
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+	chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})...)
+
+	return &webhookSigner{key: key, roots: roots, chain: chain}
+}
+
+func (s *webhookSigner) leaf(t *testing.T) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode(s.chain)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func (s *webhookSigner) header(t *testing.T, certURL, webhookID string, body []byte) http.Header {
+	t.Helper()
+	return s.headerAt(t, certURL, webhookID, body, time.Now())
+}
+
+func (s *webhookSigner) headerAt(t *testing.T, certURL, webhookID string, body []byte, sent time.Time) http.Header {
+	t.Helper()
+
+	transmissionTime := sent.UTC().Format(time.RFC3339)
+	message := "TRANSMISSION|" + transmissionTime + "|" + webhookID + "|" + strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10)
+	digest := sha256.Sum256([]byte(message))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := http.Header{}
+	header.Set("PAYPAL-AUTH-ALGO", "SHA256withRSA")
+	header.Set("PAYPAL-CERT-URL", certURL)
+	header.Set("PAYPAL-TRANSMISSION-ID", "TRANSMISSION")
+	header.Set("PAYPAL-TRANSMISSION-SIG", base64.StdEncoding.EncodeToString(signature))
+	header.Set("PAYPAL-TRANSMISSION-TIME", transmissionTime)
+	return header
+}
+
+func TestWebhookVerifier(t *testing.T) {
+	signer := newWebhookSigner(t, "messageverificationcerts.paypal.com")
+	fetches := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		w.Write(signer.chain)
+	}))
+	defer ts.Close()
+
+	v := NewWebhookVerifier("WH-ID",
+		WithVerifierHTTPClient(ts.Client()),
+		WithVerifierRoots(signer.roots),
+		WithVerifierCertHosts("127.0.0.1"),
+	)
+	ctx := context.Background()
+	body := []byte(`{"id":"WH-EVENT","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"CAPTURE"}}`)
+	header := signer.header(t, ts.URL+"/v1/notifications/certs/CERT", "WH-ID", body)
+
+	event, err := v.VerifyAndParse(ctx, header, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != "WH-EVENT" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if err := v.Verify(ctx, header, body); err != nil {
+		t.Fatal(err)
+	}
+	header = signer.header(t, ts.URL+"/v1/notifications/certs/CERT?nonce=1", "WH-ID", body)
+	if err := v.Verify(ctx, header, body); err != nil {
+		t.Fatal(err)
+	}
+	if fetches != 1 {
+		t.Errorf("expected the certificate to be fetched once, got %d", fetches)
+	}
+
+	if err := v.Verify(ctx, header, []byte(`{"id":"WH-OTHER"}`)); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("expected ErrInvalidWebhookSignature for a tampered body, got %v", err)
+	}
+	if err := NewWebhookVerifier("WH-OTHER",
+		WithVerifierHTTPClient(ts.Client()),
+		WithVerifierRoots(signer.roots),
+		WithVerifierCertHosts("127.0.0.1"),
+	).Verify(ctx, header, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("expected ErrInvalidWebhookSignature for another webhook, got %v", err)
+	}
+}
+
+func TestWebhookVerifier_certCache(t *testing.T) {
+	signer := newWebhookSigner(t, "messageverificationcerts.paypal.com")
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signer.chain)
+	}))
+	defer ts.Close()
+
+	v := NewWebhookVerifier("WH-ID",
+		WithVerifierHTTPClient(ts.Client()),
+		WithVerifierRoots(signer.roots),
+		WithVerifierCertHosts("127.0.0.1"),
+	)
+	body := []byte(`{"id":"WH-EVENT"}`)
+	for i := 0; i < 3*maxWebhookCerts; i++ {
+		header := signer.header(t, ts.URL+"/v1/notifications/certs/CERT-"+strconv.Itoa(i), "WH-ID", body)
+		if err := v.Verify(context.Background(), header, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(v.certs) != maxWebhookCerts {
+		t.Errorf("expected at most %d cached certificates, got %d", maxWebhookCerts, len(v.certs))
+	}
+
+	// Expired certificates are evicted first, the certificates expire in an hour
+	v.mu.Lock()
+	v.cacheCertificate("new", signer.leaf(t), time.Now())
+	v.cacheCertificate("newer", signer.leaf(t), time.Now().Add(2*time.Hour))
+	v.mu.Unlock()
+	if _, ok := v.certs["new"]; len(v.certs) != 1 || ok {
+		t.Errorf("expected the expired certificates to be evicted, got %d", len(v.certs))
+	}
+}
+
+func TestWebhookVerifier_replay(t *testing.T) {
+	signer := newWebhookSigner(t, "messageverificationcerts.paypal.com")
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signer.chain)
+	}))
+	defer ts.Close()
+
+	options := []WebhookVerifierOption{
+		WithVerifierHTTPClient(ts.Client()),
+		WithVerifierRoots(signer.roots),
+		WithVerifierCertHosts("127.0.0.1"),
+	}
+	ctx := context.Background()
+	body := []byte(`{"id":"WH-EVENT"}`)
+	certURL := ts.URL + "/v1/notifications/certs/CERT"
+
+	v := NewWebhookVerifier("WH-ID", options...)
+	for _, sent := range []time.Time{time.Now().Add(-time.Hour), time.Now().Add(time.Hour)} {
+		header := signer.headerAt(t, certURL, "WH-ID", body, sent)
+		if err := v.Verify(ctx, header, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+			t.Errorf("expected a notification sent at %s to be rejected, got %v", sent, err)
+		}
+	}
+
+	header := signer.headerAt(t, certURL, "WH-ID", body, time.Now().Add(-time.Hour))
+	v = NewWebhookVerifier("WH-ID", append(options, WithVerifierMaxAge(2*time.Hour))...)
+	if err := v.Verify(ctx, header, body); err != nil {
+		t.Errorf("expected a notification within the max age to be verified, got %v", err)
+	}
+	v = NewWebhookVerifier("WH-ID", append(options, WithVerifierMaxAge(0))...)
+	if err := v.Verify(ctx, header, body); err != nil {
+		t.Errorf("expected no age check with a max age of 0, got %v", err)
+	}
+}
+
+func TestWebhookVerifier_rejectedCertificate(t *testing.T) {
+	signer := newWebhookSigner(t, "example.com")
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signer.chain)
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	body := []byte(`{"id":"WH-EVENT"}`)
+	header := signer.header(t, ts.URL+"/cert", "WH-ID", body)
+
+	v := NewWebhookVerifier("WH-ID", WithVerifierHTTPClient(ts.Client()), WithVerifierRoots(signer.roots))
+	if err := v.Verify(ctx, header, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("expected a host outside the allowlist to be rejected, got %v", err)
+	}
+
+	v = NewWebhookVerifier("WH-ID",
+		WithVerifierHTTPClient(ts.Client()),
+		WithVerifierRoots(signer.roots),
+		WithVerifierCertHosts("127.0.0.1"),
+	)
+	if err := v.Verify(ctx, header, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("expected a certificate not issued to PayPal to be rejected, got %v", err)
+	}
+
+	v = NewWebhookVerifier("WH-ID", WithVerifierHTTPClient(ts.Client()), WithVerifierCertHosts("127.0.0.1"))
+	if err := v.Verify(ctx, header, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("expected an untrusted certificate to be rejected, got %v", err)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 // CreateWebhook - Subscribes your webhook listener to events.
@@ -48,6 +51,124 @@ func (c *Client) UpdateWebhook(ctx context.Context, webhookID string, fields []W
 	return webhook, err
 }
 
+// NewWebhookEventPaginator returns a Paginator over all pages of webhook events,
+// newest first, decode each page into a ListWebhookEventsResponse
+// Doc: https://developer.paypal.com/docs/api/webhooks/v1/#webhooks-events_list
+// Endpoint: GET /v1/notifications/webhooks-events
+func (c *Client) NewWebhookEventPaginator(params *ListWebhookEventsParams) *Paginator {
+	return c.NewPaginator("/v1/notifications/webhooks-events", params.query())
+}
+
+// ListWebhookEvents - Lists the first page of webhook events, newest first.
+// Use NewWebhookEventPaginator to go through the following pages.
+// Doc: https://developer.paypal.com/docs/api/webhooks/v1/#webhooks-events_list
+// Endpoint: GET /v1/notifications/webhooks-events
+func (c *Client) ListWebhookEvents(ctx context.Context, params *ListWebhookEventsParams) (*ListWebhookEventsResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v1/notifications/webhooks-events"), nil)
+	response := &ListWebhookEventsResponse{}
+	if err != nil {
+		return response, err
+	}
+	req.URL.RawQuery = params.query().Encode()
+
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// GetWebhookEvent - Shows details for a webhook event notification, by ID.
+// Doc: https://developer.paypal.com/docs/api/webhooks/v1/#webhooks-events_get
+// Endpoint: GET /v1/notifications/webhooks-events/ID
+func (c *Client) GetWebhookEvent(ctx context.Context, eventID string) (*WebhookEvent, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/notifications/webhooks-events/%s", c.APIBase, eventID), nil)
+	event := &WebhookEvent{}
+	if err != nil {
+		return event, err
+	}
+
+	err = c.SendWithAuth(req, event)
+	return event, err
+}
+
+func (params *ListWebhookEventsParams) query() url.Values {
+	q := url.Values{}
+	if params == nil {
+		return q
+	}
+	if params.PageSize != "" {
+		q.Set("page_size", params.PageSize)
+	}
+	if params.StartTime != nil {
+		q.Set("start_time", params.StartTime.UTC().Format(time.RFC3339))
+	}
+	if params.EndTime != nil {
+		q.Set("end_time", params.EndTime.UTC().Format(time.RFC3339))
+	}
+	if params.TransactionID != "" {
+		q.Set("transaction_id", params.TransactionID)
+	}
+	if params.EventType != "" {
+		q.Set("event_type", params.EventType)
+	}
+	return q
+}
+
+// ResendWebhookEvent - Resends a webhook event notification to the given
+// webhooks, or to every webhook subscribed to the event when none is given.
+// Doc: https://developer.paypal.com/docs/api/webhooks/v1/#webhooks-events_resend
+// Endpoint: POST /v1/notifications/webhooks-events/ID/resend
+func (c *Client) ResendWebhookEvent(ctx context.Context, eventID string, webhookIDs ...string) (*WebhookEvent, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/notifications/webhooks-events/%s/resend", c.APIBase, eventID), ResendWebhookEventRequest{WebhookIDs: webhookIDs})
+	event := &WebhookEvent{}
+	if err != nil {
+		return event, err
+	}
+
+	err = c.SendWithAuth(req, event)
+	return event, err
+}
+
+// SimulateWebhookEvent - Sends a sample event of the given type to a webhook,
+// identified by either its ID or its URL. Sandbox only.
+// Doc: https://developer.paypal.com/docs/api/webhooks/v1/#simulate-event_post
+// Endpoint: POST /v1/notifications/simulate-event
+func (c *Client) SimulateWebhookEvent(ctx context.Context, simulation SimulateWebhookEventRequest) (*WebhookEvent, error) {
+	event := &WebhookEvent{}
+	if simulation.EventType == "" {
+		return event, errors.New("paypal: simulated webhook event needs an event type")
+	}
+	if (simulation.WebhookID == "") == (simulation.URL == "") {
+		return event, errors.New("paypal: simulated webhook event needs either a webhook ID or a URL")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v1/notifications/simulate-event"), simulation)
+	if err != nil {
+		return event, err
+	}
+
+	err = c.SendWithAuth(req, event)
+	return event, err
+}
+
+// ReplaceWebhookEventTypes returns the field for UpdateWebhook that replaces
+// the event types of a webhook, including their pinned resource versions.
+func ReplaceWebhookEventTypes(eventTypes ...WebhookEventType) WebhookField {
+	return WebhookField{
+		Operation: "replace",
+		Path:      "/event_types",
+		Value:     eventTypes,
+	}
+}
+
+// ReplaceWebhookURL returns the field for UpdateWebhook that points a webhook
+// to a new listener URL, e.g. when rotating the URL during a deployment.
+func ReplaceWebhookURL(url string) WebhookField {
+	return WebhookField{
+		Operation: "replace",
+		Path:      "/url",
+		Value:     url,
+	}
+}
+
 // ListWebhooks - Lists webhooks for an app.
 // Endpoint: GET /v1/notifications/webhooks
 func (c *Client) ListWebhooks(ctx context.Context, anchorType string) (*ListWebhookResponse, error) {
@@ -55,13 +176,13 @@ func (c *Client) ListWebhooks(ctx context.Context, anchorType string) (*ListWebh
 		anchorType = AncorTypeApplication
 	}
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v1/notifications/webhooks"), nil)
-	q := req.URL.Query()
-	q.Add("anchor_type", anchorType)
-	req.URL.RawQuery = q.Encode()
 	resp := &ListWebhookResponse{}
 	if err != nil {
 		return nil, err
 	}
+	q := req.URL.Query()
+	q.Add("anchor_type", anchorType)
+	req.URL.RawQuery = q.Encode()
 
 	err = c.SendWithAuth(req, resp)
 	return resp, err
@@ -82,6 +203,58 @@ func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
 // VerifyWebhookSignature - Use this to verify the signature of a webhook recieved from paypal.
 // Endpoint: POST /v1/notifications/verify-webhook-signature
 func (c *Client) VerifyWebhookSignature(ctx context.Context, httpReq *http.Request, webhookID string) (*VerifyWebhookResponse, error) {
+	// Read the content
+	var bodyBytes []byte
+	if httpReq.Body != nil {
+		var err error
+		if bodyBytes, err = ioutil.ReadAll(httpReq.Body); err != nil {
+			return nil, err
+		}
+	}
+	// Restore the io.ReadCloser to its original state
+	httpReq.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
+
+	return c.verifyWebhookSignature(ctx, webhookID, httpReq.Header, bodyBytes)
+}
+
+// VerifyWebhookSignatureRaw verifies the signature of a webhook notification from
+// its headers and raw body, for frameworks that don't expose the *http.Request.
+// Check the result with Verified.
+// Endpoint: POST /v1/notifications/verify-webhook-signature
+func (c *Client) VerifyWebhookSignatureRaw(ctx context.Context, webhookID string, header http.Header, body []byte) (*VerifyWebhookResponse, error) {
+	return c.verifyWebhookSignature(ctx, webhookID, header, body)
+}
+
+// Verified reports whether PayPal verified the signature
+func (r *VerifyWebhookResponse) Verified() bool {
+	return r != nil && r.VerificationStatus == VerificationStatusSuccess
+}
+
+// VerifyAndParse verifies the signature of a webhook notification with PayPal
+// and decodes the event. It takes the headers and the body of the notification
+// so it can be called from any web framework.
+//
+// The body must be the raw bytes as received: frameworks which bind or parse
+// the request body consume it, and an event re-encoded from the parsed value
+// does not match the signature.
+func (c *Client) VerifyAndParse(ctx context.Context, webhookID string, header http.Header, body []byte) (*WebhookEvent, error) {
+	verification, err := c.verifyWebhookSignature(ctx, webhookID, header, body)
+	if err != nil {
+		return nil, err
+	}
+	if !verification.Verified() {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	event := &WebhookEvent{}
+	if err = json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (c *Client) verifyWebhookSignature(ctx context.Context, webhookID string, header http.Header, body []byte) (*VerifyWebhookResponse, error) {
 	type verifyWebhookSignatureRequest struct {
 		AuthAlgo         string          `json:"auth_algo,omitempty"`
 		CertURL          string          `json:"cert_url,omitempty"`
@@ -92,22 +265,19 @@ func (c *Client) VerifyWebhookSignature(ctx context.Context, httpReq *http.Reque
 		Event            json.RawMessage `json:"webhook_event"`
 	}
 
-	// Read the content
-	var bodyBytes []byte
-	if httpReq.Body != nil {
-		bodyBytes, _ = ioutil.ReadAll(httpReq.Body)
-	}
-	// Restore the io.ReadCloser to its original state
-	httpReq.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
-
 	verifyRequest := verifyWebhookSignatureRequest{
-		AuthAlgo:         httpReq.Header.Get("PAYPAL-AUTH-ALGO"),
-		CertURL:          httpReq.Header.Get("PAYPAL-CERT-URL"),
-		TransmissionID:   httpReq.Header.Get("PAYPAL-TRANSMISSION-ID"),
-		TransmissionSig:  httpReq.Header.Get("PAYPAL-TRANSMISSION-SIG"),
-		TransmissionTime: httpReq.Header.Get("PAYPAL-TRANSMISSION-TIME"),
+		AuthAlgo:         header.Get("PAYPAL-AUTH-ALGO"),
+		CertURL:          header.Get("PAYPAL-CERT-URL"),
+		TransmissionID:   header.Get("PAYPAL-TRANSMISSION-ID"),
+		TransmissionSig:  header.Get("PAYPAL-TRANSMISSION-SIG"),
+		TransmissionTime: header.Get("PAYPAL-TRANSMISSION-TIME"),
 		WebhookID:        webhookID,
-		Event:            json.RawMessage(bodyBytes),
+		Event:            json.RawMessage(body),
+	}
+
+	// A body that is not JSON can't be a signed event and can't be sent as webhook_event
+	if !json.Valid(body) {
+		return &VerifyWebhookResponse{VerificationStatus: VerificationStatusFailure}, nil
 	}
 
 	response := &VerifyWebhookResponse{}
@@ -128,9 +298,20 @@ func (c *Client) VerifyWebhookSignature(ctx context.Context, httpReq *http.Reque
 // Endpoint: GET /v1/notifications/webhooks-event-types
 func (c *Client) GetWebhookEventTypes(ctx context.Context) (*WebhookEventTypesResponse, error) {
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v1/notifications/webhooks-event-types"), nil)
-	q := req.URL.Query()
+	resp := &WebhookEventTypesResponse{}
+	if err != nil {
+		return nil, err
+	}
 
-	req.URL.RawQuery = q.Encode()
+	err = c.SendWithAuth(req, resp)
+	return resp, err
+}
+
+// ListWebhookSubscribedEventTypes lists the event types a webhook is subscribed to
+// Doc: https://developer.paypal.com/docs/api/webhooks/v1/#webhooks-event-types_list
+// Endpoint: GET /v1/notifications/webhooks/ID/event-types
+func (c *Client) ListWebhookSubscribedEventTypes(ctx context.Context, webhookID string) (*WebhookEventTypesResponse, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/notifications/webhooks/%s/event-types", c.APIBase, webhookID), nil)
 	resp := &WebhookEventTypesResponse{}
 	if err != nil {
 		return nil, err
@@ -139,3 +320,23 @@ func (c *Client) GetWebhookEventTypes(ctx context.Context) (*WebhookEventTypesRe
 	err = c.SendWithAuth(req, resp)
 	return resp, err
 }
+
+// UnknownWebhookEventTypes returns the names of eventTypes missing from the
+// catalog of GetWebhookEventTypes, or deprecated in it, such as typos in a
+// subscription. The "*" wildcard is always known.
+func UnknownWebhookEventTypes(catalog *WebhookEventTypesResponse, eventTypes []WebhookEventType) []string {
+	known := map[string]bool{"*": true}
+	for _, eventType := range catalog.EventTypes {
+		if eventType.Status != WebhookEventTypeStatusDeprecated {
+			known[eventType.Name] = true
+		}
+	}
+
+	var unknown []string
+	for _, eventType := range eventTypes {
+		if !known[eventType.Name] {
+			unknown = append(unknown, eventType.Name)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,268 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestWebhookManagement(t *testing.T) {
+	var requests []string
+	var fields []WebhookField
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"WH-1","url":"https://example.com/a","event_types":[{"name":"PAYMENT.CAPTURE.COMPLETED"}]}`))
+		case http.MethodPatch:
+			json.NewDecoder(r.Body).Decode(&fields)
+			w.Write([]byte(`{"id":"WH-1","url":"https://example.com/b","event_types":[{"name":"PAYMENT.CAPTURE.REFUNDED"}]}`))
+		case http.MethodGet:
+			w.Write([]byte(`{"webhooks":[{"id":"WH-1","url":"https://example.com/b"}]}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	webhook, err := c.CreateWebhook(ctx, &CreateWebhookRequest{
+		URL:        "https://example.com/a",
+		EventTypes: []WebhookEventType{{Name: "PAYMENT.CAPTURE.COMPLETED"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webhook, err = c.UpdateWebhook(ctx, webhook.ID, []WebhookField{
+		ReplaceWebhookURL("https://example.com/b"),
+		ReplaceWebhookEventTypes(WebhookEventType{Name: "PAYMENT.CAPTURE.REFUNDED"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 || fields[0].Path != "/url" || fields[0].Value != "https://example.com/b" || fields[1].Path != "/event_types" {
+		t.Errorf("unexpected fields %+v", fields)
+	}
+	if webhook.URL != "https://example.com/b" {
+		t.Errorf("unexpected webhook %+v", webhook)
+	}
+
+	webhooks, err := c.ListWebhooks(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(webhooks.Webhooks) != 1 {
+		t.Errorf("unexpected webhooks %+v", webhooks)
+	}
+
+	if err := c.DeleteWebhook(ctx, "WH-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"POST /v1/notifications/webhooks",
+		"PATCH /v1/notifications/webhooks/WH-1",
+		"GET /v1/notifications/webhooks?anchor_type=APPLICATION",
+		"DELETE /v1/notifications/webhooks/WH-1",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expecting requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("request %d: expecting %s, got %s", i, expected[i], requests[i])
+		}
+	}
+}
+
+func TestWebhookEventTypes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+		case "/v1/notifications/webhooks-event-types":
+			w.Write([]byte(`{"event_types":[` +
+				`{"name":"PAYMENT.CAPTURE.COMPLETED","description":"A payment capture completes.","status":"ENABLED"},` +
+				`{"name":"PAYMENT.SALE.COMPLETED","description":"A sale completes.","status":"DEPRECATED"}]}`))
+		case "/v1/notifications/webhooks/WH-1/event-types":
+			w.Write([]byte(`{"event_types":[{"name":"PAYMENT.CAPTURE.COMPLETED","description":"A payment capture completes.","status":"ENABLED"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	catalog, err := c.GetWebhookEventTypes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.EventTypes) != 2 || catalog.EventTypes[0].Description == "" {
+		t.Fatalf("unexpected catalog %+v", catalog)
+	}
+
+	subscribed, err := c.ListWebhookSubscribedEventTypes(ctx, "WH-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscribed.EventTypes) != 1 || subscribed.EventTypes[0].Status != WebhookEventTypeStatusEnabled {
+		t.Errorf("unexpected subscribed event types %+v", subscribed)
+	}
+
+	unknown := UnknownWebhookEventTypes(catalog, []WebhookEventType{
+		{Name: "PAYMENT.CAPTURE.COMPLETED"},
+		{Name: "PAYMENT.SALE.COMPLETED"},
+		{Name: "PAYMENT.CAPTUER.DENIED"},
+		{Name: "*"},
+	})
+	if len(unknown) != 2 || unknown[0] != "PAYMENT.SALE.COMPLETED" || unknown[1] != "PAYMENT.CAPTUER.DENIED" {
+		t.Errorf("unexpected unknown event types %v", unknown)
+	}
+}
+
+func TestResendWebhookEvent(t *testing.T) {
+	var requests []string
+	var sent ResendWebhookEventRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":"WH-EVENT","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"CAPTURE"}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	event, err := c.ResendWebhookEvent(context.Background(), "WH-EVENT", "WH-1", "WH-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != "WH-EVENT" || event.EventType != EventPaymentCaptureCompleted {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if len(requests) != 1 || requests[0] != "POST /v1/notifications/webhooks-events/WH-EVENT/resend" {
+		t.Errorf("unexpected requests %v", requests)
+	}
+	if len(sent.WebhookIDs) != 2 || sent.WebhookIDs[1] != "WH-2" {
+		t.Errorf("unexpected webhook IDs %v", sent.WebhookIDs)
+	}
+}
+
+func TestSimulateWebhookEvent(t *testing.T) {
+	var sent map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/notifications/simulate-event" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"id":"WH-SIMULATED","event_type":"PAYMENT.CAPTURE.COMPLETED","resource_version":"2.0","resource":{}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	event, err := c.SimulateWebhookEvent(ctx, SimulateWebhookEventRequest{
+		WebhookID:       "WH-1",
+		EventType:       EventPaymentCaptureCompleted,
+		ResourceVersion: "2.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != "WH-SIMULATED" || event.ResourceVersion != "2.0" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if sent["webhook_id"] != "WH-1" || sent["event_type"] != EventPaymentCaptureCompleted || sent["url"] != nil {
+		t.Errorf("unexpected request body %v", sent)
+	}
+
+	if _, err := c.SimulateWebhookEvent(ctx, SimulateWebhookEventRequest{EventType: EventPaymentCaptureCompleted}); err == nil {
+		t.Error("expecting an error without webhook ID and URL")
+	}
+	if _, err := c.SimulateWebhookEvent(ctx, SimulateWebhookEventRequest{URL: "https://example.com/webhooks"}); err == nil {
+		t.Error("expecting an error without event type")
+	}
+}
+
+func TestListAndGetWebhookEvents(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "123", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if r.URL.Path == "/v1/notifications/webhooks-events" {
+			w.Write([]byte(`{"events":[{"id":"WH-1","event_type":"PAYMENT.CAPTURE.COMPLETED"}],"count":1}`))
+			return
+		}
+		w.Write([]byte(`{"id":"WH-1","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"CAPTURE"}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("id", "secret", ts.URL)
+	ctx := context.Background()
+
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	events, err := c.ListWebhookEvents(ctx, &ListWebhookEventsParams{
+		PageSize:  "10",
+		StartTime: &start,
+		EventType: EventPaymentCaptureCompleted,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events.Count != 1 || events.Events[0].ID != "WH-1" {
+		t.Errorf("unexpected events %+v", events)
+	}
+
+	event, err := c.GetWebhookEvent(ctx, "WH-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(event.Resource) != `{"id":"CAPTURE"}` {
+		t.Errorf("unexpected resource %s", event.Resource)
+	}
+
+	if _, err := c.ListWebhookEvents(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"GET /v1/notifications/webhooks-events?event_type=PAYMENT.CAPTURE.COMPLETED&page_size=10&start_time=2026-10-01T10%3A00%3A00Z",
+		"GET /v1/notifications/webhooks-events/WH-1",
+		"GET /v1/notifications/webhooks-events",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests\n%s", strings.Join(requests, "\n"))
+	}
+}
@@ -114,3 +114,27 @@ func (c *Client) DeleteWebProfile(ctx context.Context, profileID string) error {
 
 	return nil
 }
+
+// PatchWebProfile partially updates a web experience profile with a JSON Patch
+// document, e.g. to replace /presentation/logo_image
+//
+// Endpoint: PATCH /v1/payment-experience/web-profiles/<profile-id>
+func (c *Client) PatchWebProfile(ctx context.Context, profileID string, patches []Patch) error {
+	if len(patches) == 0 {
+		return fmt.Errorf("paypal: no patches specified for WebProfile")
+	}
+
+	url := fmt.Sprintf("%s%s%s", c.APIBase, "/v1/payment-experience/web-profiles/", profileID)
+
+	req, err := c.NewRequest(ctx, "PATCH", url, patches)
+
+	if err != nil {
+		return err
+	}
+
+	if err = c.SendWithAuth(req, nil); err != nil {
+		return err
+	}
+
+	return nil
+}